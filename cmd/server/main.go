@@ -1,16 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
+	_ "time/tzdata" // Institution time zones must resolve even without system zoneinfo
 
 	"campus-core/internal/config"
 	"campus-core/internal/database"
 	"campus-core/internal/router"
 	"campus-core/internal/utils"
 	"campus-core/pkg/logger"
+	"campus-core/pkg/version"
 
 	"go.uber.org/zap"
 )
@@ -31,6 +37,7 @@ func main() {
 	logger.Info("Starting Campus Core Server",
 		zap.String("port", cfg.Server.Port),
 		zap.String("mode", cfg.Server.GinMode),
+		zap.String("version", version.String()),
 	)
 
 	if err := utils.InitValidator(); err != nil {
@@ -47,14 +54,14 @@ func main() {
 		logger.Fatal("Failed to run database migrations", zap.Error(err))
 	}
 
-	seeder := database.NewSeeder(db)
+	seeder := database.NewSeeder(db, cfg.Seed.KeepDefaultPasswords)
 	if err := seeder.SeedAll(); err != nil {
 		logger.Error("Failed to seed database", zap.Error(err))
 	}
 
 	_, err = database.ConnectRedis(&cfg.Redis)
 	if err != nil {
-		logger.Warn("Failed to connect to Redis, rate limiting will be disabled", zap.Error(err))
+		logger.Warn("Failed to connect to Redis, rate limiting and caching will be disabled", zap.Error(err))
 	} else {
 		defer database.CloseRedis()
 	}
@@ -62,10 +69,21 @@ func main() {
 	r := router.NewRouter(cfg, db)
 	engine := r.Setup()
 
+	// Count the requests being served so shutdown can report how many it drained
+	var inFlight atomic.Int64
+	handler := engine.Handler()
+	srv := &http.Server{
+		Addr: fmt.Sprintf(":%s", cfg.Server.Port),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			inFlight.Add(1)
+			defer inFlight.Add(-1)
+			handler.ServeHTTP(w, req)
+		}),
+	}
+
 	go func() {
-		addr := fmt.Sprintf(":%s", cfg.Server.Port)
-		logger.Info("Server listening", zap.String("address", addr))
-		if err := engine.Run(addr); err != nil {
+		logger.Info("Server listening", zap.String("address", srv.Addr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal("Failed to start server", zap.Error(err))
 		}
 	}()
@@ -74,6 +92,24 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	logger.Info("Shutting down server...")
-	logger.Info("Server exited gracefully")
+	// Stop accepting connections and wait for in-flight requests before the
+	// deferred calls close the database and Redis connections
+	pending := inFlight.Load()
+	logger.Info("Shutting down server...",
+		zap.Int64("in_flight_requests", pending),
+		zap.Duration("timeout", cfg.Server.ShutdownTimeout),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Error("Server shutdown timed out, abandoning in-flight requests",
+			zap.Int64("drained_requests", pending-inFlight.Load()),
+			zap.Int64("abandoned_requests", inFlight.Load()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.Info("Server exited gracefully", zap.Int64("drained_requests", pending))
 }
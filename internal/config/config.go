@@ -2,22 +2,33 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server    ServerConfig
-	Database  DatabaseConfig
-	Redis     RedisConfig
-	JWT       JWTConfig
-	RateLimit RateLimitConfig
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	JWT        JWTConfig
+	RateLimit  RateLimitConfig
+	Mail       MailConfig
+	Storage    StorageConfig
+	Export     ExportConfig
+	Password   PasswordConfig
+	Pagination PaginationConfig
+	CORS       CORSConfig
+	Phone      PhoneConfig
+	Tenant     TenantConfig
+	Seed       SeedConfig
 }
 
 type ServerConfig struct {
-	Port    string
-	GinMode string
+	Port            string
+	GinMode         string
+	ShutdownTimeout time.Duration // How long in-flight requests may take to finish on shutdown
 }
 
 type DatabaseConfig struct {
@@ -27,6 +38,12 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	MaxOpenConns    int           // Upper bound on connections to Postgres, shared by all requests
+	MaxIdleConns    int           // Connections kept open between bursts of requests
+	ConnMaxLifetime time.Duration // Connections are recycled after this long; 0 keeps them forever
+
+	ReplicaDSN string // Optional read replica for listings and reports; empty uses the primary for everything
 }
 
 type RedisConfig struct {
@@ -37,7 +54,9 @@ type RedisConfig struct {
 }
 
 type JWTConfig struct {
-	Secret        string
+	Secret        string            // Signs tokens when no keys are configured; otherwise only verifies tokens without a kid
+	Keys          map[string]string // Key ID -> secret, for key rotation
+	CurrentKID    string            // Key ID new tokens are signed with
 	AccessExpiry  time.Duration
 	RefreshExpiry time.Duration
 }
@@ -45,6 +64,64 @@ type JWTConfig struct {
 type RateLimitConfig struct {
 	Requests int
 	Duration time.Duration
+
+	LoginFailures      int           // Failed logins from one IP before it is delayed; 0 disables the lockout
+	LoginFailureWindow time.Duration // How long after the last failure the failures are remembered
+	LoginDelay         time.Duration // Delay after crossing the threshold, doubled by every further failure
+	LoginMaxDelay      time.Duration // Upper bound on the login delay
+}
+
+type MailConfig struct {
+	Driver   string // smtp, log or noop
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	AppURL   string // Base URL used to build links in emails
+}
+
+type StorageConfig struct {
+	Driver    string // local
+	LocalPath string // Upload directory for the local driver
+	BaseURL   string // Public URL prefix of uploaded files
+}
+
+type ExportConfig struct {
+	// TrueType fonts embedded in PDF exports in place of DejaVu Sans, e.g. to cover
+	// Bengali. An empty bold font uses the regular font for bold text.
+	PDFFont     string
+	PDFBoldFont string
+}
+
+type PasswordConfig struct {
+	HistorySize int // Number of recent passwords, including the current one, that can't be reused; 0 disables the check
+}
+
+type PaginationConfig struct {
+	MaxPerPage int // Largest page size clients can request
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string // "*" allows any origin; otherwise an explicit allowlist
+	AllowedMethods   []string // Empty uses the middleware defaults
+	AllowedHeaders   []string // Empty uses the middleware defaults
+	AllowCredentials bool
+}
+
+type PhoneConfig struct {
+	DefaultRegion string // ISO 3166-1 region assumed for numbers without a country code, e.g. BD
+}
+
+type TenantConfig struct {
+	// DefaultInstitutionCode is the institution used by requests that name none,
+	// for deployments hosting a single school. Super Admins are not defaulted to it.
+	// Empty requires every request to name one; an unknown code stops the server.
+	DefaultInstitutionCode string
+}
+
+type SeedConfig struct {
+	KeepDefaultPasswords bool // Let seeded demo users keep their default password instead of forcing a change
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -55,6 +132,7 @@ func LoadConfig(path string) (*Config, error) {
 
 	viper.SetDefault("SERVER_PORT", "8080")
 	viper.SetDefault("GIN_MODE", "debug")
+	viper.SetDefault("SERVER_SHUTDOWN_TIMEOUT", "30s")
 	viper.SetDefault("DB_HOST", "localhost")
 	viper.SetDefault("DB_PORT", "5432")
 	viper.SetDefault("DB_SSLMODE", "disable")
@@ -65,6 +143,20 @@ func LoadConfig(path string) (*Config, error) {
 	viper.SetDefault("JWT_REFRESH_EXPIRY", "168h")
 	viper.SetDefault("RATE_LIMIT_REQUESTS", 1000)
 	viper.SetDefault("RATE_LIMIT_DURATION", "1m")
+	viper.SetDefault("MAIL_DRIVER", "noop")
+	viper.SetDefault("MAIL_PORT", "587")
+	viper.SetDefault("MAIL_FROM", "no-reply@campus.local")
+	viper.SetDefault("APP_URL", "http://localhost:3000")
+	viper.SetDefault("STORAGE_DRIVER", "local")
+	viper.SetDefault("STORAGE_LOCAL_PATH", "./uploads")
+	viper.SetDefault("STORAGE_BASE_URL", "/uploads")
+	viper.SetDefault("PASSWORD_HISTORY_SIZE", 5)
+	viper.SetDefault("PAGINATION_MAX_PER_PAGE", 100)
+	viper.SetDefault("CORS_ALLOWED_ORIGINS", "*")
+	viper.SetDefault("CORS_ALLOW_CREDENTIALS", true)
+	viper.SetDefault("PHONE_DEFAULT_REGION", "BD")
+	viper.SetDefault("RATE_LIMIT_LOGIN_FAILURES", 10)
+	viper.SetDefault("SEED_KEEP_DEFAULT_PASSWORDS", false)
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -72,6 +164,21 @@ func LoadConfig(path string) (*Config, error) {
 		}
 	}
 
+	// The pool defaults depend on the mode, so they are set once it is known:
+	// a small pool for development and a larger one in release mode
+	poolOpen, poolIdle, poolLifetime := 10, 5, "5m"
+	if viper.GetString("GIN_MODE") == "release" {
+		poolOpen, poolIdle, poolLifetime = 50, 25, "30m"
+	}
+	viper.SetDefault("DB_MAX_OPEN_CONNS", poolOpen)
+	viper.SetDefault("DB_MAX_IDLE_CONNS", poolIdle)
+	viper.SetDefault("DB_CONN_MAX_LIFETIME", poolLifetime)
+
+	connMaxLifetime, err := time.ParseDuration(viper.GetString("DB_CONN_MAX_LIFETIME"))
+	if err != nil {
+		connMaxLifetime, _ = time.ParseDuration(poolLifetime)
+	}
+
 	accessExpiry, err := time.ParseDuration(viper.GetString("JWT_ACCESS_EXPIRY"))
 	if err != nil {
 		accessExpiry = 15 * time.Minute
@@ -82,15 +189,45 @@ func LoadConfig(path string) (*Config, error) {
 		refreshExpiry = 7 * 24 * time.Hour
 	}
 
+	jwtKeys, err := parseJWTKeys(viper.GetString("JWT_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+	currentKID := viper.GetString("JWT_CURRENT_KID")
+	if len(jwtKeys) > 0 {
+		if _, ok := jwtKeys[currentKID]; !ok {
+			return nil, fmt.Errorf("JWT_CURRENT_KID %q is not one of the keys in JWT_KEYS", currentKID)
+		}
+	}
+
+	shutdownTimeout, err := time.ParseDuration(viper.GetString("SERVER_SHUTDOWN_TIMEOUT"))
+	if err != nil {
+		shutdownTimeout = 30 * time.Second
+	}
+
 	rateLimitDuration, err := time.ParseDuration(viper.GetString("RATE_LIMIT_DURATION"))
 	if err != nil {
 		rateLimitDuration = 1 * time.Minute
 	}
 
+	loginFailureWindow, err := time.ParseDuration(viper.GetString("RATE_LIMIT_LOGIN_FAILURE_WINDOW"))
+	if err != nil {
+		loginFailureWindow = 15 * time.Minute
+	}
+	loginDelay, err := time.ParseDuration(viper.GetString("RATE_LIMIT_LOGIN_DELAY"))
+	if err != nil {
+		loginDelay = 30 * time.Second
+	}
+	loginMaxDelay, err := time.ParseDuration(viper.GetString("RATE_LIMIT_LOGIN_MAX_DELAY"))
+	if err != nil {
+		loginMaxDelay = 15 * time.Minute
+	}
+
 	config := &Config{
 		Server: ServerConfig{
-			Port:    viper.GetString("SERVER_PORT"),
-			GinMode: viper.GetString("GIN_MODE"),
+			Port:            viper.GetString("SERVER_PORT"),
+			GinMode:         viper.GetString("GIN_MODE"),
+			ShutdownTimeout: shutdownTimeout,
 		},
 		Database: DatabaseConfig{
 			Host:     viper.GetString("DB_HOST"),
@@ -99,6 +236,12 @@ func LoadConfig(path string) (*Config, error) {
 			Password: viper.GetString("DB_PASSWORD"),
 			DBName:   viper.GetString("DB_NAME"),
 			SSLMode:  viper.GetString("DB_SSLMODE"),
+
+			MaxOpenConns:    viper.GetInt("DB_MAX_OPEN_CONNS"),
+			MaxIdleConns:    viper.GetInt("DB_MAX_IDLE_CONNS"),
+			ConnMaxLifetime: connMaxLifetime,
+
+			ReplicaDSN: viper.GetString("DB_REPLICA_DSN"),
 		},
 		Redis: RedisConfig{
 			Host:     viper.GetString("REDIS_HOST"),
@@ -108,18 +251,93 @@ func LoadConfig(path string) (*Config, error) {
 		},
 		JWT: JWTConfig{
 			Secret:        viper.GetString("JWT_SECRET"),
+			Keys:          jwtKeys,
+			CurrentKID:    currentKID,
 			AccessExpiry:  accessExpiry,
 			RefreshExpiry: refreshExpiry,
 		},
 		RateLimit: RateLimitConfig{
 			Requests: viper.GetInt("RATE_LIMIT_REQUESTS"),
 			Duration: rateLimitDuration,
+
+			LoginFailures:      viper.GetInt("RATE_LIMIT_LOGIN_FAILURES"),
+			LoginFailureWindow: loginFailureWindow,
+			LoginDelay:         loginDelay,
+			LoginMaxDelay:      loginMaxDelay,
+		},
+		Mail: MailConfig{
+			Driver:   viper.GetString("MAIL_DRIVER"),
+			Host:     viper.GetString("MAIL_HOST"),
+			Port:     viper.GetString("MAIL_PORT"),
+			Username: viper.GetString("MAIL_USERNAME"),
+			Password: viper.GetString("MAIL_PASSWORD"),
+			From:     viper.GetString("MAIL_FROM"),
+			AppURL:   viper.GetString("APP_URL"),
+		},
+		Storage: StorageConfig{
+			Driver:    viper.GetString("STORAGE_DRIVER"),
+			LocalPath: viper.GetString("STORAGE_LOCAL_PATH"),
+			BaseURL:   viper.GetString("STORAGE_BASE_URL"),
+		},
+		Export: ExportConfig{
+			PDFFont:     viper.GetString("EXPORT_PDF_FONT"),
+			PDFBoldFont: viper.GetString("EXPORT_PDF_BOLD_FONT"),
+		},
+		Password: PasswordConfig{
+			HistorySize: viper.GetInt("PASSWORD_HISTORY_SIZE"),
+		},
+		Pagination: PaginationConfig{
+			MaxPerPage: viper.GetInt("PAGINATION_MAX_PER_PAGE"),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   splitList(viper.GetString("CORS_ALLOWED_ORIGINS")),
+			AllowedMethods:   splitList(viper.GetString("CORS_ALLOWED_METHODS")),
+			AllowedHeaders:   splitList(viper.GetString("CORS_ALLOWED_HEADERS")),
+			AllowCredentials: viper.GetBool("CORS_ALLOW_CREDENTIALS"),
+		},
+		Phone: PhoneConfig{
+			DefaultRegion: viper.GetString("PHONE_DEFAULT_REGION"),
+		},
+		Tenant: TenantConfig{
+			DefaultInstitutionCode: viper.GetString("TENANT_DEFAULT_INSTITUTION_CODE"),
+		},
+		Seed: SeedConfig{
+			KeepDefaultPasswords: viper.GetBool("SEED_KEEP_DEFAULT_PASSWORDS"),
 		},
 	}
 
 	return config, nil
 }
 
+// splitList splits a comma separated value, dropping empty entries
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseJWTKeys parses JWT_KEYS, a comma separated list of kid:secret pairs
+func parseJWTKeys(raw string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for i, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kid, secret, ok := strings.Cut(pair, ":")
+		kid = strings.TrimSpace(kid)
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %d, expected kid:secret", i+1)
+		}
+		keys[kid] = secret
+	}
+	return keys, nil
+}
+
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
@@ -10,15 +10,21 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 var DB *gorm.DB
 
+// ReplicaResolver names the read replica resolver. Queries are only sent to the
+// replica when they ask for it with dbresolver.Use(ReplicaResolver); everything
+// else, including every transaction, runs on the primary.
+const ReplicaResolver = "replica"
+
 func ConnectDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	dsn := cfg.GetDSN()
 
 	gormConfig := &gorm.Config{
-		Logger: gormlogger.Default.LogMode(gormlogger.Info),
+		Logger: logger.NewGormLogger(gormlogger.Info),
 	}
 
 	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
@@ -31,15 +37,35 @@ func ConnectDB(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	if cfg.ReplicaDSN != "" {
+		resolver := dbresolver.Register(dbresolver.Config{
+			Replicas: []gorm.Dialector{postgres.Open(cfg.ReplicaDSN)},
+		}, ReplicaResolver).
+			SetMaxOpenConns(cfg.MaxOpenConns).
+			SetMaxIdleConns(cfg.MaxIdleConns).
+			SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		if err := db.Use(resolver); err != nil {
+			return nil, fmt.Errorf("failed to register read replica: %w", err)
+		}
+		logger.Info("Read replica configured for listings and reports")
+	}
+
 	DB = db
-	logger.Info("Database connected successfully", zap.String("host", cfg.Host), zap.String("database", cfg.DBName))
+	logger.Info("Database connected successfully",
+		zap.String("host", cfg.Host),
+		zap.String("database", cfg.DBName),
+		zap.Int("max_open_conns", cfg.MaxOpenConns),
+		zap.Int("max_idle_conns", cfg.MaxIdleConns),
+		zap.Duration("conn_max_lifetime", cfg.ConnMaxLifetime),
+	)
 
 	return db, nil
 }
@@ -0,0 +1,8 @@
+ALTER TABLE institutions DROP COLUMN IF EXISTS require_email_verification;
+
+DROP INDEX IF EXISTS idx_users_verification_token;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS verification_token,
+    DROP COLUMN IF EXISTS email_verified_at,
+    DROP COLUMN IF EXISTS email_verified;
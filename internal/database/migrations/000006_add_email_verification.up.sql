@@ -0,0 +1,14 @@
+-- Email verification for users
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_verified BOOLEAN DEFAULT false,
+    ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS verification_token VARCHAR(500);
+
+-- Existing accounts predate verification and are treated as verified
+UPDATE users SET email_verified = true, email_verified_at = NOW() WHERE email_verified = false;
+
+CREATE INDEX IF NOT EXISTS idx_users_verification_token ON users(verification_token);
+
+-- Per-institution setting to reject logins from unverified accounts
+ALTER TABLE institutions
+    ADD COLUMN IF NOT EXISTS require_email_verification BOOLEAN DEFAULT false;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_student_promotions_deleted_at;
+DROP INDEX IF EXISTS idx_student_promotions_student_id;
+DROP INDEX IF EXISTS idx_student_promotions_batch_id;
+DROP INDEX IF EXISTS idx_student_promotions_institution_id;
+
+DROP TABLE IF EXISTS student_promotions;
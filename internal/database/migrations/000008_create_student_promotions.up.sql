@@ -0,0 +1,23 @@
+-- Student promotions (one row per student moved, grouped by batch so a promotion can be reversed)
+CREATE TABLE IF NOT EXISTS student_promotions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    institution_id UUID NOT NULL REFERENCES institutions(id),
+    batch_id UUID NOT NULL,
+    student_id UUID NOT NULL REFERENCES students(id),
+    from_class_id UUID REFERENCES classes(id),
+    from_section_id UUID REFERENCES sections(id),
+    from_roll_number INTEGER,
+    to_class_id UUID NOT NULL REFERENCES classes(id),
+    to_section_id UUID REFERENCES sections(id),
+    to_roll_number INTEGER,
+    promoted_by UUID REFERENCES users(id),
+    reverted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_promotions_institution_id ON student_promotions(institution_id);
+CREATE INDEX IF NOT EXISTS idx_student_promotions_batch_id ON student_promotions(batch_id);
+CREATE INDEX IF NOT EXISTS idx_student_promotions_student_id ON student_promotions(student_id);
+CREATE INDEX IF NOT EXISTS idx_student_promotions_deleted_at ON student_promotions(deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE institutions DROP COLUMN IF EXISTS timezone;
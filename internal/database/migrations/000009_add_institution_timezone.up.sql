@@ -0,0 +1,3 @@
+-- IANA timezone used for schedules, e.g. calendar exports
+ALTER TABLE institutions
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT 'UTC';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_substitutions_timetable_date;
+DROP INDEX IF EXISTS idx_substitutions_deleted_at;
+DROP INDEX IF EXISTS idx_substitutions_substitute_teacher_id;
+DROP INDEX IF EXISTS idx_substitutions_original_teacher_id;
+DROP INDEX IF EXISTS idx_substitutions_date;
+DROP INDEX IF EXISTS idx_substitutions_institution_id;
+
+DROP TABLE IF EXISTS substitutions;
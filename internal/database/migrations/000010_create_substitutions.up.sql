@@ -0,0 +1,23 @@
+-- Substitutions (a teacher covering another teacher's timetable slot on a given date)
+CREATE TABLE IF NOT EXISTS substitutions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    institution_id UUID NOT NULL REFERENCES institutions(id),
+    timetable_id UUID NOT NULL REFERENCES timetables(id),
+    original_teacher_id UUID NOT NULL REFERENCES teachers(id),
+    substitute_teacher_id UUID NOT NULL REFERENCES teachers(id),
+    date DATE NOT NULL,
+    reason TEXT,
+    created_by UUID REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_substitutions_institution_id ON substitutions(institution_id);
+CREATE INDEX IF NOT EXISTS idx_substitutions_date ON substitutions(date);
+CREATE INDEX IF NOT EXISTS idx_substitutions_original_teacher_id ON substitutions(original_teacher_id);
+CREATE INDEX IF NOT EXISTS idx_substitutions_substitute_teacher_id ON substitutions(substitute_teacher_id);
+CREATE INDEX IF NOT EXISTS idx_substitutions_deleted_at ON substitutions(deleted_at);
+
+-- A slot can only be covered once per date
+CREATE UNIQUE INDEX IF NOT EXISTS idx_substitutions_timetable_date ON substitutions(timetable_id, date) WHERE deleted_at IS NULL;
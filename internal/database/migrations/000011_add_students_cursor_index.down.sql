@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_students_institution_created_at_id;
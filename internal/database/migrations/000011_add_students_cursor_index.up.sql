@@ -0,0 +1,2 @@
+-- Supports keyset (cursor) pagination of students ordered by (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_students_institution_created_at_id ON students(institution_id, created_at, id);
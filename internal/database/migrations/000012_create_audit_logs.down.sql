@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_audit_logs_resource_type;
+DROP INDEX IF EXISTS idx_audit_logs_actor_user_id;
+DROP INDEX IF EXISTS idx_audit_logs_institution_created_at;
+
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,13 @@
+ALTER TABLE users DROP COLUMN IF EXISTS custom_role_id;
+
+DROP TABLE IF EXISTS role_permissions;
+
+DROP INDEX IF EXISTS idx_roles_institution_code;
+DROP INDEX IF EXISTS idx_roles_builtin_code;
+DROP INDEX IF EXISTS idx_roles_deleted_at;
+DROP INDEX IF EXISTS idx_roles_institution_id;
+DROP TABLE IF EXISTS roles;
+
+DROP INDEX IF EXISTS idx_permissions_deleted_at;
+DROP INDEX IF EXISTS idx_permissions_code;
+DROP TABLE IF EXISTS permissions;
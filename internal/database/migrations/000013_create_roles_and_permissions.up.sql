@@ -0,0 +1,39 @@
+-- Permissions that can be granted to roles
+CREATE TABLE IF NOT EXISTS permissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    code VARCHAR(100) NOT NULL,
+    description VARCHAR(255)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_permissions_code ON permissions(code);
+CREATE INDEX IF NOT EXISTS idx_permissions_deleted_at ON permissions(deleted_at);
+
+-- Roles: built-in roles have no institution, custom roles belong to one
+CREATE TABLE IF NOT EXISTS roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    institution_id UUID REFERENCES institutions(id),
+    name VARCHAR(100) NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    description VARCHAR(255),
+    is_built_in BOOLEAN DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS idx_roles_institution_id ON roles(institution_id);
+CREATE INDEX IF NOT EXISTS idx_roles_deleted_at ON roles(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_builtin_code ON roles(code) WHERE institution_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_institution_code ON roles(institution_id, code) WHERE institution_id IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id UUID NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+-- Custom role attached to a user in addition to their built-in role
+ALTER TABLE users ADD COLUMN IF NOT EXISTS custom_role_id UUID REFERENCES roles(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+ALTER TABLE institutions ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) DEFAULT 'UTC';
+
+UPDATE institutions SET timezone = institution_settings.timezone
+FROM institution_settings
+WHERE institution_settings.institution_id = institutions.id;
+
+DROP INDEX IF EXISTS idx_institution_settings_deleted_at;
+DROP INDEX IF EXISTS idx_institution_settings_institution_id;
+
+DROP TABLE IF EXISTS institution_settings;
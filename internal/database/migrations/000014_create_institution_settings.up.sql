@@ -0,0 +1,22 @@
+-- Per-institution settings (one row per institution)
+CREATE TABLE IF NOT EXISTS institution_settings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    institution_id UUID NOT NULL REFERENCES institutions(id),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    locale VARCHAR(20) NOT NULL DEFAULT 'en',
+    week_start_day INTEGER NOT NULL DEFAULT 1 CHECK (week_start_day BETWEEN 0 AND 6),
+    grading_scale JSONB
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_institution_settings_institution_id ON institution_settings(institution_id);
+CREATE INDEX IF NOT EXISTS idx_institution_settings_deleted_at ON institution_settings(deleted_at);
+
+-- Existing institutions keep their time zone; it now lives in the settings
+INSERT INTO institution_settings (institution_id, timezone)
+SELECT id, COALESCE(NULLIF(timezone, ''), 'UTC') FROM institutions
+ON CONFLICT (institution_id) DO NOTHING;
+
+ALTER TABLE institutions DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_holidays_institution_date;
+DROP INDEX IF EXISTS idx_holidays_deleted_at;
+DROP INDEX IF EXISTS idx_holidays_date;
+DROP INDEX IF EXISTS idx_holidays_academic_year_id;
+DROP INDEX IF EXISTS idx_holidays_institution_id;
+
+ALTER TABLE holidays
+    DROP COLUMN IF EXISTS academic_year_id,
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,21 @@
+-- Holidays (created in 000004) belong to an academic year and can be soft deleted
+ALTER TABLE holidays
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS academic_year_id UUID REFERENCES academic_years(id);
+
+-- Attach existing holidays to the academic year they fall in
+UPDATE holidays
+SET academic_year_id = academic_years.id
+FROM academic_years
+WHERE holidays.academic_year_id IS NULL
+    AND academic_years.institution_id = holidays.institution_id
+    AND academic_years.deleted_at IS NULL
+    AND holidays.date BETWEEN academic_years.start_date AND academic_years.end_date;
+
+CREATE INDEX IF NOT EXISTS idx_holidays_institution_id ON holidays(institution_id);
+CREATE INDEX IF NOT EXISTS idx_holidays_academic_year_id ON holidays(academic_year_id);
+CREATE INDEX IF NOT EXISTS idx_holidays_date ON holidays(date);
+CREATE INDEX IF NOT EXISTS idx_holidays_deleted_at ON holidays(deleted_at);
+
+-- An institution can only have one holiday per date
+CREATE UNIQUE INDEX IF NOT EXISTS idx_holidays_institution_date ON holidays(institution_id, date) WHERE deleted_at IS NULL;
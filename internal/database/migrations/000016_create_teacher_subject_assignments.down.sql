@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_teacher_subject_assignments_teacher_subject;
+DROP INDEX IF EXISTS idx_teacher_subject_assignments_deleted_at;
+DROP INDEX IF EXISTS idx_teacher_subject_assignments_subject_id;
+DROP INDEX IF EXISTS idx_teacher_subject_assignments_teacher_id;
+DROP INDEX IF EXISTS idx_teacher_subject_assignments_institution_id;
+
+DROP TABLE IF EXISTS teacher_subject_assignments;
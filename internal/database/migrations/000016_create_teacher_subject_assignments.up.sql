@@ -0,0 +1,18 @@
+-- Teacher subject assignments (teachers sharing a subject besides subjects.teacher_id)
+CREATE TABLE IF NOT EXISTS teacher_subject_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    institution_id UUID NOT NULL REFERENCES institutions(id),
+    teacher_id UUID NOT NULL REFERENCES teachers(id),
+    subject_id UUID NOT NULL REFERENCES subjects(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_teacher_subject_assignments_institution_id ON teacher_subject_assignments(institution_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_subject_assignments_teacher_id ON teacher_subject_assignments(teacher_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_subject_assignments_subject_id ON teacher_subject_assignments(subject_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_subject_assignments_deleted_at ON teacher_subject_assignments(deleted_at);
+
+-- A teacher can only be assigned to a subject once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teacher_subject_assignments_teacher_subject ON teacher_subject_assignments(teacher_id, subject_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_password_histories_user_created_at;
+
+DROP TABLE IF EXISTS password_histories;
@@ -0,0 +1,9 @@
+-- Password history (hashes of replaced passwords, used to prevent reuse)
+CREATE TABLE IF NOT EXISTS password_histories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_histories_user_created_at ON password_histories(user_id, created_at);
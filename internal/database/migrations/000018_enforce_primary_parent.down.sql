@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_parent_student_relations_primary;
+DROP INDEX IF EXISTS idx_parent_student_relations_parent_student;
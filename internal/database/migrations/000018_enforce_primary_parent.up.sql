@@ -0,0 +1,32 @@
+-- Relationships are stored in lowercase (father, mother, guardian)
+UPDATE parent_student_relations SET relationship = LOWER(relationship) WHERE relationship <> LOWER(relationship);
+
+-- Remove duplicate links, keeping the earliest
+UPDATE parent_student_relations r SET deleted_at = NOW()
+WHERE r.deleted_at IS NULL AND EXISTS (
+    SELECT 1 FROM parent_student_relations o
+    WHERE o.deleted_at IS NULL AND o.parent_id = r.parent_id AND o.student_id = r.student_id
+      AND (o.created_at, o.id) < (r.created_at, r.id)
+);
+
+-- Keep only the earliest primary parent of each student
+UPDATE parent_student_relations r SET is_primary = false
+WHERE r.deleted_at IS NULL AND r.is_primary AND EXISTS (
+    SELECT 1 FROM parent_student_relations o
+    WHERE o.deleted_at IS NULL AND o.is_primary AND o.student_id = r.student_id
+      AND (o.created_at, o.id) < (r.created_at, r.id)
+);
+
+-- Students without a primary parent get their earliest linked parent
+UPDATE parent_student_relations r SET is_primary = true
+WHERE r.id IN (
+    SELECT DISTINCT ON (student_id) id FROM parent_student_relations
+    WHERE deleted_at IS NULL
+    ORDER BY student_id, created_at, id
+) AND NOT EXISTS (
+    SELECT 1 FROM parent_student_relations o
+    WHERE o.deleted_at IS NULL AND o.is_primary AND o.student_id = r.student_id
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_parent_student_relations_parent_student ON parent_student_relations(parent_id, student_id) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_parent_student_relations_primary ON parent_student_relations(student_id) WHERE is_primary AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_student_transfers_deleted_at;
+DROP INDEX IF EXISTS idx_student_transfers_to_institution_id;
+DROP INDEX IF EXISTS idx_student_transfers_from_institution_id;
+DROP INDEX IF EXISTS idx_student_transfers_student_id;
+
+DROP TABLE IF EXISTS student_transfers;
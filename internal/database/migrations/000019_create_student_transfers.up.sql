@@ -0,0 +1,20 @@
+-- Student transfers (history of students moved between institutions)
+CREATE TABLE IF NOT EXISTS student_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    student_id UUID NOT NULL REFERENCES students(id),
+    from_institution_id UUID NOT NULL REFERENCES institutions(id),
+    to_institution_id UUID NOT NULL REFERENCES institutions(id),
+    from_class_id UUID REFERENCES classes(id),
+    from_section_id UUID REFERENCES sections(id),
+    from_roll_number INTEGER,
+    reason TEXT,
+    transferred_by UUID REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_transfers_student_id ON student_transfers(student_id);
+CREATE INDEX IF NOT EXISTS idx_student_transfers_from_institution_id ON student_transfers(from_institution_id);
+CREATE INDEX IF NOT EXISTS idx_student_transfers_to_institution_id ON student_transfers(to_institution_id);
+CREATE INDEX IF NOT EXISTS idx_student_transfers_deleted_at ON student_transfers(deleted_at);
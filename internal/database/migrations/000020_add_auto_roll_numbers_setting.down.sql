@@ -0,0 +1 @@
+ALTER TABLE institution_settings DROP COLUMN IF EXISTS auto_roll_numbers;
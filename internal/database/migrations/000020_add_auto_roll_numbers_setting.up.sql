@@ -0,0 +1,2 @@
+-- Institutions can turn off automatic roll number assignment
+ALTER TABLE institution_settings ADD COLUMN IF NOT EXISTS auto_roll_numbers BOOLEAN NOT NULL DEFAULT true;
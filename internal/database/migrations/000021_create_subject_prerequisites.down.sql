@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_subject_prerequisites_subject_prerequisite;
+DROP INDEX IF EXISTS idx_subject_prerequisites_deleted_at;
+DROP INDEX IF EXISTS idx_subject_prerequisites_prerequisite_subject_id;
+DROP INDEX IF EXISTS idx_subject_prerequisites_subject_id;
+DROP INDEX IF EXISTS idx_subject_prerequisites_institution_id;
+
+DROP TABLE IF EXISTS subject_prerequisites;
@@ -0,0 +1,19 @@
+-- Subject prerequisites (subjects that must be completed before another subject)
+CREATE TABLE IF NOT EXISTS subject_prerequisites (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    institution_id UUID NOT NULL REFERENCES institutions(id),
+    subject_id UUID NOT NULL REFERENCES subjects(id),
+    prerequisite_subject_id UUID NOT NULL REFERENCES subjects(id),
+    CHECK (subject_id <> prerequisite_subject_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subject_prerequisites_institution_id ON subject_prerequisites(institution_id);
+CREATE INDEX IF NOT EXISTS idx_subject_prerequisites_subject_id ON subject_prerequisites(subject_id);
+CREATE INDEX IF NOT EXISTS idx_subject_prerequisites_prerequisite_subject_id ON subject_prerequisites(prerequisite_subject_id);
+CREATE INDEX IF NOT EXISTS idx_subject_prerequisites_deleted_at ON subject_prerequisites(deleted_at);
+
+-- A prerequisite can only be linked to a subject once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subject_prerequisites_subject_prerequisite ON subject_prerequisites(subject_id, prerequisite_subject_id) WHERE deleted_at IS NULL;
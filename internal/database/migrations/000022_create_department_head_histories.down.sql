@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_department_head_histories_current;
+DROP INDEX IF EXISTS idx_department_head_histories_deleted_at;
+DROP INDEX IF EXISTS idx_department_head_histories_teacher_id;
+DROP INDEX IF EXISTS idx_department_head_histories_department_id;
+
+DROP TABLE IF EXISTS department_head_histories;
@@ -0,0 +1,24 @@
+-- Department head history (who headed a department and when)
+CREATE TABLE IF NOT EXISTS department_head_histories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    department_id UUID NOT NULL REFERENCES departments(id),
+    teacher_id UUID NOT NULL REFERENCES teachers(id),
+    from_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    to_date TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_department_head_histories_department_id ON department_head_histories(department_id);
+CREATE INDEX IF NOT EXISTS idx_department_head_histories_teacher_id ON department_head_histories(teacher_id);
+CREATE INDEX IF NOT EXISTS idx_department_head_histories_deleted_at ON department_head_histories(deleted_at);
+
+-- A department has at most one current head
+CREATE UNIQUE INDEX IF NOT EXISTS idx_department_head_histories_current ON department_head_histories(department_id) WHERE to_date IS NULL AND deleted_at IS NULL;
+
+-- Open a record for the heads already assigned
+INSERT INTO department_head_histories (department_id, teacher_id, from_date)
+SELECT id, head_of_department_id, updated_at
+FROM departments
+WHERE head_of_department_id IS NOT NULL AND deleted_at IS NULL;
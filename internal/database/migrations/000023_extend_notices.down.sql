@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_notices_deleted_at;
+DROP INDEX IF EXISTS idx_notices_published_at;
+DROP INDEX IF EXISTS idx_notices_institution_id;
+
+ALTER TABLE notices
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS updated_at;
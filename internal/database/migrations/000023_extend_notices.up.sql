@@ -0,0 +1,14 @@
+-- Notices (created in 000004) can be updated, soft deleted and expire at a point in time
+ALTER TABLE notices
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Existing notices stay visible until the end of their expiry date
+UPDATE notices
+SET expires_at = (expiry_date + 1)::TIMESTAMP WITH TIME ZONE
+WHERE expires_at IS NULL AND expiry_date IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notices_institution_id ON notices(institution_id);
+CREATE INDEX IF NOT EXISTS idx_notices_published_at ON notices(published_at);
+CREATE INDEX IF NOT EXISTS idx_notices_deleted_at ON notices(deleted_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_attendance_section_date;
+DROP INDEX IF EXISTS idx_attendance_student_date;
+CREATE INDEX IF NOT EXISTS idx_attendance_student_date ON attendance(student_id, date);
+
+ALTER TABLE attendance
+    DROP COLUMN IF EXISTS section_id,
+    DROP COLUMN IF EXISTS updated_at;
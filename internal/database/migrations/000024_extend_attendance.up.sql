@@ -0,0 +1,16 @@
+-- Attendance (created in 000003) is marked per section and can be corrected
+ALTER TABLE attendance
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS section_id UUID REFERENCES sections(id);
+
+-- Keep only the latest record per student and date before enforcing uniqueness
+DELETE FROM attendance a
+USING attendance b
+WHERE a.student_id = b.student_id
+    AND a.date = b.date
+    AND (a.created_at < b.created_at OR (a.created_at = b.created_at AND a.id < b.id));
+
+-- A student has at most one attendance record per date; marking again updates it
+DROP INDEX IF EXISTS idx_attendance_student_date;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attendance_student_date ON attendance(student_id, date);
+CREATE INDEX IF NOT EXISTS idx_attendance_section_date ON attendance(section_id, date);
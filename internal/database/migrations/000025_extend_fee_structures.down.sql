@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_fee_structures_deleted_at;
+DROP INDEX IF EXISTS idx_fee_structures_class_id;
+DROP INDEX IF EXISTS idx_fee_structures_academic_year_id;
+DROP INDEX IF EXISTS idx_fee_structures_institution_id;
+
+ALTER TABLE fee_structures
+    DROP COLUMN IF EXISTS frequency,
+    DROP COLUMN IF EXISTS academic_year_id,
+    DROP COLUMN IF EXISTS deleted_at;
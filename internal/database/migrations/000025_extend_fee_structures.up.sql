@@ -0,0 +1,20 @@
+-- Fee structures (created in 000004) belong to an academic year, recur at a
+-- frequency and can be soft deleted
+ALTER TABLE fee_structures
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS academic_year_id UUID REFERENCES academic_years(id),
+    ADD COLUMN IF NOT EXISTS frequency VARCHAR(20) NOT NULL DEFAULT 'ANNUAL';
+
+-- Attach existing fee structures to the academic year named in the legacy column
+UPDATE fee_structures
+SET academic_year_id = academic_years.id
+FROM academic_years
+WHERE fee_structures.academic_year_id IS NULL
+    AND academic_years.institution_id = fee_structures.institution_id
+    AND academic_years.name = fee_structures.academic_year
+    AND academic_years.deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_fee_structures_institution_id ON fee_structures(institution_id);
+CREATE INDEX IF NOT EXISTS idx_fee_structures_academic_year_id ON fee_structures(academic_year_id);
+CREATE INDEX IF NOT EXISTS idx_fee_structures_class_id ON fee_structures(class_id);
+CREATE INDEX IF NOT EXISTS idx_fee_structures_deleted_at ON fee_structures(deleted_at);
@@ -0,0 +1,2 @@
+-- Institutions can override the default request limit per rate limit window; NULL keeps the default
+ALTER TABLE institution_settings ADD COLUMN IF NOT EXISTS rate_limit_requests INTEGER;
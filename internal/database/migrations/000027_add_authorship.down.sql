@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_students_created_by;
+DROP INDEX IF EXISTS idx_subjects_created_by;
+DROP INDEX IF EXISTS idx_classes_created_by;
+
+ALTER TABLE students DROP COLUMN IF EXISTS updated_by, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE subjects DROP COLUMN IF EXISTS updated_by, DROP COLUMN IF EXISTS created_by;
+ALTER TABLE classes DROP COLUMN IF EXISTS updated_by, DROP COLUMN IF EXISTS created_by;
@@ -0,0 +1,18 @@
+-- Track the user who created and last updated classes, subjects and students.
+-- Existing rows keep NULL, meaning the author is unknown; seeded rows use the
+-- system actor 00000000-0000-0000-0000-000000000001, which matches no user.
+ALTER TABLE classes
+    ADD COLUMN IF NOT EXISTS created_by UUID,
+    ADD COLUMN IF NOT EXISTS updated_by UUID;
+
+ALTER TABLE subjects
+    ADD COLUMN IF NOT EXISTS created_by UUID,
+    ADD COLUMN IF NOT EXISTS updated_by UUID;
+
+ALTER TABLE students
+    ADD COLUMN IF NOT EXISTS created_by UUID,
+    ADD COLUMN IF NOT EXISTS updated_by UUID;
+
+CREATE INDEX IF NOT EXISTS idx_classes_created_by ON classes(created_by);
+CREATE INDEX IF NOT EXISTS idx_subjects_created_by ON subjects(created_by);
+CREATE INDEX IF NOT EXISTS idx_students_created_by ON students(created_by);
@@ -0,0 +1,29 @@
+ALTER TABLE student_transfers DROP COLUMN IF EXISTS version;
+ALTER TABLE student_promotions DROP COLUMN IF EXISTS version;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS version;
+ALTER TABLE permissions DROP COLUMN IF EXISTS version;
+ALTER TABLE roles DROP COLUMN IF EXISTS version;
+ALTER TABLE notices DROP COLUMN IF EXISTS version;
+ALTER TABLE fee_structures DROP COLUMN IF EXISTS version;
+ALTER TABLE holidays DROP COLUMN IF EXISTS version;
+ALTER TABLE terms DROP COLUMN IF EXISTS version;
+ALTER TABLE academic_years DROP COLUMN IF EXISTS version;
+ALTER TABLE periods DROP COLUMN IF EXISTS version;
+ALTER TABLE substitutions DROP COLUMN IF EXISTS version;
+ALTER TABLE timetables DROP COLUMN IF EXISTS version;
+ALTER TABLE teacher_subject_assignments DROP COLUMN IF EXISTS version;
+ALTER TABLE subject_prerequisites DROP COLUMN IF EXISTS version;
+ALTER TABLE subjects DROP COLUMN IF EXISTS version;
+ALTER TABLE sections DROP COLUMN IF EXISTS version;
+ALTER TABLE classes DROP COLUMN IF EXISTS version;
+ALTER TABLE department_head_histories DROP COLUMN IF EXISTS version;
+ALTER TABLE departments DROP COLUMN IF EXISTS version;
+ALTER TABLE accountants DROP COLUMN IF EXISTS version;
+ALTER TABLE parent_student_relations DROP COLUMN IF EXISTS version;
+ALTER TABLE parents DROP COLUMN IF EXISTS version;
+ALTER TABLE students DROP COLUMN IF EXISTS version;
+ALTER TABLE teachers DROP COLUMN IF EXISTS version;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE institution_settings DROP COLUMN IF EXISTS version;
+ALTER TABLE institutions DROP COLUMN IF EXISTS version;
@@ -0,0 +1 @@
+ALTER TABLE timetables DROP COLUMN IF EXISTS week_type;
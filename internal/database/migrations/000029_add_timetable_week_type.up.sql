@@ -0,0 +1,5 @@
+-- Timetable entries can run every week or only in odd or even ISO weeks.
+-- Existing entries run every week.
+ALTER TABLE timetables
+    ADD COLUMN IF NOT EXISTS week_type VARCHAR(10) NOT NULL DEFAULT 'ALL'
+        CONSTRAINT chk_timetables_week_type CHECK (week_type IN ('ALL', 'ODD', 'EVEN'));
@@ -0,0 +1,3 @@
+-- Accounts created by an admin or an import start with a password someone else
+-- chose and must change it before doing anything else. Existing accounts are not affected.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
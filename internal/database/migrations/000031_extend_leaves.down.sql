@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_leaves_deleted_at;
+DROP INDEX IF EXISTS idx_leaves_user_status;
+
+ALTER TABLE leaves
+    DROP CONSTRAINT IF EXISTS chk_leaves_date_range,
+    DROP CONSTRAINT IF EXISTS chk_leaves_status,
+    ALTER COLUMN status DROP NOT NULL;
+
+ALTER TABLE leaves
+    DROP COLUMN IF EXISTS version,
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,17 @@
+-- Leave applications (created in 000003) can be soft deleted and are reviewed with
+-- optimistic locking. approved_by and approved_at record the reviewer of both
+-- approvals and rejections.
+ALTER TABLE leaves
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+UPDATE leaves SET status = 'PENDING' WHERE status IS NULL;
+
+ALTER TABLE leaves
+    ALTER COLUMN status SET NOT NULL,
+    ADD CONSTRAINT chk_leaves_status CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    ADD CONSTRAINT chk_leaves_date_range CHECK (end_date >= start_date);
+
+-- Overlap checks look up a user's pending applications
+CREATE INDEX IF NOT EXISTS idx_leaves_user_status ON leaves(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_leaves_deleted_at ON leaves(deleted_at);
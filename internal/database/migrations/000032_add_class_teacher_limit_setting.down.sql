@@ -0,0 +1 @@
+ALTER TABLE institution_settings DROP COLUMN IF EXISTS max_classes_per_class_teacher;
@@ -0,0 +1,2 @@
+-- Institutions can cap how many classes one teacher leads as class teacher; NULL means no limit
+ALTER TABLE institution_settings ADD COLUMN IF NOT EXISTS max_classes_per_class_teacher INTEGER;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_subjects_institution_code;
+DROP INDEX IF EXISTS idx_subjects_class_name;
+DROP INDEX IF EXISTS idx_sections_class_name;
+DROP INDEX IF EXISTS idx_classes_institution_name;
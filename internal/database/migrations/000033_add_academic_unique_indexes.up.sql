@@ -0,0 +1,6 @@
+-- Enforce the name and code uniqueness the services check for, so two racing
+-- requests can't both create the same class, section or subject
+CREATE UNIQUE INDEX IF NOT EXISTS idx_classes_institution_name ON classes(institution_id, name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sections_class_name ON sections(class_id, name) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subjects_class_name ON subjects(class_id, name) WHERE class_id IS NOT NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subjects_institution_code ON subjects(institution_id, code) WHERE code IS NOT NULL AND code <> '' AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_subject_teacher_histories_current;
+DROP INDEX IF EXISTS idx_subject_teacher_histories_deleted_at;
+DROP INDEX IF EXISTS idx_subject_teacher_histories_teacher_id;
+DROP INDEX IF EXISTS idx_subject_teacher_histories_subject_id;
+
+DROP TABLE IF EXISTS subject_teacher_histories;
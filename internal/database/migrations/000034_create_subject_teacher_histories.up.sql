@@ -0,0 +1,25 @@
+-- Subject teacher history (who taught a subject and when)
+CREATE TABLE IF NOT EXISTS subject_teacher_histories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    version INTEGER NOT NULL DEFAULT 1,
+    subject_id UUID NOT NULL REFERENCES subjects(id),
+    teacher_id UUID NOT NULL REFERENCES teachers(id),
+    from_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    to_date TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_subject_teacher_histories_subject_id ON subject_teacher_histories(subject_id);
+CREATE INDEX IF NOT EXISTS idx_subject_teacher_histories_teacher_id ON subject_teacher_histories(teacher_id);
+CREATE INDEX IF NOT EXISTS idx_subject_teacher_histories_deleted_at ON subject_teacher_histories(deleted_at);
+
+-- A subject has at most one current teacher
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subject_teacher_histories_current ON subject_teacher_histories(subject_id) WHERE to_date IS NULL AND deleted_at IS NULL;
+
+-- Open a record for the teachers already assigned
+INSERT INTO subject_teacher_histories (subject_id, teacher_id, from_date)
+SELECT id, teacher_id, updated_at
+FROM subjects
+WHERE teacher_id IS NOT NULL AND deleted_at IS NULL;
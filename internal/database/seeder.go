@@ -12,14 +12,20 @@ import (
 
 type Seeder struct {
 	db *gorm.DB
+
+	// keepDefaultPasswords lets seeded users skip the forced change of their default password
+	keepDefaultPasswords bool
 }
 
-func NewSeeder(db *gorm.DB) *Seeder {
-	return &Seeder{db: db}
+func NewSeeder(db *gorm.DB, keepDefaultPasswords bool) *Seeder {
+	return &Seeder{db: db, keepDefaultPasswords: keepDefaultPasswords}
 }
 
 func (s *Seeder) SeedAll() error {
 	logger.Info("Starting database seeding...")
+	if err := s.SeedRoles(); err != nil {
+		return err
+	}
 	if err := s.SeedInstitutions(); err != nil {
 		return err
 	}
@@ -120,11 +126,14 @@ func (s *Seeder) SeedSuperAdmin() error {
 	}
 
 	superAdmin := &models.User{
-		BaseModel:    models.BaseModel{ID: uuid.New()},
-		Email:        "superadmin@campus.local",
-		PasswordHash: hashedPassword,
-		Role:         models.RoleSuperAdmin,
-		IsActive:     true,
+		BaseModel:     models.BaseModel{ID: uuid.New()},
+		Email:         "superadmin@campus.local",
+		PasswordHash:  hashedPassword,
+		Role:          models.RoleSuperAdmin,
+		IsActive:      true,
+		EmailVerified: true,
+
+		MustChangePassword: !s.keepDefaultPasswords,
 	}
 
 	if err := s.db.Create(superAdmin).Error; err != nil {
@@ -27,10 +27,12 @@ func (s *Seeder) SeedDepartments() error {
 			s.db.Model(&models.Department{}).Where("institution_id = ? AND name = ?", inst.ID, name).Count(&count)
 			if count == 0 {
 				dept := &models.Department{
-					BaseModel:     models.BaseModel{ID: uuid.New()},
-					InstitutionID: inst.ID,
-					Name:          name,
-					Description:   name + " Department",
+					TenantBaseModel: models.TenantBaseModel{
+						BaseModel:     models.BaseModel{ID: uuid.New()},
+						InstitutionID: inst.ID,
+					},
+					Name:        name,
+					Description: name + " Department",
 				}
 				if err := s.db.Create(dept).Error; err != nil {
 					return err
@@ -63,12 +65,15 @@ func (s *Seeder) SeedClasses() error {
 			if err != nil {
 				// Create class
 				class = models.Class{
-					BaseModel:     models.BaseModel{ID: uuid.New()},
-					InstitutionID: inst.ID,
-					Name:          className,
-					SectionCount:  2,
-					Capacity:      50,
+					TenantBaseModel: models.TenantBaseModel{
+						BaseModel:     models.BaseModel{ID: uuid.New()},
+						InstitutionID: inst.ID,
+					},
+					Name:         className,
+					SectionCount: 2,
+					Capacity:     50,
 				}
+				class.SetCreatedBy(models.SystemActorID)
 				if err := s.db.Create(&class).Error; err != nil {
 					return err
 				}
@@ -134,14 +139,17 @@ func (s *Seeder) createSubjectIfNotExists(classID uuid.UUID, institutionID uuid.
 	s.db.Model(&models.Subject{}).Where("class_id = ? AND name = ?", classID, name).Count(&count)
 	if count == 0 {
 		subject := &models.Subject{
-			BaseModel:     models.BaseModel{ID: uuid.New()},
-			InstitutionID: institutionID,
-			ClassID:       &classID,
-			Name:          name,
-			Code:          name[0:3] + "-101", // Dummy code
-			IsElective:    isElective,
-			CreditHours:   3.0,
+			TenantBaseModel: models.TenantBaseModel{
+				BaseModel:     models.BaseModel{ID: uuid.New()},
+				InstitutionID: institutionID,
+			},
+			ClassID:     &classID,
+			Name:        name,
+			Code:        name[0:3] + "-101", // Dummy code
+			IsElective:  isElective,
+			CreditHours: 3.0,
 		}
+		subject.SetCreatedBy(models.SystemActorID)
 		s.db.Create(subject)
 		logger.Info("Subject seeded", zap.String("name", name), zap.String("class_id", classID.String()))
 	}
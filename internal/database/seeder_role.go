@@ -0,0 +1,63 @@
+package database
+
+import (
+	"errors"
+
+	"campus-core/internal/models"
+	"campus-core/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SeedRoles creates the permissions and built-in roles.
+// Built-in roles get their default permissions only when first created,
+// so later changes made through the API are kept.
+func (s *Seeder) SeedRoles() error {
+	codes := append(models.BuiltInPermissions(), models.PermissionAll)
+
+	permissions := make(map[string]models.Permission, len(codes))
+	for _, code := range codes {
+		var perm models.Permission
+		err := s.db.Where("code = ?", code).First(&perm).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			perm = models.Permission{
+				BaseModel: models.BaseModel{ID: uuid.New()},
+				Code:      code,
+			}
+			if err := s.db.Create(&perm).Error; err != nil {
+				logger.Error("Failed to seed permission", zap.String("code", code), zap.Error(err))
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		permissions[code] = perm
+	}
+
+	for _, roleCode := range models.ValidRoles {
+		var count int64
+		s.db.Model(&models.Role{}).Where("code = ? AND institution_id IS NULL", roleCode).Count(&count)
+		if count > 0 {
+			continue
+		}
+
+		role := &models.Role{
+			BaseModel: models.BaseModel{ID: uuid.New()},
+			Name:      roleCode,
+			Code:      roleCode,
+			IsBuiltIn: true,
+		}
+		for _, code := range models.DefaultRolePermissions[roleCode] {
+			role.Permissions = append(role.Permissions, permissions[code])
+		}
+		if err := s.db.Create(role).Error; err != nil {
+			logger.Error("Failed to seed role", zap.String("code", roleCode), zap.Error(err))
+			return err
+		}
+		logger.Info("Built-in role seeded", zap.String("code", roleCode))
+	}
+
+	return nil
+}
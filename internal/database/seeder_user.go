@@ -55,11 +55,14 @@ func (s *Seeder) seedRoleUser(institutionID uuid.UUID, firstName, lastName, emai
 
 	hashedPassword, _ := utils.HashPassword("Pass@123")
 	user := &models.User{
-		BaseModel:    models.BaseModel{ID: uuid.New()},
-		Email:        email,
-		PasswordHash: hashedPassword,
-		Role:         role,
-		IsActive:     true,
+		BaseModel:     models.BaseModel{ID: uuid.New()},
+		Email:         email,
+		PasswordHash:  hashedPassword,
+		Role:          role,
+		IsActive:      true,
+		EmailVerified: true,
+
+		MustChangePassword: !s.keepDefaultPasswords,
 	}
 	if err := s.db.Create(user).Error; err != nil {
 		return err
@@ -181,6 +184,7 @@ func (s *Seeder) seedStudents(institutionID uuid.UUID) error {
 				SectionID:     &section.ID,
 				BloodGroup:    "B+",
 			}
+			student.SetCreatedBy(models.SystemActorID)
 			if err := s.db.Create(student).Error; err != nil {
 				logger.Error("Failed to create student", zap.Error(err))
 			}
@@ -219,7 +223,7 @@ func (s *Seeder) seedStudents(institutionID uuid.UUID) error {
 					BaseModel:    models.BaseModel{ID: uuid.New()},
 					ParentID:     parentID,
 					StudentID:    student.ID,
-					Relationship: "Father",
+					Relationship: models.RelationshipFather,
 					IsPrimary:    true,
 				}
 				s.db.Create(relation)
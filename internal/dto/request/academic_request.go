@@ -32,6 +32,8 @@ type UpdateClassRequest struct {
 	Name           string `json:"name" binding:"omitempty,min=1,max=50"`
 	ClassTeacherID string `json:"class_teacher_id" binding:"omitempty,uuid"`
 	Capacity       *int   `json:"capacity" binding:"omitempty,min=1,max=500"`
+	// Version is the version of the record the client read; stale updates are rejected
+	Version *int `json:"version" binding:"required,min=1"`
 }
 
 // CreateSectionRequest represents the request to create a section
@@ -48,6 +50,12 @@ type UpdateSectionRequest struct {
 	Capacity   *int   `json:"capacity" binding:"omitempty,min=1,max=100"`
 }
 
+// ReassignRollNumbersRequest represents the request to renumber a class's students by name.
+// Without a section, every section of the class is renumbered.
+type ReassignRollNumbersRequest struct {
+	SectionID string `json:"section_id" binding:"omitempty,uuid"`
+}
+
 // CreateSubjectRequest represents the request to create a subject
 type CreateSubjectRequest struct {
 	ClassID     string  `json:"class_id" binding:"omitempty,uuid"`
@@ -66,6 +74,8 @@ type UpdateSubjectRequest struct {
 	Code        string   `json:"code" binding:"omitempty,max=20"`
 	IsElective  *bool    `json:"is_elective"`
 	CreditHours *float64 `json:"credit_hours" binding:"omitempty,min=0,max=10"`
+	// Version is the version of the record the client read; stale updates are rejected
+	Version *int `json:"version" binding:"required,min=1"`
 }
 
 // AssignTeacherRequest represents the request to assign a teacher to a subject
@@ -73,6 +83,11 @@ type AssignTeacherRequest struct {
 	TeacherID string `json:"teacher_id" binding:"required,uuid"`
 }
 
+// AddPrerequisiteRequest represents the request to add a prerequisite to a subject
+type AddPrerequisiteRequest struct {
+	PrerequisiteSubjectID string `json:"prerequisite_subject_id" binding:"required,uuid"`
+}
+
 // CreateDepartmentRequest represents the request to create a department
 type CreateDepartmentRequest struct {
 	Name               string `json:"name" binding:"required,min=1,max=100"`
@@ -80,11 +95,12 @@ type CreateDepartmentRequest struct {
 	Description        string `json:"description" binding:"max=500"`
 }
 
-// UpdateDepartmentRequest represents the request to update a department
+// UpdateDepartmentRequest represents the request to update a department.
+// An empty head_of_department_id clears the head; omitting it leaves the head unchanged.
 type UpdateDepartmentRequest struct {
-	Name               string `json:"name" binding:"omitempty,min=1,max=100"`
-	HeadOfDepartmentID string `json:"head_of_department_id" binding:"omitempty,uuid"`
-	Description        string `json:"description" binding:"max=500"`
+	Name               string  `json:"name" binding:"omitempty,min=1,max=100"`
+	HeadOfDepartmentID *string `json:"head_of_department_id" binding:"omitempty,uuid"`
+	Description        string  `json:"description" binding:"max=500"`
 }
 
 // CreateTimetableRequest represents the request to create a timetable entry
@@ -95,8 +111,9 @@ type CreateTimetableRequest struct {
 	SubjectID      string `json:"subject_id" binding:"required,uuid"`
 	TeacherID      string `json:"teacher_id" binding:"required,uuid"`
 	DayOfWeek      string `json:"day_of_week" binding:"required,oneof=SUNDAY MONDAY TUESDAY WEDNESDAY THURSDAY FRIDAY SATURDAY"`
-	StartTime      string `json:"start_time" binding:"required"` // Format: "09:00"
-	EndTime        string `json:"end_time" binding:"required"`   // Format: "09:45"
+	WeekType       string `json:"week_type" binding:"omitempty,oneof=ALL ODD EVEN"`      // Defaults to ALL
+	StartTime      string `json:"start_time" binding:"required,hhmm"`                    // Format: "09:00"
+	EndTime        string `json:"end_time" binding:"required,hhmm,hhmmafter=start_time"` // Format: "09:45"
 	RoomNumber     string `json:"room_number" binding:"max=50"`
 }
 
@@ -108,13 +125,91 @@ type UpdateTimetableRequest struct {
 	SubjectID      string `json:"subject_id" binding:"omitempty,uuid"`
 	TeacherID      string `json:"teacher_id" binding:"omitempty,uuid"`
 	DayOfWeek      string `json:"day_of_week" binding:"omitempty,oneof=SUNDAY MONDAY TUESDAY WEDNESDAY THURSDAY FRIDAY SATURDAY"`
-	StartTime      string `json:"start_time"`
-	EndTime        string `json:"end_time"`
+	WeekType       string `json:"week_type" binding:"omitempty,oneof=ALL ODD EVEN"`
+	StartTime      string `json:"start_time" binding:"omitempty,hhmm"`
+	EndTime        string `json:"end_time" binding:"omitempty,hhmm,hhmmafter=start_time"`
 	RoomNumber     string `json:"room_number" binding:"max=50"`
 	IsActive       *bool  `json:"is_active"`
+	// Version is the version of the record the client read; stale updates are rejected
+	Version *int `json:"version" binding:"required,min=1"`
+}
+
+// TeacherFreeSlotsQuery represents the query parameters for finding a teacher's free slots
+type TeacherFreeSlotsQuery struct {
+	AcademicYearID string `form:"academic_year_id" binding:"omitempty,uuid"`
+	DayStart       string `form:"day_start"` // Format: "08:00", defaults to the standard school day
+	DayEnd         string `form:"day_end"`   // Format: "16:00"
+	Days           string `form:"days"`      // Comma-separated, e.g. "SUNDAY,MONDAY"; defaults to every day
+}
+
+// RoomCheckRequest represents a request to check whether a room is free
+type RoomCheckRequest struct {
+	RoomNumber     string `json:"room_number" binding:"required,max=50"`
+	DayOfWeek      string `json:"day_of_week" binding:"required,oneof=SUNDAY MONDAY TUESDAY WEDNESDAY THURSDAY FRIDAY SATURDAY"`
+	StartTime      string `json:"start_time" binding:"required,hhmm"`                    // Format: "09:00"
+	EndTime        string `json:"end_time" binding:"required,hhmm,hhmmafter=start_time"` // Format: "09:45"
+	AcademicYearID string `json:"academic_year_id" binding:"omitempty,uuid"`
+}
+
+// CopyTimetableRequest represents the request to copy a timetable to another academic year
+type CopyTimetableRequest struct {
+	SourceAcademicYearID string `json:"source_academic_year_id" binding:"required,uuid"`
+	TargetAcademicYearID string `json:"target_academic_year_id" binding:"required,uuid"`
+	SkipConflicts        bool   `json:"skip_conflicts"`
+}
+
+// CreateSubstitutionRequest represents the request to assign a substitute teacher to a slot
+type CreateSubstitutionRequest struct {
+	SubstituteTeacherID string `json:"substitute_teacher_id" binding:"required,uuid"`
+	Date                string `json:"date" binding:"required,datetime=2006-01-02"`
+	Reason              string `json:"reason" binding:"max=500"`
+}
+
+// CreateHolidayRequest represents the request to add a holiday to an academic year
+type CreateHolidayRequest struct {
+	Date string `json:"date" binding:"required,datetime=2006-01-02"`
+	Name string `json:"name" binding:"required,min=2,max=100"`
+	Type string `json:"type" binding:"omitempty,oneof=PUBLIC RELIGIOUS VACATION OTHER"`
+}
+
+// UpdateHolidayRequest represents the request to update a holiday
+type UpdateHolidayRequest struct {
+	Date string `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Name string `json:"name" binding:"omitempty,min=2,max=100"`
+	Type string `json:"type" binding:"omitempty,oneof=PUBLIC RELIGIOUS VACATION OTHER"`
+}
+
+// CreatePeriodRequest represents the request to add a period to the school day
+type CreatePeriodRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=50"`
+	StartTime string `json:"start_time" binding:"required,hhmm"`
+	EndTime   string `json:"end_time" binding:"required,hhmm,hhmmafter=start_time"`
+	Order     int    `json:"order" binding:"required,min=1"`
+	IsBreak   bool   `json:"is_break"`
+}
+
+// UpdatePeriodRequest represents the request to update a period
+type UpdatePeriodRequest struct {
+	Name      string `json:"name" binding:"omitempty,min=1,max=50"`
+	StartTime string `json:"start_time" binding:"omitempty,hhmm"`
+	EndTime   string `json:"end_time" binding:"omitempty,hhmm,hhmmafter=start_time"`
+	Order     *int   `json:"order" binding:"omitempty,min=1"`
+	IsBreak   *bool  `json:"is_break"`
+}
+
+// TeacherWorkloadQuery represents the query parameters for the teacher workload report
+type TeacherWorkloadQuery struct {
+	AcademicYearID string `form:"academic_year_id" binding:"omitempty,uuid"` // Defaults to the current academic year
+	DepartmentID   string `form:"department_id" binding:"omitempty,uuid"`
 }
 
 // BulkTimetableRequest represents the request to create multiple timetable entries
 type BulkTimetableRequest struct {
 	Entries []CreateTimetableRequest `json:"entries" binding:"required,min=1,dive"`
 }
+
+// AssignClassTeachersRequest represents the request to set the class teachers of
+// several classes at once, keyed by class ID. An empty teacher ID removes the class teacher.
+type AssignClassTeachersRequest struct {
+	Assignments map[string]string `json:"assignments" binding:"required,min=1,dive,keys,uuid,endkeys,omitempty,uuid"`
+}
@@ -0,0 +1,16 @@
+package request
+
+// MarkAttendanceRequest represents the request to mark a section's attendance for a date.
+// Students not listed get DefaultStatus when it is set and are left unmarked otherwise.
+type MarkAttendanceRequest struct {
+	Date          string                    `json:"date" binding:"required,datetime=2006-01-02"`
+	DefaultStatus string                    `json:"default_status" binding:"omitempty,oneof=PRESENT ABSENT LATE"`
+	Records       []AttendanceRecordRequest `json:"records" binding:"dive"`
+}
+
+// AttendanceRecordRequest is the attendance of one student
+type AttendanceRecordRequest struct {
+	StudentID string `json:"student_id" binding:"required,uuid"`
+	Status    string `json:"status" binding:"required,oneof=PRESENT ABSENT LATE"`
+	Remarks   string `json:"remarks"`
+}
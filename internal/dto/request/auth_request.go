@@ -2,9 +2,17 @@ package request
 
 // LoginRequest represents a login request
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required_without=Phone,omitempty,email"`
-	Phone    string `json:"phone" binding:"required_without=Email,omitempty"`
-	Password string `json:"password" binding:"required,min=8"`
+	Email      string `json:"email" binding:"required_without_all=Phone Identifier,omitempty,email"`
+	Phone      string `json:"phone" binding:"required_without_all=Email Identifier,omitempty"`
+	Identifier string `json:"identifier" binding:"omitempty,max=255"` // Email, phone or admission number
+	Password   string `json:"password" binding:"required,min=8"`
+
+	// Institution from the X-Institution-ID header, needed for admission number login
+	InstitutionID string `json:"-"`
+
+	// Client details recorded on the session, set by the handler
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 // RegisterRequest represents a user registration request (admin only)
@@ -21,6 +29,10 @@ type RegisterRequest struct {
 // RefreshTokenRequest represents a token refresh request
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+
+	// Client details recorded on the session, set by the handler
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 // ForgotPasswordRequest represents a forgot password request
@@ -28,6 +40,11 @@ type ForgotPasswordRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
+// SendVerificationRequest represents a request to (re)send an email verification link
+type SendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 // ResetPasswordRequest represents a password reset request
 type ResetPasswordRequest struct {
 	Token       string `json:"token" binding:"required"`
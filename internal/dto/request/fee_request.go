@@ -0,0 +1,21 @@
+package request
+
+// CreateFeeStructureRequest represents the request to create a fee structure
+type CreateFeeStructureRequest struct {
+	AcademicYearID string  `json:"academic_year_id" binding:"required,uuid"`
+	ClassID        string  `json:"class_id" binding:"required,uuid"`
+	Name           string  `json:"name" binding:"required,min=2,max=100"`
+	Amount         float64 `json:"amount" binding:"required,gt=0"` // Charged per payment
+	DueDate        string  `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	Frequency      string  `json:"frequency" binding:"omitempty,oneof=MONTHLY QUARTERLY HALF_YEARLY ANNUAL ONE_TIME"` // Defaults to ANNUAL
+}
+
+// UpdateFeeStructureRequest represents the request to update a fee structure
+type UpdateFeeStructureRequest struct {
+	ClassID   string   `json:"class_id" binding:"omitempty,uuid"`
+	Name      string   `json:"name" binding:"omitempty,min=2,max=100"`
+	Amount    *float64 `json:"amount" binding:"omitempty,gt=0"`
+	DueDate   string   `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	Frequency string   `json:"frequency" binding:"omitempty,oneof=MONTHLY QUARTERLY HALF_YEARLY ANNUAL ONE_TIME"`
+	IsActive  *bool    `json:"is_active"`
+}
@@ -0,0 +1,47 @@
+package request
+
+// UpdateInstitutionSettingsRequest represents a request to update institution settings.
+// Omitted fields keep their current value.
+type UpdateInstitutionSettingsRequest struct {
+	Timezone     *string            `json:"timezone"`
+	Locale       *string            `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	WeekStartDay *int               `json:"week_start_day" binding:"omitempty,min=0,max=6"`
+	GradingScale []GradeBandRequest `json:"grading_scale" binding:"omitempty,dive"`
+
+	AutoRollNumbers *bool `json:"auto_roll_numbers"`
+
+	// RateLimitRequests overrides the request limit per window; 0 restores the default
+	RateLimitRequests *int `json:"rate_limit_requests" binding:"omitempty,min=0,max=100000"`
+
+	// MaxClassesPerClassTeacher caps the classes one teacher leads; 0 removes the limit
+	MaxClassesPerClassTeacher *int `json:"max_classes_per_class_teacher" binding:"omitempty,min=0,max=100"`
+}
+
+// GradeBandRequest represents one band of a grading scale
+type GradeBandRequest struct {
+	Grade      string  `json:"grade" binding:"required,max=10"`
+	MinScore   float64 `json:"min_score" binding:"min=0,max=100"`
+	MaxScore   float64 `json:"max_score" binding:"min=0,max=100"`
+	GradePoint float64 `json:"grade_point" binding:"min=0"`
+}
+
+// OnboardInstitutionRequest represents a request to create an institution together with its first admin
+type OnboardInstitutionRequest struct {
+	Name          string              `json:"name" binding:"required"`
+	Code          string              `json:"code" binding:"required"`
+	Address       string              `json:"address"`
+	Phone         string              `json:"phone"`
+	Email         string              `json:"email" binding:"omitempty,email"`
+	PrincipalName string              `json:"principal_name"`
+	Timezone      string              `json:"timezone"`
+	Admin         OnboardAdminRequest `json:"admin" binding:"required"`
+}
+
+// OnboardAdminRequest represents the first admin of an onboarded institution
+type OnboardAdminRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Password  string `json:"password" binding:"required,min=8"`
+	Phone     string `json:"phone"`
+}
@@ -0,0 +1,13 @@
+package request
+
+// ApplyLeaveRequest represents the request to apply for leave between two dates, inclusive
+type ApplyLeaveRequest struct {
+	FromDate string `json:"from_date" binding:"required,datetime=2006-01-02"`
+	ToDate   string `json:"to_date" binding:"required,datetime=2006-01-02"`
+	Reason   string `json:"reason" binding:"required,min=3,max=1000"`
+}
+
+// ReviewLeaveRequest represents the request to approve or reject a leave application
+type ReviewLeaveRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
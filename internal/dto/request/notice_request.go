@@ -0,0 +1,13 @@
+package request
+
+import "time"
+
+// CreateNoticeRequest represents the request to publish a notice.
+// A future published_at schedules the notice; it defaults to now.
+type CreateNoticeRequest struct {
+	Title         string     `json:"title" binding:"required,max=255"`
+	Body          string     `json:"body" binding:"required"`
+	AudienceRoles []string   `json:"audience_roles" binding:"required,min=1,dive,oneof=ADMIN TEACHER STUDENT PARENT ACCOUNTANT"`
+	PublishedAt   *time.Time `json:"published_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,20 @@
+package request
+
+// CreateRoleRequest represents a request to create a custom role
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`
+	Code        string   `json:"code" binding:"required,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRolePermissionsRequest represents a request to replace a role's permissions
+type UpdateRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// AssignUserRoleRequest represents a request to attach a custom role to a user.
+// An empty RoleID detaches the current custom role.
+type AssignUserRoleRequest struct {
+	RoleID string `json:"role_id" binding:"omitempty,uuid"`
+}
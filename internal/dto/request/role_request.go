@@ -6,6 +6,9 @@ type CreateTeacherRequest struct {
 	Qualifications []string `json:"qualifications"`
 	JoiningDate    string   `json:"joining_date" binding:"required,datetime=2006-01-02"`
 	DepartmentID   string   `json:"department_id" binding:"omitempty,uuid"`
+	DateOfBirth    string   `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	Gender         string   `json:"gender" binding:"omitempty,oneof=male female other"`
+	Address        string   `json:"address" binding:"max=500"`
 }
 
 // CreateStudentRequest represents a request to create a student
@@ -13,11 +16,40 @@ type CreateStudentRequest struct {
 	RegisterRequest
 	AdmissionNumber string `json:"admission_number" binding:"required"`
 	AdmissionDate   string `json:"admission_date" binding:"required,datetime=2006-01-02"`
-	RollNumber      int    `json:"roll_number"`
+	RollNumber      int    `json:"roll_number" binding:"min=0"` // Omit to assign the next free number
 	ClassID         string `json:"class_id" binding:"omitempty,uuid"`
 	SectionID       string `json:"section_id" binding:"omitempty,uuid"`
 	BloodGroup      string `json:"blood_group"`
 	MedicalInfo     string `json:"medical_info"`
+	DateOfBirth     string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	Gender          string `json:"gender" binding:"omitempty,oneof=male female other"`
+}
+
+// TeacherImportRow represents a single parsed row of a teacher import file
+type TeacherImportRow struct {
+	Row            int      `json:"row"` // Line number in the source file
+	Email          string   `json:"email"`
+	Phone          string   `json:"phone"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	Qualifications []string `json:"qualifications"`
+	DepartmentName string   `json:"department_name"`
+	JoiningDate    string   `json:"joining_date"` // YYYY-MM-DD
+}
+
+// StudentImportRow represents a single parsed row of a student import file
+type StudentImportRow struct {
+	Row             int    `json:"row"` // Line number in the source file
+	Email           string `json:"email"`
+	Phone           string `json:"phone"`
+	FirstName       string `json:"first_name"`
+	LastName        string `json:"last_name"`
+	AdmissionNumber string `json:"admission_number"`
+	RollNumber      int    `json:"roll_number"`
+	ClassName       string `json:"class_name"`
+	SectionName     string `json:"section_name"`
+	DateOfBirth     string `json:"date_of_birth"` // YYYY-MM-DD
+	BloodGroup      string `json:"blood_group"`
 }
 
 // CreateParentRequest represents a request to create a parent
@@ -26,6 +58,9 @@ type CreateParentRequest struct {
 	Occupation       string `json:"occupation"`
 	OfficeAddress    string `json:"office_address"`
 	EmergencyContact string `json:"emergency_contact"`
+	DateOfBirth      string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	Gender           string `json:"gender" binding:"omitempty,oneof=male female other"`
+	Address          string `json:"address" binding:"max=500"`
 }
 
 // CreateAccountantRequest represents a request to create an accountant
@@ -33,4 +68,7 @@ type CreateAccountantRequest struct {
 	RegisterRequest
 	Qualification string `json:"qualification"`
 	JoiningDate   string `json:"joining_date" binding:"required,datetime=2006-01-02"`
+	DateOfBirth   string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	Gender        string `json:"gender" binding:"omitempty,oneof=male female other"`
+	Address       string `json:"address" binding:"max=500"`
 }
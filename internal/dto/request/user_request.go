@@ -19,6 +19,11 @@ type UpdateProfileRequest struct {
 	ProfileImageURL string `json:"profile_image_url" binding:"omitempty,url"`
 }
 
+// AssignTeacherSubjectRequest represents a request to assign a teacher to a subject
+type AssignTeacherSubjectRequest struct {
+	SubjectID string `json:"subject_id" binding:"required,uuid"`
+}
+
 // UpdateTeacherRequest represents a request to update a teacher
 type UpdateTeacherRequest struct {
 	Email          string   `json:"email" binding:"omitempty,email"`
@@ -27,6 +32,7 @@ type UpdateTeacherRequest struct {
 	LastName       string   `json:"last_name" binding:"omitempty,min=1,max=100"`
 	Qualifications []string `json:"qualifications" binding:"omitempty"`
 	DepartmentID   string   `json:"department_id" binding:"omitempty,uuid"`
+	JoiningDate    string   `json:"joining_date" binding:"omitempty,datetime=2006-01-02"`
 	IsActive       *bool    `json:"is_active" binding:"omitempty"`
 }
 
@@ -38,7 +44,7 @@ type UpdateStudentRequest struct {
 	LastName    string `json:"last_name" binding:"omitempty,min=1,max=100"`
 	ClassID     string `json:"class_id" binding:"omitempty,uuid"`
 	SectionID   string `json:"section_id" binding:"omitempty,uuid"`
-	RollNumber  *int   `json:"roll_number" binding:"omitempty"`
+	RollNumber  *int   `json:"roll_number" binding:"omitempty,min=0"`
 	BloodGroup  string `json:"blood_group" binding:"omitempty"`
 	MedicalInfo string `json:"medical_info" binding:"omitempty"`
 	IsActive    *bool  `json:"is_active" binding:"omitempty"`
@@ -66,9 +72,51 @@ type UpdateAccountantRequest struct {
 	IsActive      *bool  `json:"is_active" binding:"omitempty"`
 }
 
+// PromoteStudentsRequest represents a request to promote students to another class
+// Either StudentIDs or All must be given
+type PromoteStudentsRequest struct {
+	FromClassID      string   `json:"from_class_id" binding:"required,uuid"`
+	FromSectionID    string   `json:"from_section_id" binding:"omitempty,uuid"`
+	ToClassID        string   `json:"to_class_id" binding:"required,uuid"`
+	ToSectionID      string   `json:"to_section_id" binding:"omitempty,uuid"`
+	StudentIDs       []string `json:"student_ids" binding:"required_without=All,omitempty,uuidslice"`
+	All              bool     `json:"all"`
+	ResetRollNumbers bool     `json:"reset_roll_numbers"`
+}
+
+// BulkStudentStatusRequest represents a request to activate or deactivate many students.
+// Either StudentIDs or ClassID (optionally narrowed by SectionID) must be given
+type BulkStudentStatusRequest struct {
+	StudentIDs []string `json:"student_ids" binding:"required_without=ClassID,omitempty,uuidslice"`
+	ClassID    string   `json:"class_id" binding:"omitempty,uuid"`
+	SectionID  string   `json:"section_id" binding:"omitempty,uuid"`
+	IsActive   *bool    `json:"is_active" binding:"required"`
+}
+
+// MoveSectionStudentsRequest represents a request to move students to another section.
+// Moving to a section of a different class requires AllowCrossClass
+type MoveSectionStudentsRequest struct {
+	FromSectionID   string   `json:"from_section_id" binding:"required,uuid"`
+	ToSectionID     string   `json:"to_section_id" binding:"required,uuid"`
+	StudentIDs      []string `json:"student_ids" binding:"required,min=1,uuidslice"`
+	AllowCrossClass bool     `json:"allow_cross_class"`
+}
+
+// TransferStudentRequest represents a request to move a student to another institution
+type TransferStudentRequest struct {
+	TargetInstitutionID string `json:"target_institution_id" binding:"required,uuid"`
+	Reason              string `json:"reason" binding:"omitempty,max=1000"`
+}
+
 // LinkParentRequest represents a request to link a parent to a student
 type LinkParentRequest struct {
 	ParentID     string `json:"parent_id" binding:"required,uuid"`
 	Relationship string `json:"relationship" binding:"required,oneof=father mother guardian"`
 	IsPrimary    bool   `json:"is_primary"`
 }
+
+// SearchQuery represents the query parameters of the global search
+type SearchQuery struct {
+	Q    string `form:"q" binding:"required,min=2,max=100"`
+	Role string `form:"role" binding:"omitempty,oneof=STUDENT TEACHER PARENT"`
+}
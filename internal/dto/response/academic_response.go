@@ -19,6 +19,46 @@ type AcademicYearResponse struct {
 	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+// AcademicYearDeleteResponse represents the result of deleting an academic year
+type AcademicYearDeleteResponse struct {
+	ID                      uuid.UUID `json:"id"`
+	DeletedTimetableEntries int64     `json:"deleted_timetable_entries"`
+}
+
+// SectionTimetableClearResponse represents the result of clearing a section's timetable
+type SectionTimetableClearResponse struct {
+	SectionID      uuid.UUID `json:"section_id"`
+	AcademicYearID uuid.UUID `json:"academic_year_id"`
+	DeletedEntries int64     `json:"deleted_entries"`
+}
+
+// RollReassignmentResponse represents the result of renumbering a class's students
+type RollReassignmentResponse struct {
+	Renumbered int64 `json:"renumbered"`
+}
+
+// SectionRecountResponse represents the result of repairing the classes' section counts
+type SectionRecountResponse struct {
+	Corrected int64 `json:"corrected"`
+}
+
+// ClassTeacherAssignmentResult represents the outcome of assigning one class's class teacher
+type ClassTeacherAssignmentResult struct {
+	ClassID           uuid.UUID  `json:"class_id"`
+	ClassName         string     `json:"class_name,omitempty"`
+	TeacherID         *uuid.UUID `json:"teacher_id,omitempty"`
+	PreviousTeacherID *uuid.UUID `json:"previous_teacher_id,omitempty"`
+	Success           bool       `json:"success"`
+	Error             string     `json:"error,omitempty"`
+}
+
+// ClassTeacherAssignmentResponse represents the result of a bulk class teacher assignment
+type ClassTeacherAssignmentResponse struct {
+	Assigned int                            `json:"assigned"`
+	Failed   int                            `json:"failed"`
+	Results  []ClassTeacherAssignmentResult `json:"results"`
+}
+
 // ClassResponse represents the response for a class
 type ClassResponse struct {
 	ID             uuid.UUID         `json:"id"`
@@ -29,6 +69,9 @@ type ClassResponse struct {
 	ClassTeacher   *TeacherBrief     `json:"class_teacher,omitempty"`
 	Capacity       int               `json:"capacity,omitempty"`
 	Sections       []SectionResponse `json:"sections,omitempty"`
+	CreatedBy      *UserBrief        `json:"created_by,omitempty"`
+	UpdatedBy      *UserBrief        `json:"updated_by,omitempty"`
+	Version        int               `json:"version"`
 	CreatedAt      time.Time         `json:"created_at"`
 	UpdatedAt      time.Time         `json:"updated_at"`
 }
@@ -51,6 +94,14 @@ type SectionResponse struct {
 	UpdatedAt  time.Time   `json:"updated_at"`
 }
 
+// SectionWithCountResponse represents a section with its occupancy. Remaining
+// capacity is omitted for sections without a capacity.
+type SectionWithCountResponse struct {
+	SectionResponse
+	StudentCount      int64 `json:"student_count"`
+	RemainingCapacity *int  `json:"remaining_capacity,omitempty"`
+}
+
 // SectionBrief represents a brief section response (for nested objects)
 type SectionBrief struct {
 	ID   uuid.UUID `json:"id"`
@@ -69,6 +120,9 @@ type SubjectResponse struct {
 	CreditHours   float64       `json:"credit_hours,omitempty"`
 	Class         *ClassBrief   `json:"class,omitempty"`
 	Teacher       *TeacherBrief `json:"teacher,omitempty"`
+	CreatedBy     *UserBrief    `json:"created_by,omitempty"`
+	UpdatedBy     *UserBrief    `json:"updated_by,omitempty"`
+	Version       int           `json:"version"`
 	CreatedAt     time.Time     `json:"created_at"`
 	UpdatedAt     time.Time     `json:"updated_at"`
 }
@@ -80,6 +134,30 @@ type SubjectBrief struct {
 	Code string    `json:"code,omitempty"`
 }
 
+// UnassignTeacherResponse represents the result of removing a subject's teacher.
+// TimetableEntryIDs lists the active timetable entries that still use the previous teacher.
+type UnassignTeacherResponse struct {
+	Subject           *SubjectResponse `json:"subject"`
+	TimetableEntryIDs []uuid.UUID      `json:"timetable_entry_ids"`
+	Warning           string           `json:"warning,omitempty"`
+}
+
+// CreditBreakdown represents the subject count and credit hours for a group of subjects
+type CreditBreakdown struct {
+	Subjects    int64   `json:"subjects"`
+	CreditHours float64 `json:"credit_hours"`
+}
+
+// ClassCreditSummaryResponse represents the credit hour totals for a class
+type ClassCreditSummaryResponse struct {
+	ClassID          uuid.UUID       `json:"class_id"`
+	ClassName        string          `json:"class_name"`
+	TotalSubjects    int64           `json:"total_subjects"`
+	TotalCreditHours float64         `json:"total_credit_hours"`
+	Core             CreditBreakdown `json:"core"`
+	Elective         CreditBreakdown `json:"elective"`
+}
+
 // TeacherBrief represents a brief teacher response (for nested objects)
 type TeacherBrief struct {
 	ID        uuid.UUID `json:"id"`
@@ -100,6 +178,26 @@ type DepartmentResponse struct {
 	UpdatedAt          time.Time     `json:"updated_at"`
 }
 
+// DepartmentHeadHistoryResponse represents one term of a department head
+type DepartmentHeadHistoryResponse struct {
+	ID        uuid.UUID     `json:"id"`
+	TeacherID uuid.UUID     `json:"teacher_id"`
+	Teacher   *TeacherBrief `json:"teacher,omitempty"`
+	FromDate  time.Time     `json:"from_date"`
+	ToDate    *time.Time    `json:"to_date,omitempty"`
+	IsCurrent bool          `json:"is_current"`
+}
+
+// SubjectTeacherHistoryResponse represents one term of a subject's teacher
+type SubjectTeacherHistoryResponse struct {
+	ID        uuid.UUID     `json:"id"`
+	TeacherID uuid.UUID     `json:"teacher_id"`
+	Teacher   *TeacherBrief `json:"teacher,omitempty"`
+	FromDate  time.Time     `json:"from_date"`
+	ToDate    *time.Time    `json:"to_date,omitempty"`
+	IsCurrent bool          `json:"is_current"`
+}
+
 // TimetableResponse represents the response for a timetable entry
 type TimetableResponse struct {
 	ID             uuid.UUID     `json:"id"`
@@ -110,6 +208,7 @@ type TimetableResponse struct {
 	SubjectID      uuid.UUID     `json:"subject_id"`
 	TeacherID      uuid.UUID     `json:"teacher_id"`
 	DayOfWeek      string        `json:"day_of_week"`
+	WeekType       string        `json:"week_type"`
 	StartTime      string        `json:"start_time"`
 	EndTime        string        `json:"end_time"`
 	RoomNumber     string        `json:"room_number,omitempty"`
@@ -118,6 +217,7 @@ type TimetableResponse struct {
 	Section        *SectionBrief `json:"section,omitempty"`
 	Subject        *SubjectBrief `json:"subject,omitempty"`
 	Teacher        *TeacherBrief `json:"teacher,omitempty"`
+	Version        int           `json:"version"`
 	CreatedAt      time.Time     `json:"created_at"`
 	UpdatedAt      time.Time     `json:"updated_at"`
 }
@@ -131,4 +231,125 @@ type DayTimetable struct {
 // WeekTimetableResponse represents a full week's timetable
 type WeekTimetableResponse struct {
 	Days []DayTimetable `json:"days"`
+
+	// Substitutions within the requested date range, only set for teacher timetables
+	Substitutions []SubstitutionResponse `json:"substitutions,omitempty"`
+}
+
+// Substitution roles relative to the teacher whose timetable is shown
+const (
+	SubstitutionRoleCovering = "covering" // The teacher takes someone else's slot
+	SubstitutionRoleCovered  = "covered"  // The teacher's own slot is taken by someone else
+)
+
+// SubstitutionResponse represents the response for a substitution
+type SubstitutionResponse struct {
+	ID                  uuid.UUID          `json:"id"`
+	TimetableID         uuid.UUID          `json:"timetable_id"`
+	Date                string             `json:"date"`
+	OriginalTeacherID   uuid.UUID          `json:"original_teacher_id"`
+	SubstituteTeacherID uuid.UUID          `json:"substitute_teacher_id"`
+	OriginalTeacher     *TeacherBrief      `json:"original_teacher,omitempty"`
+	SubstituteTeacher   *TeacherBrief      `json:"substitute_teacher,omitempty"`
+	Reason              string             `json:"reason,omitempty"`
+	Role                string             `json:"role,omitempty"`
+	Entry               *TimetableResponse `json:"entry,omitempty"`
+	CreatedAt           time.Time          `json:"created_at"`
+}
+
+// HolidayResponse represents the response for a holiday
+type HolidayResponse struct {
+	ID             uuid.UUID `json:"id"`
+	InstitutionID  uuid.UUID `json:"institution_id"`
+	AcademicYearID uuid.UUID `json:"academic_year_id"`
+	Date           string    `json:"date"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// PeriodResponse represents the response for a period of the school day
+type PeriodResponse struct {
+	ID            uuid.UUID `json:"id"`
+	InstitutionID uuid.UUID `json:"institution_id"`
+	Name          string    `json:"name"`
+	StartTime     string    `json:"start_time"`
+	EndTime       string    `json:"end_time"`
+	Order         int       `json:"order"`
+	IsBreak       bool      `json:"is_break"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FreeSlot represents a period in which a teacher has no scheduled class
+type FreeSlot struct {
+	Day   string `json:"day"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// TeacherFreeSlotsResponse represents a teacher's free slots within the school day
+type TeacherFreeSlotsResponse struct {
+	TeacherID uuid.UUID  `json:"teacher_id"`
+	DayStart  string     `json:"day_start"`
+	DayEnd    string     `json:"day_end"`
+	Slots     []FreeSlot `json:"slots"`
+}
+
+// TeacherWorkloadResponse represents a teacher's weekly teaching load
+type TeacherWorkloadResponse struct {
+	Teacher      TeacherBrief          `json:"teacher"`
+	DepartmentID *uuid.UUID            `json:"department_id,omitempty"`
+	Periods      int                   `json:"periods"`
+	Minutes      int                   `json:"minutes"`
+	BusiestDay   *DayWorkloadResponse  `json:"busiest_day,omitempty"`
+	Days         []DayWorkloadResponse `json:"days"`
+}
+
+// DayWorkloadResponse represents a teacher's load on one day of the week
+type DayWorkloadResponse struct {
+	Day     string `json:"day"`
+	Periods int    `json:"periods"`
+	Minutes int    `json:"minutes"`
+}
+
+// RoomAvailabilityResponse represents whether a room is free at a given time
+type RoomAvailabilityResponse struct {
+	RoomNumber string              `json:"room_number"`
+	DayOfWeek  string              `json:"day_of_week"`
+	StartTime  string              `json:"start_time"`
+	EndTime    string              `json:"end_time"`
+	Available  bool                `json:"available"`
+	Conflicts  []TimetableResponse `json:"conflicts"`
+}
+
+// CopySkippedEntry represents a source timetable entry that was not copied
+type CopySkippedEntry struct {
+	SourceID uuid.UUID `json:"source_id"`
+	Reason   string    `json:"reason"`
+}
+
+// CopyTimetableResponse represents the summary of a timetable copy
+type CopyTimetableResponse struct {
+	SourceAcademicYearID uuid.UUID          `json:"source_academic_year_id"`
+	TargetAcademicYearID uuid.UUID          `json:"target_academic_year_id"`
+	Copied               int                `json:"copied"`
+	Skipped              int                `json:"skipped"`
+	SkippedEntries       []CopySkippedEntry `json:"skipped_entries"`
+}
+
+// BulkTimetableResult represents the outcome of a single entry in a bulk create
+type BulkTimetableResult struct {
+	Index   int                `json:"index"`
+	Success bool               `json:"success"`
+	Error   string             `json:"error,omitempty"`
+	Entry   *TimetableResponse `json:"entry,omitempty"`
+}
+
+// BulkTimetableResponse represents the response for a bulk timetable create
+type BulkTimetableResponse struct {
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []BulkTimetableResult `json:"results"`
 }
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttendanceResponse represents a student's attendance on a date
+type AttendanceResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	StudentID uuid.UUID  `json:"student_id"`
+	SectionID *uuid.UUID `json:"section_id,omitempty"`
+	Date      string     `json:"date"`
+	Status    string     `json:"status"`
+	MarkedBy  *uuid.UUID `json:"marked_by,omitempty"`
+	Remarks   string     `json:"remarks,omitempty"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// AttendanceCounts counts attendance records by status
+type AttendanceCounts struct {
+	Present int64 `json:"present"`
+	Absent  int64 `json:"absent"`
+	Late    int64 `json:"late"`
+}
+
+// StudentAttendanceResponse is a student's attendance history with totals
+type StudentAttendanceResponse struct {
+	StudentID uuid.UUID            `json:"student_id"`
+	Counts    AttendanceCounts     `json:"counts"`
+	Records   []AttendanceResponse `json:"records"`
+}
+
+// MarkAttendanceResponse reports the outcome of marking a section's attendance
+type MarkAttendanceResponse struct {
+	SectionID uuid.UUID `json:"section_id"`
+	Date      string    `json:"date"`
+	Marked    int       `json:"marked"`
+	Unmarked  int       `json:"unmarked"` // Students of the section without a record for the date
+}
+
+// SectionAttendanceSummary summarises a section's attendance on a date
+type SectionAttendanceSummary struct {
+	SectionID     uuid.UUID        `json:"section_id"`
+	Date          string           `json:"date"`
+	TotalStudents int64            `json:"total_students"`
+	Counts        AttendanceCounts `json:"counts"`
+	Unmarked      int64            `json:"unmarked"`
+}
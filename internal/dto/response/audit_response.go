@@ -0,0 +1,24 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLogResponse represents an audit log entry
+type AuditLogResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	InstitutionID *uuid.UUID      `json:"institution_id,omitempty"`
+	ActorUserID   *uuid.UUID      `json:"actor_user_id,omitempty"`
+	Action        string          `json:"action"`
+	Method        string          `json:"method"`
+	Path          string          `json:"path"`
+	StatusCode    int             `json:"status_code"`
+	ResourceType  string          `json:"resource_type,omitempty"`
+	ResourceID    *uuid.UUID      `json:"resource_id,omitempty"`
+	Changes       json.RawMessage `json:"changes,omitempty"`
+	IPAddress     string          `json:"ip_address,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
@@ -13,6 +13,10 @@ type LoginResponse struct {
 	TokenType    string       `json:"token_type"`
 	ExpiresAt    time.Time    `json:"expires_at"`
 	User         UserResponse `json:"user"`
+
+	// MustChangePassword tells the client to send the user to the change password
+	// screen; other requests are refused until the password is changed
+	MustChangePassword bool `json:"must_change_password"`
 }
 
 // TokenResponse represents a token refresh response
@@ -23,15 +27,53 @@ type TokenResponse struct {
 	ExpiresAt    time.Time `json:"expires_at"`
 }
 
+// ResetTokenValidationResponse represents a usable password reset token.
+// ExpiresIn is the number of seconds left before it expires.
+type ResetTokenValidationResponse struct {
+	Valid     bool      `json:"valid"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ExpiresIn int64     `json:"expires_in"`
+}
+
+// SessionResponse represents an active login session
+type SessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // UserResponse represents user data in responses
 type UserResponse struct {
-	ID          uuid.UUID        `json:"id"`
-	Email       string           `json:"email,omitempty"`
-	Phone       string           `json:"phone,omitempty"`
-	Role        string           `json:"role"`
-	IsActive    bool             `json:"is_active"`
-	LastLoginAt *time.Time       `json:"last_login_at,omitempty"`
-	Profile     *ProfileResponse `json:"profile,omitempty"`
+	ID            uuid.UUID        `json:"id"`
+	Email         string           `json:"email,omitempty"`
+	Phone         string           `json:"phone,omitempty"`
+	Role          string           `json:"role"`
+	CustomRoleID  *uuid.UUID       `json:"custom_role_id,omitempty"`
+	IsActive      bool             `json:"is_active"`
+	EmailVerified bool             `json:"email_verified"`
+	LastLoginAt   *time.Time       `json:"last_login_at,omitempty"`
+	Profile       *ProfileResponse `json:"profile,omitempty"`
+	CreatedBy     *UserBrief       `json:"created_by,omitempty"`
+	UpdatedBy     *UserBrief       `json:"updated_by,omitempty"`
+}
+
+// CredentialsResponse carries a temporary password generated for a user.
+// The password is only ever returned here; it is not stored in plain text.
+type CredentialsResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email,omitempty"`
+	Password string    `json:"password"`
+	// Emailed reports whether the password was also sent to the user's email address
+	Emailed bool `json:"emailed"`
+}
+
+// UserBrief represents a brief user reference (for nested objects).
+// Name is empty when the user can't be resolved, e.g. records written by the system
+type UserBrief struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name,omitempty"`
 }
 
 // ProfileResponse represents user profile data in responses
@@ -60,6 +102,106 @@ type ParentRelationResponse struct {
 	Parent       UserResponse `json:"parent"`
 }
 
+// StudentFullProfileResponse represents a student's full academic profile.
+// Class, Section and AcademicYear are null when not assigned.
+type StudentFullProfileResponse struct {
+	StudentID       uuid.UUID                `json:"student_id"`
+	User            UserResponse             `json:"user"`
+	AdmissionNumber string                   `json:"admission_number,omitempty"`
+	RollNumber      int                      `json:"roll_number,omitempty"`
+	AdmissionDate   *time.Time               `json:"admission_date,omitempty"`
+	BloodGroup      string                   `json:"blood_group,omitempty"`
+	MedicalInfo     string                   `json:"medical_info,omitempty"`
+	Class           *ClassBrief              `json:"class"`
+	Section         *SectionBrief            `json:"section"`
+	AcademicYear    *AcademicYearBrief       `json:"academic_year"`
+	Parents         []ParentRelationResponse `json:"parents"`
+}
+
+// StudentImportResult represents the outcome of a single row in a student import
+type StudentImportResult struct {
+	Row             int        `json:"row"`
+	Success         bool       `json:"success"`
+	StudentID       *uuid.UUID `json:"student_id,omitempty"`
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+	Email           string     `json:"email,omitempty"`
+	DefaultPassword string     `json:"default_password,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// StudentImportResponse represents the report of a student import
+type StudentImportResponse struct {
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []StudentImportResult `json:"results"`
+}
+
+// TeacherImportResult represents the outcome of a single row in a teacher import
+type TeacherImportResult struct {
+	Row             int        `json:"row"`
+	Success         bool       `json:"success"`
+	TeacherID       *uuid.UUID `json:"teacher_id,omitempty"`
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+	Email           string     `json:"email,omitempty"`
+	DepartmentID    *uuid.UUID `json:"department_id,omitempty"`
+	DefaultPassword string     `json:"default_password,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// TeacherImportResponse represents the report of a teacher import
+type TeacherImportResponse struct {
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []TeacherImportResult `json:"results"`
+}
+
+// PromotionResult represents the outcome for a single student in a promotion
+type PromotionResult struct {
+	StudentID  uuid.UUID `json:"student_id"`
+	Status     string    `json:"status"` // promoted, skipped, reverted
+	Reason     string    `json:"reason,omitempty"`
+	RollNumber int       `json:"roll_number,omitempty"`
+}
+
+// PromotionResponse represents the report of a promotion or its reversal
+type PromotionResponse struct {
+	BatchID  uuid.UUID         `json:"batch_id"`
+	Promoted int               `json:"promoted"`
+	Reverted int               `json:"reverted"`
+	Skipped  int               `json:"skipped"`
+	Results  []PromotionResult `json:"results"`
+}
+
+// BulkStatusResponse represents the outcome of a bulk status change.
+// Skipped counts the matched students that were already in the target state
+type BulkStatusResponse struct {
+	Matched int64 `json:"matched"`
+	Updated int64 `json:"updated"`
+	Skipped int64 `json:"skipped"`
+}
+
+// SectionMoveResponse represents the outcome of moving students between sections.
+// Results lists the students that were skipped and why
+type SectionMoveResponse struct {
+	Moved   int               `json:"moved"`
+	Skipped int               `json:"skipped"`
+	Results []PromotionResult `json:"results"`
+}
+
+// StudentTransferResponse represents a student's move to another institution
+type StudentTransferResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	StudentID         uuid.UUID  `json:"student_id"`
+	FromInstitutionID uuid.UUID  `json:"from_institution_id"`
+	ToInstitutionID   uuid.UUID  `json:"to_institution_id"`
+	FromClassID       *uuid.UUID `json:"from_class_id,omitempty"`
+	FromSectionID     *uuid.UUID `json:"from_section_id,omitempty"`
+	FromRollNumber    int        `json:"from_roll_number,omitempty"`
+	Reason            string     `json:"reason,omitempty"`
+	TransferredBy     *uuid.UUID `json:"transferred_by,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
 // ChildRelationResponse represents a child (student) in parent responses
 type ChildRelationResponse struct {
 	StudentID    uuid.UUID    `json:"student_id"`
@@ -67,3 +209,14 @@ type ChildRelationResponse struct {
 	IsPrimary    bool         `json:"is_primary"`
 	Student      UserResponse `json:"student"`
 }
+
+// ChildTimetableResponse represents a child's section timetable in the parent portal.
+// Timetable is empty for a child not assigned to a section.
+type ChildTimetableResponse struct {
+	StudentID uuid.UUID              `json:"student_id"`
+	FirstName string                 `json:"first_name"`
+	LastName  string                 `json:"last_name"`
+	ClassID   *uuid.UUID             `json:"class_id,omitempty"`
+	SectionID *uuid.UUID             `json:"section_id,omitempty"`
+	Timetable *WeekTimetableResponse `json:"timetable,omitempty"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DashboardSummaryResponse represents the headline numbers of an institution
+type DashboardSummaryResponse struct {
+	InstitutionID       uuid.UUID          `json:"institution_id"`
+	InstitutionName     string             `json:"institution_name"`
+	CurrentAcademicYear *AcademicYearBrief `json:"current_academic_year,omitempty"`
+	Students            int64              `json:"students"`
+	Teachers            int64              `json:"teachers"`
+	Parents             int64              `json:"parents"`
+	Classes             int64              `json:"classes"`
+	Subjects            int64              `json:"subjects"`
+	TimetableEntries    int64              `json:"timetable_entries"`
+	ActiveUsers         int64              `json:"active_users"`
+	InactiveUsers       int64              `json:"inactive_users"`
+	GeneratedAt         time.Time          `json:"generated_at"`
+}
+
+// AcademicYearBrief represents a brief academic year response (for nested objects)
+type AcademicYearBrief struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
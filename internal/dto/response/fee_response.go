@@ -0,0 +1,38 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeeStructureResponse represents a fee structure
+type FeeStructureResponse struct {
+	ID             uuid.UUID `json:"id"`
+	InstitutionID  uuid.UUID `json:"institution_id"`
+	AcademicYearID uuid.UUID `json:"academic_year_id"`
+	ClassID        uuid.UUID `json:"class_id"`
+	ClassName      string    `json:"class_name,omitempty"`
+	Name           string    `json:"name"`
+	Amount         float64   `json:"amount"`
+	Frequency      string    `json:"frequency"`
+	AnnualAmount   float64   `json:"annual_amount"`
+	DueDate        string    `json:"due_date,omitempty"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ClassFeeSummary is the total annual fee of one class
+type ClassFeeSummary struct {
+	ClassID     uuid.UUID `json:"class_id"`
+	ClassName   string    `json:"class_name"`
+	FeeCount    int       `json:"fee_count"`
+	AnnualTotal float64   `json:"annual_total"`
+}
+
+// FeeSummaryResponse lists the total annual fees per class of an academic year
+type FeeSummaryResponse struct {
+	AcademicYearID uuid.UUID         `json:"academic_year_id"`
+	Classes        []ClassFeeSummary `json:"classes"`
+}
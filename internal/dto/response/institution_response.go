@@ -0,0 +1,23 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InstitutionBrief represents a brief institution response (for nested objects)
+type InstitutionBrief struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Code      string    `json:"code"`
+	Timezone  string    `json:"timezone,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InstitutionOnboardResponse represents an onboarded institution and its first admin
+type InstitutionOnboardResponse struct {
+	Institution InstitutionBrief `json:"institution"`
+	Admin       *UserResponse    `json:"admin"`
+}
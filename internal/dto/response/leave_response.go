@@ -0,0 +1,26 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaveResponse represents the response for a leave application
+type LeaveResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	InstitutionID   uuid.UUID  `json:"institution_id"`
+	ApplicantUserID uuid.UUID  `json:"applicant_user_id"`
+	ApplicantName   string     `json:"applicant_name,omitempty"`
+	FromDate        string     `json:"from_date"`
+	ToDate          string     `json:"to_date"`
+	TotalDays       int        `json:"total_days"`
+	Reason          string     `json:"reason"`
+	Status          string     `json:"status"`
+	ReviewedBy      *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote      string     `json:"review_note,omitempty"`
+	Version         int        `json:"version"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,11 @@
+package response
+
+// MetaResponse represents the reference data the frontend offers as choices,
+// so option lists aren't hard-coded on the client
+type MetaResponse struct {
+	Roles         []string `json:"roles"`
+	Relationships []string `json:"relationships"`
+	DaysOfWeek    []string `json:"days_of_week"`
+	Genders       []string `json:"genders"`
+	BloodGroups   []string `json:"blood_groups"`
+}
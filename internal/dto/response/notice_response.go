@@ -0,0 +1,29 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notice statuses derived from the publish and expiry times
+const (
+	NoticeStatusScheduled = "SCHEDULED"
+	NoticeStatusActive    = "ACTIVE"
+	NoticeStatusExpired   = "EXPIRED"
+)
+
+// NoticeResponse represents a notice
+type NoticeResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	InstitutionID uuid.UUID  `json:"institution_id"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	AudienceRoles []string   `json:"audience_roles"`
+	Status        string     `json:"status"`
+	PublishedAt   time.Time  `json:"published_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	AuthorID      *uuid.UUID `json:"author_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
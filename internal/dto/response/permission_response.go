@@ -0,0 +1,20 @@
+package response
+
+import "github.com/google/uuid"
+
+// RoleResponse represents a role with its permissions
+type RoleResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	InstitutionID *uuid.UUID `json:"institution_id,omitempty"`
+	Name          string     `json:"name"`
+	Code          string     `json:"code"`
+	Description   string     `json:"description,omitempty"`
+	IsBuiltIn     bool       `json:"is_built_in"`
+	Permissions   []string   `json:"permissions"`
+}
+
+// PermissionResponse represents a grantable permission
+type PermissionResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import "github.com/google/uuid"
+
+// SearchResultResponse represents a student, teacher or parent found by the global search
+type SearchResultResponse struct {
+	Role            string     `json:"role"`
+	ID              uuid.UUID  `json:"id"` // ID of the student, teacher or parent
+	UserID          uuid.UUID  `json:"user_id"`
+	FirstName       string     `json:"first_name"`
+	LastName        string     `json:"last_name"`
+	Email           string     `json:"email,omitempty"`
+	Phone           string     `json:"phone,omitempty"`
+	AdmissionNumber string     `json:"admission_number,omitempty"`
+	RollNumber      *int       `json:"roll_number,omitempty"`
+	ClassID         *uuid.UUID `json:"class_id,omitempty"`
+	SectionID       *uuid.UUID `json:"section_id,omitempty"`
+}
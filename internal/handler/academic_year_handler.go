@@ -2,6 +2,8 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/middleware"
@@ -39,7 +41,7 @@ func (h *AcademicYearHandler) Create(c *gin.Context) {
 
 	resp, err := h.service.Create(&req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -65,9 +67,21 @@ func (h *AcademicYearHandler) GetAll(c *gin.Context) {
 		filter.IsCurrent = &current
 	}
 
+	// start_date and end_date keep the years overlapping that range
+	var ok bool
+	if filter.StartDate, ok = parseDateQuery(c, "start_date"); !ok {
+		return
+	}
+	if filter.EndDate, ok = parseDateQuery(c, "end_date"); !ok {
+		return
+	}
+	if filter.ActiveOn, ok = parseDateQuery(c, "active_on"); !ok {
+		return
+	}
+
 	data, pagination, err := h.service.GetAll(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -90,7 +104,7 @@ func (h *AcademicYearHandler) GetByID(c *gin.Context) {
 
 	resp, err := h.service.GetByID(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -107,7 +121,7 @@ func (h *AcademicYearHandler) GetCurrent(c *gin.Context) {
 
 	resp, err := h.service.GetCurrent(institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -136,7 +150,7 @@ func (h *AcademicYearHandler) Update(c *gin.Context) {
 
 	resp, err := h.service.Update(id, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -158,13 +172,35 @@ func (h *AcademicYearHandler) Activate(c *gin.Context) {
 	}
 
 	if err := h.service.Activate(id, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "Academic year activated successfully", nil)
 }
 
+// Deactivate handles unsetting the current academic year
+func (h *AcademicYearHandler) Deactivate(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.Deactivate(id, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Academic year deactivated successfully", nil)
+}
+
 // Delete handles deleting an academic year
 func (h *AcademicYearHandler) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -179,10 +215,29 @@ func (h *AcademicYearHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Delete(id, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+	// ?force=true also deletes the year's timetable entries
+	force, _ := strconv.ParseBool(c.Query("force"))
+
+	resp, err := h.service.Delete(id, institutionID, force)
+	if err != nil {
+		utils.RespondServiceError(c, err)
 		return
 	}
 
-	utils.NoContent(c)
+	utils.OK(c, "Academic year deleted successfully", resp)
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter, responding with
+// an error when it is malformed
+func parseDateQuery(c *gin.Context, param string) (*time.Time, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+		return nil, false
+	}
+	return &date, true
 }
@@ -31,7 +31,7 @@ func (h *AccountantHandler) Create(c *gin.Context) {
 	creatorInstID := middleware.GetInstitutionID(c)
 	resp, err := h.service.CreateAccountant(&req, creatorInstID)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -49,7 +49,7 @@ func (h *AccountantHandler) GetAll(c *gin.Context) {
 	institutionID := middleware.GetInstitutionID(c)
 	data, pagination, err := h.service.GetAllAccountants(institutionID, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -65,7 +65,7 @@ func (h *AccountantHandler) GetByID(c *gin.Context) {
 
 	accountant, err := h.service.GetAccountant(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -88,7 +88,7 @@ func (h *AccountantHandler) Update(c *gin.Context) {
 	institutionID := middleware.GetInstitutionID(c)
 	accountant, err := h.service.UpdateAccountant(id, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
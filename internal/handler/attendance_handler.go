@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AttendanceHandler handles attendance API requests
+type AttendanceHandler struct {
+	service *service.AttendanceService
+}
+
+// NewAttendanceHandler creates a new attendance handler
+func NewAttendanceHandler(service *service.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{service: service}
+}
+
+// MarkSection handles marking a section's attendance for a date
+func (h *AttendanceHandler) MarkSection(c *gin.Context) {
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.MarkAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	markedBy, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.MarkBulk(sectionID, &req, middleware.GetInstitutionID(c), markedBy)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Attendance marked successfully", resp)
+}
+
+// GetSectionSummary handles summarising a section's attendance on a date (default today)
+func (h *AttendanceHandler) GetSectionSummary(c *gin.Context) {
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	date := time.Now()
+	if dateStr := c.Query("date"); dateStr != "" {
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return
+		}
+	}
+
+	resp, err := h.service.GetSectionSummary(sectionID, date, middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetByStudent handles listing a student's attendance, optionally within a date range
+func (h *AttendanceHandler) GetByStudent(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var from, to *time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return
+		}
+		from = &parsed
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return
+		}
+		to = &parsed
+	}
+
+	callerID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.GetByStudent(studentID, from, to, callerID, middleware.GetUserRole(c), middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AuditHandler handles audit log API requests
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(service *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// List handles listing audit log entries of the caller's institution
+func (h *AuditHandler) List(c *gin.Context) {
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	filter := repository.AuditLogFilter{
+		InstitutionID: middleware.GetInstitutionID(c), // Enforce tenant
+		ResourceType:  c.Query("resource_type"),
+	}
+
+	if actorID := c.Query("actor_id"); actorID != "" {
+		if _, err := uuid.Parse(actorID); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
+		filter.ActorUserID = actorID
+	}
+
+	// Date range in days; "to" is inclusive
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return
+		}
+		filter.From = &from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	data, pagination, err := h.service.List(filter, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
+}
@@ -9,6 +9,7 @@ import (
 	"campus-core/internal/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // AuthHandler handles authentication HTTP requests
@@ -23,11 +24,12 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 
 // Login handles user login
 // @Summary User login
-// @Description Authenticate user with email/phone and password
+// @Description Authenticate user with email, phone or admission number and password
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param body body request.LoginRequest true "Login credentials"
+// @Param X-Institution-ID header string false "Institution ID, required for admission number login without a default institution"
 // @Success 200 {object} utils.APIResponse{data=response.LoginResponse}
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
@@ -38,10 +40,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		utils.ValidationError(c, utils.FormatValidationErrors(err))
 		return
 	}
+	req.InstitutionID = middleware.GetInstitutionID(c)
+	req.UserAgent = c.Request.UserAgent()
+	req.IPAddress = c.ClientIP()
 
 	resp, err := h.authService.Login(&req)
 	if err != nil {
-		utils.Error(c, http.StatusUnauthorized, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -71,7 +76,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	resp, err := h.authService.Register(&req)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -95,10 +100,12 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		utils.ValidationError(c, utils.FormatValidationErrors(err))
 		return
 	}
+	req.UserAgent = c.Request.UserAgent()
+	req.IPAddress = c.ClientIP()
 
 	resp, err := h.authService.RefreshToken(&req)
 	if err != nil {
-		utils.Error(c, http.StatusUnauthorized, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -107,11 +114,12 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout handles user logout
 // @Summary User logout
-// @Description Invalidate user's refresh token
+// @Description Revoke the session of the given refresh token, or all sessions if omitted
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param body body request.LogoutRequest false "Refresh token of the session to end"
 // @Success 200 {object} utils.APIResponse
 // @Failure 401 {object} utils.ErrorResponse
 // @Router /auth/logout [post]
@@ -122,14 +130,47 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	if err := h.authService.Logout(userID); err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+	// Body is optional
+	var req request.LogoutRequest
+	_ = c.ShouldBindJSON(&req)
+
+	if err := h.authService.Logout(userID, &req); err != nil {
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "Logged out successfully", nil)
 }
 
+// LogoutAll handles signing the user out of every session
+// @Summary Logout everywhere
+// @Description Revoke all refresh tokens and reject every access token issued so far
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.APIResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	revoked, err := h.authService.LogoutAll(userID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	message := "Logged out of all sessions"
+	if !revoked {
+		message = "Logged out of all sessions; existing access tokens remain valid until they expire"
+	}
+	utils.OK(c, message, nil)
+}
+
 // ForgotPassword handles password reset request
 // @Summary Forgot password
 // @Description Request password reset email
@@ -148,7 +189,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	}
 
 	if err := h.authService.ForgotPassword(&req); err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -174,13 +215,93 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	}
 
 	if err := h.authService.ResetPassword(&req); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "Password reset successfully", nil)
 }
 
+// ValidateResetToken handles checking a password reset token before it is used
+// @Summary Validate reset token
+// @Description Check that a password reset token is valid without consuming it
+// @Tags Auth
+// @Produce json
+// @Param token query string true "Reset token"
+// @Success 200 {object} utils.APIResponse{data=response.ResetTokenValidationResponse}
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /auth/reset-password/validate [get]
+func (h *AuthHandler) ValidateResetToken(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		utils.BadRequest(c, "Reset token is required")
+		return
+	}
+
+	resp, err := h.authService.ValidateResetToken(token)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Reset token is valid", resp)
+}
+
+// SendVerification handles (re)sending an email verification link
+// @Summary Send verification email
+// @Description Issue a new email verification token, invalidating any previous one
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body request.SendVerificationRequest true "Email address"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /auth/send-verification [post]
+func (h *AuthHandler) SendVerification(c *gin.Context) {
+	var req request.SendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	if err := h.authService.SendVerification(&req); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "If the email exists, a verification link has been sent", nil)
+}
+
+// VerifyEmail handles email verification with a token
+// @Summary Verify email
+// @Description Verify a user's email address using a verification token
+// @Tags Auth
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} utils.APIResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /auth/verify-email [get]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		utils.BadRequest(c, "Verification token is required")
+		return
+	}
+
+	alreadyVerified, err := h.authService.VerifyEmail(token)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	if alreadyVerified {
+		utils.OK(c, "Email is already verified", nil)
+		return
+	}
+
+	utils.OK(c, "Email verified successfully", nil)
+}
+
 // ChangePassword handles password change for authenticated users
 // @Summary Change password
 // @Description Change password for authenticated user
@@ -207,7 +328,7 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	}
 
 	if err := h.authService.ChangePassword(userID, &req); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -233,9 +354,66 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 
 	resp, err := h.authService.GetCurrentUser(userID)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "", resp)
 }
+
+// GetSessions lists the current user's active sessions
+// @Summary List sessions
+// @Description List the authenticated user's active login sessions
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.APIResponse{data=[]response.SessionResponse}
+// @Failure 401 {object} utils.ErrorResponse
+// @Router /auth/sessions [get]
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	sessions, err := h.authService.GetSessions(userID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", sessions)
+}
+
+// RevokeSession revokes one of the current user's sessions
+// @Summary Revoke session
+// @Description Revoke one of the authenticated user's login sessions
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} utils.APIResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	if err := h.authService.RevokeSession(userID, sessionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Session revoked successfully", nil)
+}
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/middleware"
@@ -37,9 +40,15 @@ func (h *ClassHandler) Create(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.CreateClass(&req, institutionID)
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.CreateClass(&req, institutionID, userID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -52,17 +61,24 @@ func (h *ClassHandler) GetAll(c *gin.Context) {
 	if err := c.ShouldBindQuery(&params); err != nil {
 		params = utils.DefaultPagination()
 	} else {
-		params = utils.NewPaginationParams(params.Page, params.PerPage)
+		params = utils.NewPaginationParams(params.Page, params.PerPage).WithSort(params.SortBy, params.SortOrder)
 	}
 
 	filter := repository.ClassFilter{
 		InstitutionID: middleware.GetInstitutionID(c),
 		Search:        c.Query("search"),
+		CreatedBy:     c.Query("created_by"),
+	}
+	if filter.CreatedBy != "" {
+		if _, err := uuid.Parse(filter.CreatedBy); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
 	}
 
 	data, pagination, err := h.service.GetAllClasses(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -85,7 +101,7 @@ func (h *ClassHandler) GetByID(c *gin.Context) {
 
 	resp, err := h.service.GetClassByID(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -112,9 +128,15 @@ func (h *ClassHandler) Update(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.UpdateClass(id, &req, institutionID)
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.UpdateClass(id, &req, institutionID, userID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -136,7 +158,7 @@ func (h *ClassHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.DeleteClass(id, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -157,13 +179,109 @@ func (h *ClassHandler) GetStudents(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.GetClassStudents(id, institutionID)
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	data, pagination, err := h.service.GetClassStudents(id, institutionID, params)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
-	utils.OK(c, "", resp)
+	utils.Paginated(c, data, pagination)
+}
+
+// ReassignRollNumbers handles renumbering the students of a class by name
+func (h *ClassHandler) ReassignRollNumbers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	// The body is optional
+	var req request.ReassignRollNumbersRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	resp, err := h.service.ReassignRollNumbers(id, institutionID, &req)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Roll numbers reassigned successfully", resp)
+}
+
+// RecountSections handles repairing the section counts of the institution's classes
+func (h *ClassHandler) RecountSections(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.RecountSections(institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Section counts recounted successfully", resp)
+}
+
+// AssignTeachers handles setting the class teachers of several classes at once
+func (h *ClassHandler) AssignTeachers(c *gin.Context) {
+	var req request.AssignClassTeachersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.AssignClassTeachers(&req, institutionID, userID)
+	if err != nil {
+		if resp == nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+		// Report which assignments failed; nothing was changed
+		statusCode := http.StatusBadRequest
+		if appErr, ok := err.(*utils.AppError); ok {
+			statusCode = appErr.StatusCode
+		}
+		c.JSON(statusCode, utils.APIResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    resp,
+		})
+		return
+	}
+
+	utils.OK(c, "Class teachers assigned successfully", resp)
 }
 
 // GetTeachers handles getting all teachers for a class
@@ -182,7 +300,7 @@ func (h *ClassHandler) GetTeachers(c *gin.Context) {
 
 	resp, err := h.service.GetClassTeachers(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -211,14 +329,15 @@ func (h *ClassHandler) CreateSection(c *gin.Context) {
 
 	resp, err := h.service.CreateSection(classID, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Created(c, "Section created successfully", resp)
 }
 
-// GetSections handles getting all sections for a class
+// GetSections handles getting all sections for a class, with their student
+// counts when include_counts=true
 func (h *ClassHandler) GetSections(c *gin.Context) {
 	classID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -232,9 +351,19 @@ func (h *ClassHandler) GetSections(c *gin.Context) {
 		return
 	}
 
+	if includeCounts, _ := strconv.ParseBool(c.Query("include_counts")); includeCounts {
+		resp, err := h.service.GetSectionsWithCounts(classID, institutionID)
+		if err != nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+		utils.OK(c, "", resp)
+		return
+	}
+
 	resp, err := h.service.GetSectionsByClass(classID, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -257,7 +386,7 @@ func (h *ClassHandler) UpdateSection(c *gin.Context) {
 
 	resp, err := h.service.UpdateSection(sectionID, &req)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -273,7 +402,7 @@ func (h *ClassHandler) DeleteSection(c *gin.Context) {
 	}
 
 	if err := h.service.DeleteSection(sectionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -288,11 +417,24 @@ func (h *ClassHandler) GetSectionStudents(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.GetSectionStudents(sectionID)
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.BadRequest(c, "Invalid institution ID")
 		return
 	}
 
-	utils.OK(c, "", resp)
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	data, pagination, err := h.service.GetSectionStudents(sectionID, institutionID, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
 }
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DashboardHandler handles admin dashboard API requests
+type DashboardHandler struct {
+	service *service.DashboardService
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(service *service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{service: service}
+}
+
+// GetSummary handles getting the headline numbers of the caller's institution
+func (h *DashboardHandler) GetSummary(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInstitutionIDRequired)
+		return
+	}
+
+	resp, err := h.service.GetSummary(institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
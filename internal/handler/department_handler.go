@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
 	"campus-core/internal/dto/request"
@@ -8,9 +9,12 @@ import (
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
 	"campus-core/internal/utils"
+	"campus-core/pkg/export"
+	"campus-core/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // DepartmentHandler handles department API requests
@@ -39,7 +43,7 @@ func (h *DepartmentHandler) Create(c *gin.Context) {
 
 	resp, err := h.service.Create(&req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -62,7 +66,7 @@ func (h *DepartmentHandler) GetAll(c *gin.Context) {
 
 	data, pagination, err := h.service.GetAll(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -85,7 +89,7 @@ func (h *DepartmentHandler) GetByID(c *gin.Context) {
 
 	resp, err := h.service.GetByID(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -114,7 +118,7 @@ func (h *DepartmentHandler) Update(c *gin.Context) {
 
 	resp, err := h.service.Update(id, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -136,7 +140,7 @@ func (h *DepartmentHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(id, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -159,7 +163,60 @@ func (h *DepartmentHandler) GetStaff(c *gin.Context) {
 
 	resp, err := h.service.GetDepartmentStaff(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// ExportStaff handles downloading a department's staff directory
+func (h *DepartmentHandler) ExportStaff(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	stream, err := h.service.ExportStaff(id, institutionID, c.DefaultQuery("format", export.FormatCSV))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stream.Name))
+	c.Header("Content-Type", stream.ContentType)
+	c.Status(http.StatusOK)
+
+	// The status is already sent, so a failure can only cut the download short
+	if err := stream.Write(c.Writer); err != nil {
+		logger.Error("Failed to export department staff", zap.Error(err))
+	}
+}
+
+// GetHeadHistory handles listing a department's past and current heads
+func (h *DepartmentHandler) GetHeadHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetHeadHistory(id, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FeeStructureHandler handles fee structure API requests
+type FeeStructureHandler struct {
+	service *service.FeeStructureService
+}
+
+// NewFeeStructureHandler creates a new fee structure handler
+func NewFeeStructureHandler(service *service.FeeStructureService) *FeeStructureHandler {
+	return &FeeStructureHandler{service: service}
+}
+
+// Create handles fee structure creation
+func (h *FeeStructureHandler) Create(c *gin.Context) {
+	var req request.CreateFeeStructureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.Create(&req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Fee structure created successfully", resp)
+}
+
+// GetAll handles listing fee structures, optionally filtered by class and academic year
+func (h *FeeStructureHandler) GetAll(c *gin.Context) {
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	filter := repository.FeeStructureFilter{
+		InstitutionID: middleware.GetInstitutionID(c), // Enforce tenant
+	}
+	if classID := c.Query("class_id"); classID != "" {
+		if _, err := uuid.Parse(classID); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
+		filter.ClassID = classID
+	}
+	if academicYearID := c.Query("academic_year_id"); academicYearID != "" {
+		if _, err := uuid.Parse(academicYearID); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
+		filter.AcademicYearID = academicYearID
+	}
+
+	data, pagination, err := h.service.GetAll(filter, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
+}
+
+// GetByID handles getting a fee structure by ID
+func (h *FeeStructureHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetByID(id, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetSummary handles totalling the annual fees per class of an academic year (default current)
+func (h *FeeStructureHandler) GetSummary(c *gin.Context) {
+	var academicYearID *uuid.UUID
+	if idStr := c.Query("academic_year_id"); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
+		academicYearID = &id
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetClassSummary(academicYearID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// Update handles fee structure update
+func (h *FeeStructureHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.UpdateFeeStructureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.Update(id, &req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Fee structure updated successfully", resp)
+}
+
+// Delete handles fee structure deletion
+func (h *FeeStructureHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.Delete(id, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HolidayHandler handles holiday API requests
+type HolidayHandler struct {
+	service *service.HolidayService
+}
+
+// NewHolidayHandler creates a new holiday handler
+func NewHolidayHandler(service *service.HolidayService) *HolidayHandler {
+	return &HolidayHandler{service: service}
+}
+
+// Create handles adding a holiday to an academic year
+func (h *HolidayHandler) Create(c *gin.Context) {
+	academicYearID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.CreateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.Create(academicYearID, &req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Holiday created successfully", resp)
+}
+
+// GetAll handles listing the holidays of an academic year
+func (h *HolidayHandler) GetAll(c *gin.Context) {
+	academicYearID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetAll(academicYearID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// Update handles updating a holiday
+func (h *HolidayHandler) Update(c *gin.Context) {
+	academicYearID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+	id, err := uuid.Parse(c.Param("holidayId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.UpdateHolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.Update(academicYearID, id, &req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Holiday updated successfully", resp)
+}
+
+// Delete handles removing a holiday
+func (h *HolidayHandler) Delete(c *gin.Context) {
+	academicYearID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+	id, err := uuid.Parse(c.Param("holidayId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.Delete(academicYearID, id, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
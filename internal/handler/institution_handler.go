@@ -3,7 +3,10 @@ package handler
 import (
 	"net/http"
 
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
 	"campus-core/internal/models"
+	"campus-core/internal/repository"
 	"campus-core/internal/service"
 	"campus-core/internal/utils"
 
@@ -30,6 +33,7 @@ func (h *InstitutionHandler) Create(c *gin.Context) {
 		Phone         string `json:"phone"`
 		Email         string `json:"email" binding:"omitempty,email"`
 		PrincipalName string `json:"principal_name"`
+		Timezone      string `json:"timezone"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -44,17 +48,46 @@ func (h *InstitutionHandler) Create(c *gin.Context) {
 		Phone:         input.Phone,
 		Email:         input.Email,
 		PrincipalName: input.PrincipalName,
+		Settings:      &models.InstitutionSettings{Timezone: input.Timezone},
 		IsActive:      true,
 	}
 
 	if err := h.service.Create(institution); err != nil {
-		utils.Error(c, http.StatusBadRequest, err) // Could be 409 if code exists
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Created(c, "Institution created successfully", institution)
 }
 
+// Onboard creates an institution together with its first admin
+func (h *InstitutionHandler) Onboard(c *gin.Context) {
+	var req request.OnboardInstitutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institution := &models.Institution{
+		Name:          req.Name,
+		Code:          req.Code,
+		Address:       req.Address,
+		Phone:         req.Phone,
+		Email:         req.Email,
+		PrincipalName: req.PrincipalName,
+		Settings:      &models.InstitutionSettings{Timezone: req.Timezone},
+		IsActive:      true,
+	}
+
+	resp, err := h.service.Onboard(institution, &req.Admin)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Institution onboarded successfully", resp)
+}
+
 // GetAll returns all institutions
 func (h *InstitutionHandler) GetAll(c *gin.Context) {
 	var params utils.PaginationParams
@@ -68,7 +101,7 @@ func (h *InstitutionHandler) GetAll(c *gin.Context) {
 
 	data, pagination, err := h.service.GetAll(params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -85,7 +118,7 @@ func (h *InstitutionHandler) GetByID(c *gin.Context) {
 
 	institution, err := h.service.GetByID(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -108,7 +141,7 @@ func (h *InstitutionHandler) Update(c *gin.Context) {
 
 	institution, err := h.service.Update(id, updates)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -124,7 +157,7 @@ func (h *InstitutionHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(id); err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -141,7 +174,24 @@ func (h *InstitutionHandler) GetStats(c *gin.Context) {
 
 	stats, err := h.service.GetStats(id)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", stats)
+}
+
+// GetDetailedStats returns institution stats broken down by class, section, gender and department
+func (h *InstitutionHandler) GetDetailedStats(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	stats, err := h.service.GetDetailedStats(id)
+	if err != nil {
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -165,7 +215,7 @@ func (h *InstitutionHandler) ToggleStatus(c *gin.Context) {
 	}
 
 	if err := h.service.ToggleStatus(id, req.IsActive); err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -176,7 +226,7 @@ func (h *InstitutionHandler) ToggleStatus(c *gin.Context) {
 	utils.OK(c, "Institution "+status+" successfully", nil)
 }
 
-// GetAdmins returns a list of admins for an institution
+// GetAdmins returns a page of the admins of an institution
 func (h *InstitutionHandler) GetAdmins(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -184,13 +234,25 @@ func (h *InstitutionHandler) GetAdmins(c *gin.Context) {
 		return
 	}
 
-	admins, err := h.service.GetAdmins(id)
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	filter := repository.AdminFilter{
+		Search:     c.Query("search"),
+		ActiveOnly: c.Query("is_active") == "true",
+	}
+
+	admins, pagination, err := h.service.GetAdmins(id, filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
-	utils.OK(c, "", admins)
+	utils.Paginated(c, admins, pagination)
 }
 
 // AssignAdmin assigns an admin to an institution
@@ -215,9 +277,84 @@ func (h *InstitutionHandler) AssignAdmin(c *gin.Context) {
 
 	admin, err := h.service.AssignAdmin(id, req.Email, req.FirstName, req.LastName, req.Password, req.Phone)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Created(c, "Admin assigned successfully", admin)
 }
+
+// GetSettings returns the settings of an institution
+func (h *InstitutionHandler) GetSettings(c *gin.Context) {
+	id, ok := h.ownInstitutionID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.service.GetSettings(id)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", settings)
+}
+
+// UpdateSettings updates the settings of an institution
+func (h *InstitutionHandler) UpdateSettings(c *gin.Context) {
+	id, ok := h.ownInstitutionID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateInstitutionSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(id, &req)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Institution settings updated successfully", settings)
+}
+
+// UploadLogo replaces the institution's logo with an uploaded image (multipart field "file")
+func (h *InstitutionHandler) UploadLogo(c *gin.Context) {
+	id, ok := h.ownInstitutionID(c)
+	if !ok {
+		return
+	}
+
+	data, err := readImageUpload(c, "file")
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	institution, err := h.service.UpdateLogo(id, data)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Institution logo updated successfully", institution)
+}
+
+// ownInstitutionID parses the institution ID and ensures admins only reach their own institution
+func (h *InstitutionHandler) ownInstitutionID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return uuid.Nil, false
+	}
+
+	if middleware.GetUserRole(c) != models.RoleSuperAdmin && middleware.GetInstitutionID(c) != id.String() {
+		utils.Error(c, http.StatusForbidden, utils.ErrCrossTenantAccess)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/middleware"
+	"campus-core/internal/models"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LeaveHandler handles leave application API requests
+type LeaveHandler struct {
+	service *service.LeaveService
+}
+
+// NewLeaveHandler creates a new leave handler
+func NewLeaveHandler(service *service.LeaveService) *LeaveHandler {
+	return &LeaveHandler{service: service}
+}
+
+// Apply handles applying for leave
+func (h *LeaveHandler) Apply(c *gin.Context) {
+	var req request.ApplyLeaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	applicantID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.Apply(&req, applicantID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Leave application submitted successfully", resp)
+}
+
+// ListMine handles listing the caller's own leave applications
+func (h *LeaveHandler) ListMine(c *gin.Context) {
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	status, ok := parseLeaveStatus(c)
+	if !ok {
+		return
+	}
+
+	applicantID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	data, pagination, err := h.service.ListMine(applicantID, middleware.GetInstitutionID(c), status, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
+}
+
+// List handles listing the institution's leave applications for approvers
+func (h *LeaveHandler) List(c *gin.Context) {
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	status, ok := parseLeaveStatus(c)
+	if !ok {
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	data, pagination, err := h.service.List(institutionID, status, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
+}
+
+// Approve handles approving a leave application
+func (h *LeaveHandler) Approve(c *gin.Context) {
+	h.review(c, h.service.Approve, "Leave application approved successfully")
+}
+
+// Reject handles rejecting a leave application
+func (h *LeaveHandler) Reject(c *gin.Context) {
+	h.review(c, h.service.Reject, "Leave application rejected successfully")
+}
+
+// review handles a review decision on a leave application
+func (h *LeaveHandler) review(c *gin.Context, decide func(uuid.UUID, *request.ReviewLeaveRequest, uuid.UUID, uuid.UUID) (*response.LeaveResponse, error), message string) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	// The body is optional
+	var req request.ReviewLeaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	reviewerID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := decide(id, &req, reviewerID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, message, resp)
+}
+
+// parseLeaveStatus reads the optional status query parameter, responding with
+// an error when it is not PENDING, APPROVED or REJECTED
+func parseLeaveStatus(c *gin.Context) (string, bool) {
+	status := models.LeaveStatus(strings.ToUpper(c.Query("status")))
+	switch status {
+	case "", models.LeaveStatusPending, models.LeaveStatusApproved, models.LeaveStatusRejected:
+		return string(status), true
+	}
+	utils.BadRequest(c, "status must be PENDING, APPROVED or REJECTED")
+	return "", false
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetaHandler serves the reference data used by the frontend
+type MetaHandler struct {
+	meta response.MetaResponse
+	etag string
+}
+
+// NewMetaHandler creates a new meta handler. The reference data only changes
+// with a new release, so its ETag is computed once.
+func NewMetaHandler() *MetaHandler {
+	meta := response.MetaResponse{
+		Roles:         models.ValidRoles,
+		Relationships: models.ValidRelationships,
+		DaysOfWeek:    make([]string, len(models.DaysOfWeek)),
+		Genders:       models.ValidGenders,
+		BloodGroups:   models.BloodGroups,
+	}
+	for i, day := range models.DaysOfWeek {
+		meta.DaysOfWeek[i] = string(day)
+	}
+
+	body, _ := json.Marshal(meta)
+	sum := sha256.Sum256(body)
+	return &MetaHandler{
+		meta: meta,
+		etag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+}
+
+// Get returns the reference data. Clients revalidate with If-None-Match and
+// get 304 Not Modified while it is unchanged.
+func (h *MetaHandler) Get(c *gin.Context) {
+	c.Header("ETag", h.etag)
+	c.Header("Cache-Control", "private, no-cache")
+	if c.GetHeader("If-None-Match") == h.etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	utils.OK(c, "", h.meta)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NoticeHandler handles notice API requests
+type NoticeHandler struct {
+	service *service.NoticeService
+}
+
+// NewNoticeHandler creates a new notice handler
+func NewNoticeHandler(service *service.NoticeService) *NoticeHandler {
+	return &NoticeHandler{service: service}
+}
+
+// Create handles publishing a notice
+func (h *NoticeHandler) Create(c *gin.Context) {
+	var req request.CreateNoticeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	authorID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.Create(&req, institutionID, authorID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Notice published successfully", resp)
+}
+
+// List handles listing the notices visible to the caller
+func (h *NoticeHandler) List(c *gin.Context) {
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	data, pagination, err := h.service.List(middleware.GetUserRole(c), middleware.GetInstitutionID(c), params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
+}
+
+// Delete handles deleting a notice
+func (h *NoticeHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.Delete(id, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
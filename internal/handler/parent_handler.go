@@ -31,7 +31,7 @@ func (h *ParentHandler) Create(c *gin.Context) {
 	creatorInstID := middleware.GetInstitutionID(c)
 	resp, err := h.service.CreateParent(&req, creatorInstID)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -49,7 +49,7 @@ func (h *ParentHandler) GetAll(c *gin.Context) {
 	institutionID := middleware.GetInstitutionID(c)
 	data, pagination, err := h.service.GetAllParents(institutionID, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -65,7 +65,7 @@ func (h *ParentHandler) GetByID(c *gin.Context) {
 
 	parent, err := h.service.GetParent(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -88,7 +88,7 @@ func (h *ParentHandler) Update(c *gin.Context) {
 	institutionID := middleware.GetInstitutionID(c)
 	parent, err := h.service.UpdateParent(id, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -104,9 +104,42 @@ func (h *ParentHandler) GetChildren(c *gin.Context) {
 
 	children, err := h.service.GetParentChildren(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "", children)
 }
+
+// GetChildrenTimetables handles getting the timetables of a parent's children
+func (h *ParentHandler) GetChildrenTimetables(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var academicYearID *uuid.UUID
+	if ayIDStr := c.Query("academic_year_id"); ayIDStr != "" {
+		ayID, err := uuid.Parse(ayIDStr)
+		if err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
+		academicYearID = &ayID
+	}
+
+	callerID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.GetChildrenTimetables(id, academicYearID, callerID, middleware.GetUserRole(c), middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PeriodHandler handles period API requests
+type PeriodHandler struct {
+	service *service.PeriodService
+}
+
+// NewPeriodHandler creates a new period handler
+func NewPeriodHandler(service *service.PeriodService) *PeriodHandler {
+	return &PeriodHandler{service: service}
+}
+
+// Create handles adding a period to the school day
+func (h *PeriodHandler) Create(c *gin.Context) {
+	var req request.CreatePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.Create(&req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Period created successfully", resp)
+}
+
+// GetAll handles listing the periods of the school day
+func (h *PeriodHandler) GetAll(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetAll(institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetByID handles getting a single period
+func (h *PeriodHandler) GetByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetByID(id, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// Update handles updating a period
+func (h *PeriodHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.UpdatePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.Update(id, &req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Period updated successfully", resp)
+}
+
+// Delete handles removing a period
+func (h *PeriodHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.Delete(id, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
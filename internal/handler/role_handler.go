@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RoleHandler handles custom role and permission API requests
+type RoleHandler struct {
+	service *service.RoleService
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(service *service.RoleService) *RoleHandler {
+	return &RoleHandler{service: service}
+}
+
+// GetAll handles listing the built-in and institution roles
+func (h *RoleHandler) GetAll(c *gin.Context) {
+	roles, err := h.service.ListRoles(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", roles)
+}
+
+// GetPermissions handles listing the grantable permissions
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	permissions, err := h.service.ListPermissions()
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", permissions)
+}
+
+// Create handles creating a custom role
+func (h *RoleHandler) Create(c *gin.Context) {
+	var req request.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInstitutionIDRequired)
+		return
+	}
+
+	resp, err := h.service.CreateRole(&req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Role created successfully", resp)
+}
+
+// GetRolePermissions handles getting a role with its permissions
+// The :role parameter is a role ID or a built-in role code such as TEACHER
+func (h *RoleHandler) GetRolePermissions(c *gin.Context) {
+	resp, err := h.service.GetRole(c.Param("role"), middleware.GetUserRole(c), middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// UpdatePermissions handles replacing a role's permissions
+func (h *RoleHandler) UpdatePermissions(c *gin.Context) {
+	var req request.UpdateRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	resp, err := h.service.UpdatePermissions(c.Param("role"), &req, middleware.GetUserRole(c), middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Role permissions updated successfully", resp)
+}
+
+// AssignUserRole handles attaching a custom role to a user
+func (h *RoleHandler) AssignUserRole(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.AssignUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	if err := h.service.AssignUserRole(userID, &req, middleware.GetUserRole(c), middleware.GetInstitutionID(c)); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "User role updated successfully", nil)
+}
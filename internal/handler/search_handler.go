@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles global search API requests
+type SearchHandler struct {
+	service *service.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(service *service.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search handles searching students, teachers and parents of the caller's institution
+func (h *SearchHandler) Search(c *gin.Context) {
+	var query request.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	var params utils.PaginationParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		params = utils.DefaultPagination()
+	} else {
+		params = utils.NewPaginationParams(params.Page, params.PerPage)
+	}
+
+	institutionID := middleware.GetInstitutionID(c) // Enforce tenant
+	data, pagination, err := h.service.Search(query.Q, query.Role, institutionID, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Paginated(c, data, pagination)
+}
@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
 	"campus-core/internal/service"
 	"campus-core/internal/utils"
 
@@ -28,34 +35,218 @@ func (h *StudentHandler) Create(c *gin.Context) {
 		return
 	}
 
+	// Admins may enroll beyond capacity with ?force=true
+	force, _ := strconv.ParseBool(c.Query("force"))
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
 	creatorInstID := middleware.GetInstitutionID(c)
-	resp, err := h.service.CreateStudent(&req, creatorInstID)
+	resp, err := h.service.CreateStudent(c.Request.Context(), &req, creatorInstID, userID, force)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Created(c, "Student created successfully", resp)
 }
 
+// maxStudentImportRows limits the number of data rows accepted in one import file
+const maxStudentImportRows = 2000
+
+// Import creates students from an uploaded CSV file.
+// The file is sent as multipart field "file" with a header row; pass
+// skip_invalid=true to create the valid rows even if some rows fail.
+func (h *StudentHandler) Import(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, "CSV file is required in form field 'file'")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Unable to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	rows, err := parseStudentImportCSV(file)
+	if err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+
+	skipInvalid, _ := strconv.ParseBool(c.Query("skip_invalid"))
+	institutionID := middleware.GetInstitutionID(c)
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.BulkImport(rows, institutionID, userID, skipInvalid)
+	if err != nil {
+		if resp == nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+		// Report which rows failed
+		statusCode := http.StatusBadRequest
+		if appErr, ok := err.(*utils.AppError); ok {
+			statusCode = appErr.StatusCode
+		}
+		c.JSON(statusCode, utils.APIResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    resp,
+		})
+		return
+	}
+
+	utils.Created(c, "Students imported successfully", resp)
+}
+
+// parseStudentImportCSV reads a student import CSV into rows.
+// Columns are matched by header name and may appear in any order.
+func parseStudentImportCSV(r io.Reader) ([]request.StudentImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV file is empty")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+	for _, required := range []string{"email", "first_name", "last_name", "class"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var rows []request.StudentImportRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV on line %d: %w", line, err)
+		}
+		if len(rows) >= maxStudentImportRows {
+			return nil, fmt.Errorf("too many rows, at most %d are allowed per import", maxStudentImportRows)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := request.StudentImportRow{
+			Row:             line,
+			Email:           field("email"),
+			Phone:           field("phone"),
+			FirstName:       field("first_name"),
+			LastName:        field("last_name"),
+			AdmissionNumber: field("admission_number"),
+			ClassName:       field("class"),
+			SectionName:     field("section"),
+			DateOfBirth:     field("date_of_birth"),
+			BloodGroup:      field("blood_group"),
+		}
+		if roll := field("roll_number"); roll != "" {
+			// Invalid numbers are reported per row by the service
+			row.RollNumber, err = strconv.Atoi(roll)
+			if err != nil {
+				row.RollNumber = -1
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("CSV file has no data rows")
+	}
+
+	return rows, nil
+}
+
 func (h *StudentHandler) GetAll(c *gin.Context) {
+	// ?cursor= (empty for the first page) switches to cursor pagination
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.getAllCursor(c)
+		return
+	}
+
 	var params utils.PaginationParams
 	if err := c.ShouldBindQuery(&params); err != nil {
 		params = utils.DefaultPagination()
 	} else {
-		params = utils.NewPaginationParams(params.Page, params.PerPage)
+		params = utils.NewPaginationParams(params.Page, params.PerPage).WithSort(params.SortBy, params.SortOrder)
 	}
 
-	institutionID := middleware.GetInstitutionID(c)
-	data, pagination, err := h.service.GetAllStudents(institutionID, params)
+	filter := repository.StudentFilter{
+		InstitutionID: middleware.GetInstitutionID(c),
+		CreatedBy:     c.Query("created_by"),
+		BloodGroup:    strings.TrimSpace(c.Query("blood_group")),
+	}
+	if filter.CreatedBy != "" {
+		if _, err := uuid.Parse(filter.CreatedBy); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
+	}
+	if value := c.Query("has_medical_info"); value != "" {
+		hasMedicalInfo, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.BadRequest(c, "has_medical_info must be true or false")
+			return
+		}
+		filter.HasMedicalInfo = &hasMedicalInfo
+	}
+
+	data, pagination, err := h.service.GetAllStudents(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Paginated(c, data, pagination)
 }
 
+// getAllCursor lists students using cursor pagination
+func (h *StudentHandler) getAllCursor(c *gin.Context) {
+	var params utils.CursorParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidFieldFormat)
+		return
+	}
+	params = utils.NewCursorParams(params.Cursor, params.Limit)
+
+	institutionID := middleware.GetInstitutionID(c)
+	data, pagination, err := h.service.GetStudentsCursor(institutionID, params)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.CursorPaginated(c, data, pagination)
+}
+
 func (h *StudentHandler) GetByID(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -65,13 +256,30 @@ func (h *StudentHandler) GetByID(c *gin.Context) {
 
 	student, err := h.service.GetStudent(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "", student)
 }
 
+// GetFullProfile returns a student with class, section, parents and the current academic year
+func (h *StudentHandler) GetFullProfile(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	profile, err := h.service.GetFullProfile(id, middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", profile)
+}
+
 func (h *StudentHandler) Update(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -85,10 +293,19 @@ func (h *StudentHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// Admins may enroll beyond capacity with ?force=true
+	force, _ := strconv.ParseBool(c.Query("force"))
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
 	institutionID := middleware.GetInstitutionID(c)
-	student, err := h.service.UpdateStudent(id, &req, institutionID)
+	student, err := h.service.UpdateStudent(id, &req, institutionID, userID, force)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -104,7 +321,7 @@ func (h *StudentHandler) GetParents(c *gin.Context) {
 
 	parents, err := h.service.GetStudentParents(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -125,7 +342,7 @@ func (h *StudentHandler) LinkParent(c *gin.Context) {
 	}
 
 	if err := h.service.LinkParent(studentID, &req); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -146,9 +363,136 @@ func (h *StudentHandler) UnlinkParent(c *gin.Context) {
 	}
 
 	if err := h.service.UnlinkParent(studentID, parentID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "Parent unlinked successfully", nil)
 }
+
+// SetPrimaryParent makes a linked parent the student's primary parent
+func (h *StudentHandler) SetPrimaryParent(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	parentID, err := uuid.Parse(c.Param("parentId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	if err := h.service.SetPrimaryParent(studentID, parentID, middleware.GetInstitutionID(c)); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Primary parent updated successfully", nil)
+}
+
+// Promote moves students to another class or section
+func (h *StudentHandler) Promote(c *gin.Context) {
+	var req request.PromoteStudentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	institutionID := middleware.GetInstitutionID(c)
+	resp, err := h.service.PromoteStudents(&req, institutionID, userID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Students promoted successfully", resp)
+}
+
+// BulkStatus activates or deactivates many students at once
+func (h *StudentHandler) BulkStatus(c *gin.Context) {
+	var req request.BulkStudentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	resp, err := h.service.BulkSetStatus(&req, middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Student status updated successfully", resp)
+}
+
+// MoveSection moves students from one section to another
+func (h *StudentHandler) MoveSection(c *gin.Context) {
+	var req request.MoveSectionStudentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	resp, err := h.service.MoveSectionStudents(&req, middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Students moved successfully", resp)
+}
+
+// Transfer moves a student to another institution
+func (h *StudentHandler) Transfer(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.TransferStudentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.TransferStudent(studentID, &req, userID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Student transferred successfully", resp)
+}
+
+// RevertPromotion moves the students of a promotion batch back to their previous class
+func (h *StudentHandler) RevertPromotion(c *gin.Context) {
+	batchID, err := uuid.Parse(c.Param("batchId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID := middleware.GetInstitutionID(c)
+	resp, err := h.service.RevertPromotion(batchID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Promotion reverted successfully", resp)
+}
@@ -37,9 +37,15 @@ func (h *SubjectHandler) Create(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.Create(&req, institutionID)
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.Create(&req, institutionID, userID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -52,7 +58,7 @@ func (h *SubjectHandler) GetAll(c *gin.Context) {
 	if err := c.ShouldBindQuery(&params); err != nil {
 		params = utils.DefaultPagination()
 	} else {
-		params = utils.NewPaginationParams(params.Page, params.PerPage)
+		params = utils.NewPaginationParams(params.Page, params.PerPage).WithSort(params.SortBy, params.SortOrder)
 	}
 
 	filter := repository.SubjectFilter{
@@ -60,6 +66,13 @@ func (h *SubjectHandler) GetAll(c *gin.Context) {
 		ClassID:       c.Query("class_id"),
 		TeacherID:     c.Query("teacher_id"),
 		Search:        c.Query("search"),
+		CreatedBy:     c.Query("created_by"),
+	}
+	if filter.CreatedBy != "" {
+		if _, err := uuid.Parse(filter.CreatedBy); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+			return
+		}
 	}
 
 	if isElective := c.Query("is_elective"); isElective != "" {
@@ -69,7 +82,7 @@ func (h *SubjectHandler) GetAll(c *gin.Context) {
 
 	data, pagination, err := h.service.GetAll(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -92,7 +105,7 @@ func (h *SubjectHandler) GetByID(c *gin.Context) {
 
 	resp, err := h.service.GetByID(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -115,7 +128,7 @@ func (h *SubjectHandler) GetByClassID(c *gin.Context) {
 
 	resp, err := h.service.GetByClassID(classID, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -142,9 +155,15 @@ func (h *SubjectHandler) Update(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.Update(id, &req, institutionID)
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.Update(id, &req, institutionID, userID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -166,7 +185,7 @@ func (h *SubjectHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(id, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -194,9 +213,158 @@ func (h *SubjectHandler) AssignTeacher(c *gin.Context) {
 	}
 
 	if err := h.service.AssignTeacher(subjectID, &req, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "Teacher assigned successfully", nil)
 }
+
+// UnassignTeacher handles removing the teacher from a subject
+func (h *SubjectHandler) UnassignTeacher(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.UnassignTeacher(subjectID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Teacher unassigned successfully", resp)
+}
+
+// GetPrerequisites handles listing a subject's prerequisites
+func (h *SubjectHandler) GetPrerequisites(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetPrerequisites(subjectID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetTeacherHistory handles listing a subject's past and current teachers
+func (h *SubjectHandler) GetTeacherHistory(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetAssignmentHistory(subjectID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// AddPrerequisite handles adding a prerequisite to a subject
+func (h *SubjectHandler) AddPrerequisite(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.AddPrerequisiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.AddPrerequisite(subjectID, &req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Prerequisite added successfully", resp)
+}
+
+// RemovePrerequisite handles removing a prerequisite from a subject
+func (h *SubjectHandler) RemovePrerequisite(c *gin.Context) {
+	subjectID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	prerequisiteID, err := uuid.Parse(c.Param("prerequisiteId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.RemovePrerequisite(subjectID, prerequisiteID, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Prerequisite removed successfully", nil)
+}
+
+// GetClassCreditSummary handles getting the credit hour totals for a class
+func (h *SubjectHandler) GetClassCreditSummary(c *gin.Context) {
+	classID, err := uuid.Parse(c.Param("classId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.GetClassCreditSummary(classID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
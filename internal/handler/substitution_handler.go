@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SubstitutionHandler handles substitution API requests
+type SubstitutionHandler struct {
+	service *service.SubstitutionService
+}
+
+// NewSubstitutionHandler creates a new substitution handler
+func NewSubstitutionHandler(service *service.SubstitutionService) *SubstitutionHandler {
+	return &SubstitutionHandler{service: service}
+}
+
+// Create handles assigning a substitute teacher to a timetable slot
+func (h *SubstitutionHandler) Create(c *gin.Context) {
+	timetableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.CreateSubstitutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	resp, err := h.service.Create(timetableID, &req, institutionID, userID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Substitution created successfully", resp)
+}
+
+// ListByDate handles listing substitutions on a date
+func (h *SubstitutionHandler) ListByDate(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	date := c.Query("date")
+	if date == "" {
+		utils.BadRequest(c, "date query parameter is required")
+		return
+	}
+
+	resp, err := h.service.ListByDate(institutionID, date)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// Delete handles removing a substitution
+func (h *SubstitutionHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	if err := h.service.Delete(id, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
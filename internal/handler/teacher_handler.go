@@ -1,7 +1,13 @@
 package handler
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/middleware"
@@ -31,13 +37,132 @@ func (h *TeacherHandler) Create(c *gin.Context) {
 	creatorInstID := middleware.GetInstitutionID(c)
 	resp, err := h.service.CreateTeacher(&req, creatorInstID)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err) // Can be duplicate key error etc.
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Created(c, "Teacher created successfully", resp)
 }
 
+// maxTeacherImportRows limits the number of data rows accepted in one import file
+const maxTeacherImportRows = 1000
+
+// Import creates teachers from an uploaded CSV file.
+// The file is sent as multipart field "file" with a header row; pass
+// skip_invalid=true to create the valid rows even if some rows fail.
+func (h *TeacherHandler) Import(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, "CSV file is required in form field 'file'")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Unable to read uploaded file")
+		return
+	}
+	defer file.Close()
+
+	rows, err := parseTeacherImportCSV(file)
+	if err != nil {
+		utils.BadRequest(c, err.Error())
+		return
+	}
+
+	skipInvalid, _ := strconv.ParseBool(c.Query("skip_invalid"))
+	institutionID := middleware.GetInstitutionID(c)
+
+	resp, err := h.service.BulkImport(rows, institutionID, skipInvalid)
+	if err != nil {
+		if resp == nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+		// Report which rows failed
+		c.JSON(utils.StatusForError(err), utils.APIResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    resp,
+		})
+		return
+	}
+
+	utils.Created(c, "Teachers imported successfully", resp)
+}
+
+// parseTeacherImportCSV reads a teacher import CSV into rows.
+// Columns are matched by header name and may appear in any order; the
+// qualifications column holds a comma-separated list.
+func parseTeacherImportCSV(r io.Reader) ([]request.TeacherImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV file is empty")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[strings.ReplaceAll(name, " ", "_")] = i
+	}
+	for _, required := range []string{"email", "first_name", "last_name", "joining_date"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	var rows []request.TeacherImportRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV on line %d: %w", line, err)
+		}
+		if len(rows) >= maxTeacherImportRows {
+			return nil, fmt.Errorf("too many rows, at most %d are allowed per import", maxTeacherImportRows)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var qualifications []string
+		for _, q := range strings.Split(field("qualifications"), ",") {
+			if q = strings.TrimSpace(q); q != "" {
+				qualifications = append(qualifications, q)
+			}
+		}
+
+		rows = append(rows, request.TeacherImportRow{
+			Row:            line,
+			Email:          field("email"),
+			Phone:          field("phone"),
+			FirstName:      field("first_name"),
+			LastName:       field("last_name"),
+			Qualifications: qualifications,
+			DepartmentName: field("department"),
+			JoiningDate:    field("joining_date"),
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("CSV file has no data rows")
+	}
+
+	return rows, nil
+}
+
 func (h *TeacherHandler) GetAll(c *gin.Context) {
 	var params utils.PaginationParams
 	if err := c.ShouldBindQuery(&params); err != nil {
@@ -49,7 +174,7 @@ func (h *TeacherHandler) GetAll(c *gin.Context) {
 	institutionID := middleware.GetInstitutionID(c)
 	data, pagination, err := h.service.GetAllTeachers(institutionID, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -65,7 +190,7 @@ func (h *TeacherHandler) GetByID(c *gin.Context) {
 
 	teacher, err := h.service.GetTeacher(id)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -88,7 +213,7 @@ func (h *TeacherHandler) Update(c *gin.Context) {
 	institutionID := middleware.GetInstitutionID(c)
 	teacher, err := h.service.UpdateTeacher(id, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -102,9 +227,10 @@ func (h *TeacherHandler) GetClasses(c *gin.Context) {
 		return
 	}
 
-	classes, err := h.service.GetTeacherClasses(id)
+	institutionID := middleware.GetInstitutionID(c)
+	classes, err := h.service.GetTeacherClasses(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -118,11 +244,55 @@ func (h *TeacherHandler) GetSubjects(c *gin.Context) {
 		return
 	}
 
-	subjects, err := h.service.GetTeacherSubjects(id)
+	institutionID := middleware.GetInstitutionID(c)
+	subjects, err := h.service.GetTeacherSubjects(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "", subjects)
 }
+
+func (h *TeacherHandler) AssignSubject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var req request.AssignTeacherSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID := middleware.GetInstitutionID(c)
+	if err := h.service.AssignSubject(id, &req, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.Created(c, "Subject assigned successfully", nil)
+}
+
+func (h *TeacherHandler) UnassignSubject(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+	subjectID, err := uuid.Parse(c.Param("subjectId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID := middleware.GetInstitutionID(c)
+	if err := h.service.UnassignSubject(id, subjectID, institutionID); err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/middleware"
+	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
 	"campus-core/internal/utils"
@@ -39,20 +43,92 @@ func (h *TimetableHandler) Create(c *gin.Context) {
 
 	resp, err := h.service.Create(&req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Created(c, "Timetable entry created successfully", resp)
 }
 
+// BulkCreate handles creating multiple timetable entries in one request
+func (h *TimetableHandler) BulkCreate(c *gin.Context) {
+	var req request.BulkTimetableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.BulkCreate(&req, institutionID)
+	if err != nil {
+		if resp == nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+		// Report which entries failed; nothing was created
+		statusCode := http.StatusBadRequest
+		if appErr, ok := err.(*utils.AppError); ok {
+			statusCode = appErr.StatusCode
+		}
+		c.JSON(statusCode, utils.APIResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    resp,
+		})
+		return
+	}
+
+	utils.Created(c, "Timetable entries created successfully", resp)
+}
+
+// Copy handles copying a timetable from one academic year to another
+func (h *TimetableHandler) Copy(c *gin.Context) {
+	var req request.CopyTimetableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.CopyTimetable(&req, institutionID)
+	if err != nil {
+		if resp == nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+		// Report which entries blocked the copy; nothing was created
+		statusCode := http.StatusBadRequest
+		if appErr, ok := err.(*utils.AppError); ok {
+			statusCode = appErr.StatusCode
+		}
+		c.JSON(statusCode, utils.APIResponse{
+			Success: false,
+			Message: err.Error(),
+			Data:    resp,
+		})
+		return
+	}
+
+	utils.Created(c, "Timetable copied successfully", resp)
+}
+
 // GetAll handles listing all timetable entries
 func (h *TimetableHandler) GetAll(c *gin.Context) {
 	var params utils.PaginationParams
 	if err := c.ShouldBindQuery(&params); err != nil {
 		params = utils.DefaultPagination()
 	} else {
-		params = utils.NewPaginationParams(params.Page, params.PerPage)
+		params = utils.NewPaginationParams(params.Page, params.PerPage).WithSort(params.SortBy, params.SortOrder)
 	}
 
 	filter := repository.TimetableFilter{
@@ -72,7 +148,7 @@ func (h *TimetableHandler) GetAll(c *gin.Context) {
 
 	data, pagination, err := h.service.GetAll(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -95,7 +171,7 @@ func (h *TimetableHandler) GetByID(c *gin.Context) {
 
 	resp, err := h.service.GetByID(id, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -124,15 +200,84 @@ func (h *TimetableHandler) GetByClassID(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.service.GetByClassID(classID, institutionID, academicYearID)
+	weekType, ok := parseWeekType(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.GetByClassID(classID, institutionID, academicYearID, weekType)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "", resp)
 }
 
+// ExportByClassID handles downloading a class timetable as a PDF or CSV file
+func (h *TimetableHandler) ExportByClassID(c *gin.Context) {
+	classID, err := uuid.Parse(c.Param("classId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	var academicYearID *uuid.UUID
+	if ayIDStr := c.Query("academic_year_id"); ayIDStr != "" {
+		ayID, err := uuid.Parse(ayIDStr)
+		if err == nil {
+			academicYearID = &ayID
+		}
+	}
+
+	file, err := h.service.ExportClassTimetable(classID, institutionID, academicYearID, c.DefaultQuery("format", "pdf"))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// ExportTeacherICS handles downloading a teacher's schedule as an iCalendar file
+func (h *TimetableHandler) ExportTeacherICS(c *gin.Context) {
+	teacherID, err := uuid.Parse(c.Param("teacherId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	var academicYearID *uuid.UUID
+	if ayIDStr := c.Query("academic_year_id"); ayIDStr != "" {
+		ayID, err := uuid.Parse(ayIDStr)
+		if err == nil {
+			academicYearID = &ayID
+		}
+	}
+
+	file, err := h.service.ExportICS(teacherID, institutionID, academicYearID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.Name))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
 // GetBySectionID handles getting timetable for a section
 func (h *TimetableHandler) GetBySectionID(c *gin.Context) {
 	sectionID, err := uuid.Parse(c.Param("sectionId"))
@@ -149,9 +294,14 @@ func (h *TimetableHandler) GetBySectionID(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.service.GetBySectionID(sectionID, academicYearID)
+	weekType, ok := parseWeekType(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.GetBySectionID(sectionID, academicYearID, weekType)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -174,9 +324,160 @@ func (h *TimetableHandler) GetByTeacherID(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.service.GetByTeacherID(teacherID, academicYearID)
+	weekType, ok := parseWeekType(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseSubstitutionRange(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.GetByTeacherID(teacherID, academicYearID, weekType, from, to)
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetMine handles getting the timetable of the signed-in student or teacher
+func (h *TimetableHandler) GetMine(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	var academicYearID *uuid.UUID
+	if ayIDStr := c.Query("academic_year_id"); ayIDStr != "" {
+		ayID, err := uuid.Parse(ayIDStr)
+		if err == nil {
+			academicYearID = &ayID
+		}
+	}
+
+	weekType, ok := parseWeekType(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseSubstitutionRange(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.GetMine(userID, middleware.GetUserRole(c), institutionID, academicYearID, weekType, from, to)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetTeacherFreeSlots handles finding the periods in which a teacher has no class
+func (h *TimetableHandler) GetTeacherFreeSlots(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	teacherID, err := uuid.Parse(c.Param("teacherId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	var query request.TeacherFreeSlotsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	resp, err := h.service.GetTeacherFreeSlots(teacherID, institutionID, &query)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetTeacherWorkload handles the weekly teacher workload report
+func (h *TimetableHandler) GetTeacherWorkload(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	var query request.TeacherWorkloadQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	resp, err := h.service.GetTeacherWorkload(&query, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// GetRoomSchedule handles getting the timetable of a room
+func (h *TimetableHandler) GetRoomSchedule(c *gin.Context) {
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	var academicYearID *uuid.UUID
+	if ayIDStr := c.Query("academic_year_id"); ayIDStr != "" {
+		ayID, err := uuid.Parse(ayIDStr)
+		if err == nil {
+			academicYearID = &ayID
+		}
+	}
+
+	resp, err := h.service.GetRoomSchedule(c.Param("roomNumber"), institutionID, academicYearID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "", resp)
+}
+
+// CheckRoomAvailability handles checking whether a room is free at a given time
+func (h *TimetableHandler) CheckRoomAvailability(c *gin.Context) {
+	var req request.RoomCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(c, utils.FormatValidationErrors(err))
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	resp, err := h.service.CheckRoomAvailability(&req, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -205,7 +506,7 @@ func (h *TimetableHandler) Update(c *gin.Context) {
 
 	resp, err := h.service.Update(id, &req, institutionID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -227,9 +528,79 @@ func (h *TimetableHandler) Delete(c *gin.Context) {
 	}
 
 	if err := h.service.Delete(id, institutionID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.NoContent(c)
 }
+
+// ClearSection handles deleting a section's timetable for one academic year
+func (h *TimetableHandler) ClearSection(c *gin.Context) {
+	sectionID, err := uuid.Parse(c.Param("sectionId"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	institutionID, err := uuid.Parse(middleware.GetInstitutionID(c))
+	if err != nil {
+		utils.BadRequest(c, "Invalid institution ID")
+		return
+	}
+
+	// The academic year is required so other years' schedules are never touched
+	ayIDStr := c.Query("academic_year_id")
+	if ayIDStr == "" {
+		utils.BadRequest(c, "academic_year_id is required")
+		return
+	}
+	academicYearID, err := uuid.Parse(ayIDStr)
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	resp, err := h.service.ClearSection(sectionID, academicYearID, institutionID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Section timetable cleared successfully", resp)
+}
+
+// parseWeekType reads the optional week_type query parameter, responding with
+// an error when it is not ALL, ODD or EVEN
+func parseWeekType(c *gin.Context) (models.WeekType, bool) {
+	weekType := models.WeekType(strings.ToUpper(c.Query("week_type")))
+	switch weekType {
+	case "", models.WeekTypeAll, models.WeekTypeOdd, models.WeekTypeEven:
+		return weekType, true
+	}
+	utils.BadRequest(c, "week_type must be ALL, ODD or EVEN")
+	return "", false
+}
+
+// parseSubstitutionRange reads the from and to query parameters giving the dates
+// whose substitutions are merged into a teacher's timetable, by default the coming
+// week. It responds with an error and returns false when a date is malformed.
+func parseSubstitutionRange(c *gin.Context) (time.Time, time.Time, bool) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if fromStr := c.Query("from"); fromStr != "" {
+		var err error
+		if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	to := from.AddDate(0, 0, 6)
+	if toStr := c.Query("to"); toStr != "" {
+		var err error
+		if to, err = time.Parse("2006-01-02", toStr); err != nil {
+			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidDateFormat)
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return from, to, true
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+
+	"campus-core/internal/utils"
+	"campus-core/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readImageUpload reads an uploaded image from a multipart form field,
+// rejecting files larger than storage.MaxImageSize
+func readImageUpload(c *gin.Context, field string) ([]byte, error) {
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		return nil, utils.ErrRequiredFieldMissing.Wrap(fmt.Errorf("image file is required in form field '%s'", field))
+	}
+	if fileHeader.Size > storage.MaxImageSize {
+		return nil, utils.ErrFileTooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, utils.ErrInvalidFieldFormat.Wrap(err)
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so oversized content is detected even if the header lies
+	data, err := io.ReadAll(io.LimitReader(file, storage.MaxImageSize+1))
+	if err != nil {
+		return nil, utils.ErrInvalidFieldFormat.Wrap(err)
+	}
+	if len(data) > storage.MaxImageSize {
+		return nil, utils.ErrFileTooLarge
+	}
+	return data, nil
+}
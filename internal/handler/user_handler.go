@@ -1,16 +1,21 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/middleware"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
 	"campus-core/internal/utils"
+	"campus-core/pkg/export"
+	"campus-core/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // UserHandler handles user API requests
@@ -36,7 +41,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	resp, err := h.service.CreateUser(&req, creatorRole, creatorInstID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -64,15 +69,47 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 		filter.IsActive = &active
 	}
 
+	// Lets admins find deleted users to restore
+	filter.IncludeDeleted = c.Query("include_deleted") == "true"
+
 	data, pagination, err := h.service.GetAllUsers(filter, params)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.Paginated(c, data, pagination)
 }
 
+// ExportUsers handles downloading the users matching the list filters as a CSV or Excel file
+func (h *UserHandler) ExportUsers(c *gin.Context) {
+	filter := repository.UserFilter{
+		Role:          c.Query("role"),
+		Search:        c.Query("search"),
+		InstitutionID: middleware.GetInstitutionID(c), // Enforce tenant
+	}
+
+	if isActive := c.Query("is_active"); isActive != "" {
+		active := isActive == "true"
+		filter.IsActive = &active
+	}
+
+	stream, err := h.service.Export(filter, c.DefaultQuery("format", export.FormatCSV))
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", stream.Name))
+	c.Header("Content-Type", stream.ContentType)
+	c.Status(http.StatusOK)
+
+	// The status is already sent, so a failure can only cut the download short
+	if err := stream.Write(c.Writer); err != nil {
+		logger.Error("Failed to export users", zap.Error(err))
+	}
+}
+
 // GetUser gets a single user
 func (h *UserHandler) GetUser(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -81,24 +118,10 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
-	user, err := h.service.GetUser(id)
+	// Scoped to the caller's institution; other tenants' users are reported as not found
+	user, err := h.service.GetUser(id, middleware.GetUserRole(c), middleware.GetInstitutionID(c))
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
-		return
-	}
-
-	// Security: Check tenant access?
-	// If FindByID doesn't filter by tenant, user from other tenant might be returned.
-	// User Service/Repo should handle this via Tenant Scope if injected.
-	// But `FindByID` in repo currently handles it? `FindByID` in `user_repository` doesn't use `institution_id` filter explicitly yet.
-	// We need to ensure data privacy.
-	// Ideally, `FindByID` should also check institution_id if the user is not Super Admin.
-	// Or we rely on `TenantMiddleware` setting the scope, and repo using it.
-	// My `FindByID` implementation in `user_repository` doesn't check scope. I should fix that.
-
-	currentInstID := middleware.GetInstitutionID(c)
-	if currentInstID != "" && user.Profile != nil && user.Profile.InstitutionID != nil && user.Profile.InstitutionID.String() != currentInstID {
-		utils.Error(c, http.StatusNotFound, utils.ErrUserNotFound) // Pretend not found
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -122,7 +145,7 @@ func (h *UserHandler) ToggleStatus(c *gin.Context) {
 	}
 
 	if err := h.service.ToggleStatus(id, req.IsActive); err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -149,7 +172,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	user, err := h.service.UpdateUser(id, &req, creatorRole, currentInstID)
 	if err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -164,24 +187,65 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	// Get current user ID to prevent self-deletion
-	currentUserID, _ := middleware.GetUserID(c)
-	if currentUserID == id {
-		utils.Error(c, http.StatusBadRequest, utils.ErrCannotDeleteSelf)
+	// The acting user is passed on to prevent self-deletion
+	currentUserID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
 		return
 	}
 
 	currentInstID := middleware.GetInstitutionID(c)
 	creatorRole := middleware.GetUserRole(c)
 
-	if err := h.service.DeleteUser(id, creatorRole, currentInstID); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+	if err := h.service.DeleteUser(id, currentUserID, creatorRole, currentInstID); err != nil {
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "User deleted successfully", nil)
 }
 
+// RestoreUser restores a soft-deleted user
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	currentInstID := middleware.GetInstitutionID(c)
+	creatorRole := middleware.GetUserRole(c)
+
+	user, err := h.service.RestoreUser(id, creatorRole, currentInstID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "User restored successfully", user)
+}
+
+// ResetCredentials generates a new temporary password for a user.
+// The password is shown in this response only.
+func (h *UserHandler) ResetCredentials(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		return
+	}
+
+	currentInstID := middleware.GetInstitutionID(c)
+	creatorRole := middleware.GetUserRole(c)
+
+	credentials, err := h.service.RegenerateCredentials(id, creatorRole, currentInstID)
+	if err != nil {
+		utils.RespondServiceError(c, err)
+		return
+	}
+
+	utils.OK(c, "Credentials reset successfully", credentials)
+}
+
 // GetProfile gets current user's profile
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
@@ -190,16 +254,17 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := h.service.GetUser(userID)
+	user, err := h.service.GetUser(userID, middleware.GetUserRole(c), middleware.GetInstitutionID(c))
 	if err != nil {
-		utils.Error(c, http.StatusNotFound, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
 	utils.OK(c, "", user)
 }
 
-// UpdateProfile updates current user's profile
+// UpdateProfile updates current user's profile.
+// The user always comes from the token, so a user can only update their own profile.
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
 	if !exists {
@@ -207,18 +272,15 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		FirstName string `json:"first_name" binding:"required"`
-		LastName  string `json:"last_name" binding:"required"`
-	}
+	var req request.UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ValidationError(c, utils.FormatValidationErrors(err))
 		return
 	}
 
-	user, err := h.service.UpdateProfile(userID, req.FirstName, req.LastName)
+	user, err := h.service.UpdateProfile(userID, &req)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -233,6 +295,24 @@ func (h *UserHandler) UpdateAvatar(c *gin.Context) {
 		return
 	}
 
+	// A multipart request uploads a new image; otherwise an external URL is set
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		data, err := readImageUpload(c, "file")
+		if err != nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+
+		user, err := h.service.UploadAvatar(userID, data)
+		if err != nil {
+			utils.RespondServiceError(c, err)
+			return
+		}
+
+		utils.OK(c, "Avatar updated successfully", user)
+		return
+	}
+
 	var req struct {
 		AvatarURL string `json:"avatar_url" binding:"required,url"`
 	}
@@ -243,7 +323,7 @@ func (h *UserHandler) UpdateAvatar(c *gin.Context) {
 
 	user, err := h.service.UpdateAvatar(userID, req.AvatarURL)
 	if err != nil {
-		utils.Error(c, http.StatusInternalServerError, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
@@ -265,7 +345,7 @@ func (h *UserHandler) UpdatePassword(c *gin.Context) {
 	}
 
 	if err := h.service.UpdatePassword(userID, req.OldPassword, req.NewPassword); err != nil {
-		utils.Error(c, http.StatusBadRequest, err)
+		utils.RespondServiceError(c, err)
 		return
 	}
 
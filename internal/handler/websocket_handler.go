@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"campus-core/internal/middleware"
+	"campus-core/internal/utils"
+	"campus-core/pkg/ws"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocketHandler upgrades authenticated requests to realtime notification connections
+type WebSocketHandler struct {
+	hub *ws.Hub
+}
+
+// NewWebSocketHandler creates a new WebSocket handler
+func NewWebSocketHandler(hub *ws.Hub) *WebSocketHandler {
+	return &WebSocketHandler{hub: hub}
+}
+
+// Connect upgrades the request and joins the caller to their institution's room.
+// The caller is already authenticated, so the origin isn't checked: connections
+// are authorized by the access token rather than by cookies. The connection is
+// closed when that token expires or is revoked, e.g. by logout-all or deactivation.
+func (h *WebSocketHandler) Connect(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		utils.Error(c, http.StatusUnauthorized, utils.ErrTokenMissing)
+		return
+	}
+
+	institutionID := middleware.GetInstitutionID(c)
+	if institutionID == "" {
+		utils.BadRequest(c, "Institution context is required")
+		return
+	}
+
+	role := middleware.GetUserRole(c)
+	claims := middleware.GetTokenClaims(c)
+	server := websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			client := ws.NewClient(conn, userID.String(), role, institutionID)
+			if claims != nil {
+				if claims.ExpiresAt != nil {
+					client.ExpiresAt = claims.ExpiresAt.Time
+				}
+				client.Revoked = func() bool { return middleware.IsTokenRevoked(claims) }
+			}
+			h.hub.Serve(client)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"campus-core/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxAuditBodySize is the largest request body stored with an audit entry
+const maxAuditBodySize = 64 << 10
+
+// redactedValue replaces sensitive values in stored request bodies
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeyParts marks JSON keys whose values must never be stored
+var sensitiveKeyParts = []string{"password", "token", "secret"}
+
+// AuditRecorder stores audit log entries
+type AuditRecorder interface {
+	Record(entry *models.AuditLog)
+}
+
+// AuditMiddleware records every write request (non-GET) with its actor and outcome.
+// JSON request bodies are stored with sensitive fields redacted; other bodies
+// (e.g. file uploads) are not stored.
+func AuditMiddleware(recorder AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		action := auditAction(c.Request.Method)
+		if action == "" {
+			c.Next()
+			return
+		}
+
+		var changes *string
+		if c.Request.Body != nil && strings.HasPrefix(c.ContentType(), "application/json") {
+			body, err := io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			if err == nil && len(body) > 0 && len(body) <= maxAuditBodySize {
+				changes = redactJSON(body)
+			}
+		}
+
+		c.Next()
+
+		entry := &models.AuditLog{
+			Action:       action,
+			Method:       c.Request.Method,
+			Path:         c.Request.URL.Path,
+			StatusCode:   c.Writer.Status(),
+			ResourceType: auditResourceType(c.FullPath()),
+			ResourceID:   auditResourceID(c),
+			Changes:      changes,
+			IPAddress:    c.ClientIP(),
+		}
+		if userID, ok := GetUserID(c); ok {
+			entry.ActorUserID = &userID
+		}
+		if instID, err := uuid.Parse(GetInstitutionID(c)); err == nil {
+			entry.InstitutionID = &instID
+		}
+
+		// Store asynchronously so auditing adds no latency to the response
+		go recorder.Record(entry)
+	}
+}
+
+// auditAction maps an HTTP method to an audit action, or "" for reads
+func auditAction(method string) string {
+	switch method {
+	case http.MethodPost:
+		return models.AuditActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return models.AuditActionUpdate
+	case http.MethodDelete:
+		return models.AuditActionDelete
+	default:
+		return ""
+	}
+}
+
+// auditResourceType returns the first path segment after the API version,
+// e.g. "students" for /api/v1/students/:id
+func auditResourceType(route string) string {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	for i, segment := range segments {
+		if segment == "api" || (i == 1 && strings.HasPrefix(segment, "v")) {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			return ""
+		}
+		return segment
+	}
+	return ""
+}
+
+// auditResourceID returns the resource ID from the path parameters, preferring :id
+func auditResourceID(c *gin.Context) *uuid.UUID {
+	if id, err := uuid.Parse(c.Param("id")); err == nil {
+		return &id
+	}
+	for _, param := range c.Params {
+		if id, err := uuid.Parse(param.Value); err == nil {
+			return &id
+		}
+	}
+	return nil
+}
+
+// redactJSON returns the JSON body with sensitive fields replaced, or nil if it is not valid JSON
+func redactJSON(body []byte) *string {
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil
+	}
+	redacted, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return nil
+	}
+	s := string(redacted)
+	return &s
+}
+
+// redactValue walks decoded JSON and redacts values of sensitive keys
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isSensitiveKey reports whether a JSON key holds a secret
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
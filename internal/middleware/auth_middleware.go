@@ -36,12 +36,21 @@ func AuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if IsTokenRevoked(claims) {
+			utils.Error(c, 401, utils.ErrTokenRevoked)
+			c.Abort()
+			return
+		}
 
 		// Set user context
+		// Permissions are resolved per request (cached) rather than taken from the
+		// token, so changes to a role apply without waiting for tokens to expire
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_role", claims.Role)
-		c.Set("user_permissions", claims.Permissions)
+		c.Set("user_permissions", GetPermissionsForUser(claims.Role, claims.CustomRoleID))
+		c.Set(mustChangePasswordKey, claims.MustChangePassword)
+		c.Set(tokenClaimsKey, claims)
 
 		if claims.InstitutionID != "" {
 			c.Set("institution_id", claims.InstitutionID)
@@ -67,11 +76,11 @@ func OptionalAuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 		}
 
 		claims, err := jwtManager.ValidateAccessToken(parts[1])
-		if err == nil {
+		if err == nil && !IsTokenRevoked(claims) {
 			c.Set("user_id", claims.UserID)
 			c.Set("user_email", claims.Email)
 			c.Set("user_role", claims.Role)
-			c.Set("user_permissions", claims.Permissions)
+			c.Set("user_permissions", GetPermissionsForUser(claims.Role, claims.CustomRoleID))
 			if claims.InstitutionID != "" {
 				c.Set("institution_id", claims.InstitutionID)
 			}
@@ -81,6 +90,18 @@ func OptionalAuthMiddleware(jwtManager *utils.JWTManager) gin.HandlerFunc {
 	}
 }
 
+// tokenClaimsKey is the context key of the validated access token's claims
+const tokenClaimsKey = "token_claims"
+
+// GetTokenClaims returns the claims of the request's access token, or nil when AuthMiddleware didn't run
+func GetTokenClaims(c *gin.Context) *utils.Claims {
+	claims, _ := c.Get(tokenClaimsKey)
+	if cl, ok := claims.(*utils.Claims); ok {
+		return cl
+	}
+	return nil
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 	userID, exists := c.Get("user_id")
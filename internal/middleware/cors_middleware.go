@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,8 +22,8 @@ func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Institution-ID", "X-Request-ID"},
-		ExposeHeaders:    []string{"Content-Length", "X-Request-ID"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Institution-ID", "X-Request-ID", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "X-Request-ID", "X-Total-Count", "X-Page", "X-Per-Page", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
 	}
@@ -33,36 +34,56 @@ func CORS() gin.HandlerFunc {
 	return CORSWithConfig(DefaultCORSConfig())
 }
 
-// CORSWithConfig returns a CORS middleware handler with custom config
+// CORSWithConfig returns a CORS middleware handler with custom config.
+// Requests from origins that aren't allowed get no CORS headers, and their
+// preflight requests are rejected. When credentials are allowed the matched
+// origin is reflected, since browsers refuse "*" for credentialed requests.
 func CORSWithConfig(config CORSConfig) gin.HandlerFunc {
+	wildcard := false
+	allowed := make(map[string]bool, len(config.AllowOrigins))
+	for _, o := range config.AllowOrigins {
+		if o == "*" {
+			wildcard = true
+		}
+		allowed[strings.TrimRight(o, "/")] = true
+	}
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != ""
 
-		// Check if origin is allowed
-		allowOrigin := ""
-		for _, o := range config.AllowOrigins {
-			if o == "*" || o == origin {
-				allowOrigin = origin
-				if o == "*" {
-					allowOrigin = "*"
-				}
-				break
-			}
+		// Not a cross-origin request
+		if origin == "" {
+			c.Next()
+			return
 		}
 
-		if allowOrigin != "" {
-			c.Header("Access-Control-Allow-Origin", allowOrigin)
-			c.Header("Access-Control-Allow-Methods", joinStrings(config.AllowMethods))
-			c.Header("Access-Control-Allow-Headers", joinStrings(config.AllowHeaders))
-			c.Header("Access-Control-Expose-Headers", joinStrings(config.ExposeHeaders))
+		// The allowed origin depends on the request, so caches must key on it
+		c.Writer.Header().Add("Vary", "Origin")
 
-			if config.AllowCredentials && allowOrigin != "*" {
-				c.Header("Access-Control-Allow-Credentials", "true")
+		if !wildcard && !allowed[origin] {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
 			}
+			c.Next()
+			return
+		}
+
+		allowOrigin := origin
+		if wildcard && !config.AllowCredentials {
+			allowOrigin = "*"
+		}
+		c.Header("Access-Control-Allow-Origin", allowOrigin)
+		c.Header("Access-Control-Expose-Headers", joinStrings(config.ExposeHeaders))
+		if config.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
 		}
 
 		// Handle preflight requests
-		if c.Request.Method == http.MethodOptions {
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", joinStrings(config.AllowMethods))
+			c.Header("Access-Control-Allow-Headers", joinStrings(config.AllowHeaders))
 			c.Header("Access-Control-Max-Age", intToString(config.MaxAge))
 			c.AbortWithStatus(http.StatusNoContent)
 			return
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSWithConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	allowlist := DefaultCORSConfig()
+	allowlist.AllowOrigins = []string{"https://app.example.com", "https://admin.example.com/"}
+
+	wildcard := DefaultCORSConfig()
+	wildcard.AllowCredentials = false
+
+	tests := []struct {
+		name            string
+		config          CORSConfig
+		method          string
+		origin          string
+		preflight       bool
+		wantStatus      int
+		wantAllowOrigin string
+		wantCredentials string
+		wantMethods     bool
+	}{
+		{
+			name: "allowed origin", config: allowlist, method: http.MethodGet, origin: "https://app.example.com",
+			wantStatus: http.StatusOK, wantAllowOrigin: "https://app.example.com", wantCredentials: "true",
+		},
+		{
+			name: "allowed origin configured with a trailing slash", config: allowlist, method: http.MethodGet, origin: "https://admin.example.com",
+			wantStatus: http.StatusOK, wantAllowOrigin: "https://admin.example.com", wantCredentials: "true",
+		},
+		{
+			name: "disallowed origin gets no CORS headers", config: allowlist, method: http.MethodGet, origin: "https://evil.example.com",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "same-origin request", config: allowlist, method: http.MethodGet,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "allowed preflight", config: allowlist, method: http.MethodOptions, origin: "https://app.example.com", preflight: true,
+			wantStatus: http.StatusNoContent, wantAllowOrigin: "https://app.example.com", wantCredentials: "true", wantMethods: true,
+		},
+		{
+			name: "disallowed preflight", config: allowlist, method: http.MethodOptions, origin: "https://evil.example.com", preflight: true,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "wildcard with credentials reflects the origin", config: DefaultCORSConfig(), method: http.MethodGet, origin: "https://any.example.com",
+			wantStatus: http.StatusOK, wantAllowOrigin: "https://any.example.com", wantCredentials: "true",
+		},
+		{
+			name: "wildcard without credentials", config: wildcard, method: http.MethodGet, origin: "https://any.example.com",
+			wantStatus: http.StatusOK, wantAllowOrigin: "*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(CORSWithConfig(tt.config))
+			engine.Handle(tt.method, "/resource", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/resource", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantAllowOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Methods") != ""; got != tt.wantMethods {
+				t.Errorf("Access-Control-Allow-Methods set = %v, want %v", got, tt.wantMethods)
+			}
+			if tt.origin != "" && w.Header().Get("Vary") != "Origin" {
+				t.Errorf("Vary = %q, want Origin", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"campus-core/internal/utils"
+	"campus-core/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader carries the client-chosen key that identifies a retried request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyReplayedHeader marks a response replayed from an earlier request
+const idempotencyReplayedHeader = "Idempotent-Replayed"
+
+// idempotencyTTL is how long a response can be replayed for its key
+const idempotencyTTL = 24 * time.Hour
+
+// idempotencyClaimTTL bounds how long a key stays claimed by a request that is
+// still running, so a crashed process does not block retries for a whole day
+const idempotencyClaimTTL = 5 * time.Minute
+
+// maxIdempotencyKeyLength rejects keys that are clearly not request identifiers
+const maxIdempotencyKeyLength = 255
+
+// idempotencyRecord is the stored outcome of the first request with a key.
+// Status is zero while that request is still being processed. Headers holds the
+// response headers set by the handler, such as Content-Type and Location.
+type idempotencyRecord struct {
+	BodyHash string      `json:"body_hash"`
+	Status   int         `json:"status"`
+	Headers  http.Header `json:"headers,omitempty"`
+	Body     []byte      `json:"body,omitempty"`
+}
+
+// idempotencyWriter copies the response body while it is written to the client
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to retry.
+// The first response for a key, user and path is stored and replayed for later
+// requests with the same key instead of running the handler again. Reusing a key
+// with a different body is rejected. Server errors and panics are not stored so
+// the request can be retried. Without Redis requests run normally.
+func Idempotency(store *cache.Cache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || !store.Enabled() {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utils.BadRequest(c, "Idempotency-Key is too long")
+			c.Abort()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				utils.BadRequest(c, "Failed to read request body")
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		bodySum := sha256.Sum256(body)
+		bodyHash := hex.EncodeToString(bodySum[:])
+
+		userID := ""
+		if id, ok := GetUserID(c); ok {
+			userID = id.String()
+		}
+		keySum := sha256.Sum256([]byte(key + "\x00" + userID + "\x00" + c.Request.URL.Path))
+		storeKey := hex.EncodeToString(keySum[:])
+
+		// Claim the key first so concurrent retries don't both run the handler
+		stored, ok := store.SetIfAbsent(storeKey, idempotencyRecord{BodyHash: bodyHash}, idempotencyClaimTTL)
+		if !ok {
+			c.Next()
+			return
+		}
+		if !stored {
+			var previous idempotencyRecord
+			if !store.Get(storeKey, &previous) {
+				// Expired or unreadable in the meantime; run the request normally
+				c.Next()
+				return
+			}
+			switch {
+			case previous.BodyHash != bodyHash:
+				utils.Error(c, http.StatusConflict, utils.ErrIdempotencyKeyReused)
+			case previous.Status == 0:
+				utils.Error(c, http.StatusConflict, utils.ErrIdempotencyInProgress)
+			default:
+				for name, values := range previous.Headers {
+					c.Writer.Header()[name] = values
+				}
+				c.Header(idempotencyReplayedHeader, "true")
+				c.Data(previous.Status, previous.Headers.Get("Content-Type"), previous.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		// Release the claim if the handler panics, so the request can be retried
+		completed := false
+		defer func() {
+			if !completed {
+				store.Delete(storeKey)
+			}
+		}()
+
+		headersBefore := c.Writer.Header().Clone()
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+		completed = true
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			store.Delete(storeKey)
+			return
+		}
+		store.Set(storeKey, idempotencyRecord{
+			BodyHash: bodyHash,
+			Status:   status,
+			Headers:  handlerHeaders(headersBefore, writer.Header()),
+			Body:     writer.body.Bytes(),
+		}, idempotencyTTL)
+	}
+}
+
+// handlerHeaders returns the response headers that were added or changed after
+// before was taken. Headers set by earlier middleware, such as CORS and rate limit
+// headers, are left out because they are set again on the replayed request.
+func handlerHeaders(before, after http.Header) http.Header {
+	headers := http.Header{}
+	for name, values := range after {
+		if !slices.Equal(before[name], values) {
+			headers[name] = values
+		}
+	}
+	return headers
+}
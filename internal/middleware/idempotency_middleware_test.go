@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"campus-core/internal/database"
+	"campus-core/internal/testutil"
+	"campus-core/pkg/cache"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// newIdempotentRouter serves handler on POST / behind the idempotency middleware
+func newIdempotentRouter(t *testing.T, handler gin.HandlerFunc) (*gin.Engine, *miniredis.Miniredis) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := testutil.Redis(t)
+	router := gin.New()
+	router.Use(Recovery())
+	router.Use(func(c *gin.Context) {
+		c.Header("X-RateLimit-Remaining", "9")
+	})
+	router.POST("/", Idempotency(cache.New(database.RedisClient, "idempotency")), handler)
+	return router, server
+}
+
+// postIdempotent sends a POST with the given Idempotency-Key and body
+func postIdempotent(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set(IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	calls := 0
+	router, _ := newIdempotentRouter(t, func(c *gin.Context) {
+		calls++
+		c.Header("Location", "/items/1")
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	first := postIdempotent(router, "key-1", `{"name":"a"}`)
+	second := postIdempotent(router, "key-1", `{"name":"a"}`)
+
+	if calls != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("replay = %d %s, want %d %s", second.Code, second.Body, first.Code, first.Body)
+	}
+	if got := second.Header().Get(idempotencyReplayedHeader); got != "true" {
+		t.Errorf("replay %s = %q, want %q", idempotencyReplayedHeader, got, "true")
+	}
+	for _, name := range []string{"Location", "Content-Type"} {
+		if got, want := second.Header().Get(name), first.Header().Get(name); got != want {
+			t.Errorf("replay %s = %q, want %q", name, got, want)
+		}
+	}
+	if got := second.Header().Values("X-RateLimit-Remaining"); len(got) != 1 {
+		t.Errorf("replay X-RateLimit-Remaining = %v, want a single value", got)
+	}
+
+	if w := postIdempotent(router, "key-1", `{"name":"b"}`); w.Code != http.StatusConflict {
+		t.Errorf("reused key with a different body: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestIdempotencyDoesNotStoreFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		failure gin.HandlerFunc
+	}{
+		{"server error", func(c *gin.Context) { c.Status(http.StatusServiceUnavailable) }},
+		{"panic", func(c *gin.Context) { panic("handler failed") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			router, server := newIdempotentRouter(t, func(c *gin.Context) {
+				calls++
+				if calls == 1 {
+					tt.failure(c)
+					return
+				}
+				c.Status(http.StatusCreated)
+			})
+
+			if w := postIdempotent(router, "key-1", "{}"); w.Code < http.StatusInternalServerError {
+				t.Fatalf("first request status = %d, want a server error", w.Code)
+			}
+			if keys := server.Keys(); len(keys) != 0 {
+				t.Errorf("keys after failure = %v, want none", keys)
+			}
+			if w := postIdempotent(router, "key-1", "{}"); w.Code != http.StatusCreated {
+				t.Errorf("retry status = %d, want %d", w.Code, http.StatusCreated)
+			}
+			if calls != 2 {
+				t.Errorf("handler ran %d times, want 2", calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyClaimExpiresSooner(t *testing.T) {
+	var server *miniredis.Miniredis
+	router, server := newIdempotentRouter(t, func(c *gin.Context) {
+		keys := server.Keys()
+		if len(keys) != 1 {
+			t.Fatalf("keys while running = %v, want one claim", keys)
+		}
+		if ttl := server.TTL(keys[0]); ttl <= 0 || ttl > idempotencyClaimTTL {
+			t.Errorf("claim TTL = %v, want at most %v", ttl, idempotencyClaimTTL)
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	postIdempotent(router, "key-1", "{}")
+
+	keys := server.Keys()
+	if len(keys) != 1 {
+		t.Fatalf("keys after response = %v, want one record", keys)
+	}
+	if ttl := server.TTL(keys[0]); ttl != idempotencyTTL {
+		t.Errorf("record TTL = %v, want %v", ttl, idempotencyTTL)
+	}
+}
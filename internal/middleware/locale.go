@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"campus-core/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// InstitutionLocale returns a resolver for the locale configured in the settings
+// of the request's institution, or "" when there is none
+func InstitutionLocale(repo *repository.InstitutionRepository) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		id, err := uuid.Parse(GetInstitutionID(c))
+		if err != nil {
+			return ""
+		}
+		institution, err := repo.FindByID(id)
+		if err != nil || institution.Settings == nil {
+			return ""
+		}
+		return institution.Settings.Locale
+	}
+}
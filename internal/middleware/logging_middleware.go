@@ -6,36 +6,29 @@ import (
 	"campus-core/pkg/logger"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// RequestLogger returns a middleware that logs HTTP requests
+// RequestLogger returns a middleware that logs HTTP requests.
+// It runs after RequestID, whose request-scoped logger adds the correlation ID.
 func RequestLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Generate request ID
-		requestID := c.GetHeader("X-Request-ID")
-		if requestID == "" {
-			requestID = uuid.New().String()
-		}
-		c.Set("request_id", requestID)
-		c.Header("X-Request-ID", requestID)
-
 		// Start timer
 		start := time.Now()
 		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
 
 		// Process request
 		c.Next()
 
+		// Read after the handlers so credentials they strip from the URL (e.g. WebSocket tokens) aren't logged
+		query := c.Request.URL.RawQuery
+
 		// Calculate latency
 		latency := time.Since(start)
 		statusCode := c.Writer.Status()
 
 		// Build log fields
 		fields := []zap.Field{
-			zap.String("request_id", requestID),
 			zap.Int("status", statusCode),
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
@@ -57,15 +50,16 @@ func RequestLogger() gin.HandlerFunc {
 		}
 
 		// Log based on status code
+		log := logger.FromContext(c)
 		switch {
 		case statusCode >= 500:
-			logger.Error("Server error", fields...)
+			log.Error("Server error", fields...)
 		case statusCode >= 400:
-			logger.Warn("Client error", fields...)
+			log.Warn("Client error", fields...)
 		case statusCode >= 300:
-			logger.Info("Redirect", fields...)
+			log.Info("Redirect", fields...)
 		default:
-			logger.Info("Request completed", fields...)
+			log.Info("Request completed", fields...)
 		}
 	}
 }
@@ -73,14 +67,10 @@ func RequestLogger() gin.HandlerFunc {
 // DebugLogger returns a more verbose logger for development
 func DebugLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := uuid.New().String()
-		c.Set("request_id", requestID)
-		c.Header("X-Request-ID", requestID)
-
 		start := time.Now()
+		log := logger.FromContext(c)
 
-		logger.Debug("Request started",
-			zap.String("request_id", requestID),
+		log.Debug("Request started",
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("ip", c.ClientIP()),
@@ -88,8 +78,7 @@ func DebugLogger() gin.HandlerFunc {
 
 		c.Next()
 
-		logger.Debug("Request completed",
-			zap.String("request_id", requestID),
+		log.Debug("Request completed",
 			zap.Int("status", c.Writer.Status()),
 			zap.Duration("latency", time.Since(start)),
 		)
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"campus-core/internal/database"
+	"campus-core/internal/utils"
+	"campus-core/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LoginLockoutConfig holds the limits on failed logins from one IP address
+type LoginLockoutConfig struct {
+	Threshold int           // Failed logins tolerated before the IP is delayed; 0 disables the lockout
+	Window    time.Duration // How long after the last failure the failures are remembered
+	Delay     time.Duration // Delay after crossing the threshold, doubled by every further failure
+	MaxDelay  time.Duration // Upper bound on the delay
+}
+
+// LoginLockout slows down repeated failed logins from the same IP address,
+// whichever accounts they target, to frustrate credential stuffing. Once the
+// threshold is crossed, logins from the IP are refused with a Retry-After header
+// for a delay that doubles with every further failure. Without Redis logins are
+// not limited.
+//
+// Every attempt is counted before the handler runs, so concurrent attempts can't
+// all slip in under the threshold, and the count is rolled back unless the
+// credentials were rejected. Failures therefore only expire with the window; a
+// successful login does not clear them.
+//
+// It is kept apart from AuthRateLimit, which counts every request to the auth
+// endpoints in a fixed window whatever the outcome. The lockout counts only
+// rejected credentials, escalates its delay and has its own configuration.
+func LoginLockout(config LoginLockoutConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if config.Threshold <= 0 || database.RedisClient == nil {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		failuresKey := "login:failures:" + c.ClientIP()
+		lockKey := "login:lock:" + c.ClientIP()
+
+		ttl, err := database.RedisClient.TTL(ctx, lockKey).Result()
+		if err != nil {
+			logger.Error("Login lockout check failed", zap.Error(err))
+		} else if ttl > 0 {
+			refuseLogin(c, ttl)
+			return
+		}
+
+		// Claim the attempt before running it
+		attempts, err := database.RedisClient.Incr(ctx, failuresKey).Result()
+		if err != nil {
+			logger.Error("Login failure count failed", zap.Error(err))
+			c.Next()
+			return
+		}
+		database.RedisClient.Expire(ctx, failuresKey, config.Window)
+
+		// Past the threshold only one attempt may run per delay: the one that sets the lock
+		locked := false
+		if attempts > int64(config.Threshold) {
+			delay := loginLockoutDelay(config, attempts-int64(config.Threshold))
+			locked, err = database.RedisClient.SetNX(ctx, lockKey, attempts, delay).Result()
+			if err != nil {
+				logger.Error("Login lockout failed", zap.Error(err))
+			} else if !locked {
+				releaseLoginAttempt(ctx, failuresKey)
+				ttl, _ := database.RedisClient.TTL(ctx, lockKey).Result()
+				refuseLogin(c, ttl)
+				return
+			}
+		}
+
+		c.Next()
+
+		if c.Writer.Status() != http.StatusUnauthorized {
+			releaseLoginAttempt(ctx, failuresKey)
+			if locked {
+				database.RedisClient.Del(ctx, lockKey)
+			}
+			return
+		}
+		// Reaching the threshold locks the IP, unless a later attempt already did
+		if attempts == int64(config.Threshold) {
+			if err := database.RedisClient.SetNX(ctx, lockKey, attempts, loginLockoutDelay(config, 0)).Err(); err != nil {
+				logger.Error("Login lockout failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// releaseLoginAttempt rolls back an attempt that did not fail on bad credentials
+func releaseLoginAttempt(ctx context.Context, failuresKey string) {
+	if err := database.RedisClient.Decr(ctx, failuresKey).Err(); err != nil {
+		logger.Error("Login failure count rollback failed", zap.Error(err))
+	}
+}
+
+// refuseLogin rejects a login from a locked out IP address
+func refuseLogin(c *gin.Context, retryAfter time.Duration) {
+	c.Header("Retry-After", fmt.Sprintf("%d", int(math.Ceil(retryAfter.Seconds()))))
+	utils.Error(c, http.StatusTooManyRequests, utils.ErrTooManyLoginAttempts)
+	c.Abort()
+}
+
+// loginLockoutDelay returns the delay after the given number of failures past the threshold
+func loginLockoutDelay(config LoginLockoutConfig, extra int64) time.Duration {
+	delay := config.Delay
+	for i := int64(0); i < extra && delay < config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if config.MaxDelay > 0 && delay > config.MaxDelay {
+		delay = config.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"campus-core/internal/testutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+var testLockoutConfig = LoginLockoutConfig{
+	Threshold: 3,
+	Window:    15 * time.Minute,
+	Delay:     30 * time.Second,
+	MaxDelay:  5 * time.Minute,
+}
+
+// newLockoutRouter serves POST /login behind LoginLockout; the handler responds
+// with the status returned by status
+func newLockoutRouter(status func() int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/login", LoginLockout(testLockoutConfig), func(c *gin.Context) {
+		c.Status(status())
+	})
+	return router
+}
+
+func login(router *gin.Engine) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+	return w
+}
+
+func TestLoginLockoutEscalates(t *testing.T) {
+	server := testutil.Redis(t)
+	router := newLockoutRouter(func() int { return http.StatusUnauthorized })
+
+	for i := 1; i <= testLockoutConfig.Threshold; i++ {
+		if w := login(router); w.Code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d status = %d, want %d", i, w.Code, http.StatusUnauthorized)
+		}
+	}
+
+	w := login(router)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("attempt past the threshold status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want %q", got, "30")
+	}
+
+	server.FastForward(testLockoutConfig.Delay)
+	if w := login(router); w.Code != http.StatusUnauthorized {
+		t.Fatalf("attempt after the delay status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if ttl := server.TTL("login:lock:192.0.2.1"); ttl != 2*testLockoutConfig.Delay {
+		t.Errorf("lock after another failure = %v, want %v", ttl, 2*testLockoutConfig.Delay)
+	}
+}
+
+func TestLoginLockoutRollsBackOtherOutcomes(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"success", http.StatusOK},
+		{"invalid request", http.StatusBadRequest},
+		{"server error", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.Redis(t)
+			status := http.StatusUnauthorized
+			router := newLockoutRouter(func() int { return status })
+
+			login(router)
+			login(router)
+			status = tt.status
+			for i := 0; i < 2*testLockoutConfig.Threshold; i++ {
+				if w := login(router); w.Code != tt.status {
+					t.Fatalf("status = %d, want %d", w.Code, tt.status)
+				}
+			}
+
+			if got, _ := server.Get("login:failures:192.0.2.1"); got != "2" {
+				t.Errorf("failures = %q, want %q", got, "2")
+			}
+		})
+	}
+}
+
+func TestLoginLockoutConcurrentAttempts(t *testing.T) {
+	testutil.Redis(t)
+
+	var running atomic.Int32
+	release := make(chan struct{})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/login", LoginLockout(testLockoutConfig), func(c *gin.Context) {
+		running.Add(1)
+		<-release
+		c.Status(http.StatusUnauthorized)
+	})
+
+	const attempts = 10
+	// Past the threshold, one attempt runs and locks out the rest
+	allowed := testLockoutConfig.Threshold + 1
+
+	results := make(chan int, attempts)
+	for i := 0; i < attempts; i++ {
+		go func() { results <- login(router).Code }()
+	}
+
+	for i := 0; i < attempts-allowed; i++ {
+		select {
+		case code := <-results:
+			if code != http.StatusTooManyRequests {
+				t.Errorf("refused attempt status = %d, want %d", code, http.StatusTooManyRequests)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("only %d of %d attempts were refused", i, attempts-allowed)
+		}
+	}
+	close(release)
+	for i := 0; i < allowed; i++ {
+		if code := <-results; code != http.StatusUnauthorized {
+			t.Errorf("attempt status = %d, want %d", code, http.StatusUnauthorized)
+		}
+	}
+
+	if got := running.Load(); got != int32(allowed) {
+		t.Errorf("handler ran %d times, want %d", got, allowed)
+	}
+}
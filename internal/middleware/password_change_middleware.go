@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+
+	"campus-core/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// mustChangePasswordKey is the context key of the token's forced password change flag
+const mustChangePasswordKey = "must_change_password"
+
+// PasswordChangeChecker reports whether a user still has to change their password
+type PasswordChangeChecker interface {
+	MustChangePassword(userID uuid.UUID) (bool, error)
+}
+
+// RequirePasswordChanged refuses requests from users who must change a password
+// set by someone else, except on the given routes (e.g. the change password route).
+// Only tokens issued while the flag was set are checked against the database, so
+// a user who has since changed the password is let through with their old token.
+func RequirePasswordChanged(checker PasswordChangeChecker, allowedRoutes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool(mustChangePasswordKey) {
+			c.Next()
+			return
+		}
+		for _, route := range allowedRoutes {
+			if c.FullPath() == route {
+				c.Next()
+				return
+			}
+		}
+
+		userID, ok := GetUserID(c)
+		if !ok {
+			utils.Error(c, http.StatusUnauthorized, utils.ErrTokenInvalid)
+			c.Abort()
+			return
+		}
+		must, err := checker.MustChangePassword(userID)
+		if err != nil {
+			utils.Error(c, http.StatusInternalServerError, utils.ErrInternalServer.Wrap(err))
+			c.Abort()
+			return
+		}
+		if must {
+			utils.Error(c, http.StatusForbidden, utils.ErrMustChangePassword)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
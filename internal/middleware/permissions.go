@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+
+	"campus-core/internal/database"
+	"campus-core/internal/models"
+	"campus-core/pkg/cache"
+	"campus-core/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// permissionCacheTTL is how long role permissions loaded from the database are reused
+const permissionCacheTTL = 5 * time.Minute
+
+type cachedPermissions struct {
+	Permissions []string  `json:"permissions"`
+	Found       bool      `json:"found"` // Whether the role exists in the database
+	expiresAt   time.Time // Only used by the in-memory cache
+}
+
+var (
+	permissionCache   = make(map[string]cachedPermissions)
+	permissionCacheMu sync.RWMutex
+)
+
+// GetPermissionsForRole returns the permissions for a built-in role.
+// They are read from the roles table (cached), falling back to the defaults
+// when the database is unavailable or the role has not been seeded.
+func GetPermissionsForRole(role string) []string {
+	// Super Admin always has every permission
+	if role == models.RoleSuperAdmin {
+		return []string{models.PermissionAll}
+	}
+
+	if perms, found, ok := loadRolePermissions("role:"+role, "code = ? AND institution_id IS NULL", role); ok && found {
+		return perms
+	}
+	if perms, ok := models.DefaultRolePermissions[role]; ok {
+		return perms
+	}
+	return []string{}
+}
+
+// GetPermissionsForUser returns the permissions of a user's built-in role
+// merged with those of their custom role, if any
+func GetPermissionsForUser(role string, customRoleID *uuid.UUID) []string {
+	perms := GetPermissionsForRole(role)
+	if customRoleID == nil || contains(perms, models.PermissionAll) {
+		return perms
+	}
+
+	custom, found, ok := loadRolePermissions("custom:"+customRoleID.String(), "id = ?", *customRoleID)
+	if !ok || !found {
+		return perms
+	}
+
+	merged := append([]string{}, perms...)
+	for _, p := range custom {
+		// Custom roles can never grant every permission
+		if p != models.PermissionAll && !contains(merged, p) {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+// InvalidatePermissionCache drops cached role permissions after roles change
+func InvalidatePermissionCache() {
+	permissionCacheMu.Lock()
+	permissionCache = make(map[string]cachedPermissions)
+	permissionCacheMu.Unlock()
+
+	permissionStore().Clear()
+}
+
+// permissionStore returns the Redis cache for role permissions. Sharing it lets
+// every server instance see invalidations; without Redis the in-memory cache is used.
+func permissionStore() *cache.Cache {
+	return cache.New(database.RedisClient, "cache:permissions")
+}
+
+// loadRolePermissions loads a role's permission codes through the cache.
+// ok is false when the database could not be queried.
+func loadRolePermissions(key string, query string, args ...interface{}) (perms []string, found bool, ok bool) {
+	store := permissionStore()
+	if store.Enabled() {
+		var cached cachedPermissions
+		if store.Get(key, &cached) {
+			return cached.Permissions, cached.Found, true
+		}
+	} else {
+		permissionCacheMu.RLock()
+		cached, hit := permissionCache[key]
+		permissionCacheMu.RUnlock()
+		if hit && time.Now().Before(cached.expiresAt) {
+			return cached.Permissions, cached.Found, true
+		}
+	}
+
+	if database.DB == nil {
+		return nil, false, false
+	}
+
+	var roles []models.Role
+	if err := database.DB.Preload("Permissions").Where(query, args...).Limit(1).Find(&roles).Error; err != nil {
+		logger.Error("Failed to load role permissions", zap.String("key", key), zap.Error(err))
+		return nil, false, false
+	}
+
+	cached := cachedPermissions{expiresAt: time.Now().Add(permissionCacheTTL)}
+	if len(roles) > 0 {
+		cached.Permissions = roles[0].PermissionCodes()
+		cached.Found = true
+	}
+
+	if store.Enabled() {
+		store.Set(key, cached, permissionCacheTTL)
+	} else {
+		permissionCacheMu.Lock()
+		permissionCache[key] = cached
+		permissionCacheMu.Unlock()
+	}
+
+	return cached.Permissions, cached.Found, true
+}
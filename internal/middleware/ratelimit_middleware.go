@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"campus-core/internal/database"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
 	"campus-core/internal/utils"
 	"campus-core/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +23,11 @@ type RateLimitConfig struct {
 	Requests int                       // Maximum number of requests
 	Duration time.Duration             // Time window
 	KeyFunc  func(*gin.Context) string // Function to generate the rate limit key
+
+	// LimitFunc optionally overrides Requests per request; a result of 0 keeps Requests
+	LimitFunc func(*gin.Context) int
+	// Skip optionally exempts requests from this limiter
+	Skip func(*gin.Context) bool
 }
 
 // DefaultRateLimitConfig returns default rate limit config
@@ -43,9 +52,55 @@ func UserKeyFunc(c *gin.Context) string {
 	return "ratelimit:" + c.ClientIP()
 }
 
+// InstitutionKeyFunc uses the request's institution as the rate limit key, so all
+// users of a school share one quota regardless of the addresses they connect from.
+// Super admins and requests without an institution fall back to the user key.
+func InstitutionKeyFunc(c *gin.Context) string {
+	if institutionID := GetInstitutionID(c); institutionID != "" && GetUserRole(c) != models.RoleSuperAdmin {
+		return "ratelimit:institution:" + institutionID
+	}
+	return UserKeyFunc(c)
+}
+
+// InstitutionRequestLimit returns a LimitFunc reading the rate limit override from
+// the settings of the request's institution, or 0 when there is none
+func InstitutionRequestLimit(repo *repository.InstitutionRepository) func(*gin.Context) int {
+	return func(c *gin.Context) int {
+		if GetUserRole(c) == models.RoleSuperAdmin {
+			return 0
+		}
+		id, err := uuid.Parse(GetInstitutionID(c))
+		if err != nil {
+			return 0
+		}
+		institution, err := repo.FindByID(id)
+		if err != nil || institution.Settings == nil || institution.Settings.RateLimitRequests == nil {
+			return 0
+		}
+		return *institution.Settings.RateLimitRequests
+	}
+}
+
+// SkipAuthenticated returns a Skip function exempting requests that carry a valid
+// access token; those are limited per institution once authenticated instead
+func SkipAuthenticated(jwtManager *utils.JWTManager) func(*gin.Context) bool {
+	return func(c *gin.Context) bool {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			return false
+		}
+		_, err := jwtManager.ValidateAccessToken(parts[1])
+		return err == nil
+	}
+}
+
 // RateLimit returns a rate limiting middleware
 func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if config.Skip != nil && config.Skip(c) {
+			c.Next()
+			return
+		}
 		if database.RedisClient == nil {
 			// Skip rate limiting if Redis is not available
 			logger.Warn("Rate limiting skipped: Redis not connected")
@@ -55,6 +110,12 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 
 		ctx := context.Background()
 		key := config.KeyFunc(c)
+		limit := config.Requests
+		if config.LimitFunc != nil {
+			if override := config.LimitFunc(c); override > 0 {
+				limit = override
+			}
+		}
 
 		// Get current count
 		count, err := database.RedisClient.Get(ctx, key).Int64()
@@ -65,11 +126,11 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 		}
 
 		// Check if limit exceeded
-		if count >= int64(config.Requests) {
+		if count >= int64(limit) {
 			// Get TTL for Retry-After header
 			ttl, _ := database.RedisClient.TTL(ctx, key).Result()
 
-			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", config.Requests))
+			c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", limit))
 			c.Header("X-RateLimit-Remaining", "0")
 			c.Header("Retry-After", fmt.Sprintf("%d", int(ttl.Seconds())))
 
@@ -96,12 +157,12 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 		}
 
 		// Set rate limit headers
-		remaining := config.Requests - int(count) - 1
+		remaining := limit - int(count) - 1
 		if remaining < 0 {
 			remaining = 0
 		}
 
-		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", config.Requests))
+		c.Header("X-RateLimit-Limit", fmt.Sprintf("%d", limit))
 		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", remaining))
 
 		c.Next()
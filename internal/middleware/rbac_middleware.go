@@ -113,68 +113,3 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
-
-// RolePermissions maps roles to their permissions
-var RolePermissions = map[string][]string{
-	models.RoleSuperAdmin: {"*"},
-	models.RoleAdmin: {
-		"USER_CREATE", "USER_UPDATE", "USER_DELETE", "USER_VIEW",
-		"STUDENT_MANAGE", "TEACHER_MANAGE", "CLASS_MANAGE",
-		"SECTION_MANAGE", "SUBJECT_MANAGE", "DEPARTMENT_MANAGE",
-		"ACADEMIC_YEAR_MANAGE", "TIMETABLE_MANAGE",
-		"FEE_STRUCTURE_MANAGE",
-		"NOTICE_PUBLISH", "ANNOUNCEMENT_CREATE",
-		"REPORT_GENERATE",
-		"LEAVE_APPROVE",
-		"LIBRARY_MANAGE",
-		"EVENT_MANAGE",
-	},
-	models.RoleTeacher: {
-		"ATTENDANCE_MARK", "ATTENDANCE_VIEW",
-		"ASSIGNMENT_CREATE", "ASSIGNMENT_GRADE",
-		"EXAM_CREATE", "RESULT_ENTER",
-		"STUDENT_PROGRESS_VIEW",
-		"PARENT_COMMUNICATE", "MESSAGE_SEND",
-		"LEAVE_APPLY",
-		"RESOURCE_UPLOAD", "MATERIAL_UPLOAD",
-		"TIMETABLE_VIEW",
-		"ONLINE_CLASS_CREATE",
-	},
-	models.RoleStudent: {
-		"PROFILE_VIEW_OWN", "PROFILE_UPDATE_OWN",
-		"ASSIGNMENT_VIEW", "ASSIGNMENT_SUBMIT",
-		"RESULT_VIEW_OWN",
-		"ATTENDANCE_VIEW_OWN",
-		"FEE_VIEW_OWN",
-		"LEAVE_APPLY",
-		"LIBRARY_BORROW", "LIBRARY_VIEW",
-		"EVENT_VIEW",
-		"MATERIAL_DOWNLOAD",
-		"MESSAGE_SEND", "NOTICE_VIEW",
-	},
-	models.RoleParent: {
-		"STUDENT_PROGRESS_VIEW",
-		"FEE_PAY", "FEE_VIEW_CHILD",
-		"TEACHER_COMMUNICATE", "MESSAGE_SEND",
-		"ATTENDANCE_VIEW_CHILD",
-		"LEAVE_APPLY_CHILD",
-		"MEETING_SCHEDULE",
-		"EVENT_VIEW", "NOTICE_VIEW",
-	},
-	models.RoleAccountant: {
-		"FEE_COLLECT", "FEE_VIEW_ALL", "FEE_STRUCTURE_VIEW",
-		"EXPENSE_MANAGE", "EXPENSE_CREATE", "EXPENSE_VIEW",
-		"SALARY_PROCESS", "SALARY_VIEW",
-		"FINANCIAL_REPORT_GENERATE",
-		"INVOICE_GENERATE",
-		"SCHOLARSHIP_MANAGE", "DISCOUNT_APPLY",
-	},
-}
-
-// GetPermissionsForRole returns the permissions for a given role
-func GetPermissionsForRole(role string) []string {
-	if perms, ok := RolePermissions[role]; ok {
-		return perms
-	}
-	return []string{}
-}
@@ -20,7 +20,7 @@ func Recovery() gin.HandlerFunc {
 				stack := string(debug.Stack())
 
 				// Log the panic
-				logger.Error("Panic recovered",
+				logger.FromContext(c).Error("Panic recovered",
 					zap.Any("error", err),
 					zap.String("path", c.Request.URL.Path),
 					zap.String("method", c.Request.Method),
@@ -47,7 +47,7 @@ func RecoveryWithCallback(callback func(c *gin.Context, err interface{})) gin.Ha
 			if err := recover(); err != nil {
 				stack := string(debug.Stack())
 
-				logger.Error("Panic recovered",
+				logger.FromContext(c).Error("Panic recovered",
 					zap.Any("error", err),
 					zap.String("path", c.Request.URL.Path),
 					zap.String("method", c.Request.Method),
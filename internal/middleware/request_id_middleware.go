@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"regexp"
+
+	"campus-core/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header the correlation ID is read from and returned in
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern limits client supplied IDs so they can't inject arbitrary content into logs
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID returns a middleware that assigns every request a correlation ID.
+// The ID is taken from the X-Request-ID header when valid and generated otherwise.
+// It is echoed in the response, stored on the context and attached to a request-scoped
+// logger that logger.FromContext returns for the rest of the request.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		reqLogger := logger.FromContext(c).With(zap.String("request_id", requestID))
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), reqLogger))
+
+		c.Next()
+	}
+}
+
+// GetRequestID gets the correlation ID of the current request
+func GetRequestID(c *gin.Context) string {
+	if requestID, exists := c.Get("request_id"); exists {
+		return requestID.(string)
+	}
+	return ""
+}
@@ -1,10 +1,11 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
-	"campus-core/internal/database"
 	"campus-core/internal/models"
+	"campus-core/internal/repository"
 	"campus-core/internal/utils"
 	"campus-core/pkg/logger"
 
@@ -13,8 +14,13 @@ import (
 	"go.uber.org/zap"
 )
 
-// TenantMiddleware handles multi-tenancy resolution
-func TenantMiddleware() gin.HandlerFunc {
+// TenantMiddleware handles multi-tenancy resolution. Requests for a disabled
+// institution are refused, except from a Super Admin. Institutions are looked up
+// through the repository's Redis cache, which is cleared when one is updated, so
+// disabling an institution locks its users out on their next request.
+// defaultInstitutionID, when not empty, is used for requests that name no institution,
+// except from a Super Admin, whose requests stay global unless they name one.
+func TenantMiddleware(repo *repository.InstitutionRepository, defaultInstitutionID string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 1. Check if institution_id is already in context (from AuthMiddleware)
 		if authInstitutionID := GetInstitutionID(c); authInstitutionID != "" {
@@ -34,12 +40,18 @@ func TenantMiddleware() gin.HandlerFunc {
 					return
 				}
 			}
+			if !requireActiveInstitution(c, repo) {
+				return
+			}
 			c.Next()
 			return
 		}
 
 		// 2. If not authenticated or no institution in token (e.g. Super Admin or Public Public), check Header
 		institutionID := c.GetHeader("X-Institution-ID")
+		if institutionID == "" && GetUserRole(c) != models.RoleSuperAdmin {
+			institutionID = defaultInstitutionID
+		}
 		if institutionID == "" {
 			// For public endpoints that require tenant context
 			// We don't abort here because some endpoints might be truly global (like /health or /login)
@@ -49,42 +61,53 @@ func TenantMiddleware() gin.HandlerFunc {
 		}
 
 		// 3. Validate Institution ID format
-		id, err := uuid.Parse(institutionID)
-		if err != nil {
+		if _, err := uuid.Parse(institutionID); err != nil {
 			utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
 			c.Abort()
 			return
 		}
 
-		// 4. Validate existence (Optional: Cache this check)
-		// For now, we'll assume it exists to avoid DB hit on every request,
-		// or we can do a quick check if we have a cache.
-		// Since Redis is available, we could cache valid institution IDs.
-		if database.RedisClient != nil {
-			ctx := c.Request.Context()
-			cacheKey := "institution:exists:" + institutionID
-			exists, _ := database.Exists(ctx, cacheKey)
-			if !exists {
-				// Double check DB if not in cache (or if cache expired)
-				var count int64
-				if err := database.DB.Model(&models.Institution{}).Where("id = ? AND is_active = ?", id, true).Count(&count).Error; err != nil {
-					logger.Error("Failed to check institution existence", zap.Error(err))
-				} else if count == 0 {
-					utils.Error(c, http.StatusNotFound, utils.ErrInstitutionNotFound)
-					c.Abort()
-					return
-				} else {
-					// Cache for 1 hour
-					_ = database.SetWithExpiry(ctx, cacheKey, "1", 3600*1000000000) // 1 hour
-				}
-			}
-		}
-
+		// 4. Validate existence and status
 		c.Set("institution_id", institutionID)
+		if !requireActiveInstitution(c, repo) {
+			return
+		}
 		c.Next()
 	}
 }
 
+// requireActiveInstitution aborts the request when the institution in context does
+// not exist or is disabled. Super Admins may still reach disabled institutions.
+func requireActiveInstitution(c *gin.Context, repo *repository.InstitutionRepository) bool {
+	if GetUserRole(c) == models.RoleSuperAdmin {
+		return true
+	}
+	id, err := uuid.Parse(GetInstitutionID(c))
+	if err != nil {
+		utils.Error(c, http.StatusBadRequest, utils.ErrInvalidUUID)
+		c.Abort()
+		return false
+	}
+
+	institution, err := repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, utils.ErrInstitutionNotFound) {
+			utils.Error(c, http.StatusNotFound, utils.ErrInstitutionNotFound)
+		} else {
+			logger.Error("Failed to check institution status", zap.Error(err))
+			utils.Error(c, http.StatusInternalServerError, utils.ErrInternalServer.Wrap(err))
+		}
+		c.Abort()
+		return false
+	}
+	if !institution.IsActive {
+		utils.Error(c, http.StatusForbidden, utils.ErrInstitutionDisabled)
+		c.Abort()
+		return false
+	}
+	return true
+}
+
 // RequireTenant requires standard tenant context to be present
 func RequireTenant() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// tenantRequest describes the caller seen by TenantMiddleware: the role and
+// institution AuthMiddleware would have set, and the X-Institution-ID header
+type tenantRequest struct {
+	role          string
+	institutionID string
+	header        string
+}
+
+// serveTenant runs a request through TenantMiddleware and returns the response.
+// The handler echoes the institution the middleware resolved.
+func serveTenant(t *testing.T, repo *repository.InstitutionRepository, defaultInstitutionID string, req tenantRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if req.role != "" {
+			c.Set("user_role", req.role)
+		}
+		if req.institutionID != "" {
+			c.Set("institution_id", req.institutionID)
+		}
+	})
+	router.Use(TenantMiddleware(repo, defaultInstitutionID))
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, GetInstitutionID(c))
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if req.header != "" {
+		r.Header.Set("X-Institution-ID", req.header)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestTenantMiddlewareDisabledInstitution(t *testing.T) {
+	db := testutil.DB(t)
+	testutil.Redis(t)
+	repo := repository.NewInstitutionRepository(db)
+
+	active := testutil.Institution(t, db)
+	disabled := testutil.Institution(t, db)
+	if err := db.Model(disabled).Update("is_active", false).Error; err != nil {
+		t.Fatalf("failed to disable institution: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		req        tenantRequest
+		wantStatus int
+	}{
+		{"user of an active institution", tenantRequest{role: models.RoleTeacher, institutionID: active.ID.String()}, http.StatusOK},
+		{"user of a disabled institution", tenantRequest{role: models.RoleTeacher, institutionID: disabled.ID.String()}, http.StatusForbidden},
+		{"admin of a disabled institution", tenantRequest{role: models.RoleAdmin, institutionID: disabled.ID.String()}, http.StatusForbidden},
+		{"public request naming a disabled institution", tenantRequest{header: disabled.ID.String()}, http.StatusForbidden},
+		{"unknown institution", tenantRequest{header: uuid.NewString()}, http.StatusNotFound},
+		{"malformed institution", tenantRequest{header: "not-a-uuid"}, http.StatusBadRequest},
+		{"super admin reaching a disabled institution", tenantRequest{role: models.RoleSuperAdmin, header: disabled.ID.String()}, http.StatusOK},
+		{"super admin switching to a disabled institution", tenantRequest{role: models.RoleSuperAdmin, institutionID: active.ID.String(), header: disabled.ID.String()}, http.StatusOK},
+		{"user switching institution", tenantRequest{role: models.RoleTeacher, institutionID: active.ID.String(), header: disabled.ID.String()}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serveTenant(t, repo, "", tt.req); w.Code != tt.wantStatus {
+				t.Errorf("TenantMiddleware() status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTenantMiddlewareDefaultInstitution(t *testing.T) {
+	db := testutil.DB(t)
+	testutil.Redis(t)
+	repo := repository.NewInstitutionRepository(db)
+
+	school := testutil.Institution(t, db)
+	other := testutil.Institution(t, db)
+
+	tests := []struct {
+		name                 string
+		defaultInstitutionID string
+		req                  tenantRequest
+		want                 string
+	}{
+		{"single-tenant public request", school.ID.String(), tenantRequest{}, school.ID.String()},
+		{"single-tenant request naming an institution", school.ID.String(), tenantRequest{header: other.ID.String()}, other.ID.String()},
+		{"single-tenant user of another institution", school.ID.String(), tenantRequest{role: models.RoleTeacher, institutionID: other.ID.String()}, other.ID.String()},
+		{"single-tenant super admin stays global", school.ID.String(), tenantRequest{role: models.RoleSuperAdmin}, ""},
+		{"single-tenant super admin naming an institution", school.ID.String(), tenantRequest{role: models.RoleSuperAdmin, header: other.ID.String()}, other.ID.String()},
+		{"multi-tenant public request", "", tenantRequest{}, ""},
+		{"multi-tenant request naming an institution", "", tenantRequest{header: school.ID.String()}, school.ID.String()},
+		{"multi-tenant user", "", tenantRequest{role: models.RoleTeacher, institutionID: school.ID.String()}, school.ID.String()},
+		{"multi-tenant super admin", "", tenantRequest{role: models.RoleSuperAdmin}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveTenant(t, repo, tt.defaultInstitutionID, tt.req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("TenantMiddleware() status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body.String())
+			}
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("TenantMiddleware() institution = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"time"
+
+	"campus-core/internal/database"
+	"campus-core/internal/utils"
+	"campus-core/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+// revocationStore returns the Redis store of per-user token revocations.
+// Without Redis nothing is recorded and tokens stay valid until they expire.
+func revocationStore() *cache.Cache {
+	return cache.New(database.RedisClient, "auth:revoked")
+}
+
+// RevokeUserTokens rejects every access token issued to the user before now.
+// Times are compared in milliseconds, so a token issued right after the
+// revocation, e.g. by logging in again, stays valid. The marker only has to
+// outlive the tokens it rejects, so ttl should be the access token lifetime.
+// It reports whether the revocation was recorded.
+func RevokeUserTokens(userID uuid.UUID, ttl time.Duration) bool {
+	store := revocationStore()
+	if !store.Enabled() {
+		return false
+	}
+	store.Set(userID.String(), time.Now().UnixMilli(), ttl)
+	return true
+}
+
+// IsTokenRevoked reports whether the user's tokens were revoked after the token was issued.
+// Tokens issued in the same millisecond as the revocation are not revoked.
+// Issue times decode from JSON floats and can come out a millisecond early,
+// far less than it takes to log in again after a revocation.
+func IsTokenRevoked(claims *utils.Claims) bool {
+	if claims.IssuedAt == nil {
+		return false
+	}
+
+	var revokedAt int64
+	if !revocationStore().Get(claims.UserID.String(), &revokedAt) {
+		return false
+	}
+	return claims.IssuedAt.UnixMilli() < revokedAt
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestIsTokenRevoked(t *testing.T) {
+	testutil.Redis(t)
+	userID := uuid.New()
+	claimsAt := func(issuedAt time.Time) *utils.Claims {
+		return &utils.Claims{
+			UserID:           userID,
+			RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)},
+		}
+	}
+
+	before := claimsAt(time.Now())
+	time.Sleep(2 * time.Millisecond)
+	if !RevokeUserTokens(userID, time.Minute) {
+		t.Fatal("RevokeUserTokens() = false, want true with Redis")
+	}
+	time.Sleep(2 * time.Millisecond)
+	// Issued moments after the revocation, usually within the same second, e.g. by logging in again
+	after := claimsAt(time.Now())
+
+	if !IsTokenRevoked(before) {
+		t.Error("IsTokenRevoked() = false for a token issued before the revocation, want true")
+	}
+	if IsTokenRevoked(after) {
+		t.Error("IsTokenRevoked() = true for a token issued after the revocation, want false")
+	}
+
+	other := claimsAt(time.Now().Add(-time.Hour))
+	other.UserID = uuid.New()
+	if IsTokenRevoked(other) {
+		t.Error("IsTokenRevoked() = true for another user's token, want false")
+	}
+}
+
+func TestIssuedAtSurvivesSigning(t *testing.T) {
+	// The revocation check relies on tokens carrying millisecond issue times
+	m := utils.NewJWTManager("test-secret", time.Hour, 24*time.Hour)
+	// Decoding the float issue time can lose up to a millisecond
+	before := time.Now().Truncate(time.Millisecond).Add(-time.Millisecond)
+	token, _, err := m.GenerateAccessToken(uuid.New(), "user@example.com", "teacher", nil, "", nil, false)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() unexpected error: %v", err)
+	}
+	claims, err := m.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() unexpected error: %v", err)
+	}
+	if claims.IssuedAt.Before(before) {
+		t.Errorf("IssuedAt = %v, want no earlier than %v", claims.IssuedAt.Time, before)
+	}
+}
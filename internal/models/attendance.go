@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AttendanceStatus represents whether a student attended on a date
+type AttendanceStatus string
+
+const (
+	AttendanceStatusPresent AttendanceStatus = "PRESENT"
+	AttendanceStatusAbsent  AttendanceStatus = "ABSENT"
+	AttendanceStatusLate    AttendanceStatus = "LATE"
+)
+
+// Attendance is a student's attendance on one date.
+// There is one record per student and date, so marking again overwrites it
+// instead of soft deleting, hence no deleted_at.
+type Attendance struct {
+	ID            uuid.UUID        `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CreatedAt     time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+	InstitutionID uuid.UUID        `gorm:"type:uuid;not null;index" json:"institution_id"`
+	StudentID     uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_attendance_student_date" json:"student_id"`
+	SectionID     *uuid.UUID       `gorm:"type:uuid;index" json:"section_id,omitempty"`
+	Date          time.Time        `gorm:"type:date;not null;uniqueIndex:idx_attendance_student_date" json:"date"`
+	Status        AttendanceStatus `gorm:"size:20;not null" json:"status"`
+	MarkedBy      *uuid.UUID       `gorm:"type:uuid" json:"marked_by,omitempty"`
+	Remarks       string           `gorm:"type:text" json:"remarks,omitempty"`
+
+	// Relations
+	Student *Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+// TableName specifies the table name for Attendance
+func (Attendance) TableName() string {
+	return "attendance"
+}
+
+// BeforeCreate generates a new UUID if not set
+func (a *Attendance) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Audit actions derived from the HTTP method of a write request
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditLog records a write operation for accountability.
+// Entries are append-only, so there are no update or soft-delete timestamps.
+type AuditLog struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	InstitutionID *uuid.UUID `gorm:"type:uuid;index" json:"institution_id,omitempty"`
+	ActorUserID   *uuid.UUID `gorm:"type:uuid;index" json:"actor_user_id,omitempty"`
+	Action        string     `gorm:"size:20;not null" json:"action"`
+	Method        string     `gorm:"size:10;not null" json:"method"`
+	Path          string     `gorm:"size:500;not null" json:"path"`
+	StatusCode    int        `gorm:"not null" json:"status_code"`
+	ResourceType  string     `gorm:"size:50;index" json:"resource_type,omitempty"`
+	ResourceID    *uuid.UUID `gorm:"type:uuid" json:"resource_id,omitempty"`
+	Changes       *string    `gorm:"column:changes_json;type:jsonb" json:"changes,omitempty"` // Redacted request body
+	IPAddress     string     `gorm:"size:45" json:"ip_address,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+// TableName specifies the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// BeforeCreate generates a new UUID if not set
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"campus-core/internal/utils"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,6 +15,9 @@ type BaseModel struct {
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// Version is incremented by updates that use optimistic locking
+	Version int `gorm:"not null;default:1" json:"version"`
 }
 
 // BeforeCreate generates a new UUID if not set
@@ -28,3 +33,55 @@ type TenantBaseModel struct {
 	BaseModel
 	InstitutionID uuid.UUID `gorm:"type:uuid;not null;index" json:"institution_id"`
 }
+
+// SystemActorID is recorded as the author of records written by the system
+// rather than a user, such as seeded data. It matches no user.
+var SystemActorID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Authorship records which user created and last updated a record.
+// Records written before authorship was tracked have neither set.
+type Authorship struct {
+	CreatedBy *uuid.UUID `gorm:"type:uuid;index" json:"created_by,omitempty"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+
+	// Relations
+	Creator *User `gorm:"foreignKey:CreatedBy" json:"-"`
+	Updater *User `gorm:"foreignKey:UpdatedBy" json:"-"`
+}
+
+// SetCreatedBy records the user creating the record, who is also its first updater
+func (a *Authorship) SetCreatedBy(actorID uuid.UUID) {
+	if actorID == uuid.Nil {
+		return
+	}
+	a.CreatedBy = &actorID
+	a.UpdatedBy = &actorID
+}
+
+// SetUpdatedBy records the user updating the record
+func (a *Authorship) SetUpdatedBy(actorID uuid.UUID) {
+	if actorID == uuid.Nil {
+		return
+	}
+	a.UpdatedBy = &actorID
+	a.Updater = nil
+}
+
+// TenantScoped is implemented by models embedding TenantBaseModel
+type TenantScoped interface {
+	TenantID() uuid.UUID
+}
+
+// TenantID returns the institution the record belongs to
+func (t TenantBaseModel) TenantID() uuid.UUID {
+	return t.InstitutionID
+}
+
+// ScopeTenant returns a GORM scope limiting queries on model to one institution.
+// Models that don't embed TenantBaseModel, and an empty institution ID, are left unscoped.
+func ScopeTenant(model interface{}, institutionID string) func(db *gorm.DB) *gorm.DB {
+	if _, ok := model.(TenantScoped); !ok {
+		return func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return utils.TenantScope(institutionID)
+}
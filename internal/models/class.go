@@ -1,13 +1,15 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // Class represents a student class (e.g., Class 10)
 type Class struct {
-	BaseModel
-	InstitutionID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"institution_id"`
+	TenantBaseModel
+	Authorship
 	Name           string     `gorm:"size:50;not null" json:"name"`
 	SectionCount   int        `gorm:"default:1" json:"section_count"`
 	ClassTeacherID *uuid.UUID `gorm:"type:uuid" json:"class_teacher_id,omitempty"`
@@ -42,14 +44,14 @@ func (Section) TableName() string {
 
 // Subject represents an academic subject
 type Subject struct {
-	BaseModel
-	InstitutionID uuid.UUID  `gorm:"type:uuid;not null;index" json:"institution_id"`
-	ClassID       *uuid.UUID `gorm:"type:uuid" json:"class_id,omitempty"`
-	TeacherID     *uuid.UUID `gorm:"type:uuid" json:"teacher_id,omitempty"`
-	Name          string     `gorm:"size:100;not null" json:"name"`
-	Code          string     `gorm:"size:20" json:"code,omitempty"`
-	IsElective    bool       `gorm:"default:false" json:"is_elective"`
-	CreditHours   float64    `gorm:"type:decimal(4,2)" json:"credit_hours,omitempty"`
+	TenantBaseModel
+	Authorship
+	ClassID     *uuid.UUID `gorm:"type:uuid" json:"class_id,omitempty"`
+	TeacherID   *uuid.UUID `gorm:"type:uuid" json:"teacher_id,omitempty"`
+	Name        string     `gorm:"size:100;not null" json:"name"`
+	Code        string     `gorm:"size:20" json:"code,omitempty"`
+	IsElective  bool       `gorm:"default:false" json:"is_elective"`
+	CreditHours float64    `gorm:"type:decimal(4,2)" json:"credit_hours,omitempty"`
 
 	// Relations
 	Class   *Class   `gorm:"foreignKey:ClassID" json:"class,omitempty"`
@@ -60,3 +62,37 @@ type Subject struct {
 func (Subject) TableName() string {
 	return "subjects"
 }
+
+// SubjectTeacherHistory records a teacher's term teaching a subject.
+// The current teacher's record has no ToDate.
+type SubjectTeacherHistory struct {
+	BaseModel
+	SubjectID uuid.UUID  `gorm:"type:uuid;not null;index" json:"subject_id"`
+	TeacherID uuid.UUID  `gorm:"type:uuid;not null;index" json:"teacher_id"`
+	FromDate  time.Time  `gorm:"not null" json:"from_date"`
+	ToDate    *time.Time `json:"to_date,omitempty"`
+
+	// Relations
+	Teacher *Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+}
+
+// TableName specifies the table name for SubjectTeacherHistory
+func (SubjectTeacherHistory) TableName() string {
+	return "subject_teacher_histories"
+}
+
+// SubjectPrerequisite marks a subject that must be completed before another one
+type SubjectPrerequisite struct {
+	TenantBaseModel
+	SubjectID             uuid.UUID `gorm:"type:uuid;not null;index" json:"subject_id"`
+	PrerequisiteSubjectID uuid.UUID `gorm:"type:uuid;not null;index" json:"prerequisite_subject_id"`
+
+	// Relations
+	Subject      *Subject `gorm:"foreignKey:SubjectID" json:"subject,omitempty"`
+	Prerequisite *Subject `gorm:"foreignKey:PrerequisiteSubjectID" json:"prerequisite,omitempty"`
+}
+
+// TableName specifies the table name for SubjectPrerequisite
+func (SubjectPrerequisite) TableName() string {
+	return "subject_prerequisites"
+}
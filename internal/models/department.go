@@ -1,13 +1,14 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // Department represents an academic department (e.g., Science, Arts)
 type Department struct {
-	BaseModel
-	InstitutionID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"institution_id"`
+	TenantBaseModel
 	Name               string     `gorm:"size:100;not null" json:"name"`
 	HeadOfDepartmentID *uuid.UUID `gorm:"type:uuid" json:"head_of_department_id,omitempty"`
 	Description        string     `gorm:"type:text" json:"description,omitempty"`
@@ -20,3 +21,21 @@ type Department struct {
 func (Department) TableName() string {
 	return "departments"
 }
+
+// DepartmentHeadHistory records a teacher's term as head of a department.
+// The current head's record has no ToDate.
+type DepartmentHeadHistory struct {
+	BaseModel
+	DepartmentID uuid.UUID  `gorm:"type:uuid;not null;index" json:"department_id"`
+	TeacherID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"teacher_id"`
+	FromDate     time.Time  `gorm:"not null" json:"from_date"`
+	ToDate       *time.Time `json:"to_date,omitempty"`
+
+	// Relations
+	Teacher *Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+}
+
+// TableName specifies the table name for DepartmentHeadHistory
+func (DepartmentHeadHistory) TableName() string {
+	return "department_head_histories"
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FeeFrequency represents how often a fee is charged within an academic year
+type FeeFrequency string
+
+const (
+	FeeFrequencyMonthly    FeeFrequency = "MONTHLY"
+	FeeFrequencyQuarterly  FeeFrequency = "QUARTERLY"
+	FeeFrequencyHalfYearly FeeFrequency = "HALF_YEARLY"
+	FeeFrequencyAnnual     FeeFrequency = "ANNUAL"
+	FeeFrequencyOneTime    FeeFrequency = "ONE_TIME"
+)
+
+// PaymentsPerYear returns how many times a fee of this frequency is charged in an academic year
+func (f FeeFrequency) PaymentsPerYear() int {
+	switch f {
+	case FeeFrequencyMonthly:
+		return 12
+	case FeeFrequencyQuarterly:
+		return 4
+	case FeeFrequencyHalfYearly:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// FeeStructure is a fee charged to the students of a class in an academic year
+type FeeStructure struct {
+	TenantBaseModel
+	AcademicYearID uuid.UUID    `gorm:"type:uuid;not null;index" json:"academic_year_id"`
+	ClassID        uuid.UUID    `gorm:"type:uuid;not null;index" json:"class_id"`
+	Name           string       `gorm:"size:100;not null" json:"name"`
+	Amount         float64      `gorm:"column:total_amount;type:decimal(10,2)" json:"amount"` // Charged per payment
+	DueDate        *time.Time   `gorm:"type:date" json:"due_date,omitempty"`
+	Frequency      FeeFrequency `gorm:"size:20;not null;default:ANNUAL" json:"frequency"`
+	IsActive       bool         `gorm:"default:true" json:"is_active"`
+
+	// Relations
+	AcademicYear *AcademicYear `gorm:"foreignKey:AcademicYearID" json:"academic_year,omitempty"`
+	Class        *Class        `gorm:"foreignKey:ClassID" json:"class,omitempty"`
+}
+
+// TableName specifies the table name for FeeStructure
+func (FeeStructure) TableName() string {
+	return "fee_structures"
+}
+
+// AnnualAmount returns the total charged over an academic year
+func (f *FeeStructure) AnnualAmount() float64 {
+	return f.Amount * float64(f.Frequency.PaymentsPerYear())
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HolidayType represents the kind of non-teaching day
+type HolidayType string
+
+const (
+	HolidayTypePublic    HolidayType = "PUBLIC"    // National or public holiday
+	HolidayTypeReligious HolidayType = "RELIGIOUS" // Religious festival
+	HolidayTypeVacation  HolidayType = "VACATION"  // School vacation day
+	HolidayTypeOther     HolidayType = "OTHER"
+)
+
+// Holiday is a non-teaching day within an academic year
+type Holiday struct {
+	TenantBaseModel
+	AcademicYearID uuid.UUID   `gorm:"type:uuid;not null;index" json:"academic_year_id"`
+	Date           time.Time   `gorm:"type:date;not null;index" json:"date"`
+	Name           string      `gorm:"size:100;not null" json:"name"`
+	Type           HolidayType `gorm:"column:holiday_type;size:50" json:"type"`
+
+	// Relations
+	AcademicYear *AcademicYear `gorm:"foreignKey:AcademicYearID" json:"academic_year,omitempty"`
+}
+
+// TableName specifies the table name for Holiday
+func (Holiday) TableName() string {
+	return "holidays"
+}
@@ -1,9 +1,17 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// InstitutionCodeConstraint is the unique constraint on institution codes
+const InstitutionCodeConstraint = "institutions_code_key"
+
 // Institution represents a school/institution in the system
 type Institution struct {
 	BaseModel
@@ -17,6 +25,12 @@ type Institution struct {
 	LogoURL         string `gorm:"size:500" json:"logo_url,omitempty"`
 	AcademicYear    string `gorm:"size:20" json:"academic_year,omitempty"`
 	IsActive        bool   `gorm:"default:true" json:"is_active"`
+
+	// RequireEmailVerification blocks login for users who have not verified their email
+	RequireEmailVerification bool `gorm:"default:false" json:"require_email_verification"`
+
+	// Relations
+	Settings *InstitutionSettings `gorm:"foreignKey:InstitutionID" json:"settings,omitempty"`
 }
 
 // TableName specifies the table name for Institution
@@ -33,3 +47,145 @@ type InstitutionStats struct {
 	ActiveUsers   int64     `json:"active_users"`
 	InstitutionID uuid.UUID `json:"-"`
 }
+
+// InstitutionDetailedStats breaks an institution's student and teacher counts down
+// by class, section, gender and department
+type InstitutionDetailedStats struct {
+	StudentsByClass      []ClassStudentCount      `json:"students_by_class"`
+	StudentsBySection    []SectionStudentCount    `json:"students_by_section"`
+	StudentsByGender     []GenderStudentCount     `json:"students_by_gender"`
+	TeachersByDepartment []DepartmentTeacherCount `json:"teachers_by_department"`
+	UnassignedTeachers   int64                    `json:"unassigned_teachers"`
+	InstitutionID        uuid.UUID                `json:"-"`
+}
+
+// ClassStudentCount is the number of students in a class
+type ClassStudentCount struct {
+	ClassID   uuid.UUID `json:"class_id"`
+	ClassName string    `json:"class_name"`
+	Students  int64     `json:"students"`
+}
+
+// SectionStudentCount is the number of students in a section
+type SectionStudentCount struct {
+	ClassID     uuid.UUID `json:"class_id"`
+	ClassName   string    `json:"class_name"`
+	SectionID   uuid.UUID `json:"section_id"`
+	SectionName string    `json:"section_name"`
+	Students    int64     `json:"students"`
+}
+
+// GenderStudentCount is the number of students of a gender; students without one are counted as "unspecified"
+type GenderStudentCount struct {
+	Gender   string `json:"gender"`
+	Students int64  `json:"students"`
+}
+
+// DepartmentTeacherCount is the number of teachers in a department
+type DepartmentTeacherCount struct {
+	DepartmentID   uuid.UUID `json:"department_id"`
+	DepartmentName string    `json:"department_name"`
+	Teachers       int64     `json:"teachers"`
+}
+
+// Default institution settings
+const (
+	DefaultTimezone     = "UTC"
+	DefaultLocale       = "en"
+	DefaultWeekStartDay = int(time.Monday)
+)
+
+// InstitutionSettings holds per-institution configuration
+type InstitutionSettings struct {
+	BaseModel
+	InstitutionID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex" json:"institution_id"`
+	Timezone      string       `gorm:"size:64;not null;default:UTC" json:"timezone"` // IANA name, e.g. "Asia/Dhaka"
+	Locale        string       `gorm:"size:20;not null;default:en" json:"locale"`    // BCP 47 tag, e.g. "bn-BD"
+	WeekStartDay  int          `gorm:"not null;default:1" json:"week_start_day"`     // 0 = Sunday ... 6 = Saturday
+	GradingScale  GradingScale `gorm:"type:jsonb" json:"grading_scale"`
+
+	// AutoRollNumbers gives students created without a roll number the next free one in their section
+	AutoRollNumbers bool `gorm:"not null;default:true" json:"auto_roll_numbers"`
+
+	// RateLimitRequests overrides the request limit shared by the institution's users; nil keeps the default
+	RateLimitRequests *int `json:"rate_limit_requests,omitempty"`
+
+	// MaxClassesPerClassTeacher caps how many classes one teacher leads as class teacher; nil means no limit
+	MaxClassesPerClassTeacher *int `json:"max_classes_per_class_teacher,omitempty"`
+}
+
+// TableName specifies the table name for InstitutionSettings
+func (InstitutionSettings) TableName() string {
+	return "institution_settings"
+}
+
+// Location returns the institution's time zone, falling back to UTC if unset or invalid
+func (s *InstitutionSettings) Location() *time.Location {
+	if s == nil || s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// GradeBand maps a score range (percent, inclusive) to a grade
+type GradeBand struct {
+	Grade      string  `json:"grade"`
+	MinScore   float64 `json:"min_score"`
+	MaxScore   float64 `json:"max_score"`
+	GradePoint float64 `json:"grade_point"`
+}
+
+// GradingScale is an ordered list of grade bands stored as JSON
+type GradingScale []GradeBand
+
+// Value implements driver.Valuer
+func (g GradingScale) Value() (driver.Value, error) {
+	if g == nil {
+		return nil, nil
+	}
+	return json.Marshal(g)
+}
+
+// Scan implements sql.Scanner
+func (g *GradingScale) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*g = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, g)
+	case string:
+		return json.Unmarshal([]byte(v), g)
+	default:
+		return errors.New("unsupported grading scale value")
+	}
+}
+
+// DefaultGradingScale returns a letter grade scale on a 5.0 grade point basis
+func DefaultGradingScale() GradingScale {
+	return GradingScale{
+		{Grade: "A+", MinScore: 80, MaxScore: 100, GradePoint: 5.0},
+		{Grade: "A", MinScore: 70, MaxScore: 79.99, GradePoint: 4.0},
+		{Grade: "A-", MinScore: 60, MaxScore: 69.99, GradePoint: 3.5},
+		{Grade: "B", MinScore: 50, MaxScore: 59.99, GradePoint: 3.0},
+		{Grade: "C", MinScore: 40, MaxScore: 49.99, GradePoint: 2.0},
+		{Grade: "D", MinScore: 33, MaxScore: 39.99, GradePoint: 1.0},
+		{Grade: "F", MinScore: 0, MaxScore: 32.99, GradePoint: 0.0},
+	}
+}
+
+// DefaultInstitutionSettings returns the settings given to a new institution
+func DefaultInstitutionSettings(institutionID uuid.UUID) *InstitutionSettings {
+	return &InstitutionSettings{
+		InstitutionID:   institutionID,
+		Timezone:        DefaultTimezone,
+		Locale:          DefaultLocale,
+		WeekStartDay:    DefaultWeekStartDay,
+		GradingScale:    DefaultGradingScale(),
+		AutoRollNumbers: true,
+	}
+}
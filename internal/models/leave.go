@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaveStatus represents where a leave application is in its review
+type LeaveStatus string
+
+const (
+	LeaveStatusPending  LeaveStatus = "PENDING"
+	LeaveStatusApproved LeaveStatus = "APPROVED"
+	LeaveStatusRejected LeaveStatus = "REJECTED"
+)
+
+// LeaveApplication is a user's request to be absent from FromDate to ToDate, inclusive.
+// Pending applications are approved or rejected by a user with LEAVE_APPROVE.
+type LeaveApplication struct {
+	TenantBaseModel
+	ApplicantUserID uuid.UUID   `gorm:"column:user_id;type:uuid;not null;index" json:"applicant_user_id"`
+	FromDate        time.Time   `gorm:"column:start_date;type:date;not null" json:"from_date"`
+	ToDate          time.Time   `gorm:"column:end_date;type:date;not null" json:"to_date"`
+	TotalDays       int         `gorm:"not null" json:"total_days"`
+	Reason          string      `gorm:"type:text;not null" json:"reason"`
+	Status          LeaveStatus `gorm:"size:20;not null;default:PENDING" json:"status"`
+	ReviewedBy      *uuid.UUID  `gorm:"column:approved_by;type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time  `gorm:"column:approved_at" json:"reviewed_at,omitempty"`
+	ReviewNote      string      `gorm:"column:rejection_reason;type:text" json:"review_note,omitempty"`
+
+	// Relations
+	Applicant *User `gorm:"foreignKey:ApplicantUserID" json:"applicant,omitempty"`
+}
+
+// TableName specifies the table name for LeaveApplication
+func (LeaveApplication) TableName() string {
+	return "leaves"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Notice is a message published to some roles of an institution.
+// It is visible from PublishedAt until ExpiresAt, if set.
+type Notice struct {
+	TenantBaseModel
+	Title         string         `gorm:"size:255;not null" json:"title"`
+	Body          string         `gorm:"column:content;type:text" json:"body"`
+	AudienceRoles pq.StringArray `gorm:"column:target_audience;type:varchar(50)[]" json:"audience_roles"`
+	PublishedAt   time.Time      `gorm:"not null;index" json:"published_at"`
+	ExpiresAt     *time.Time     `json:"expires_at,omitempty"`
+	AuthorID      *uuid.UUID     `gorm:"column:published_by;type:uuid" json:"author_id,omitempty"`
+
+	// Relations
+	Author *User `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+}
+
+// TableName specifies the table name for Notice
+func (Notice) TableName() string {
+	return "notices"
+}
+
+// IsVisibleAt reports whether the notice is published and not yet expired at t
+func (n *Notice) IsVisibleAt(t time.Time) bool {
+	return !n.PublishedAt.After(t) && (n.ExpiresAt == nil || n.ExpiresAt.After(t))
+}
@@ -22,13 +22,32 @@ func (Parent) TableName() string {
 	return "parents"
 }
 
+// Relationship constants for parent-student relations
+const (
+	RelationshipFather   = "father"
+	RelationshipMother   = "mother"
+	RelationshipGuardian = "guardian"
+)
+
+// ValidRelationships contains all valid parent-student relationships
+var ValidRelationships = []string{RelationshipFather, RelationshipMother, RelationshipGuardian}
+
+// IsValidRelationship checks if a parent-student relationship is valid
+func IsValidRelationship(relationship string) bool {
+	switch relationship {
+	case RelationshipFather, RelationshipMother, RelationshipGuardian:
+		return true
+	}
+	return false
+}
+
 // ParentStudentRelation represents the relationship between parents and students
 type ParentStudentRelation struct {
 	BaseModel
 	ParentID     uuid.UUID `gorm:"type:uuid;not null;index" json:"parent_id"`
 	StudentID    uuid.UUID `gorm:"type:uuid;not null;index" json:"student_id"`
-	Relationship string    `gorm:"size:50" json:"relationship"` // Father, Mother, Guardian
-	IsPrimary    bool      `gorm:"default:false" json:"is_primary"`
+	Relationship string    `gorm:"size:50" json:"relationship"`     // father, mother or guardian
+	IsPrimary    bool      `gorm:"default:false" json:"is_primary"` // Exactly one relation per student is primary
 
 	// Relations
 	Parent  *Parent  `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
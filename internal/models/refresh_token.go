@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken represents an issued refresh token.
+// Tokens rotated from the same login share a FamilyID, which identifies a session.
+type RefreshToken struct {
+	BaseModel
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	JTI       string     `gorm:"column:jti;size:64;uniqueIndex;not null" json:"-"`
+	FamilyID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"family_id"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	UserAgent string     `gorm:"size:500" json:"user_agent,omitempty"`
+	IPAddress string     `gorm:"size:45" json:"ip_address,omitempty"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName specifies the table name for RefreshToken
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsActive reports whether the token can still be exchanged
+func (t *RefreshToken) IsActive() bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// PermissionAll grants every permission (Super Admin)
+const PermissionAll = "*"
+
+// Permission represents a named permission that can be granted to roles
+type Permission struct {
+	BaseModel
+	Code        string `gorm:"size:100;not null;uniqueIndex" json:"code"`
+	Description string `gorm:"size:255" json:"description,omitempty"`
+}
+
+// TableName specifies the table name for Permission
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// Role represents a set of permissions.
+// Built-in roles are global (no institution) and match User.Role; custom roles
+// belong to an institution and are attached to users through User.CustomRoleID.
+type Role struct {
+	BaseModel
+	InstitutionID *uuid.UUID `gorm:"type:uuid;index" json:"institution_id,omitempty"`
+	Name          string     `gorm:"size:100;not null" json:"name"`
+	Code          string     `gorm:"size:50;not null" json:"code"`
+	Description   string     `gorm:"size:255" json:"description,omitempty"`
+	IsBuiltIn     bool       `gorm:"default:false" json:"is_built_in"`
+
+	// Relations
+	Permissions []Permission `gorm:"many2many:role_permissions;" json:"permissions,omitempty"`
+}
+
+// TableName specifies the table name for Role
+func (Role) TableName() string {
+	return "roles"
+}
+
+// PermissionCodes returns the codes of the role's permissions
+func (r *Role) PermissionCodes() []string {
+	codes := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		codes = append(codes, p.Code)
+	}
+	return codes
+}
+
+// DefaultRolePermissions maps the built-in roles to their default permissions.
+// They seed the roles table and are the fallback when the database has no entry for a role.
+var DefaultRolePermissions = map[string][]string{
+	RoleSuperAdmin: {PermissionAll},
+	RoleAdmin: {
+		"USER_CREATE", "USER_UPDATE", "USER_DELETE", "USER_VIEW",
+		"STUDENT_MANAGE", "TEACHER_MANAGE", "CLASS_MANAGE",
+		"SECTION_MANAGE", "SUBJECT_MANAGE", "DEPARTMENT_MANAGE",
+		"ACADEMIC_YEAR_MANAGE", "TIMETABLE_MANAGE",
+		"FEE_STRUCTURE_MANAGE",
+		"NOTICE_PUBLISH", "ANNOUNCEMENT_CREATE",
+		"REPORT_GENERATE",
+		"LEAVE_APPROVE",
+		"LIBRARY_MANAGE",
+		"EVENT_MANAGE",
+	},
+	RoleTeacher: {
+		"ATTENDANCE_MARK", "ATTENDANCE_VIEW",
+		"ASSIGNMENT_CREATE", "ASSIGNMENT_GRADE",
+		"EXAM_CREATE", "RESULT_ENTER",
+		"STUDENT_PROGRESS_VIEW",
+		"PARENT_COMMUNICATE", "MESSAGE_SEND",
+		"LEAVE_APPLY",
+		"RESOURCE_UPLOAD", "MATERIAL_UPLOAD",
+		"TIMETABLE_VIEW",
+		"ONLINE_CLASS_CREATE",
+	},
+	RoleStudent: {
+		"PROFILE_VIEW_OWN", "PROFILE_UPDATE_OWN",
+		"ASSIGNMENT_VIEW", "ASSIGNMENT_SUBMIT",
+		"RESULT_VIEW_OWN",
+		"ATTENDANCE_VIEW_OWN",
+		"FEE_VIEW_OWN",
+		"LEAVE_APPLY",
+		"LIBRARY_BORROW", "LIBRARY_VIEW",
+		"EVENT_VIEW",
+		"MATERIAL_DOWNLOAD",
+		"MESSAGE_SEND", "NOTICE_VIEW",
+	},
+	RoleParent: {
+		"STUDENT_PROGRESS_VIEW",
+		"FEE_PAY", "FEE_VIEW_CHILD",
+		"TEACHER_COMMUNICATE", "MESSAGE_SEND",
+		"ATTENDANCE_VIEW_CHILD",
+		"LEAVE_APPLY_CHILD",
+		"MEETING_SCHEDULE",
+		"EVENT_VIEW", "NOTICE_VIEW",
+	},
+	RoleAccountant: {
+		"FEE_COLLECT", "FEE_VIEW_ALL", "FEE_STRUCTURE_VIEW",
+		"EXPENSE_MANAGE", "EXPENSE_CREATE", "EXPENSE_VIEW",
+		"SALARY_PROCESS", "SALARY_VIEW",
+		"FINANCIAL_REPORT_GENERATE",
+		"INVOICE_GENERATE",
+		"SCHOLARSHIP_MANAGE", "DISCOUNT_APPLY",
+	},
+}
+
+// BuiltInPermissions returns the canonical list of grantable permissions:
+// the union of the built-in roles' default permissions, sorted
+func BuiltInPermissions() []string {
+	seen := make(map[string]bool)
+	var codes []string
+	for _, perms := range DefaultRolePermissions {
+		for _, code := range perms {
+			if code != PermissionAll && !seen[code] {
+				seen[code] = true
+				codes = append(codes, code)
+			}
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// IsBuiltInPermission checks if a permission is in the canonical list
+func IsBuiltInPermission(code string) bool {
+	for _, p := range BuiltInPermissions() {
+		if p == code {
+			return true
+		}
+	}
+	return false
+}
@@ -6,9 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// BloodGroups contains the ABO and Rh blood groups recorded for students
+var BloodGroups = []string{"A+", "A-", "B+", "B-", "AB+", "AB-", "O+", "O-"}
+
 // Student represents a student in the system
 type Student struct {
 	TenantBaseModel
+	Authorship
 	UserID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
 	ClassID       *uuid.UUID `gorm:"type:uuid" json:"class_id,omitempty"`
 	SectionID     *uuid.UUID `gorm:"type:uuid" json:"section_id,omitempty"`
@@ -26,3 +30,46 @@ type Student struct {
 func (Student) TableName() string {
 	return "students"
 }
+
+// StudentPromotion records a student's move to another class so it can be reversed.
+// Promotions made in one request share a BatchID.
+type StudentPromotion struct {
+	TenantBaseModel
+	BatchID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"batch_id"`
+	StudentID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"student_id"`
+	FromClassID    *uuid.UUID `gorm:"type:uuid" json:"from_class_id,omitempty"`
+	FromSectionID  *uuid.UUID `gorm:"type:uuid" json:"from_section_id,omitempty"`
+	FromRollNumber int        `json:"from_roll_number,omitempty"`
+	ToClassID      uuid.UUID  `gorm:"type:uuid;not null" json:"to_class_id"`
+	ToSectionID    *uuid.UUID `gorm:"type:uuid" json:"to_section_id,omitempty"`
+	ToRollNumber   int        `json:"to_roll_number,omitempty"`
+	PromotedBy     *uuid.UUID `gorm:"type:uuid" json:"promoted_by,omitempty"`
+	RevertedAt     *time.Time `json:"reverted_at,omitempty"`
+
+	// Relations
+	Student *Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+// TableName specifies the table name for StudentPromotion
+func (StudentPromotion) TableName() string {
+	return "student_promotions"
+}
+
+// StudentTransfer records a student's move to another institution.
+// The class and section are cleared on transfer, so the previous ones are kept here.
+type StudentTransfer struct {
+	BaseModel
+	StudentID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"student_id"`
+	FromInstitutionID uuid.UUID  `gorm:"type:uuid;not null;index" json:"from_institution_id"`
+	ToInstitutionID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"to_institution_id"`
+	FromClassID       *uuid.UUID `gorm:"type:uuid" json:"from_class_id,omitempty"`
+	FromSectionID     *uuid.UUID `gorm:"type:uuid" json:"from_section_id,omitempty"`
+	FromRollNumber    int        `json:"from_roll_number,omitempty"`
+	Reason            string     `gorm:"type:text" json:"reason,omitempty"`
+	TransferredBy     *uuid.UUID `gorm:"type:uuid" json:"transferred_by,omitempty"`
+}
+
+// TableName specifies the table name for StudentTransfer
+func (StudentTransfer) TableName() string {
+	return "student_transfers"
+}
@@ -23,3 +23,20 @@ type Teacher struct {
 func (Teacher) TableName() string {
 	return "teachers"
 }
+
+// TeacherSubjectAssignment assigns a teacher to a subject in addition to the
+// subject's primary teacher, e.g. when several teachers share a subject
+type TeacherSubjectAssignment struct {
+	TenantBaseModel
+	TeacherID uuid.UUID `gorm:"type:uuid;not null;index" json:"teacher_id"`
+	SubjectID uuid.UUID `gorm:"type:uuid;not null;index" json:"subject_id"`
+
+	// Relations
+	Teacher *Teacher `gorm:"foreignKey:TeacherID" json:"teacher,omitempty"`
+	Subject *Subject `gorm:"foreignKey:SubjectID" json:"subject,omitempty"`
+}
+
+// TableName specifies the table name for TeacherSubjectAssignment
+func (TeacherSubjectAssignment) TableName() string {
+	return "teacher_subject_assignments"
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -17,16 +19,34 @@ const (
 	Saturday  DayOfWeek = "SATURDAY"
 )
 
+// DaysOfWeek contains the days of the week, starting on Sunday
+var DaysOfWeek = []DayOfWeek{Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday}
+
+// WeekType selects the weeks in which a timetable entry takes place.
+// Odd and even refer to ISO week numbers.
+type WeekType string
+
+const (
+	WeekTypeAll  WeekType = "ALL"
+	WeekTypeOdd  WeekType = "ODD"
+	WeekTypeEven WeekType = "EVEN"
+)
+
+// Overlaps reports whether entries of the two week types can meet in the same week
+func (w WeekType) Overlaps(other WeekType) bool {
+	return w == WeekTypeAll || other == WeekTypeAll || w == other
+}
+
 // Timetable represents a scheduled class period
 type Timetable struct {
-	BaseModel
-	InstitutionID  uuid.UUID `gorm:"type:uuid;not null;index" json:"institution_id"`
+	TenantBaseModel
 	AcademicYearID uuid.UUID `gorm:"type:uuid;not null;index" json:"academic_year_id"`
 	ClassID        uuid.UUID `gorm:"type:uuid;not null;index" json:"class_id"`
 	SectionID      uuid.UUID `gorm:"type:uuid;not null;index" json:"section_id"`
 	SubjectID      uuid.UUID `gorm:"type:uuid;not null;index" json:"subject_id"`
 	TeacherID      uuid.UUID `gorm:"type:uuid;not null;index" json:"teacher_id"`
 	DayOfWeek      DayOfWeek `gorm:"size:20;not null" json:"day_of_week"`
+	WeekType       WeekType  `gorm:"size:10;not null;default:ALL" json:"week_type"`
 	StartTime      string    `gorm:"size:10;not null" json:"start_time"` // Format: "09:00"
 	EndTime        string    `gorm:"size:10;not null" json:"end_time"`   // Format: "09:45"
 	RoomNumber     string    `gorm:"size:50" json:"room_number,omitempty"`
@@ -64,3 +84,24 @@ type Period struct {
 func (Period) TableName() string {
 	return "periods"
 }
+
+// Substitution assigns a substitute teacher to a timetable slot on a single date
+type Substitution struct {
+	TenantBaseModel
+	TimetableID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"timetable_id"`
+	OriginalTeacherID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"original_teacher_id"`
+	SubstituteTeacherID uuid.UUID  `gorm:"type:uuid;not null;index" json:"substitute_teacher_id"`
+	Date                time.Time  `gorm:"type:date;not null;index" json:"date"`
+	Reason              string     `gorm:"type:text" json:"reason,omitempty"`
+	CreatedBy           *uuid.UUID `gorm:"type:uuid" json:"created_by,omitempty"`
+
+	// Relations
+	Timetable         *Timetable `gorm:"foreignKey:TimetableID" json:"timetable,omitempty"`
+	OriginalTeacher   *Teacher   `gorm:"foreignKey:OriginalTeacherID" json:"original_teacher,omitempty"`
+	SubstituteTeacher *Teacher   `gorm:"foreignKey:SubstituteTeacherID" json:"substitute_teacher,omitempty"`
+}
+
+// TableName specifies the table name for Substitution
+func (Substitution) TableName() string {
+	return "substitutions"
+}
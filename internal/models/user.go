@@ -36,19 +36,46 @@ func IsValidRole(role string) bool {
 	return false
 }
 
+// UserEmailIndex is the unique index on the emails of users that aren't deleted
+const UserEmailIndex = "idx_users_email"
+
+// Gender constants
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+	GenderOther  = "other"
+)
+
+// ValidGenders contains all valid genders
+var ValidGenders = []string{GenderMale, GenderFemale, GenderOther}
+
+// IsValidGender checks if a gender is valid
+func IsValidGender(gender string) bool {
+	switch gender {
+	case GenderMale, GenderFemale, GenderOther:
+		return true
+	}
+	return false
+}
+
 // User represents a user in the system
 type User struct {
 	BaseModel
-	Email            string       `gorm:"size:255;uniqueIndex" json:"email,omitempty"`
-	Phone            string       `gorm:"size:20" json:"phone,omitempty"`
-	PasswordHash     string       `gorm:"size:255" json:"-"`
-	Role             string       `gorm:"size:50;not null" json:"role"`
-	IsActive         bool         `gorm:"default:true" json:"is_active"`
-	LastLoginAt      *time.Time   `json:"last_login_at,omitempty"`
-	RefreshToken     string       `gorm:"size:500" json:"-"`
-	ResetToken       string       `gorm:"size:255" json:"-"`
-	ResetTokenExpiry *time.Time   `json:"-"`
-	Profile          *UserProfile `gorm:"foreignKey:UserID" json:"profile,omitempty"`
+	Email             string     `gorm:"size:255;uniqueIndex" json:"email,omitempty"`
+	Phone             string     `gorm:"size:20" json:"phone,omitempty"`
+	PasswordHash      string     `gorm:"size:255" json:"-"`
+	Role              string     `gorm:"size:50;not null" json:"role"`
+	CustomRoleID      *uuid.UUID `gorm:"type:uuid" json:"custom_role_id,omitempty"` // Extra permissions from an institution role
+	IsActive          bool       `gorm:"default:true" json:"is_active"`
+	LastLoginAt       *time.Time `json:"last_login_at,omitempty"`
+	ResetToken        string     `gorm:"size:255" json:"-"`
+	ResetTokenExpiry  *time.Time `json:"-"`
+	EmailVerified     bool       `gorm:"default:false" json:"email_verified"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at,omitempty"`
+	VerificationToken string     `gorm:"size:500" json:"-"`
+	// MustChangePassword blocks the account until the user replaces a password set by someone else
+	MustChangePassword bool         `gorm:"not null;default:false" json:"must_change_password"`
+	Profile            *UserProfile `gorm:"foreignKey:UserID" json:"profile,omitempty"`
 }
 
 // TableName specifies the table name for User
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"campus-core/internal/models"
 	"campus-core/internal/utils"
@@ -15,6 +16,9 @@ type AcademicYearFilter struct {
 	InstitutionID string
 	IsCurrent     *bool
 	Search        string
+	StartDate     *time.Time // Only years still running on or after this date
+	EndDate       *time.Time // Only years already started on or before this date
+	ActiveOn      *time.Time // Only the year whose range contains this date
 }
 
 // AcademicYearRepository handles database operations for academic years
@@ -27,6 +31,11 @@ func NewAcademicYearRepository(db *gorm.DB) *AcademicYearRepository {
 	return &AcademicYearRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *AcademicYearRepository) WithTx(tx *gorm.DB) *AcademicYearRepository {
+	return &AcademicYearRepository{db: tx}
+}
+
 // FindByID finds an academic year by ID
 func (r *AcademicYearRepository) FindByID(id uuid.UUID) (*models.AcademicYear, error) {
 	var ay models.AcademicYear
@@ -58,7 +67,7 @@ func (r *AcademicYearRepository) FindAll(filter AcademicYearFilter, params utils
 	var academicYears []models.AcademicYear
 	var total int64
 
-	query := r.db.Model(&models.AcademicYear{})
+	query := onReplica(r.db).Model(&models.AcademicYear{})
 
 	// Apply filters
 	if filter.InstitutionID != "" {
@@ -70,6 +79,19 @@ func (r *AcademicYearRepository) FindAll(filter AcademicYearFilter, params utils
 	if filter.Search != "" {
 		query = query.Where("name ILIKE ?", "%"+filter.Search+"%")
 	}
+	if filter.StartDate != nil {
+		query = query.Where("end_date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("start_date <= ?", *filter.EndDate)
+	}
+	if filter.ActiveOn != nil {
+		active := r.db.Model(&models.AcademicYear{}).Select("id").Scopes(activeOnScope(*filter.ActiveOn))
+		if filter.InstitutionID != "" {
+			active = active.Where("institution_id = ?", filter.InstitutionID)
+		}
+		query = query.Where("id = (?)", active.Limit(1))
+	}
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -99,6 +121,28 @@ func (r *AcademicYearRepository) FindCurrent(institutionID uuid.UUID) (*models.A
 	return &ay, nil
 }
 
+// FindByDate finds the academic year of an institution whose range contains the date.
+// Years should not overlap, but if they do the most recently started one is returned.
+func (r *AcademicYearRepository) FindByDate(institutionID uuid.UUID, date time.Time) (*models.AcademicYear, error) {
+	var ay models.AcademicYear
+	err := r.db.Scopes(activeOnScope(date)).First(&ay, "institution_id = ?", institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &ay, nil
+}
+
+// activeOnScope keeps the academic years whose range contains the date,
+// most recently started first
+func activeOnScope(date time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("start_date <= ? AND end_date >= ?", date, date).Order("start_date DESC")
+	}
+}
+
 // Create creates a new academic year
 func (r *AcademicYearRepository) Create(ay *models.AcademicYear) error {
 	return r.db.Create(ay).Error
@@ -130,6 +174,13 @@ func (r *AcademicYearRepository) SetCurrent(id, institutionID uuid.UUID) error {
 	})
 }
 
+// ClearCurrent unsets the current flag of an academic year
+func (r *AcademicYearRepository) ClearCurrent(id, institutionID uuid.UUID) error {
+	return r.db.Model(&models.AcademicYear{}).
+		Where("id = ? AND institution_id = ?", id, institutionID).
+		Update("is_current", false).Error
+}
+
 // NameExists checks if an academic year name exists for an institution
 func (r *AcademicYearRepository) NameExists(name string, institutionID uuid.UUID, excludeID *uuid.UUID) (bool, error) {
 	var count int64
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAcademicYearFindByDate(t *testing.T) {
+	db := testutil.DB(t)
+	repo := NewAcademicYearRepository(db)
+
+	// A winter break separates the first two years; the third overlaps the second
+	institution := testutil.Institution(t, db)
+	first := testutil.AcademicYear(t, db, institution.ID, date(2024, 1, 1), date(2024, 11, 30))
+	second := testutil.AcademicYear(t, db, institution.ID, date(2025, 1, 1), date(2025, 11, 30))
+	overlapping := testutil.AcademicYear(t, db, institution.ID, date(2025, 9, 1), date(2026, 6, 30))
+
+	// Another institution's year covering the break must not leak in
+	other := testutil.Institution(t, db)
+	testutil.AcademicYear(t, db, other.ID, date(2024, 12, 1), date(2024, 12, 31))
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		want    uuid.UUID
+		wantErr error
+	}{
+		{name: "inside a year", date: date(2024, 6, 15), want: first.ID},
+		{name: "first day", date: date(2025, 1, 1), want: second.ID},
+		{name: "last day", date: date(2024, 11, 30), want: first.ID},
+		{name: "between two years", date: date(2024, 12, 15), wantErr: utils.ErrNotFound},
+		{name: "before every year", date: date(2023, 6, 1), wantErr: utils.ErrNotFound},
+		{name: "overlap returns the most recently started", date: date(2025, 10, 1), want: overlapping.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			year, err := repo.FindByDate(institution.ID, tt.date)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("FindByDate(%s) error = %v, want %v", tt.date.Format("2006-01-02"), err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindByDate(%s) unexpected error: %v", tt.date.Format("2006-01-02"), err)
+			}
+			if year.ID != tt.want {
+				t.Errorf("FindByDate(%s) = %s, want %s", tt.date.Format("2006-01-02"), year.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestAcademicYearFindAllDateFilters(t *testing.T) {
+	db := testutil.DB(t)
+	repo := NewAcademicYearRepository(db)
+
+	institution := testutil.Institution(t, db)
+	first := testutil.AcademicYear(t, db, institution.ID, date(2024, 1, 1), date(2024, 11, 30))
+	second := testutil.AcademicYear(t, db, institution.ID, date(2025, 1, 1), date(2025, 11, 30))
+
+	between := date(2024, 12, 15)
+	inFirst := date(2024, 3, 1)
+	rangeStart, rangeEnd := date(2024, 12, 1), date(2025, 2, 1)
+
+	tests := []struct {
+		name   string
+		filter AcademicYearFilter
+		want   []uuid.UUID
+	}{
+		{name: "active between two years", filter: AcademicYearFilter{ActiveOn: &between}},
+		{name: "active inside a year", filter: AcademicYearFilter{ActiveOn: &inFirst}, want: []uuid.UUID{first.ID}},
+		{name: "running after the break starts", filter: AcademicYearFilter{StartDate: &rangeStart}, want: []uuid.UUID{second.ID}},
+		{name: "started before the break ends", filter: AcademicYearFilter{EndDate: &rangeStart}, want: []uuid.UUID{first.ID}},
+		{name: "range spanning the break", filter: AcademicYearFilter{StartDate: &rangeStart, EndDate: &rangeEnd}, want: []uuid.UUID{second.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.InstitutionID = institution.ID.String()
+			years, total, err := repo.FindAll(tt.filter, utils.PaginationParams{Page: 1, PerPage: 10})
+			if err != nil {
+				t.Fatalf("FindAll() unexpected error: %v", err)
+			}
+			if total != int64(len(tt.want)) || len(years) != len(tt.want) {
+				t.Fatalf("FindAll() returned %d years (total %d), want %d", len(years), total, len(tt.want))
+			}
+			for i, id := range tt.want {
+				if years[i].ID != id {
+					t.Errorf("FindAll()[%d] = %s, want %s", i, years[i].ID, id)
+				}
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ func (r *AccountantRepository) FindAll(institutionID string, params utils.Pagina
 	var accountants []models.Accountant
 	var total int64
 
-	db := r.db.Model(&models.Accountant{}).Preload("User.Profile")
+	db := onReplica(r.db).Model(&models.Accountant{}).Preload("User.Profile")
 
 	if institutionID != "" {
 		db = db.Where("institution_id = ?", institutionID)
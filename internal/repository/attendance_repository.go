@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"campus-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AttendanceRepository handles database operations for attendance
+type AttendanceRepository struct {
+	db *gorm.DB
+}
+
+// NewAttendanceRepository creates a new attendance repository
+func NewAttendanceRepository(db *gorm.DB) *AttendanceRepository {
+	return &AttendanceRepository{db: db}
+}
+
+// Upsert stores attendance records, overwriting any existing record of the same student and date
+func (r *AttendanceRepository) Upsert(records []models.Attendance) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "student_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"section_id", "status", "marked_by", "remarks", "updated_at"}),
+	}).Create(&records).Error
+}
+
+// FindByStudent finds a student's attendance between two optional dates (inclusive), newest first
+func (r *AttendanceRepository) FindByStudent(studentID uuid.UUID, from, to *time.Time) ([]models.Attendance, error) {
+	var records []models.Attendance
+	query := r.db.Where("student_id = ?", studentID)
+	if from != nil {
+		query = query.Where("date >= ?", from.Format("2006-01-02"))
+	}
+	if to != nil {
+		query = query.Where("date <= ?", to.Format("2006-01-02"))
+	}
+	err := query.Order("date DESC").Find(&records).Error
+	return records, err
+}
+
+// CountBySectionAndDate counts a section's attendance records on a date by status
+func (r *AttendanceRepository) CountBySectionAndDate(sectionID uuid.UUID, date time.Time) (map[models.AttendanceStatus]int64, error) {
+	var rows []struct {
+		Status models.AttendanceStatus
+		Count  int64
+	}
+	err := r.db.Model(&models.Attendance{}).
+		Select("status, COUNT(*) AS count").
+		Where("section_id = ? AND date = ?", sectionID, date.Format("2006-01-02")).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.AttendanceStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
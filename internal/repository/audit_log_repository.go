@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter holds filter criteria for audit logs
+type AuditLogFilter struct {
+	InstitutionID string
+	ActorUserID   string
+	ResourceType  string
+	From          *time.Time
+	To            *time.Time // Exclusive upper bound
+}
+
+// AuditLogRepository handles database operations for audit logs
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create stores an audit log entry
+func (r *AuditLogRepository) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// FindAll finds audit log entries with filters, newest first
+func (r *AuditLogRepository) FindAll(filter AuditLogFilter, params utils.PaginationParams) ([]models.AuditLog, int64, error) {
+	var logs []models.AuditLog
+	var total int64
+
+	query := onReplica(r.db).Model(&models.AuditLog{})
+
+	// Apply filters
+	if filter.InstitutionID != "" {
+		query = query.Where("institution_id = ?", filter.InstitutionID)
+	}
+	if filter.ActorUserID != "" {
+		query = query.Where("actor_user_id = ?", filter.ActorUserID)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and ordering
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Order("created_at DESC").Offset(offset).Limit(params.PerPage).Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
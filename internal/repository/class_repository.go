@@ -14,6 +14,15 @@ import (
 type ClassFilter struct {
 	InstitutionID string
 	Search        string
+	CreatedBy     string
+}
+
+// classSortColumns lists the columns classes can be sorted by
+var classSortColumns = map[string]string{
+	"name":       "name",
+	"capacity":   "capacity",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
 }
 
 // ClassRepository handles database operations for classes
@@ -26,10 +35,16 @@ func NewClassRepository(db *gorm.DB) *ClassRepository {
 	return &ClassRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *ClassRepository) WithTx(tx *gorm.DB) *ClassRepository {
+	return &ClassRepository{db: tx}
+}
+
 // FindByID finds a class by ID
 func (r *ClassRepository) FindByID(id uuid.UUID) (*models.Class, error) {
 	var class models.Class
-	err := r.db.Preload("Sections").Preload("ClassTeacher").First(&class, "id = ?", id).Error
+	err := r.db.Preload("Sections").Preload("ClassTeacher").Preload("Creator.Profile").Preload("Updater.Profile").
+		First(&class, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrNotFound
@@ -42,7 +57,7 @@ func (r *ClassRepository) FindByID(id uuid.UUID) (*models.Class, error) {
 // FindByIDWithInstitution finds a class by ID with institution filter
 func (r *ClassRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Class, error) {
 	var class models.Class
-	err := r.db.Preload("Sections").Preload("ClassTeacher").
+	err := r.db.Preload("Sections").Preload("ClassTeacher").Preload("Creator.Profile").Preload("Updater.Profile").
 		First(&class, "id = ? AND institution_id = ?", id, institutionID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -58,24 +73,30 @@ func (r *ClassRepository) FindAll(filter ClassFilter, params utils.PaginationPar
 	var classes []models.Class
 	var total int64
 
-	query := r.db.Model(&models.Class{})
+	query := onReplica(r.db).Model(&models.Class{}).Scopes(models.ScopeTenant(&models.Class{}, filter.InstitutionID))
 
 	// Apply filters
-	if filter.InstitutionID != "" {
-		query = query.Where("institution_id = ?", filter.InstitutionID)
-	}
 	if filter.Search != "" {
 		query = query.Where("name ILIKE ?", "%"+filter.Search+"%")
 	}
+	if filter.CreatedBy != "" {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	// Apply requested sort; the default order breaks ties
+	query, err := utils.ApplySort(query, classSortColumns, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Apply pagination and ordering
 	offset := (params.Page - 1) * params.PerPage
-	err := query.Preload("Sections").Preload("ClassTeacher").
+	err = query.Preload("Sections").Preload("ClassTeacher").Preload("Creator.Profile").Preload("Updater.Profile").
 		Order("name ASC").Offset(offset).Limit(params.PerPage).Find(&classes).Error
 	if err != nil {
 		return nil, 0, err
@@ -98,7 +119,7 @@ func (r *ClassRepository) Create(class *models.Class) error {
 
 // Update updates a class
 func (r *ClassRepository) Update(class *models.Class) error {
-	return r.db.Save(class).Error
+	return saveVersioned(r.db, class, &class.BaseModel)
 }
 
 // Delete soft deletes a class
@@ -118,6 +139,36 @@ func (r *ClassRepository) NameExists(name string, institutionID uuid.UUID, exclu
 	return count > 0, err
 }
 
+// liveSectionCount counts the sections of the class being updated
+const liveSectionCount = "(SELECT COUNT(*) FROM sections WHERE sections.class_id = classes.id AND sections.deleted_at IS NULL)"
+
+// RecountSections sets the section count of a class from its sections
+func (r *ClassRepository) RecountSections(classID uuid.UUID) error {
+	return r.db.Model(&models.Class{}).Where("id = ?", classID).
+		UpdateColumn("section_count", gorm.Expr(liveSectionCount)).Error
+}
+
+// RecountAllSections repairs the section counts of an institution's classes
+// and returns how many classes had a wrong count
+func (r *ClassRepository) RecountAllSections(institutionID uuid.UUID) (int64, error) {
+	result := r.db.Model(&models.Class{}).
+		Where("institution_id = ? AND section_count <> "+liveSectionCount, institutionID).
+		UpdateColumn("section_count", gorm.Expr(liveSectionCount))
+	return result.RowsAffected, result.Error
+}
+
+// CountByClassTeacher counts the classes a teacher leads as class teacher,
+// leaving out the given classes
+func (r *ClassRepository) CountByClassTeacher(teacherID uuid.UUID, excludeClassIDs []uuid.UUID) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.Class{}).Where("class_teacher_id = ?", teacherID)
+	if len(excludeClassIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeClassIDs)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
 // GetClassStudentCount gets the count of students in a class
 func (r *ClassRepository) GetClassStudentCount(classID uuid.UUID) (int64, error) {
 	var count int64
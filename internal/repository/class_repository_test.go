@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"campus-core/internal/models"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestClassRepositoryFindAllTenantScope(t *testing.T) {
+	db := testutil.DB(t)
+	repo := NewClassRepository(db)
+
+	// Both classes share a marker so the search ignores everything else in the database
+	marker := uuid.NewString()[:8]
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+	classA := testutil.Class(t, db, schoolA.ID)
+	classB := testutil.Class(t, db, schoolB.ID)
+	for _, class := range []*models.Class{classA, classB} {
+		if err := db.Model(class).Update("name", class.Name+" "+marker).Error; err != nil {
+			t.Fatalf("failed to rename class: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		institution string
+		want        []uuid.UUID
+	}{
+		{name: "institution A", institution: schoolA.ID.String(), want: []uuid.UUID{classA.ID}},
+		{name: "institution B", institution: schoolB.ID.String(), want: []uuid.UUID{classB.ID}},
+		{name: "super admin without institution", institution: "", want: []uuid.UUID{classA.ID, classB.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := ClassFilter{InstitutionID: tt.institution, Search: marker}
+			classes, total, err := repo.FindAll(filter, utils.PaginationParams{Page: 1, PerPage: 10})
+			if err != nil {
+				t.Fatalf("FindAll() unexpected error: %v", err)
+			}
+			if total != int64(len(tt.want)) || len(classes) != len(tt.want) {
+				t.Fatalf("FindAll() returned %d classes (total %d), want %d", len(classes), total, len(tt.want))
+			}
+			found := make(map[uuid.UUID]bool)
+			for _, class := range classes {
+				found[class.ID] = true
+			}
+			for _, id := range tt.want {
+				if !found[id] {
+					t.Errorf("FindAll() is missing class %s", id)
+				}
+			}
+		})
+	}
+}
+
+func TestClassRepositoryUpdateVersionConflict(t *testing.T) {
+	db := testutil.DB(t)
+	repo := NewClassRepository(db)
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+
+	// Two admins load the class at the same version
+	first, err := repo.FindByIDWithInstitution(class.ID, institution.ID)
+	if err != nil {
+		t.Fatalf("FindByIDWithInstitution() unexpected error: %v", err)
+	}
+	second, err := repo.FindByIDWithInstitution(class.ID, institution.ID)
+	if err != nil {
+		t.Fatalf("FindByIDWithInstitution() unexpected error: %v", err)
+	}
+	read := first.Version
+
+	first.Name = "First edit"
+	if err := repo.Update(first); err != nil {
+		t.Fatalf("Update() of the fresh copy unexpected error: %v", err)
+	}
+	if first.Version != read+1 {
+		t.Errorf("Update() version = %d, want %d", first.Version, read+1)
+	}
+
+	second.Name = "Second edit"
+	if err := repo.Update(second); !errors.Is(err, utils.ErrVersionConflict) {
+		t.Fatalf("Update() of the stale copy error = %v, want ErrVersionConflict", err)
+	}
+	if second.Version != read {
+		t.Errorf("rejected Update() version = %d, want it left at %d", second.Version, read)
+	}
+
+	stored, err := repo.FindByIDWithInstitution(class.ID, institution.ID)
+	if err != nil {
+		t.Fatalf("FindByIDWithInstitution() unexpected error: %v", err)
+	}
+	if stored.Name != "First edit" || stored.Version != read+1 {
+		t.Errorf("stored class = %q at version %d, want %q at version %d", stored.Name, stored.Version, "First edit", read+1)
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DashboardCounts holds the headline numbers of an institution
+type DashboardCounts struct {
+	Students         int64
+	Teachers         int64
+	Parents          int64
+	Classes          int64
+	Subjects         int64
+	TimetableEntries int64
+	ActiveUsers      int64
+	InactiveUsers    int64
+}
+
+// dashboardCountsQuery counts everything in one round trip. Timetable entries
+// are limited to the academic year when one is given.
+const dashboardCountsQuery = `SELECT
+	(SELECT COUNT(*) FROM students WHERE institution_id = @institution AND deleted_at IS NULL) AS students,
+	(SELECT COUNT(*) FROM teachers WHERE institution_id = @institution AND deleted_at IS NULL) AS teachers,
+	(SELECT COUNT(*) FROM parents WHERE institution_id = @institution AND deleted_at IS NULL) AS parents,
+	(SELECT COUNT(*) FROM classes WHERE institution_id = @institution AND deleted_at IS NULL) AS classes,
+	(SELECT COUNT(*) FROM subjects WHERE institution_id = @institution AND deleted_at IS NULL) AS subjects,
+	(SELECT COUNT(*) FROM timetables WHERE institution_id = @institution AND deleted_at IS NULL
+		AND (CAST(@academic_year AS UUID) IS NULL OR academic_year_id = @academic_year)) AS timetable_entries,
+	users.active AS active_users,
+	users.inactive AS inactive_users
+FROM (
+	SELECT COUNT(*) FILTER (WHERE users.is_active) AS active,
+		COUNT(*) FILTER (WHERE NOT users.is_active) AS inactive
+	FROM users
+	JOIN user_profiles ON user_profiles.user_id = users.id AND user_profiles.deleted_at IS NULL
+	WHERE users.deleted_at IS NULL AND user_profiles.institution_id = @institution
+) AS users`
+
+// DashboardRepository handles the aggregate queries behind the admin dashboard
+type DashboardRepository struct {
+	db *gorm.DB
+}
+
+// NewDashboardRepository creates a new dashboard repository
+func NewDashboardRepository(db *gorm.DB) *DashboardRepository {
+	return &DashboardRepository{db: db}
+}
+
+// Counts counts the records of an institution
+func (r *DashboardRepository) Counts(institutionID uuid.UUID, academicYearID *uuid.UUID) (*DashboardCounts, error) {
+	var counts DashboardCounts
+	err := onReplica(r.db).Raw(dashboardCountsQuery, map[string]interface{}{
+		"institution":   institutionID,
+		"academic_year": academicYearID,
+	}).Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"campus-core/internal/models"
 	"campus-core/internal/utils"
@@ -26,6 +27,11 @@ func NewDepartmentRepository(db *gorm.DB) *DepartmentRepository {
 	return &DepartmentRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *DepartmentRepository) WithTx(tx *gorm.DB) *DepartmentRepository {
+	return &DepartmentRepository{db: tx}
+}
+
 // FindByID finds a department by ID
 func (r *DepartmentRepository) FindByID(id uuid.UUID) (*models.Department, error) {
 	var dept models.Department
@@ -58,12 +64,9 @@ func (r *DepartmentRepository) FindAll(filter DepartmentFilter, params utils.Pag
 	var departments []models.Department
 	var total int64
 
-	query := r.db.Model(&models.Department{})
+	query := onReplica(r.db).Model(&models.Department{}).Scopes(models.ScopeTenant(&models.Department{}, filter.InstitutionID))
 
 	// Apply filters
-	if filter.InstitutionID != "" {
-		query = query.Where("institution_id = ?", filter.InstitutionID)
-	}
 	if filter.Search != "" {
 		query = query.Where("name ILIKE ?", "%"+filter.Search+"%")
 	}
@@ -126,3 +129,31 @@ func (r *DepartmentRepository) GetStaffCount(departmentID uuid.UUID) (int64, err
 	err := r.db.Model(&models.Teacher{}).Where("department_id = ?", departmentID).Count(&count).Error
 	return count, err
 }
+
+// FindHeadHistory lists a department's heads, most recent first
+func (r *DepartmentRepository) FindHeadHistory(departmentID uuid.UUID) ([]models.DepartmentHeadHistory, error) {
+	var history []models.DepartmentHeadHistory
+	err := r.db.Where("department_id = ?", departmentID).
+		Preload("Teacher").Preload("Teacher.User").Preload("Teacher.User.Profile").
+		Order("from_date DESC").Find(&history).Error
+	return history, err
+}
+
+// ChangeHead closes the department's open head record at the given time and,
+// when headID is set, opens a new one for that teacher
+func (r *DepartmentRepository) ChangeHead(departmentID uuid.UUID, headID *uuid.UUID, at time.Time) error {
+	err := r.db.Model(&models.DepartmentHeadHistory{}).
+		Where("department_id = ? AND to_date IS NULL", departmentID).
+		Update("to_date", at).Error
+	if err != nil {
+		return err
+	}
+	if headID == nil {
+		return nil
+	}
+	return r.db.Create(&models.DepartmentHeadHistory{
+		DepartmentID: departmentID,
+		TeacherID:    *headID,
+		FromDate:     at,
+	}).Error
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeeStructureFilter holds filter criteria for fee structures
+type FeeStructureFilter struct {
+	InstitutionID  string
+	ClassID        string
+	AcademicYearID string
+}
+
+// FeeStructureRepository handles database operations for fee structures
+type FeeStructureRepository struct {
+	db *gorm.DB
+}
+
+// NewFeeStructureRepository creates a new fee structure repository
+func NewFeeStructureRepository(db *gorm.DB) *FeeStructureRepository {
+	return &FeeStructureRepository{db: db}
+}
+
+// Create creates a new fee structure
+func (r *FeeStructureRepository) Create(fee *models.FeeStructure) error {
+	return r.db.Create(fee).Error
+}
+
+// FindByIDWithInstitution finds a fee structure by ID with institution filter
+func (r *FeeStructureRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.FeeStructure, error) {
+	var fee models.FeeStructure
+	err := r.db.Preload("Class").First(&fee, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &fee, nil
+}
+
+// FindAll finds fee structures with filters
+func (r *FeeStructureRepository) FindAll(filter FeeStructureFilter, params utils.PaginationParams) ([]models.FeeStructure, int64, error) {
+	var fees []models.FeeStructure
+	var total int64
+
+	query := onReplica(r.db).Model(&models.FeeStructure{}).Scopes(models.ScopeTenant(&models.FeeStructure{}, filter.InstitutionID))
+
+	// Apply filters
+	if filter.ClassID != "" {
+		query = query.Where("class_id = ?", filter.ClassID)
+	}
+	if filter.AcademicYearID != "" {
+		query = query.Where("academic_year_id = ?", filter.AcademicYearID)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and ordering
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Preload("Class").
+		Order("due_date ASC NULLS LAST, name ASC").Offset(offset).Limit(params.PerPage).Find(&fees).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return fees, total, nil
+}
+
+// FindActiveByAcademicYear finds the active fee structures of an academic year with their classes
+func (r *FeeStructureRepository) FindActiveByAcademicYear(academicYearID, institutionID uuid.UUID) ([]models.FeeStructure, error) {
+	var fees []models.FeeStructure
+	err := r.db.Preload("Class").
+		Where("academic_year_id = ? AND institution_id = ? AND is_active = ?", academicYearID, institutionID, true).
+		Find(&fees).Error
+	return fees, err
+}
+
+// NameExists checks whether a class already has a fee structure with a name in an academic year
+func (r *FeeStructureRepository) NameExists(name string, classID, academicYearID uuid.UUID, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.FeeStructure{}).
+		Where("LOWER(name) = LOWER(?) AND class_id = ? AND academic_year_id = ?", name, classID, academicYearID)
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// Update updates a fee structure
+func (r *FeeStructureRepository) Update(fee *models.FeeStructure) error {
+	return r.db.Save(fee).Error
+}
+
+// Delete soft deletes a fee structure
+func (r *FeeStructureRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.FeeStructure{}, "id = ?", id).Error
+}
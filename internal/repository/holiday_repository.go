@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// HolidayRepository handles database operations for holidays
+type HolidayRepository struct {
+	db *gorm.DB
+}
+
+// NewHolidayRepository creates a new holiday repository
+func NewHolidayRepository(db *gorm.DB) *HolidayRepository {
+	return &HolidayRepository{db: db}
+}
+
+// Create creates a new holiday
+func (r *HolidayRepository) Create(holiday *models.Holiday) error {
+	return r.db.Create(holiday).Error
+}
+
+// FindByIDWithInstitution finds a holiday by ID with institution filter
+func (r *HolidayRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Holiday, error) {
+	var holiday models.Holiday
+	err := r.db.First(&holiday, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &holiday, nil
+}
+
+// FindByAcademicYear finds all holidays of an academic year ordered by date
+func (r *HolidayRepository) FindByAcademicYear(academicYearID uuid.UUID) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	err := r.db.Where("academic_year_id = ?", academicYearID).Order("date ASC").Find(&holidays).Error
+	return holidays, err
+}
+
+// FindInRange finds the holidays of an institution between two dates (inclusive)
+func (r *HolidayRepository) FindInRange(institutionID uuid.UUID, from, to time.Time) ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	err := r.db.Where("institution_id = ? AND date BETWEEN ? AND ?",
+		institutionID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").Find(&holidays).Error
+	return holidays, err
+}
+
+// DateExists checks whether an institution already has a holiday on a date
+func (r *HolidayRepository) DateExists(institutionID uuid.UUID, date time.Time, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.Holiday{}).
+		Where("institution_id = ? AND date = ?", institutionID, date.Format("2006-01-02"))
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// Update updates a holiday
+func (r *HolidayRepository) Update(holiday *models.Holiday) error {
+	return r.db.Save(holiday).Error
+}
+
+// Delete soft deletes a holiday
+func (r *HolidayRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Holiday{}, "id = ?", id).Error
+}
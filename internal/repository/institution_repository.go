@@ -2,22 +2,33 @@ package repository
 
 import (
 	"errors"
+	"strings"
+	"time"
 
+	"campus-core/internal/database"
 	"campus-core/internal/models"
 	"campus-core/internal/utils"
+	"campus-core/pkg/cache"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// institutionCacheTTL is how long institutions found by ID are cached in Redis
+const institutionCacheTTL = 5 * time.Minute
+
 // InstitutionRepository handles database operations for institutions
 type InstitutionRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	cache *cache.Cache // Institutions by ID; disabled when Redis is not connected
 }
 
 // NewInstitutionRepository creates a new institution repository
 func NewInstitutionRepository(db *gorm.DB) *InstitutionRepository {
-	return &InstitutionRepository{db: db}
+	return &InstitutionRepository{
+		db:    db,
+		cache: cache.New(database.RedisClient, "cache:institution"),
+	}
 }
 
 // Create creates a new institution
@@ -25,15 +36,21 @@ func (r *InstitutionRepository) Create(institution *models.Institution) error {
 	return r.db.Create(institution).Error
 }
 
-// FindByID finds an institution by ID
+// FindByID finds an institution by ID, with its settings
 func (r *InstitutionRepository) FindByID(id uuid.UUID) (*models.Institution, error) {
 	var institution models.Institution
-	if err := r.db.First(&institution, "id = ?", id).Error; err != nil {
+	if r.cache.Get(id.String(), &institution) {
+		return &institution, nil
+	}
+
+	if err := r.db.Preload("Settings").First(&institution, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrInstitutionNotFound
 		}
 		return nil, err
 	}
+
+	r.cache.Set(id.String(), &institution, institutionCacheTTL)
 	return &institution, nil
 }
 
@@ -49,14 +66,56 @@ func (r *InstitutionRepository) FindByCode(code string) (*models.Institution, er
 	return &institution, nil
 }
 
+// RequiresEmailVerification reports whether an institution rejects logins from unverified users
+func (r *InstitutionRepository) RequiresEmailVerification(id uuid.UUID) (bool, error) {
+	var institution models.Institution
+	err := r.db.Select("require_email_verification").First(&institution, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return institution.RequireEmailVerification, nil
+}
+
+// FindSettings finds the settings of an institution
+func (r *InstitutionRepository) FindSettings(institutionID uuid.UUID) (*models.InstitutionSettings, error) {
+	var settings models.InstitutionSettings
+	if err := r.db.First(&settings, "institution_id = ?", institutionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveSettings creates or updates the settings of an institution
+func (r *InstitutionRepository) SaveSettings(settings *models.InstitutionSettings) error {
+	if err := r.db.Save(settings).Error; err != nil {
+		return err
+	}
+	r.cache.Delete(settings.InstitutionID.String())
+	return nil
+}
+
 // Update updates an institution
 func (r *InstitutionRepository) Update(institution *models.Institution) error {
-	return r.db.Save(institution).Error
+	if err := r.db.Save(institution).Error; err != nil {
+		return err
+	}
+	r.cache.Delete(institution.ID.String())
+	return nil
 }
 
 // Delete deletes an institution
 func (r *InstitutionRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Institution{}, "id = ?", id).Error
+	if err := r.db.Delete(&models.Institution{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+	r.cache.Delete(id.String())
+	return nil
 }
 
 // FindAll returns a list of institutions with pagination
@@ -65,12 +124,12 @@ func (r *InstitutionRepository) FindAll(params utils.PaginationParams) ([]models
 	var total int64
 
 	// Count total
-	if err := r.db.Model(&models.Institution{}).Count(&total).Error; err != nil {
+	if err := onReplica(r.db).Model(&models.Institution{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated data
-	err := r.db.Scopes(utils.Paginate(params)).Find(&institutions).Error
+	err := onReplica(r.db).Scopes(utils.Paginate(params)).Find(&institutions).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -109,6 +168,72 @@ func (r *InstitutionRepository) GetStats(id uuid.UUID) (*models.InstitutionStats
 	return &stats, nil
 }
 
+// GetDetailedStats returns student counts per class, section and gender and teacher counts
+// per department. Classes, sections and departments without members are reported with zero.
+func (r *InstitutionRepository) GetDetailedStats(id uuid.UUID) (*models.InstitutionDetailedStats, error) {
+	stats := models.InstitutionDetailedStats{
+		InstitutionID:        id,
+		StudentsByClass:      make([]models.ClassStudentCount, 0),
+		StudentsBySection:    make([]models.SectionStudentCount, 0),
+		StudentsByGender:     make([]models.GenderStudentCount, 0),
+		TeachersByDepartment: make([]models.DepartmentTeacherCount, 0),
+	}
+
+	err := r.db.Table("classes").
+		Select("classes.id AS class_id, classes.name AS class_name, COUNT(students.id) AS students").
+		Joins("LEFT JOIN students ON students.class_id = classes.id AND students.institution_id = classes.institution_id AND students.deleted_at IS NULL").
+		Where("classes.institution_id = ? AND classes.deleted_at IS NULL", id).
+		Group("classes.id, classes.name").
+		Order("classes.name ASC").
+		Scan(&stats.StudentsByClass).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Table("sections").
+		Select("classes.id AS class_id, classes.name AS class_name, sections.id AS section_id, sections.name AS section_name, COUNT(students.id) AS students").
+		Joins("JOIN classes ON classes.id = sections.class_id").
+		Joins("LEFT JOIN students ON students.section_id = sections.id AND students.institution_id = classes.institution_id AND students.deleted_at IS NULL").
+		Where("classes.institution_id = ? AND classes.deleted_at IS NULL AND sections.deleted_at IS NULL", id).
+		Group("classes.id, classes.name, sections.id, sections.name").
+		Order("classes.name ASC, sections.name ASC").
+		Scan(&stats.StudentsBySection).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Table("students").
+		Select("COALESCE(NULLIF(user_profiles.gender, ''), 'unspecified') AS gender, COUNT(students.id) AS students").
+		Joins("LEFT JOIN user_profiles ON user_profiles.user_id = students.user_id AND user_profiles.deleted_at IS NULL").
+		Where("students.institution_id = ? AND students.deleted_at IS NULL", id).
+		Group("1").
+		Order("gender ASC").
+		Scan(&stats.StudentsByGender).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Table("departments").
+		Select("departments.id AS department_id, departments.name AS department_name, COUNT(teachers.id) AS teachers").
+		Joins("LEFT JOIN teachers ON teachers.department_id = departments.id AND teachers.institution_id = departments.institution_id AND teachers.deleted_at IS NULL").
+		Where("departments.institution_id = ? AND departments.deleted_at IS NULL", id).
+		Group("departments.id, departments.name").
+		Order("departments.name ASC").
+		Scan(&stats.TeachersByDepartment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Model(&models.Teacher{}).
+		Where("institution_id = ? AND department_id IS NULL", id).
+		Count(&stats.UnassignedTeachers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
 // CodeExists checks if a code already exists
 func (r *InstitutionRepository) CodeExists(code string) (bool, error) {
 	var count int64
@@ -116,77 +241,168 @@ func (r *InstitutionRepository) CodeExists(code string) (bool, error) {
 	return count > 0, err
 }
 
-// GetAdmins returns all admin users for an institution
-func (r *InstitutionRepository) GetAdmins(institutionID uuid.UUID) ([]models.User, error) {
+// AdminFilter holds filter criteria for an institution's admins
+type AdminFilter struct {
+	Search     string // Search in email and name
+	ActiveOnly bool
+}
+
+// GetAdmins returns a page of the admin users of an institution, oldest first
+func (r *InstitutionRepository) GetAdmins(institutionID uuid.UUID, filter AdminFilter, params utils.PaginationParams) ([]models.User, int64, error) {
 	var users []models.User
+	var total int64
 
-	err := r.db.Preload("Profile").
+	query := r.db.Model(&models.User{}).
 		Joins("INNER JOIN user_profiles ON user_profiles.user_id = users.id").
-		Where("user_profiles.institution_id = ? AND users.role = ?", institutionID, models.RoleAdmin).
-		Find(&users).Error
+		Where("user_profiles.institution_id = ? AND users.role = ?", institutionID, models.RoleAdmin)
+
+	// Apply filters
+	if filter.ActiveOnly {
+		query = query.Where("users.is_active = ?", true)
+	}
+	if filter.Search != "" {
+		search := "%" + strings.ToLower(filter.Search) + "%"
+		query = query.Where(
+			"LOWER(users.email) LIKE ? OR LOWER(user_profiles.first_name) LIKE ? OR LOWER(user_profiles.last_name) LIKE ?",
+			search, search, search,
+		)
+	}
 
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and ordering
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Preload("Profile").
+		Order("users.created_at ASC").Order("users.id ASC").
+		Offset(offset).Limit(params.PerPage).Find(&users).Error
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
 // CreateAdmin creates a new admin user for an institution
 func (r *InstitutionRepository) CreateAdmin(institutionID uuid.UUID, email, firstName, lastName, password, phone string) (*models.User, error) {
-	// Check if email already exists
-	var count int64
-	if err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+	hashedPassword, phone, err := r.prepareAdmin(email, password, phone)
+	if err != nil {
 		return nil, err
 	}
-	if count > 0 {
-		return nil, utils.ErrEmailAlreadyExists
+
+	var user *models.User
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		user, err = createAdmin(tx, institutionID, email, firstName, lastName, hashedPassword, phone)
+		return err
+	})
+	if err != nil {
+		return nil, createAdminError(err)
 	}
 
-	// Hash password
-	hashedPassword, err := utils.HashPassword(password)
+	return user, nil
+}
+
+// CreateWithAdmin creates an institution, its settings and its first admin user
+// in one transaction, so the institution is rolled back if the admin can't be created
+func (r *InstitutionRepository) CreateWithAdmin(institution *models.Institution, email, firstName, lastName, password, phone string) (*models.User, error) {
+	hashedPassword, phone, err := r.prepareAdmin(email, password, phone)
 	if err != nil {
-		return nil, utils.ErrInternalServer.Wrap(err)
+		return nil, err
 	}
 
 	var user *models.User
 	err = r.db.Transaction(func(tx *gorm.DB) error {
-		// Create user
-		user = &models.User{
-			BaseModel: models.BaseModel{
-				ID: uuid.New(),
-			},
-			Email:        email,
-			Phone:        phone,
-			PasswordHash: hashedPassword,
-			Role:         models.RoleAdmin,
-			IsActive:     true,
-		}
-		if err := tx.Create(user).Error; err != nil {
+		if err := tx.Create(institution).Error; err != nil {
 			return err
 		}
+		user, err = createAdmin(tx, institution.ID, email, firstName, lastName, hashedPassword, phone)
+		return err
+	})
+	if err != nil {
+		return nil, createAdminError(err)
+	}
 
-		// Create profile
-		profile := &models.UserProfile{
-			BaseModel: models.BaseModel{
-				ID: uuid.New(),
-			},
-			UserID:        user.ID,
-			InstitutionID: &institutionID,
-			FirstName:     firstName,
-			LastName:      lastName,
+	return user, nil
+}
+
+// prepareAdmin checks that an admin email and phone are free, hashes the password
+// and returns the phone in E.164 form
+func (r *InstitutionRepository) prepareAdmin(email, password, phone string) (string, string, error) {
+	// Check if email already exists
+	var count int64
+	if err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return "", "", err
+	}
+	if count > 0 {
+		return "", "", utils.ErrEmailAlreadyExists
+	}
+
+	if phone != "" {
+		normalized, err := utils.NormalizePhone(phone)
+		if err != nil {
+			return "", "", err
 		}
-		if err := tx.Create(profile).Error; err != nil {
-			return err
+		if err := r.db.Model(&models.User{}).Where("phone = ?", normalized).Count(&count).Error; err != nil {
+			return "", "", err
 		}
+		if count > 0 {
+			return "", "", utils.ErrPhoneAlreadyExists
+		}
+		phone = normalized
+	}
 
-		user.Profile = profile
-		return nil
-	})
-
+	hashedPassword, err := utils.HashPassword(password)
 	if err != nil {
-		return nil, utils.ErrInternalServer.Wrap(err)
+		return "", "", utils.ErrInternalServer.Wrap(err)
+	}
+	return hashedPassword, phone, nil
+}
+
+// createAdminError reports a duplicate institution code or email that slipped
+// past the checks as a conflict, and any other failure as an internal error
+func createAdminError(err error) error {
+	switch {
+	case utils.IsUniqueViolation(err, models.InstitutionCodeConstraint):
+		return utils.ErrInstitutionCodeExists
+	case utils.IsUniqueViolation(err, models.UserEmailIndex):
+		return utils.ErrEmailAlreadyExists
+	default:
+		return utils.ErrInternalServer.Wrap(err)
+	}
+}
+
+// createAdmin creates an admin user and profile for an institution
+func createAdmin(tx *gorm.DB, institutionID uuid.UUID, email, firstName, lastName, hashedPassword, phone string) (*models.User, error) {
+	user := &models.User{
+		BaseModel: models.BaseModel{
+			ID: uuid.New(),
+		},
+		Email:              email,
+		Phone:              phone,
+		PasswordHash:       hashedPassword,
+		MustChangePassword: true,
+		Role:               models.RoleAdmin,
+		IsActive:           true,
+	}
+	if err := tx.Create(user).Error; err != nil {
+		return nil, err
+	}
+
+	profile := &models.UserProfile{
+		BaseModel: models.BaseModel{
+			ID: uuid.New(),
+		},
+		UserID:        user.ID,
+		InstitutionID: &institutionID,
+		FirstName:     firstName,
+		LastName:      lastName,
+	}
+	if err := tx.Create(profile).Error; err != nil {
+		return nil, err
 	}
 
+	user.Profile = profile
 	return user, nil
 }
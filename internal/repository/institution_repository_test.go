@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/lib/pq"
+)
+
+func TestCreateAdminError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   string
+		wantStatus int
+	}{
+		{
+			name:       "duplicate institution code",
+			err:        &pq.Error{Code: "23505", Constraint: models.InstitutionCodeConstraint},
+			wantCode:   utils.ErrInstitutionCodeExists.Code,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "duplicate admin email",
+			err:        fmt.Errorf("create admin: %w", &pq.Error{Code: "23505", Constraint: models.UserEmailIndex}),
+			wantCode:   utils.ErrEmailAlreadyExists.Code,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "unrelated unique violation",
+			err:        &pq.Error{Code: "23505", Constraint: "idx_users_phone"},
+			wantCode:   utils.ErrInternalServer.Code,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "other database error",
+			err:        errors.New("connection reset"),
+			wantCode:   utils.ErrInternalServer.Code,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := createAdminError(tt.err)
+			var appErr *utils.AppError
+			if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+				t.Fatalf("createAdminError() = %v, want code %s", err, tt.wantCode)
+			}
+			if status := utils.StatusForError(err); status != tt.wantStatus {
+				t.Errorf("createAdminError() status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LeaveFilter holds filter criteria for leave applications
+type LeaveFilter struct {
+	InstitutionID   string
+	ApplicantUserID string
+	Status          string
+}
+
+// LeaveRepository handles database operations for leave applications
+type LeaveRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaveRepository creates a new leave repository
+func NewLeaveRepository(db *gorm.DB) *LeaveRepository {
+	return &LeaveRepository{db: db}
+}
+
+// Create creates a new leave application
+func (r *LeaveRepository) Create(leave *models.LeaveApplication) error {
+	return r.db.Create(leave).Error
+}
+
+// FindByIDWithInstitution finds a leave application by ID with institution filter
+func (r *LeaveRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.LeaveApplication, error) {
+	var leave models.LeaveApplication
+	err := r.db.Preload("Applicant.Profile").
+		First(&leave, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &leave, nil
+}
+
+// FindAll finds leave applications with filters, latest starting first
+func (r *LeaveRepository) FindAll(filter LeaveFilter, params utils.PaginationParams) ([]models.LeaveApplication, int64, error) {
+	var leaves []models.LeaveApplication
+	var total int64
+
+	query := onReplica(r.db).Model(&models.LeaveApplication{}).Scopes(utils.TenantScope(filter.InstitutionID))
+
+	// Apply filters
+	if filter.ApplicantUserID != "" {
+		query = query.Where("user_id = ?", filter.ApplicantUserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and ordering
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Preload("Applicant.Profile").
+		Order("start_date DESC").Order("created_at DESC").
+		Offset(offset).Limit(params.PerPage).Find(&leaves).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return leaves, total, nil
+}
+
+// HasOverlappingPending checks whether a user has a pending application
+// sharing at least one day with the given inclusive date range
+func (r *LeaveRepository) HasOverlappingPending(userID uuid.UUID, from, to time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.LeaveApplication{}).
+		Where("user_id = ? AND status = ?", userID, models.LeaveStatusPending).
+		Where("start_date <= ? AND end_date >= ?", to, from).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update updates a leave application, failing with a version conflict when it
+// was changed since it was read
+func (r *LeaveRepository) Update(leave *models.LeaveApplication) error {
+	return saveVersioned(r.db, leave, &leave.BaseModel)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NoticeFilter holds filter criteria for notices
+type NoticeFilter struct {
+	InstitutionID string
+	Role          string     // Only notices whose audience includes this role
+	VisibleAt     *time.Time // Only notices published and not expired at this time
+}
+
+// NoticeRepository handles database operations for notices
+type NoticeRepository struct {
+	db *gorm.DB
+}
+
+// NewNoticeRepository creates a new notice repository
+func NewNoticeRepository(db *gorm.DB) *NoticeRepository {
+	return &NoticeRepository{db: db}
+}
+
+// Create creates a new notice
+func (r *NoticeRepository) Create(notice *models.Notice) error {
+	return r.db.Create(notice).Error
+}
+
+// FindByIDWithInstitution finds a notice by ID with institution filter
+func (r *NoticeRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Notice, error) {
+	var notice models.Notice
+	err := r.db.First(&notice, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &notice, nil
+}
+
+// FindAll finds notices with filters, most recently published first
+func (r *NoticeRepository) FindAll(filter NoticeFilter, params utils.PaginationParams) ([]models.Notice, int64, error) {
+	var notices []models.Notice
+	var total int64
+
+	query := onReplica(r.db).Model(&models.Notice{}).Scopes(utils.TenantScope(filter.InstitutionID))
+
+	// Apply filters
+	if filter.Role != "" {
+		query = query.Where("? = ANY(target_audience)", filter.Role)
+	}
+	if filter.VisibleAt != nil {
+		query = query.Where("published_at <= ? AND (expires_at IS NULL OR expires_at > ?)", *filter.VisibleAt, *filter.VisibleAt)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination and ordering
+	offset := (params.Page - 1) * params.PerPage
+	err := query.Order("published_at DESC").Offset(offset).Limit(params.PerPage).Find(&notices).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notices, total, nil
+}
+
+// Delete soft deletes a notice
+func (r *NoticeRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Notice{}, "id = ?", id).Error
+}
@@ -38,7 +38,7 @@ func (r *ParentRepository) FindAll(institutionID string, params utils.Pagination
 	var parents []models.Parent
 	var total int64
 
-	db := r.db.Model(&models.Parent{}).Preload("User.Profile")
+	db := onReplica(r.db).Model(&models.Parent{}).Preload("User.Profile")
 
 	if institutionID != "" {
 		db = db.Where("institution_id = ?", institutionID)
@@ -62,3 +62,13 @@ func (r *ParentRepository) Update(parent *models.Parent) error {
 func (r *ParentRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Parent{}, "id = ?", id).Error
 }
+
+// IsParentOf checks whether the parent with the given user ID is linked to a student
+func (r *ParentRepository) IsParentOf(userID, studentID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ParentStudentRelation{}).
+		Joins("JOIN parents ON parents.id = parent_student_relations.parent_id AND parents.deleted_at IS NULL").
+		Where("parents.user_id = ? AND parent_student_relations.student_id = ?", userID, studentID).
+		Count(&count).Error
+	return count > 0, err
+}
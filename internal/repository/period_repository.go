@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PeriodRepository handles database operations for the periods of the school day
+type PeriodRepository struct {
+	db *gorm.DB
+}
+
+// NewPeriodRepository creates a new period repository
+func NewPeriodRepository(db *gorm.DB) *PeriodRepository {
+	return &PeriodRepository{db: db}
+}
+
+// Create creates a new period
+func (r *PeriodRepository) Create(period *models.Period) error {
+	return r.db.Create(period).Error
+}
+
+// FindByIDWithInstitution finds a period by ID with institution filter
+func (r *PeriodRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Period, error) {
+	var period models.Period
+	err := r.db.First(&period, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+// FindByInstitution finds the periods of an institution in day order
+func (r *PeriodRepository) FindByInstitution(institutionID uuid.UUID) ([]models.Period, error) {
+	var periods []models.Period
+	err := r.db.Where("institution_id = ?", institutionID).Order(`"order" ASC, start_time ASC`).Find(&periods).Error
+	return periods, err
+}
+
+// Update updates a period
+func (r *PeriodRepository) Update(period *models.Period) error {
+	return r.db.Save(period).Error
+}
+
+// Delete soft deletes a period
+func (r *PeriodRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Period{}, "id = ?", id).Error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"campus-core/internal/database"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// onReplica sends a query to the read replica when one is configured. Only
+// reads that can tolerate replication lag are replica-safe: the FindAll
+// listings and the dashboard counts, which only feed what a client displays.
+// Lookups a write depends on, such as the FindByID calls before an update or
+// the existence checks before a create, stay on the primary, and a query run
+// in a transaction always uses the primary.
+func onReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(database.ReplicaResolver))
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoleRepository handles database operations for roles and permissions
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+// Create creates a new role with its permissions
+func (r *RoleRepository) Create(role *models.Role) error {
+	return r.db.Create(role).Error
+}
+
+// FindByID finds a role by ID with its permissions
+func (r *RoleRepository) FindByID(id uuid.UUID) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions").First(&role, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindBuiltInByCode finds a built-in role by its code with its permissions
+func (r *RoleRepository) FindBuiltInByCode(code string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions").First(&role, "code = ? AND institution_id IS NULL", code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindAll finds the built-in roles and the custom roles of an institution
+func (r *RoleRepository) FindAll(institutionID *uuid.UUID) ([]models.Role, error) {
+	var roles []models.Role
+	query := r.db.Preload("Permissions")
+	if institutionID != nil {
+		query = query.Where("institution_id IS NULL OR institution_id = ?", *institutionID)
+	} else {
+		query = query.Where("institution_id IS NULL")
+	}
+	err := query.Order("is_built_in DESC, name ASC").Find(&roles).Error
+	return roles, err
+}
+
+// CodeExists checks if a role code is taken within an institution or by a built-in role
+func (r *RoleRepository) CodeExists(institutionID uuid.UUID, code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Role{}).
+		Where("code = ? AND (institution_id IS NULL OR institution_id = ?)", code, institutionID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ReplacePermissions replaces the permissions of a role
+func (r *RoleRepository) ReplacePermissions(role *models.Role, permissions []models.Permission) error {
+	return r.db.Model(role).Association("Permissions").Replace(permissions)
+}
+
+// FindPermissionsByCodes finds permissions by their codes
+func (r *RoleRepository) FindPermissionsByCodes(codes []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(codes) == 0 {
+		return permissions, nil
+	}
+	err := r.db.Where("code IN ?", codes).Find(&permissions).Error
+	return permissions, err
+}
+
+// FindAllPermissions finds all permissions
+func (r *RoleRepository) FindAllPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := r.db.Order("code ASC").Find(&permissions).Error
+	return permissions, err
+}
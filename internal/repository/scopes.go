@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // TenantScope filters queries by institution_id
@@ -23,3 +27,24 @@ func TenantScope(institutionID string) func(db *gorm.DB) *gorm.DB {
 func ActiveScope(db *gorm.DB) *gorm.DB {
 	return db.Where("is_active = ?", true)
 }
+
+// saveVersioned saves all fields of a model read at base.Version and increments
+// the version. It returns utils.ErrVersionConflict when the row has been updated
+// since it was read, leaving the version unchanged. Associations are not saved.
+func saveVersioned(db *gorm.DB, model interface{}, base *models.BaseModel) error {
+	read := base.Version
+	base.Version++
+
+	result := db.Model(model).Select("*").Omit(clause.Associations).
+		Where("version = ?", read).
+		Updates(model)
+	if result.Error != nil {
+		base.Version = read
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		base.Version = read
+		return utils.ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SearchableRoles are the roles covered by the global search
+var SearchableRoles = []string{models.RoleStudent, models.RoleTeacher, models.RoleParent}
+
+// SearchFilter holds criteria for the global search
+type SearchFilter struct {
+	InstitutionID string
+	Term          string
+	Role          string // Limits results to one of SearchableRoles
+}
+
+// SearchResult is a user matched by the global search
+type SearchResult struct {
+	UserID          uuid.UUID
+	RecordID        uuid.UUID // ID in the students, teachers or parents table
+	Role            string
+	FirstName       string
+	LastName        string
+	Email           string
+	Phone           string
+	AdmissionNumber string
+	RollNumber      *int
+	ClassID         *uuid.UUID
+	SectionID       *uuid.UUID
+}
+
+// SearchRepository handles the global search across students, teachers and parents
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search finds students, teachers and parents matching the filter, ordered by name
+func (r *SearchRepository) Search(filter SearchFilter, params utils.PaginationParams) ([]SearchResult, int64, error) {
+	var results []SearchResult
+	var total int64
+
+	query := r.db.Table("users").
+		Joins("JOIN user_profiles ON user_profiles.user_id = users.id AND user_profiles.deleted_at IS NULL").
+		Joins("LEFT JOIN students ON students.user_id = users.id AND students.deleted_at IS NULL").
+		Joins("LEFT JOIN teachers ON teachers.user_id = users.id AND teachers.deleted_at IS NULL").
+		Joins("LEFT JOIN parents ON parents.user_id = users.id AND parents.deleted_at IS NULL").
+		Where("users.deleted_at IS NULL").
+		Where("COALESCE(students.id, teachers.id, parents.id) IS NOT NULL")
+
+	if filter.Role != "" {
+		query = query.Where("users.role = ?", filter.Role)
+	} else {
+		query = query.Where("users.role IN ?", SearchableRoles)
+	}
+	if filter.InstitutionID != "" {
+		query = query.Where("user_profiles.institution_id = ?", filter.InstitutionID)
+	}
+	query = searchCondition(query, filter.Term)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Select(`users.id AS user_id,
+			COALESCE(students.id, teachers.id, parents.id) AS record_id,
+			users.role, users.email, users.phone,
+			user_profiles.first_name, user_profiles.last_name, user_profiles.admission_number,
+			students.roll_number, students.class_id, students.section_id`).
+		Order("user_profiles.first_name ASC, user_profiles.last_name ASC, users.id ASC").
+		Scopes(utils.Paginate(params)).
+		Scan(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// searchCondition matches the term case-insensitively against names, contact
+// details, admission numbers and (exactly) roll numbers. Matching lives only
+// here so it can later move to a tsvector column with a GIN index.
+func searchCondition(db *gorm.DB, term string) *gorm.DB {
+	return db.Where(`(user_profiles.first_name ILIKE @like
+		OR user_profiles.last_name ILIKE @like
+		OR CONCAT_WS(' ', user_profiles.first_name, user_profiles.last_name) ILIKE @like
+		OR users.email ILIKE @like
+		OR users.phone ILIKE @like
+		OR user_profiles.admission_number ILIKE @like
+		OR CAST(students.roll_number AS TEXT) = @term)`,
+		map[string]interface{}{"like": "%" + term + "%", "term": term})
+}
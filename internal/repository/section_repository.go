@@ -26,6 +26,11 @@ func NewSectionRepository(db *gorm.DB) *SectionRepository {
 	return &SectionRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *SectionRepository) WithTx(tx *gorm.DB) *SectionRepository {
+	return &SectionRepository{db: tx}
+}
+
 // FindByID finds a section by ID
 func (r *SectionRepository) FindByID(id uuid.UUID) (*models.Section, error) {
 	var section models.Section
@@ -51,7 +56,7 @@ func (r *SectionRepository) FindAll(filter SectionFilter, params utils.Paginatio
 	var sections []models.Section
 	var total int64
 
-	query := r.db.Model(&models.Section{})
+	query := onReplica(r.db).Model(&models.Section{})
 
 	// Apply filters
 	if filter.ClassID != "" {
@@ -104,6 +109,15 @@ func (r *SectionRepository) NameExistsInClass(name string, classID uuid.UUID, ex
 	return count > 0, err
 }
 
+// BelongsToClass checks if a section belongs to a class
+func (r *SectionRepository) BelongsToClass(sectionID, classID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Section{}).
+		Where("id = ? AND class_id = ?", sectionID, classID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // GetSectionStudentCount gets the count of students in a section
 func (r *SectionRepository) GetSectionStudentCount(sectionID uuid.UUID) (int64, error) {
 	var count int64
@@ -111,6 +125,37 @@ func (r *SectionRepository) GetSectionStudentCount(sectionID uuid.UUID) (int64,
 	return count, err
 }
 
+// StudentCountsByClass counts the students of each section of a class in one
+// query. Sections without students are included with a count of zero.
+func (r *SectionRepository) StudentCountsByClass(classID uuid.UUID) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		SectionID uuid.UUID
+		Count     int64
+	}
+	err := r.db.Model(&models.Section{}).
+		Select("sections.id AS section_id, COUNT(students.id) AS count").
+		Joins("LEFT JOIN students ON students.section_id = sections.id AND students.deleted_at IS NULL").
+		Where("sections.class_id = ?", classID).
+		Group("sections.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.SectionID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetSectionTimetableCount gets the count of timetable entries scheduled for a section
+func (r *SectionRepository) GetSectionTimetableCount(sectionID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Timetable{}).Where("section_id = ?", sectionID).Count(&count).Error
+	return count, err
+}
+
 // GetSectionStudents gets all students in a section
 func (r *SectionRepository) GetSectionStudents(sectionID uuid.UUID) ([]models.Student, error) {
 	var students []models.Student
@@ -8,8 +8,28 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// StudentFilter holds filter criteria for students
+type StudentFilter struct {
+	InstitutionID string
+	ClassID       string
+	SectionID     string
+	CreatedBy     string
+	BloodGroup    string
+	// HasMedicalInfo restricts to students with (true) or without (false) recorded medical info
+	HasMedicalInfo *bool
+}
+
+// studentSortColumns lists the columns students can be sorted by
+var studentSortColumns = map[string]string{
+	"roll_number":    "roll_number",
+	"admission_date": "admission_date",
+	"created_at":     "created_at",
+	"updated_at":     "updated_at",
+}
+
 // StudentRepository handles student data
 type StudentRepository struct {
 	db *gorm.DB
@@ -19,13 +39,19 @@ func NewStudentRepository(db *gorm.DB) *StudentRepository {
 	return &StudentRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *StudentRepository) WithTx(tx *gorm.DB) *StudentRepository {
+	return &StudentRepository{db: tx}
+}
+
 func (r *StudentRepository) Create(student *models.Student) error {
 	return r.db.Create(student).Error
 }
 
 func (r *StudentRepository) FindByID(id uuid.UUID) (*models.Student, error) {
 	var student models.Student
-	if err := r.db.Preload("User.Profile").First(&student, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("User.Profile").Preload("Creator.Profile").Preload("Updater.Profile").
+		First(&student, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrResourceNotFound
 		}
@@ -53,30 +79,162 @@ func (r *StudentRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Student{}, "id = ?", id).Error
 }
 
-// FindAll returns filtered students (class, section filters can be added)
-func (r *StudentRepository) FindAll(institutionID string, classID, sectionID string, params utils.PaginationParams) ([]models.Student, int64, error) {
+// rollScope restricts a query to the students sharing roll numbers with a
+// class and section: the section when given, otherwise the class's students
+// without a section
+func rollScope(db *gorm.DB, classID uuid.UUID, sectionID *uuid.UUID) *gorm.DB {
+	db = db.Model(&models.Student{}).Where("class_id = ?", classID)
+	if sectionID != nil {
+		return db.Where("section_id = ?", *sectionID)
+	}
+	return db.Where("section_id IS NULL")
+}
+
+// RollNumberExists checks if a roll number is taken in a class and section,
+// ignoring the student excludeID
+func (r *StudentRepository) RollNumberExists(classID uuid.UUID, sectionID *uuid.UUID, roll int, excludeID *uuid.UUID) (bool, error) {
+	var count int64
+	db := rollScope(r.db, classID, sectionID).Where("roll_number = ?", roll)
+	if excludeID != nil {
+		db = db.Where("id != ?", *excludeID)
+	}
+	err := db.Count(&count).Error
+	return count > 0, err
+}
+
+// NextRollNumber returns the roll number after the highest one in a class and section
+func (r *StudentRepository) NextRollNumber(classID uuid.UUID, sectionID *uuid.UUID) (int, error) {
+	var max int
+	err := rollScope(r.db, classID, sectionID).Select("COALESCE(MAX(roll_number), 0)").Scan(&max).Error
+	return max + 1, err
+}
+
+// RenumberRolls numbers the students of a class from 1 by first and last
+// name, separately for each section. With a section, only that section is
+// renumbered. The class row is locked while renumbering, as when roll numbers
+// are assigned. Returns the number of students renumbered.
+func (r *StudentRepository) RenumberRolls(classID uuid.UUID, sectionID *uuid.UUID) (int64, error) {
+	var renumbered int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var class models.Class
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&class, "id = ?", classID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrResourceNotFound
+			}
+			return err
+		}
+
+		ranked := tx.Table("students").
+			Select(`students.id, ROW_NUMBER() OVER (
+				PARTITION BY students.section_id
+				ORDER BY LOWER(user_profiles.first_name), LOWER(user_profiles.last_name), students.created_at, students.id
+			) AS roll`).
+			Joins("LEFT JOIN user_profiles ON user_profiles.user_id = students.user_id AND user_profiles.deleted_at IS NULL").
+			Where("students.deleted_at IS NULL AND students.class_id = ?", classID)
+		if sectionID != nil {
+			ranked = ranked.Where("students.section_id = ?", *sectionID)
+		}
+
+		result := tx.Exec(`UPDATE students SET roll_number = ranked.roll, updated_at = NOW()
+			FROM (?) AS ranked WHERE students.id = ranked.id`, ranked)
+		renumbered = result.RowsAffected
+		return result.Error
+	})
+	return renumbered, err
+}
+
+// FindAll returns filtered students
+func (r *StudentRepository) FindAll(filter StudentFilter, params utils.PaginationParams) ([]models.Student, int64, error) {
 	var students []models.Student
 	var total int64
 
-	db := r.db.Model(&models.Student{}).Preload("User.Profile")
+	db := onReplica(r.db).Model(&models.Student{}).Preload("User.Profile").Preload("Creator.Profile").Preload("Updater.Profile")
 
-	if institutionID != "" {
-		db = db.Where("institution_id = ?", institutionID)
+	if filter.InstitutionID != "" {
+		db = db.Where("institution_id = ?", filter.InstitutionID)
+	}
+	if filter.ClassID != "" {
+		db = db.Where("class_id = ?", filter.ClassID)
 	}
-	if classID != "" {
-		db = db.Where("class_id = ?", classID)
+	if filter.SectionID != "" {
+		db = db.Where("section_id = ?", filter.SectionID)
 	}
-	if sectionID != "" {
-		db = db.Where("section_id = ?", sectionID)
+	if filter.CreatedBy != "" {
+		db = db.Where("created_by = ?", filter.CreatedBy)
+	}
+	if filter.BloodGroup != "" {
+		db = db.Where("UPPER(blood_group) = UPPER(?)", filter.BloodGroup)
+	}
+	if filter.HasMedicalInfo != nil {
+		if *filter.HasMedicalInfo {
+			db = db.Where("TRIM(COALESCE(medical_info, '')) <> ''")
+		} else {
+			db = db.Where("TRIM(COALESCE(medical_info, '')) = ''")
+		}
 	}
 
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := db.Scopes(utils.Paginate(params)).Find(&students).Error; err != nil {
+	db, err := utils.ApplySort(db, studentSortColumns, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := db.Order("created_at ASC").Scopes(utils.Paginate(params)).Find(&students).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return students, total, nil
 }
+
+// FindByClassID returns a page of the students of a class ordered by roll number
+func (r *StudentRepository) FindByClassID(classID uuid.UUID, params utils.PaginationParams) ([]models.Student, int64, error) {
+	return r.findByRoll(r.db.Where("class_id = ?", classID), params)
+}
+
+// FindBySectionID returns a page of the students of a section ordered by roll number
+func (r *StudentRepository) FindBySectionID(sectionID uuid.UUID, params utils.PaginationParams) ([]models.Student, int64, error) {
+	return r.findByRoll(r.db.Where("section_id = ?", sectionID), params)
+}
+
+// findByRoll counts and pages the students matched by db in roll number order.
+// Students without a roll number come last.
+func (r *StudentRepository) findByRoll(db *gorm.DB, params utils.PaginationParams) ([]models.Student, int64, error) {
+	var students []models.Student
+	var total int64
+
+	db = db.Model(&models.Student{})
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Preload("User.Profile").
+		Order("NULLIF(roll_number, 0) ASC NULLS LAST, created_at ASC").
+		Scopes(utils.Paginate(params)).Find(&students).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return students, total, nil
+}
+
+// FindAllCursor returns up to limit students after the cursor, ordered by (created_at, id).
+// Keyset pagination keeps pages stable when students are added while a client scrolls.
+func (r *StudentRepository) FindAllCursor(institutionID string, after *utils.Cursor, limit int) ([]models.Student, error) {
+	var students []models.Student
+
+	db := r.db.Model(&models.Student{}).Preload("User.Profile")
+	if institutionID != "" {
+		db = db.Where("institution_id = ?", institutionID)
+	}
+	if after != nil {
+		db = db.Where("(created_at, id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	err := db.Order("created_at ASC, id ASC").Limit(limit).Find(&students).Error
+	if err != nil {
+		return nil, err
+	}
+	return students, nil
+}
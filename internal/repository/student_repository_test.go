@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// studentCreatedAt creates a student with the given creation time
+func studentCreatedAt(t *testing.T, db *gorm.DB, institutionID uuid.UUID, createdAt time.Time) *models.Student {
+	t.Helper()
+
+	student := testutil.Student(t, db, institutionID, nil, nil)
+	if err := db.Model(student).UpdateColumn("created_at", createdAt).Error; err != nil {
+		t.Fatalf("failed to set created_at: %v", err)
+	}
+	student.CreatedAt = createdAt
+	return student
+}
+
+func TestStudentRepositoryFindAllCursorStableAcrossInserts(t *testing.T) {
+	db := testutil.DB(t)
+	repo := NewStudentRepository(db)
+	institution := testutil.Institution(t, db)
+	other := testutil.Institution(t, db)
+
+	base := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	var want []uuid.UUID
+	for i := 0; i < 5; i++ {
+		want = append(want, studentCreatedAt(t, db, institution.ID, base.Add(time.Duration(i)*time.Minute)).ID)
+	}
+	// Two students created in the same instant are ordered by ID
+	want = append(want, studentCreatedAt(t, db, institution.ID, base.Add(10*time.Minute)).ID)
+	tied := studentCreatedAt(t, db, institution.ID, base.Add(10*time.Minute))
+	if tied.ID.String() < want[len(want)-1].String() {
+		want[len(want)-1], tied.ID = tied.ID, want[len(want)-1]
+	}
+	want = append(want, tied.ID)
+	studentCreatedAt(t, db, other.ID, base.Add(time.Minute))
+
+	var got []uuid.UUID
+	var after *utils.Cursor
+	for page := 0; ; page++ {
+		students, err := repo.FindAllCursor(institution.ID.String(), after, 2)
+		if err != nil {
+			t.Fatalf("FindAllCursor() unexpected error: %v", err)
+		}
+		if len(students) == 0 {
+			break
+		}
+		for _, student := range students {
+			got = append(got, student.ID)
+		}
+		last := students[len(students)-1]
+		after = &utils.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+
+		if page == 0 {
+			// Rows inserted mid-scroll before the cursor are skipped and rows
+			// after it are returned once, without shifting the pages
+			studentCreatedAt(t, db, institution.ID, base.Add(-time.Hour))
+			want = append(want, studentCreatedAt(t, db, institution.ID, base.Add(time.Hour)).ID)
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("FindAllCursor() returned %d students, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FindAllCursor() row %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"campus-core/internal/models"
 	"campus-core/internal/utils"
@@ -17,6 +18,16 @@ type SubjectFilter struct {
 	TeacherID     string
 	IsElective    *bool
 	Search        string
+	CreatedBy     string
+}
+
+// subjectSortColumns lists the columns subjects can be sorted by
+var subjectSortColumns = map[string]string{
+	"name":         "name",
+	"code":         "code",
+	"credit_hours": "credit_hours",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
 }
 
 // SubjectRepository handles database operations for subjects
@@ -29,10 +40,16 @@ func NewSubjectRepository(db *gorm.DB) *SubjectRepository {
 	return &SubjectRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *SubjectRepository) WithTx(tx *gorm.DB) *SubjectRepository {
+	return &SubjectRepository{db: tx}
+}
+
 // FindByID finds a subject by ID
 func (r *SubjectRepository) FindByID(id uuid.UUID) (*models.Subject, error) {
 	var subject models.Subject
-	err := r.db.Preload("Class").Preload("Teacher").First(&subject, "id = ?", id).Error
+	err := r.db.Preload("Class").Preload("Teacher").Preload("Creator.Profile").Preload("Updater.Profile").
+		First(&subject, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, utils.ErrNotFound
@@ -45,7 +62,7 @@ func (r *SubjectRepository) FindByID(id uuid.UUID) (*models.Subject, error) {
 // FindByIDWithInstitution finds a subject by ID with institution filter
 func (r *SubjectRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Subject, error) {
 	var subject models.Subject
-	err := r.db.Preload("Class").Preload("Teacher").
+	err := r.db.Preload("Class").Preload("Teacher").Preload("Creator.Profile").Preload("Updater.Profile").
 		First(&subject, "id = ? AND institution_id = ?", id, institutionID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -61,12 +78,9 @@ func (r *SubjectRepository) FindAll(filter SubjectFilter, params utils.Paginatio
 	var subjects []models.Subject
 	var total int64
 
-	query := r.db.Model(&models.Subject{})
+	query := onReplica(r.db).Model(&models.Subject{}).Scopes(models.ScopeTenant(&models.Subject{}, filter.InstitutionID))
 
 	// Apply filters
-	if filter.InstitutionID != "" {
-		query = query.Where("institution_id = ?", filter.InstitutionID)
-	}
 	if filter.ClassID != "" {
 		query = query.Where("class_id = ?", filter.ClassID)
 	}
@@ -76,6 +90,9 @@ func (r *SubjectRepository) FindAll(filter SubjectFilter, params utils.Paginatio
 	if filter.IsElective != nil {
 		query = query.Where("is_elective = ?", *filter.IsElective)
 	}
+	if filter.CreatedBy != "" {
+		query = query.Where("created_by = ?", filter.CreatedBy)
+	}
 	if filter.Search != "" {
 		query = query.Where("name ILIKE ? OR code ILIKE ?", "%"+filter.Search+"%", "%"+filter.Search+"%")
 	}
@@ -85,9 +102,15 @@ func (r *SubjectRepository) FindAll(filter SubjectFilter, params utils.Paginatio
 		return nil, 0, err
 	}
 
+	// Apply requested sort; the default order breaks ties
+	query, err := utils.ApplySort(query, subjectSortColumns, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Apply pagination and ordering
 	offset := (params.Page - 1) * params.PerPage
-	err := query.Preload("Class").Preload("Teacher").
+	err = query.Preload("Class").Preload("Teacher").Preload("Creator.Profile").Preload("Updater.Profile").
 		Order("name ASC").Offset(offset).Limit(params.PerPage).Find(&subjects).Error
 	if err != nil {
 		return nil, 0, err
@@ -121,12 +144,18 @@ func (r *SubjectRepository) Create(subject *models.Subject) error {
 
 // Update updates a subject
 func (r *SubjectRepository) Update(subject *models.Subject) error {
-	return r.db.Save(subject).Error
+	return saveVersioned(r.db, subject, &subject.BaseModel)
 }
 
-// Delete soft deletes a subject
+// Delete soft deletes a subject along with its prerequisite links
 func (r *SubjectRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.Subject{}, "id = ?", id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subject_id = ? OR prerequisite_subject_id = ?", id, id).
+			Delete(&models.SubjectPrerequisite{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Subject{}, "id = ?", id).Error
+	})
 }
 
 // NameExistsInClass checks if a subject name exists for a class
@@ -166,3 +195,97 @@ func (r *SubjectRepository) UnassignTeacher(subjectID uuid.UUID) error {
 		Where("id = ?", subjectID).
 		Update("teacher_id", nil).Error
 }
+
+// FindTeacherHistory lists a subject's teachers, most recent first
+func (r *SubjectRepository) FindTeacherHistory(subjectID uuid.UUID) ([]models.SubjectTeacherHistory, error) {
+	var history []models.SubjectTeacherHistory
+	err := r.db.Where("subject_id = ?", subjectID).
+		Preload("Teacher").Preload("Teacher.User").Preload("Teacher.User.Profile").
+		Order("from_date DESC").Find(&history).Error
+	return history, err
+}
+
+// ChangeTeacher closes the subject's open teacher record at the given time and,
+// when teacherID is set, opens a new one for that teacher
+func (r *SubjectRepository) ChangeTeacher(subjectID uuid.UUID, teacherID *uuid.UUID, at time.Time) error {
+	err := r.db.Model(&models.SubjectTeacherHistory{}).
+		Where("subject_id = ? AND to_date IS NULL", subjectID).
+		Update("to_date", at).Error
+	if err != nil {
+		return err
+	}
+	if teacherID == nil {
+		return nil
+	}
+	return r.db.Create(&models.SubjectTeacherHistory{
+		SubjectID: subjectID,
+		TeacherID: *teacherID,
+		FromDate:  at,
+	}).Error
+}
+
+// FindPrerequisites returns the direct prerequisites of a subject
+func (r *SubjectRepository) FindPrerequisites(subjectID uuid.UUID) ([]models.Subject, error) {
+	var subjects []models.Subject
+	err := r.db.Joins("JOIN subject_prerequisites sp ON sp.prerequisite_subject_id = subjects.id AND sp.deleted_at IS NULL").
+		Where("sp.subject_id = ?", subjectID).
+		Order("subjects.name ASC").Find(&subjects).Error
+	return subjects, err
+}
+
+// AddPrerequisite links a prerequisite to a subject
+func (r *SubjectRepository) AddPrerequisite(prerequisite *models.SubjectPrerequisite) error {
+	return r.db.Create(prerequisite).Error
+}
+
+// PrerequisiteExists checks whether a subject already has the given prerequisite
+func (r *SubjectRepository) PrerequisiteExists(subjectID, prerequisiteID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.SubjectPrerequisite{}).
+		Where("subject_id = ? AND prerequisite_subject_id = ?", subjectID, prerequisiteID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// RemovePrerequisite unlinks a prerequisite from a subject; it returns false if there was none
+func (r *SubjectRepository) RemovePrerequisite(subjectID, prerequisiteID uuid.UUID) (bool, error) {
+	result := r.db.Where("subject_id = ? AND prerequisite_subject_id = ?", subjectID, prerequisiteID).
+		Delete(&models.SubjectPrerequisite{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RequiresTransitively reports whether subjectID depends on targetID through
+// its chain of prerequisites, at any depth
+func (r *SubjectRepository) RequiresTransitively(subjectID, targetID uuid.UUID) (bool, error) {
+	var found bool
+	err := r.db.Raw(`
+		WITH RECURSIVE chain AS (
+			SELECT prerequisite_subject_id FROM subject_prerequisites
+			WHERE subject_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT sp.prerequisite_subject_id FROM subject_prerequisites sp
+			JOIN chain c ON sp.subject_id = c.prerequisite_subject_id
+			WHERE sp.deleted_at IS NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM chain WHERE prerequisite_subject_id = ?)`,
+		subjectID, targetID).Scan(&found).Error
+	return found, err
+}
+
+// CreditTotal holds the subject count and credit hours for one side of the elective split
+type CreditTotal struct {
+	IsElective  bool
+	Subjects    int64
+	CreditHours float64
+}
+
+// CreditTotalsByClass sums the credit hours of a class's subjects, grouped by elective vs core
+func (r *SubjectRepository) CreditTotalsByClass(classID uuid.UUID) ([]CreditTotal, error) {
+	var totals []CreditTotal
+	err := r.db.Model(&models.Subject{}).
+		Select("is_elective, COUNT(*) AS subjects, COALESCE(SUM(credit_hours), 0) AS credit_hours").
+		Where("class_id = ?", classID).
+		Group("is_elective").
+		Scan(&totals).Error
+	return totals, err
+}
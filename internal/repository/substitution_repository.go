@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SubstitutionRepository handles database operations for substitutions
+type SubstitutionRepository struct {
+	db *gorm.DB
+}
+
+// NewSubstitutionRepository creates a new substitution repository
+func NewSubstitutionRepository(db *gorm.DB) *SubstitutionRepository {
+	return &SubstitutionRepository{db: db}
+}
+
+// Create creates a new substitution
+func (r *SubstitutionRepository) Create(sub *models.Substitution) error {
+	return r.db.Create(sub).Error
+}
+
+// FindByIDWithInstitution finds a substitution by ID with institution filter
+func (r *SubstitutionRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Substitution, error) {
+	var sub models.Substitution
+	err := r.preload(r.db).First(&sub, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// FindByDate finds all substitutions of an institution on a date
+func (r *SubstitutionRepository) FindByDate(institutionID uuid.UUID, date time.Time) ([]models.Substitution, error) {
+	var subs []models.Substitution
+	err := r.preload(r.db).
+		Where("institution_id = ? AND date = ?", institutionID, date.Format("2006-01-02")).
+		Order("created_at ASC").Find(&subs).Error
+	return subs, err
+}
+
+// FindByTeacherInRange finds substitutions between two dates (inclusive) in
+// which the teacher is either the original or the substitute teacher
+func (r *SubstitutionRepository) FindByTeacherInRange(teacherID uuid.UUID, from, to time.Time) ([]models.Substitution, error) {
+	var subs []models.Substitution
+	err := r.preload(r.db).
+		Where("(original_teacher_id = ? OR substitute_teacher_id = ?) AND date BETWEEN ? AND ?",
+			teacherID, teacherID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").Find(&subs).Error
+	return subs, err
+}
+
+// ExistsForSlot checks whether a timetable slot already has a substitute on a date
+func (r *SubstitutionRepository) ExistsForSlot(timetableID uuid.UUID, date time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Substitution{}).
+		Where("timetable_id = ? AND date = ?", timetableID, date.Format("2006-01-02")).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasOverlappingSubstitution checks whether a teacher already covers another
+// slot on the date that overlaps the given day and time range.
+// Start and end times are expected to be normalized to "HH:MM".
+func (r *SubstitutionRepository) HasOverlappingSubstitution(teacherID uuid.UUID, date time.Time, startTime, endTime string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Substitution{}).
+		Joins("JOIN timetables ON timetables.id = substitutions.timetable_id").
+		Where("substitutions.substitute_teacher_id = ? AND substitutions.date = ?", teacherID, date.Format("2006-01-02")).
+		Where("LPAD(timetables.start_time, 5, '0') < ? AND LPAD(timetables.end_time, 5, '0') > ?", endTime, startTime).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Delete deletes a substitution
+func (r *SubstitutionRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.Substitution{}, "id = ?", id).Error
+}
+
+// preload adds the relations needed to build substitution responses
+func (r *SubstitutionRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Timetable.Class").Preload("Timetable.Section").Preload("Timetable.Subject").
+		Preload("OriginalTeacher.User.Profile").Preload("SubstituteTeacher.User.Profile")
+}
@@ -34,6 +34,19 @@ func (r *TeacherRepository) FindByID(id uuid.UUID) (*models.Teacher, error) {
 	return &teacher, nil
 }
 
+// FindByIDWithInstitution finds a teacher by ID with institution filter
+func (r *TeacherRepository) FindByIDWithInstitution(id, institutionID uuid.UUID) (*models.Teacher, error) {
+	var teacher models.Teacher
+	err := r.db.Preload("User.Profile").First(&teacher, "id = ? AND institution_id = ?", id, institutionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return &teacher, nil
+}
+
 func (r *TeacherRepository) FindByUserID(userID uuid.UUID) (*models.Teacher, error) {
 	var teacher models.Teacher
 	if err := r.db.Preload("User.Profile").First(&teacher, "user_id = ?", userID).Error; err != nil {
@@ -57,7 +70,7 @@ func (r *TeacherRepository) FindAll(institutionID string, params utils.Paginatio
 	var teachers []models.Teacher
 	var total int64
 
-	db := r.db.Model(&models.Teacher{}).Preload("User.Profile")
+	db := onReplica(r.db).Model(&models.Teacher{}).Preload("User.Profile")
 
 	if institutionID != "" {
 		db = db.Where("institution_id = ?", institutionID)
@@ -73,3 +86,48 @@ func (r *TeacherRepository) FindAll(institutionID string, params utils.Paginatio
 
 	return teachers, total, nil
 }
+
+// teacherSubjectsCondition matches the subjects a teacher teaches, either as the
+// subject's teacher or through a subject assignment
+const teacherSubjectsCondition = `subjects.teacher_id = ? OR subjects.id IN (
+	SELECT subject_id FROM teacher_subject_assignments WHERE teacher_id = ? AND deleted_at IS NULL)`
+
+// FindSubjects finds the subjects a teacher teaches, ordered by name
+func (r *TeacherRepository) FindSubjects(teacherID uuid.UUID) ([]models.Subject, error) {
+	var subjects []models.Subject
+	err := r.db.Where(teacherSubjectsCondition, teacherID, teacherID).
+		Order("name ASC").Find(&subjects).Error
+	return subjects, err
+}
+
+// FindClasses finds the classes a teacher is class teacher of or teaches a subject in, ordered by name
+func (r *TeacherRepository) FindClasses(teacherID uuid.UUID) ([]models.Class, error) {
+	var classes []models.Class
+	subjectClasses := r.db.Model(&models.Subject{}).Select("subjects.class_id").
+		Where("subjects.class_id IS NOT NULL").
+		Where(teacherSubjectsCondition, teacherID, teacherID)
+	err := r.db.Where("class_teacher_id = ? OR id IN (?)", teacherID, subjectClasses).
+		Order("name ASC").Find(&classes).Error
+	return classes, err
+}
+
+// AssignSubject assigns a teacher to a subject
+func (r *TeacherRepository) AssignSubject(assignment *models.TeacherSubjectAssignment) error {
+	return r.db.Create(assignment).Error
+}
+
+// SubjectAssignmentExists checks whether a teacher is already assigned to a subject
+func (r *TeacherRepository) SubjectAssignmentExists(teacherID, subjectID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.TeacherSubjectAssignment{}).
+		Where("teacher_id = ? AND subject_id = ?", teacherID, subjectID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UnassignSubject removes a teacher's subject assignment; it returns false if there was none
+func (r *TeacherRepository) UnassignSubject(teacherID, subjectID uuid.UUID) (bool, error) {
+	result := r.db.Where("teacher_id = ? AND subject_id = ?", teacherID, subjectID).
+		Delete(&models.TeacherSubjectAssignment{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -22,6 +22,33 @@ type TimetableFilter struct {
 	IsActive       *bool
 }
 
+// timetableSortColumns lists the columns timetable entries can be sorted by
+var timetableSortColumns = map[string]string{
+	"day_of_week": "day_of_week",
+	"start_time":  "start_time",
+	"end_time":    "end_time",
+	"room_number": "room_number",
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+}
+
+// TeacherWorkload is the number of periods and minutes a teacher is scheduled for
+type TeacherWorkload struct {
+	TeacherID    uuid.UUID
+	FirstName    string
+	LastName     string
+	DepartmentID *uuid.UUID
+	Periods      int
+	Minutes      int
+	Days         map[models.DayOfWeek]DayWorkload
+}
+
+// DayWorkload is a teacher's scheduled periods and minutes on one day of the week
+type DayWorkload struct {
+	Periods int
+	Minutes int
+}
+
 // TimetableRepository handles database operations for timetable
 type TimetableRepository struct {
 	db *gorm.DB
@@ -32,6 +59,11 @@ func NewTimetableRepository(db *gorm.DB) *TimetableRepository {
 	return &TimetableRepository{db: db}
 }
 
+// WithTx returns a repository that runs its queries in the given transaction
+func (r *TimetableRepository) WithTx(tx *gorm.DB) *TimetableRepository {
+	return &TimetableRepository{db: tx}
+}
+
 // FindByID finds a timetable entry by ID
 func (r *TimetableRepository) FindByID(id uuid.UUID) (*models.Timetable, error) {
 	var tt models.Timetable
@@ -65,12 +97,9 @@ func (r *TimetableRepository) FindAll(filter TimetableFilter, params utils.Pagin
 	var timetables []models.Timetable
 	var total int64
 
-	query := r.db.Model(&models.Timetable{})
+	query := onReplica(r.db).Model(&models.Timetable{}).Scopes(models.ScopeTenant(&models.Timetable{}, filter.InstitutionID))
 
 	// Apply filters
-	if filter.InstitutionID != "" {
-		query = query.Where("institution_id = ?", filter.InstitutionID)
-	}
 	if filter.AcademicYearID != "" {
 		query = query.Where("academic_year_id = ?", filter.AcademicYearID)
 	}
@@ -98,9 +127,15 @@ func (r *TimetableRepository) FindAll(filter TimetableFilter, params utils.Pagin
 		return nil, 0, err
 	}
 
+	// Apply requested sort; the default order breaks ties
+	query, err := utils.ApplySort(query, timetableSortColumns, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	// Apply pagination and ordering
 	offset := (params.Page - 1) * params.PerPage
-	err := query.Preload("Class").Preload("Section").Preload("Subject").Preload("Teacher").
+	err = query.Preload("Class").Preload("Section").Preload("Subject").Preload("Teacher").
 		Order("day_of_week ASC, start_time ASC").Offset(offset).Limit(params.PerPage).Find(&timetables).Error
 	if err != nil {
 		return nil, 0, err
@@ -109,10 +144,11 @@ func (r *TimetableRepository) FindAll(filter TimetableFilter, params utils.Pagin
 	return timetables, total, nil
 }
 
-// FindByClassID finds all timetable entries for a class
-func (r *TimetableRepository) FindByClassID(classID uuid.UUID, academicYearID *uuid.UUID) ([]models.Timetable, error) {
+// FindByClassID finds all timetable entries for a class.
+// An optional week type keeps only the entries held in such weeks.
+func (r *TimetableRepository) FindByClassID(classID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType) ([]models.Timetable, error) {
 	var timetables []models.Timetable
-	query := r.db.Where("class_id = ? AND is_active = ?", classID, true)
+	query := r.db.Where("class_id = ? AND is_active = ?", classID, true).Scopes(weekTypeScope(weekType))
 	if academicYearID != nil {
 		query = query.Where("academic_year_id = ?", *academicYearID)
 	}
@@ -121,10 +157,40 @@ func (r *TimetableRepository) FindByClassID(classID uuid.UUID, academicYearID *u
 	return timetables, err
 }
 
-// FindBySectionID finds all timetable entries for a section
-func (r *TimetableRepository) FindBySectionID(sectionID uuid.UUID, academicYearID *uuid.UUID) ([]models.Timetable, error) {
+// FindRoomSchedule finds all active timetable entries held in a room
+// The room number is matched case- and whitespace-insensitively
+func (r *TimetableRepository) FindRoomSchedule(institutionID uuid.UUID, roomNumber string, academicYearID *uuid.UUID) ([]models.Timetable, error) {
 	var timetables []models.Timetable
-	query := r.db.Where("section_id = ? AND is_active = ?", sectionID, true)
+	query := r.db.Where("institution_id = ? AND is_active = ?", institutionID, true).
+		Where(roomMatchCondition, utils.NormalizeRoomNumber(roomNumber))
+	if academicYearID != nil {
+		query = query.Where("academic_year_id = ?", *academicYearID)
+	}
+	err := query.Preload("Class").Preload("Section").Preload("Subject").Preload("Teacher").
+		Order("day_of_week ASC, start_time ASC").Find(&timetables).Error
+	return timetables, err
+}
+
+// FindRoomConflicts finds active entries in a room that overlap the given day and time range
+// Start and end times are expected to be normalized to "HH:MM"
+func (r *TimetableRepository) FindRoomConflicts(institutionID uuid.UUID, roomNumber string, day models.DayOfWeek, startTime, endTime string, academicYearID *uuid.UUID) ([]models.Timetable, error) {
+	var timetables []models.Timetable
+	query := r.db.Where("institution_id = ? AND day_of_week = ? AND is_active = ?", institutionID, day, true).
+		Where(roomMatchCondition, utils.NormalizeRoomNumber(roomNumber)).
+		Where(overlapCondition, endTime, startTime)
+	if academicYearID != nil {
+		query = query.Where("academic_year_id = ?", *academicYearID)
+	}
+	err := query.Preload("Class").Preload("Section").Preload("Subject").Preload("Teacher").
+		Order("start_time ASC").Find(&timetables).Error
+	return timetables, err
+}
+
+// FindBySectionID finds all timetable entries for a section.
+// An optional week type keeps only the entries held in such weeks.
+func (r *TimetableRepository) FindBySectionID(sectionID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType) ([]models.Timetable, error) {
+	var timetables []models.Timetable
+	query := r.db.Where("section_id = ? AND is_active = ?", sectionID, true).Scopes(weekTypeScope(weekType))
 	if academicYearID != nil {
 		query = query.Where("academic_year_id = ?", *academicYearID)
 	}
@@ -133,10 +199,11 @@ func (r *TimetableRepository) FindBySectionID(sectionID uuid.UUID, academicYearI
 	return timetables, err
 }
 
-// FindByTeacherID finds all timetable entries for a teacher
-func (r *TimetableRepository) FindByTeacherID(teacherID uuid.UUID, academicYearID *uuid.UUID) ([]models.Timetable, error) {
+// FindByTeacherID finds all timetable entries for a teacher.
+// An optional week type keeps only the entries held in such weeks.
+func (r *TimetableRepository) FindByTeacherID(teacherID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType) ([]models.Timetable, error) {
 	var timetables []models.Timetable
-	query := r.db.Where("teacher_id = ? AND is_active = ?", teacherID, true)
+	query := r.db.Where("teacher_id = ? AND is_active = ?", teacherID, true).Scopes(weekTypeScope(weekType))
 	if academicYearID != nil {
 		query = query.Where("academic_year_id = ?", *academicYearID)
 	}
@@ -145,6 +212,14 @@ func (r *TimetableRepository) FindByTeacherID(teacherID uuid.UUID, academicYearI
 	return timetables, err
 }
 
+// FindBySubjectTeacher finds the active timetable entries that pair a subject with a teacher
+func (r *TimetableRepository) FindBySubjectTeacher(subjectID, teacherID uuid.UUID) ([]models.Timetable, error) {
+	var timetables []models.Timetable
+	err := r.db.Where("subject_id = ? AND teacher_id = ? AND is_active = ?", subjectID, teacherID, true).
+		Order("day_of_week ASC, start_time ASC").Find(&timetables).Error
+	return timetables, err
+}
+
 // Create creates a new timetable entry
 func (r *TimetableRepository) Create(tt *models.Timetable) error {
 	return r.db.Create(tt).Error
@@ -152,7 +227,7 @@ func (r *TimetableRepository) Create(tt *models.Timetable) error {
 
 // Update updates a timetable entry
 func (r *TimetableRepository) Update(tt *models.Timetable) error {
-	return r.db.Save(tt).Error
+	return saveVersioned(r.db, tt, &tt.BaseModel)
 }
 
 // Delete soft deletes a timetable entry
@@ -160,16 +235,58 @@ func (r *TimetableRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Timetable{}, "id = ?", id).Error
 }
 
-// CheckConflict checks for scheduling conflicts
+// overlapCondition matches rows whose interval overlaps [new start, new end).
+// Stored times are left-padded so legacy values such as "9:00" or "09:00:00"
+// compare correctly against normalized "HH:MM" input.
+const overlapCondition = "LPAD(start_time, 5, '0') < ? AND LPAD(end_time, 5, '0') > ?"
+
+// roomMatchCondition matches a room number normalized with utils.NormalizeRoomNumber.
+// Rooms are free text, so "Room 101" and " room  101" refer to the same room.
+const roomMatchCondition = "LOWER(BTRIM(REGEXP_REPLACE(room_number, '\\s+', ' ', 'g'))) = ?"
+
+// weekTypeScope keeps the entries held in weeks of the given type: ODD and EVEN
+// include the entries held every week, ALL keeps only those. Empty matches all.
+func weekTypeScope(weekType models.WeekType) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch weekType {
+		case "":
+			return db
+		case models.WeekTypeAll:
+			return db.Where("week_type = ?", models.WeekTypeAll)
+		default:
+			return db.Where("week_type IN ?", []models.WeekType{models.WeekTypeAll, weekType})
+		}
+	}
+}
+
+// weekOverlapScope keeps the entries that can meet an entry of the given week
+// type in the same week. Entries held every week meet all others.
+func weekOverlapScope(weekType models.WeekType) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if weekType == "" || weekType == models.WeekTypeAll {
+			return db
+		}
+		return db.Where("week_type IN ?", []models.WeekType{models.WeekTypeAll, weekType})
+	}
+}
+
+// CheckConflict checks for scheduling conflicts within the entry's academic year.
+// Odd-week and even-week entries never conflict with each other.
+// Start and end times are expected to be normalized to "HH:MM"
 // Returns true if there's a conflict
 func (r *TimetableRepository) CheckConflict(tt *models.Timetable, excludeID *uuid.UUID) (bool, error) {
+	return checkTimetableConflict(r.db, tt, excludeID)
+}
+
+// checkTimetableConflict runs the conflict queries against the given connection
+func checkTimetableConflict(db *gorm.DB, tt *models.Timetable, excludeID *uuid.UUID) (bool, error) {
 	var count int64
 
 	// Check teacher conflict: same teacher, same day, overlapping time
-	teacherQuery := r.db.Model(&models.Timetable{}).
-		Where("teacher_id = ? AND day_of_week = ? AND is_active = ?", tt.TeacherID, tt.DayOfWeek, true).
-		Where("((start_time <= ? AND end_time > ?) OR (start_time < ? AND end_time >= ?) OR (start_time >= ? AND end_time <= ?))",
-			tt.StartTime, tt.StartTime, tt.EndTime, tt.EndTime, tt.StartTime, tt.EndTime)
+	teacherQuery := db.Model(&models.Timetable{}).
+		Where("teacher_id = ? AND academic_year_id = ? AND day_of_week = ? AND is_active = ?", tt.TeacherID, tt.AcademicYearID, tt.DayOfWeek, true).
+		Where(overlapCondition, tt.EndTime, tt.StartTime).
+		Scopes(weekOverlapScope(tt.WeekType))
 	if excludeID != nil {
 		teacherQuery = teacherQuery.Where("id != ?", *excludeID)
 	}
@@ -181,10 +298,10 @@ func (r *TimetableRepository) CheckConflict(tt *models.Timetable, excludeID *uui
 	}
 
 	// Check section conflict: same section, same day, overlapping time
-	sectionQuery := r.db.Model(&models.Timetable{}).
-		Where("section_id = ? AND day_of_week = ? AND is_active = ?", tt.SectionID, tt.DayOfWeek, true).
-		Where("((start_time <= ? AND end_time > ?) OR (start_time < ? AND end_time >= ?) OR (start_time >= ? AND end_time <= ?))",
-			tt.StartTime, tt.StartTime, tt.EndTime, tt.EndTime, tt.StartTime, tt.EndTime)
+	sectionQuery := db.Model(&models.Timetable{}).
+		Where("section_id = ? AND academic_year_id = ? AND day_of_week = ? AND is_active = ?", tt.SectionID, tt.AcademicYearID, tt.DayOfWeek, true).
+		Where(overlapCondition, tt.EndTime, tt.StartTime).
+		Scopes(weekOverlapScope(tt.WeekType))
 	if excludeID != nil {
 		sectionQuery = sectionQuery.Where("id != ?", *excludeID)
 	}
@@ -197,10 +314,11 @@ func (r *TimetableRepository) CheckConflict(tt *models.Timetable, excludeID *uui
 
 	// Check room conflict if room is specified
 	if tt.RoomNumber != "" {
-		roomQuery := r.db.Model(&models.Timetable{}).
-			Where("room_number = ? AND day_of_week = ? AND is_active = ?", tt.RoomNumber, tt.DayOfWeek, true).
-			Where("((start_time <= ? AND end_time > ?) OR (start_time < ? AND end_time >= ?) OR (start_time >= ? AND end_time <= ?))",
-				tt.StartTime, tt.StartTime, tt.EndTime, tt.EndTime, tt.StartTime, tt.EndTime)
+		roomQuery := db.Model(&models.Timetable{}).
+			Where("institution_id = ? AND academic_year_id = ? AND day_of_week = ? AND is_active = ?", tt.InstitutionID, tt.AcademicYearID, tt.DayOfWeek, true).
+			Where(roomMatchCondition, utils.NormalizeRoomNumber(tt.RoomNumber)).
+			Where(overlapCondition, tt.EndTime, tt.StartTime).
+			Scopes(weekOverlapScope(tt.WeekType))
 		if excludeID != nil {
 			roomQuery = roomQuery.Where("id != ?", *excludeID)
 		}
@@ -220,7 +338,137 @@ func (r *TimetableRepository) BulkCreate(timetables []models.Timetable) error {
 	return r.db.CreateInBatches(timetables, 100).Error
 }
 
+// BulkCreateWithConflictCheck creates timetable entries in a single transaction,
+// re-checking each entry for conflicts against existing rows and the entries
+// inserted before it. On conflict the whole batch is rolled back and the index
+// of the offending entry is returned.
+func (r *TimetableRepository) BulkCreateWithConflictCheck(timetables []models.Timetable) (int, error) {
+	conflictIndex := -1
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range timetables {
+			hasConflict, err := checkTimetableConflict(tx, &timetables[i], nil)
+			if err != nil {
+				return err
+			}
+			if hasConflict {
+				conflictIndex = i
+				return utils.ErrScheduleConflict
+			}
+			if err := tx.Create(&timetables[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return conflictIndex, err
+}
+
+// BulkCreateSkippingConflicts creates timetable entries in a single transaction,
+// skipping any entry that conflicts with existing rows or entries inserted before
+// it. The indexes of the skipped entries are returned.
+func (r *TimetableRepository) BulkCreateSkippingConflicts(timetables []models.Timetable) ([]int, error) {
+	var skipped []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		skipped = nil
+		for i := range timetables {
+			hasConflict, err := checkTimetableConflict(tx, &timetables[i], nil)
+			if err != nil {
+				return err
+			}
+			if hasConflict {
+				skipped = append(skipped, i)
+				continue
+			}
+			if err := tx.Create(&timetables[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return skipped, err
+}
+
+// FindActiveByAcademicYear finds all active timetable entries of an academic year
+func (r *TimetableRepository) FindActiveByAcademicYear(academicYearID, institutionID uuid.UUID) ([]models.Timetable, error) {
+	var timetables []models.Timetable
+	err := r.db.Where("academic_year_id = ? AND institution_id = ? AND is_active = ?", academicYearID, institutionID, true).
+		Order("day_of_week ASC, start_time ASC").Find(&timetables).Error
+	return timetables, err
+}
+
+// CountByAcademicYear counts the timetable entries of an academic year
+func (r *TimetableRepository) CountByAcademicYear(academicYearID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Timetable{}).Where("academic_year_id = ?", academicYearID).Count(&count).Error
+	return count, err
+}
+
 // DeleteByAcademicYear deletes all timetable entries for an academic year
 func (r *TimetableRepository) DeleteByAcademicYear(academicYearID uuid.UUID) error {
 	return r.db.Where("academic_year_id = ?", academicYearID).Delete(&models.Timetable{}).Error
 }
+
+// DeleteBySection soft deletes the timetable entries of a section in an academic year
+// and returns the number of entries deleted
+func (r *TimetableRepository) DeleteBySection(sectionID, academicYearID uuid.UUID) (int64, error) {
+	result := r.db.Where("section_id = ? AND academic_year_id = ?", sectionID, academicYearID).Delete(&models.Timetable{})
+	return result.RowsAffected, result.Error
+}
+
+// GetTeacherWorkload counts the active periods and minutes of every teacher of an
+// institution in an academic year, broken down by day. Teachers without periods
+// are included with zero counts. departmentID optionally restricts the teachers.
+func (r *TimetableRepository) GetTeacherWorkload(institutionID, academicYearID uuid.UUID, departmentID *uuid.UUID) ([]TeacherWorkload, error) {
+	var rows []struct {
+		TeacherID    uuid.UUID
+		FirstName    string
+		LastName     string
+		DepartmentID *uuid.UUID
+		DayOfWeek    *string
+		Periods      int
+		Minutes      int
+	}
+
+	query := r.db.Table("teachers").
+		Select(`teachers.id AS teacher_id, user_profiles.first_name, user_profiles.last_name, teachers.department_id,
+			timetables.day_of_week, COUNT(timetables.id) AS periods,
+			COALESCE(SUM(EXTRACT(EPOCH FROM (timetables.end_time::time - timetables.start_time::time)) / 60), 0)::int AS minutes`).
+		Joins("LEFT JOIN user_profiles ON user_profiles.user_id = teachers.user_id AND user_profiles.deleted_at IS NULL").
+		Joins(`LEFT JOIN timetables ON timetables.teacher_id = teachers.id AND timetables.academic_year_id = ?
+			AND timetables.is_active = ? AND timetables.deleted_at IS NULL`, academicYearID, true).
+		Where("teachers.institution_id = ? AND teachers.deleted_at IS NULL", institutionID)
+	if departmentID != nil {
+		query = query.Where("teachers.department_id = ?", *departmentID)
+	}
+
+	err := query.Group("teachers.id, user_profiles.first_name, user_profiles.last_name, teachers.department_id, timetables.day_of_week").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Fold the per-day rows into one workload per teacher, keeping query order stable
+	var workloads []TeacherWorkload
+	index := make(map[uuid.UUID]int)
+	for _, row := range rows {
+		i, ok := index[row.TeacherID]
+		if !ok {
+			i = len(workloads)
+			index[row.TeacherID] = i
+			workloads = append(workloads, TeacherWorkload{
+				TeacherID:    row.TeacherID,
+				FirstName:    row.FirstName,
+				LastName:     row.LastName,
+				DepartmentID: row.DepartmentID,
+				Days:         make(map[models.DayOfWeek]DayWorkload),
+			})
+		}
+		if row.DayOfWeek == nil {
+			continue
+		}
+		workloads[i].Periods += row.Periods
+		workloads[i].Minutes += row.Minutes
+		workloads[i].Days[models.DayOfWeek(*row.DayOfWeek)] = DayWorkload{Periods: row.Periods, Minutes: row.Minutes}
+	}
+	return workloads, nil
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/testutil"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// timetableFixture is an institution with one scheduled period and spare
+// teachers and sections to build candidate entries from
+type timetableFixture struct {
+	repo         *TimetableRepository
+	existing     *models.Timetable
+	otherTeacher uuid.UUID
+	otherSection uuid.UUID
+	otherClass   uuid.UUID
+}
+
+func newTimetableFixture(t *testing.T, db *gorm.DB) *timetableFixture {
+	t.Helper()
+
+	institution := testutil.Institution(t, db)
+	year := testutil.AcademicYear(t, db, institution.ID,
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	class := testutil.Class(t, db, institution.ID)
+	otherClass := testutil.Class(t, db, institution.ID)
+	subject := testutil.Subject(t, db, institution.ID, &class.ID)
+
+	existing := &models.Timetable{
+		AcademicYearID: year.ID,
+		ClassID:        class.ID,
+		SectionID:      testutil.Section(t, db, class.ID).ID,
+		SubjectID:      subject.ID,
+		TeacherID:      testutil.Teacher(t, db, institution.ID).ID,
+		DayOfWeek:      models.Monday,
+		WeekType:       models.WeekTypeAll,
+		StartTime:      "09:00",
+		EndTime:        "09:45",
+		RoomNumber:     "101",
+		IsActive:       true,
+	}
+	existing.InstitutionID = institution.ID
+	testutil.Create(t, db, existing)
+
+	return &timetableFixture{
+		repo:         NewTimetableRepository(db),
+		existing:     existing,
+		otherTeacher: testutil.Teacher(t, db, institution.ID).ID,
+		otherSection: testutil.Section(t, db, otherClass.ID).ID,
+		otherClass:   otherClass.ID,
+	}
+}
+
+// candidate returns an entry that shares nothing with the existing one but its
+// academic year, day and week type
+func (f *timetableFixture) candidate(start, end string) *models.Timetable {
+	tt := &models.Timetable{
+		AcademicYearID: f.existing.AcademicYearID,
+		ClassID:        f.otherClass,
+		SectionID:      f.otherSection,
+		SubjectID:      f.existing.SubjectID,
+		TeacherID:      f.otherTeacher,
+		DayOfWeek:      f.existing.DayOfWeek,
+		WeekType:       models.WeekTypeAll,
+		StartTime:      start,
+		EndTime:        end,
+		IsActive:       true,
+	}
+	tt.InstitutionID = f.existing.InstitutionID
+	return tt
+}
+
+func TestCheckConflictOverlaps(t *testing.T) {
+	db := testutil.DB(t)
+	f := newTimetableFixture(t, db)
+
+	// The existing entry runs from 09:00 to 09:45
+	overlaps := []struct {
+		name     string
+		start    string
+		end      string
+		conflict bool
+	}{
+		{name: "adjacent before", start: "08:15", end: "09:00", conflict: false},
+		{name: "adjacent after", start: "09:45", end: "10:30", conflict: false},
+		{name: "identical", start: "09:00", end: "09:45", conflict: true},
+		{name: "nested inside", start: "09:10", end: "09:30", conflict: true},
+		{name: "enclosing", start: "08:30", end: "10:00", conflict: true},
+		{name: "partial at start", start: "08:30", end: "09:15", conflict: true},
+		{name: "partial at end", start: "09:30", end: "10:15", conflict: true},
+	}
+
+	resources := []struct {
+		name  string
+		share func(tt *models.Timetable)
+	}{
+		{name: "teacher", share: func(tt *models.Timetable) { tt.TeacherID = f.existing.TeacherID }},
+		{name: "section", share: func(tt *models.Timetable) {
+			tt.ClassID = f.existing.ClassID
+			tt.SectionID = f.existing.SectionID
+		}},
+		{name: "room", share: func(tt *models.Timetable) { tt.RoomNumber = " 101 " }},
+	}
+
+	for _, resource := range resources {
+		for _, overlap := range overlaps {
+			t.Run(resource.name+"/"+overlap.name, func(t *testing.T) {
+				tt := f.candidate(overlap.start, overlap.end)
+				resource.share(tt)
+
+				conflict, err := f.repo.CheckConflict(tt, nil)
+				if err != nil {
+					t.Fatalf("CheckConflict() unexpected error: %v", err)
+				}
+				if conflict != overlap.conflict {
+					t.Errorf("CheckConflict() = %v, want %v", conflict, overlap.conflict)
+				}
+			})
+		}
+	}
+}
+
+func TestCheckConflictIgnoresUnrelatedEntries(t *testing.T) {
+	db := testutil.DB(t)
+	f := newTimetableFixture(t, db)
+
+	tests := []struct {
+		name    string
+		entry   func() *models.Timetable
+		exclude *uuid.UUID
+	}{
+		{name: "no shared resource", entry: func() *models.Timetable {
+			return f.candidate("09:00", "09:45")
+		}},
+		{name: "other day", entry: func() *models.Timetable {
+			tt := f.candidate("09:00", "09:45")
+			tt.TeacherID = f.existing.TeacherID
+			tt.DayOfWeek = models.Tuesday
+			return tt
+		}},
+		{name: "other room", entry: func() *models.Timetable {
+			tt := f.candidate("09:00", "09:45")
+			tt.RoomNumber = "102"
+			return tt
+		}},
+		{name: "entry being updated", entry: func() *models.Timetable {
+			tt := *f.existing
+			return &tt
+		}, exclude: &f.existing.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflict, err := f.repo.CheckConflict(tt.entry(), tt.exclude)
+			if err != nil {
+				t.Fatalf("CheckConflict() unexpected error: %v", err)
+			}
+			if conflict {
+				t.Error("CheckConflict() = true, want false")
+			}
+		})
+	}
+}
+
+func TestCheckConflictWeekTypes(t *testing.T) {
+	db := testutil.DB(t)
+	f := newTimetableFixture(t, db)
+
+	tests := []struct {
+		existing  models.WeekType
+		candidate models.WeekType
+		conflict  bool
+	}{
+		{existing: models.WeekTypeAll, candidate: models.WeekTypeAll, conflict: true},
+		{existing: models.WeekTypeAll, candidate: models.WeekTypeOdd, conflict: true},
+		{existing: models.WeekTypeAll, candidate: models.WeekTypeEven, conflict: true},
+		{existing: models.WeekTypeOdd, candidate: models.WeekTypeAll, conflict: true},
+		{existing: models.WeekTypeEven, candidate: models.WeekTypeAll, conflict: true},
+		{existing: models.WeekTypeOdd, candidate: models.WeekTypeOdd, conflict: true},
+		{existing: models.WeekTypeOdd, candidate: models.WeekTypeEven, conflict: false},
+		{existing: models.WeekTypeEven, candidate: models.WeekTypeOdd, conflict: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.existing)+"/"+string(tt.candidate), func(t *testing.T) {
+			if err := db.Model(f.existing).Update("week_type", tt.existing).Error; err != nil {
+				t.Fatalf("failed to set week type: %v", err)
+			}
+
+			// Same teacher in the same slot
+			candidate := f.candidate("09:00", "09:45")
+			candidate.TeacherID = f.existing.TeacherID
+			candidate.WeekType = tt.candidate
+
+			conflict, err := f.repo.CheckConflict(candidate, nil)
+			if err != nil {
+				t.Fatalf("CheckConflict() unexpected error: %v", err)
+			}
+			if conflict != tt.conflict {
+				t.Errorf("CheckConflict() = %v, want %v", conflict, tt.conflict)
+			}
+		})
+	}
+}
+
+func TestFindBySectionIDWeekType(t *testing.T) {
+	db := testutil.DB(t)
+	f := newTimetableFixture(t, db)
+
+	// The fixture's entry is held every week; add one for odd and one for even weeks
+	odd := *f.existing
+	odd.ID = uuid.Nil
+	odd.WeekType = models.WeekTypeOdd
+	odd.StartTime, odd.EndTime = "10:00", "10:45"
+	testutil.Create(t, db, &odd)
+	even := odd
+	even.ID = uuid.Nil
+	even.WeekType = models.WeekTypeEven
+	testutil.Create(t, db, &even)
+
+	tests := []struct {
+		weekType models.WeekType
+		want     []models.WeekType
+	}{
+		{weekType: "", want: []models.WeekType{models.WeekTypeAll, models.WeekTypeOdd, models.WeekTypeEven}},
+		{weekType: models.WeekTypeAll, want: []models.WeekType{models.WeekTypeAll}},
+		{weekType: models.WeekTypeOdd, want: []models.WeekType{models.WeekTypeAll, models.WeekTypeOdd}},
+		{weekType: models.WeekTypeEven, want: []models.WeekType{models.WeekTypeAll, models.WeekTypeEven}},
+	}
+
+	for _, tt := range tests {
+		t.Run("week type "+string(tt.weekType), func(t *testing.T) {
+			entries, err := f.repo.FindBySectionID(f.existing.SectionID, nil, tt.weekType)
+			if err != nil {
+				t.Fatalf("FindBySectionID() unexpected error: %v", err)
+			}
+			got := make(map[models.WeekType]int)
+			for _, entry := range entries {
+				got[entry.WeekType]++
+			}
+			if len(entries) != len(tt.want) {
+				t.Fatalf("FindBySectionID() returned %d entries %v, want %v", len(entries), got, tt.want)
+			}
+			for _, weekType := range tt.want {
+				if got[weekType] != 1 {
+					t.Errorf("FindBySectionID() returned %d %s entries, want 1", got[weekType], weekType)
+				}
+			}
+		})
+	}
+}
@@ -14,10 +14,11 @@ import (
 
 // UserFilter holds filter criteria for users
 type UserFilter struct {
-	InstitutionID string
-	Role          string
-	Search        string // Search in email, phone, name
-	IsActive      *bool
+	InstitutionID  string
+	Role           string
+	Search         string // Search in email, phone, name
+	IsActive       *bool
+	IncludeDeleted bool // Also return soft-deleted users
 }
 
 // UserRepository handles database operations for users
@@ -43,6 +44,23 @@ func (r *UserRepository) FindByID(id uuid.UUID) (*models.User, error) {
 	return &user, nil
 }
 
+// FindByIDScoped finds a user by ID within an institution
+// Users belonging to other institutions are reported as not found
+func (r *UserRepository) FindByIDScoped(id, institutionID uuid.UUID) (*models.User, error) {
+	var user models.User
+	err := r.db.Preload("Profile").
+		Joins("JOIN user_profiles ON user_profiles.user_id = users.id AND user_profiles.deleted_at IS NULL").
+		Where("users.id = ? AND user_profiles.institution_id = ?", id, institutionID).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // FindByEmail finds a user by email
 func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 	var user models.User
@@ -82,6 +100,23 @@ func (r *UserRepository) FindByEmailOrPhone(identifier string) (*models.User, er
 	return &user, nil
 }
 
+// FindByAdmissionNumber finds a user by admission number within an institution
+// Admission numbers are only unique per institution
+func (r *UserRepository) FindByAdmissionNumber(institutionID uuid.UUID, admissionNumber string) (*models.User, error) {
+	var user models.User
+	err := r.db.Preload("Profile").
+		Joins("JOIN user_profiles ON user_profiles.user_id = users.id AND user_profiles.deleted_at IS NULL").
+		Where("user_profiles.institution_id = ? AND user_profiles.admission_number = ?", institutionID, admissionNumber).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // Create creates a new user
 func (r *UserRepository) Create(user *models.User) error {
 	return r.db.Create(user).Error
@@ -92,40 +127,51 @@ func (r *UserRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
 
+// SaveProfile creates or updates a user's profile.
+// Update doesn't write the columns of an existing profile, so profile edits go through here.
+func (r *UserRepository) SaveProfile(profile *models.UserProfile) error {
+	return r.db.Save(profile).Error
+}
+
 // Delete soft deletes a user
 func (r *UserRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.User{}, "id = ?", id).Error
 }
 
-// UpdateLastLogin updates the last login time
-func (r *UserRepository) UpdateLastLogin(id uuid.UUID) error {
-	now := time.Now()
-	return r.db.Model(&models.User{}).Where("id = ?", id).Update("last_login_at", now).Error
-}
-
-// SaveRefreshToken saves or updates the refresh token for a user
-func (r *UserRepository) SaveRefreshToken(id uuid.UUID, token string) error {
-	return r.db.Model(&models.User{}).Where("id = ?", id).Update("refresh_token", token).Error
-}
-
-// InvalidateRefreshToken clears the refresh token for a user
-func (r *UserRepository) InvalidateRefreshToken(id uuid.UUID) error {
-	return r.db.Model(&models.User{}).Where("id = ?", id).Update("refresh_token", "").Error
-}
-
-// FindByRefreshToken finds a user by refresh token
-func (r *UserRepository) FindByRefreshToken(token string) (*models.User, error) {
+// FindDeletedByID finds a soft-deleted user by ID, optionally within an institution
+func (r *UserRepository) FindDeletedByID(id uuid.UUID, institutionID string) (*models.User, error) {
 	var user models.User
-	err := r.db.Preload("Profile").First(&user, "refresh_token = ?", token).Error
+	db := r.db.Unscoped().Preload("Profile").Where("users.id = ? AND users.deleted_at IS NOT NULL", id)
+	if institutionID != "" {
+		db = db.Joins("JOIN user_profiles ON user_profiles.user_id = users.id").
+			Where("user_profiles.institution_id = ?", institutionID)
+	}
+	err := db.First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, utils.ErrRefreshTokenInvalid
+			return nil, utils.ErrUserNotFound
 		}
 		return nil, err
 	}
 	return &user, nil
 }
 
+// Restore clears the soft-delete marker of a user
+func (r *UserRepository) Restore(id uuid.UUID) error {
+	return r.db.Unscoped().Model(&models.User{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// UpdateCustomRole attaches a custom role to a user, or detaches it when roleID is nil
+func (r *UserRepository) UpdateCustomRole(id uuid.UUID, roleID *uuid.UUID) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Update("custom_role_id", roleID).Error
+}
+
+// UpdateLastLogin updates the last login time
+func (r *UserRepository) UpdateLastLogin(id uuid.UUID) error {
+	now := time.Now()
+	return r.db.Model(&models.User{}).Where("id = ?", id).Update("last_login_at", now).Error
+}
+
 // SaveResetToken saves a password reset token
 func (r *UserRepository) SaveResetToken(id uuid.UUID, token string, expiry time.Time) error {
 	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
@@ -155,9 +201,52 @@ func (r *UserRepository) ClearResetToken(id uuid.UUID) error {
 	}).Error
 }
 
-// UpdatePassword updates the user's password
+// SaveVerificationToken stores the latest email verification token,
+// replacing (and so invalidating) any previously issued one
+func (r *UserRepository) SaveVerificationToken(id uuid.UUID, token string) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Update("verification_token", token).Error
+}
+
+// MarkEmailVerified marks the user's email as verified and clears the token
+func (r *UserRepository) MarkEmailVerified(id uuid.UUID) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email_verified":     true,
+		"email_verified_at":  time.Now(),
+		"verification_token": "",
+	}).Error
+}
+
+// UpdatePassword updates the user's password and lifts any forced password change
 func (r *UserRepository) UpdatePassword(id uuid.UUID, passwordHash string) error {
-	return r.db.Model(&models.User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password_hash":        passwordHash,
+		"must_change_password": false,
+	}).Error
+}
+
+// SetTemporaryPassword replaces a user's password with one they must change on next
+// login and revokes their refresh tokens in the same transaction, so the old
+// sessions never outlive the old password
+func (r *UserRepository) SetTemporaryPassword(id uuid.UUID, passwordHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"password_hash":        passwordHash,
+			"must_change_password": true,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", time.Now()).Error
+	})
+}
+
+// MustChangePassword reports whether a user still has to replace a password set by someone else
+func (r *UserRepository) MustChangePassword(id uuid.UUID) (bool, error) {
+	var must bool
+	err := r.db.Model(&models.User{}).Select("must_change_password").Where("id = ?", id).Scan(&must).Error
+	return must, err
 }
 
 // EmailExists checks if an email is already registered
@@ -195,12 +284,48 @@ func (r *UserRepository) FindAll(filter UserFilter, pagination utils.PaginationP
 	var users []models.User
 	var total int64
 
-	db := r.db.Model(&models.User{}).Preload("Profile")
+	db := onReplica(r.filtered(filter))
+
+	// Count total
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply Pagination
+	err := db.Scopes(utils.Paginate(pagination)).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// FindAllInBatches calls fn with consecutive batches of the users matching the
+// filter, ordered by ID, so callers can process every match without loading
+// them all at once. It stops at the first error returned by fn.
+func (r *UserRepository) FindAllInBatches(filter UserFilter, batchSize int, fn func(users []models.User) error) error {
+	var users []models.User
+	return r.filtered(filter).FindInBatches(&users, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(users)
+	}).Error
+}
+
+// filtered builds the query of the users matching a filter
+func (r *UserRepository) filtered(filter UserFilter) *gorm.DB {
+	db := r.db
+	if filter.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	db = db.Model(&models.User{}).Preload("Profile")
+
+	// Profiles are needed for the tenant scope and name search
+	if filter.InstitutionID != "" || filter.Search != "" {
+		db = db.Joins("LEFT JOIN user_profiles ON user_profiles.user_id = users.id")
+	}
 
 	// Apply Tenant Scope
 	if filter.InstitutionID != "" {
-		db = db.Joins("LEFT JOIN user_profiles ON user_profiles.user_id = users.id").
-			Where("user_profiles.institution_id = ?", filter.InstitutionID)
+		db = db.Where("user_profiles.institution_id = ?", filter.InstitutionID)
 	}
 
 	// Apply Role Scope
@@ -223,21 +348,21 @@ func (r *UserRepository) FindAll(filter UserFilter, pagination utils.PaginationP
 		db = db.Where("users.is_active = ?", *filter.IsActive)
 	}
 
-	// Count total
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Apply Pagination
-	err := db.Scopes(utils.Paginate(pagination)).Find(&users).Error
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return users, total, nil
+	return db
 }
 
 // UpdateStatus updates the user's active status
 func (r *UserRepository) UpdateStatus(id uuid.UUID, isActive bool) error {
 	return r.db.Model(&models.User{}).Where("id = ?", id).Update("is_active", isActive).Error
 }
+
+// CountOtherActiveAdmins counts the active admins of an institution other than excludeID
+func (r *UserRepository) CountOtherActiveAdmins(institutionID, excludeID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).
+		Joins("INNER JOIN user_profiles ON user_profiles.user_id = users.id").
+		Where("user_profiles.institution_id = ? AND users.role = ? AND users.is_active = ? AND users.id <> ?",
+			institutionID, models.RoleAdmin, true, excludeID).
+		Count(&count).Error
+	return count, err
+}
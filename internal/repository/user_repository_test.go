@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"campus-core/internal/models"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+)
+
+func TestUserRepositoryFindByIDScoped(t *testing.T) {
+	db := testutil.DB(t)
+	repo := NewUserRepository(db)
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+	user := testutil.User(t, db, models.RoleTeacher, &schoolA.ID)
+
+	found, err := repo.FindByIDScoped(user.ID, schoolA.ID)
+	if err != nil {
+		t.Fatalf("FindByIDScoped() in the user's institution unexpected error: %v", err)
+	}
+	if found.ID != user.ID || found.Profile == nil {
+		t.Errorf("FindByIDScoped() = %+v, want the user with their profile", found)
+	}
+
+	// Another institution can't tell the user exists
+	if _, err := repo.FindByIDScoped(user.ID, schoolB.ID); !errors.Is(err, utils.ErrUserNotFound) {
+		t.Errorf("FindByIDScoped() across institutions error = %v, want ErrUserNotFound", err)
+	}
+}
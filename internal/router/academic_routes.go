@@ -3,15 +3,18 @@ package router
 import (
 	"campus-core/internal/handler"
 	"campus-core/internal/middleware"
+	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
+	"campus-core/pkg/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-// setupAcademicRoutes configures all academic management routes
-func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
+// setupAcademicRoutes configures all academic management routes and returns
+// the timetable service for the routes of other modules that show timetables
+func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB, store storage.Storage) *service.TimetableService {
 	// Initialize repositories
 	academicYearRepo := repository.NewAcademicYearRepository(db)
 	classRepo := repository.NewClassRepository(db)
@@ -20,22 +23,40 @@ func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 	departmentRepo := repository.NewDepartmentRepository(db)
 	timetableRepo := repository.NewTimetableRepository(db)
 	teacherRepo := repository.NewTeacherRepository(db)
+	institutionRepo := repository.NewInstitutionRepository(db)
+	substitutionRepo := repository.NewSubstitutionRepository(db)
+	studentRepo := repository.NewStudentRepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
+	periodRepo := repository.NewPeriodRepository(db)
+	attendanceRepo := repository.NewAttendanceRepository(db)
+	parentRepo := repository.NewParentRepository(db)
 
 	// Initialize services
-	academicYearService := service.NewAcademicYearService(academicYearRepo)
-	classService := service.NewClassService(classRepo, sectionRepo, teacherRepo)
-	subjectService := service.NewSubjectService(subjectRepo, classRepo, teacherRepo)
-	departmentService := service.NewDepartmentService(departmentRepo, teacherRepo)
+	institutionService := service.NewInstitutionService(institutionRepo, store)
+	academicYearService := service.NewAcademicYearService(academicYearRepo, timetableRepo, db)
+	holidayService := service.NewHolidayService(holidayRepo, academicYearRepo)
+	periodService := service.NewPeriodService(periodRepo)
+	classService := service.NewClassService(classRepo, sectionRepo, teacherRepo, studentRepo, db)
+	subjectService := service.NewSubjectService(subjectRepo, classRepo, teacherRepo, timetableRepo, db)
+	departmentService := service.NewDepartmentService(departmentRepo, teacherRepo, db)
 	timetableService := service.NewTimetableService(
-		timetableRepo, classRepo, sectionRepo, subjectRepo, teacherRepo, academicYearRepo,
+		timetableRepo, classRepo, sectionRepo, subjectRepo, teacherRepo, studentRepo, academicYearRepo, institutionService, substitutionRepo, holidayService, periodService,
 	)
+	substitutionService := service.NewSubstitutionService(
+		substitutionRepo, timetableRepo, teacherRepo, academicYearRepo, timetableService,
+	)
+	attendanceService := service.NewAttendanceService(attendanceRepo, sectionRepo, studentRepo, parentRepo, holidayService)
 
 	// Initialize handlers
 	academicYearHandler := handler.NewAcademicYearHandler(academicYearService)
+	holidayHandler := handler.NewHolidayHandler(holidayService)
+	periodHandler := handler.NewPeriodHandler(periodService)
 	classHandler := handler.NewClassHandler(classService)
 	subjectHandler := handler.NewSubjectHandler(subjectService)
 	departmentHandler := handler.NewDepartmentHandler(departmentService)
 	timetableHandler := handler.NewTimetableHandler(timetableService)
+	substitutionHandler := handler.NewSubstitutionHandler(substitutionService)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceService)
 
 	// Academic Years routes
 	academicYears := rg.Group("/academic-years")
@@ -48,9 +69,31 @@ func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 		academicYears.POST("", middleware.RequireAdmin(), academicYearHandler.Create)
 		academicYears.PUT("/:id", middleware.RequireAdmin(), academicYearHandler.Update)
 		academicYears.PATCH("/:id/activate", middleware.RequireAdmin(), academicYearHandler.Activate)
+		academicYears.PATCH("/:id/deactivate", middleware.RequireAdmin(), academicYearHandler.Deactivate)
 		academicYears.DELETE("/:id", middleware.RequireAdmin(), academicYearHandler.Delete)
 	}
 
+	// Holidays routes (nested under academic years)
+	holidays := rg.Group("/academic-years/:id/holidays")
+	holidays.Use(middleware.RequireAdmin())
+	{
+		holidays.GET("", holidayHandler.GetAll)
+		holidays.POST("", holidayHandler.Create)
+		holidays.PUT("/:holidayId", holidayHandler.Update)
+		holidays.DELETE("/:holidayId", holidayHandler.Delete)
+	}
+
+	// Periods routes (the institution's bell schedule)
+	periods := rg.Group("/periods")
+	periods.Use(middleware.RequireAdmin())
+	{
+		periods.GET("", periodHandler.GetAll)
+		periods.GET("/:id", periodHandler.GetByID)
+		periods.POST("", periodHandler.Create)
+		periods.PUT("/:id", periodHandler.Update)
+		periods.DELETE("/:id", periodHandler.Delete)
+	}
+
 	// Classes routes
 	classes := rg.Group("/classes")
 	{
@@ -61,8 +104,11 @@ func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 
 		// Admin only routes
 		classes.POST("", middleware.RequireAdmin(), classHandler.Create)
+		classes.POST("/recount-sections", middleware.RequireAdmin(), classHandler.RecountSections)
+		classes.POST("/assign-teachers", middleware.RequireAdmin(), classHandler.AssignTeachers)
 		classes.PUT("/:id", middleware.RequireAdmin(), classHandler.Update)
 		classes.DELETE("/:id", middleware.RequireAdmin(), classHandler.Delete)
+		classes.POST("/:id/reassign-rolls", middleware.RequireAdmin(), classHandler.ReassignRollNumbers)
 	}
 
 	// Sections routes (nested under classes)
@@ -80,18 +126,34 @@ func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 		sectionRoutes.DELETE("/:id", middleware.RequireAdmin(), classHandler.DeleteSection)
 	}
 
+	// Attendance routes
+	attendance := rg.Group("/attendance")
+	{
+		attendance.GET("/student/:id", attendanceHandler.GetByStudent)
+
+		// Teacher routes
+		attendance.POST("/section/:sectionId", middleware.RequireTeacher(), attendanceHandler.MarkSection)
+		attendance.GET("/section/:sectionId", middleware.RequireTeacher(), attendanceHandler.GetSectionSummary)
+	}
+
 	// Subjects routes
 	subjects := rg.Group("/subjects")
 	{
 		subjects.GET("", subjectHandler.GetAll)
 		subjects.GET("/:id", subjectHandler.GetByID)
 		subjects.GET("/class/:classId", subjectHandler.GetByClassID)
+		subjects.GET("/class/:classId/credits", subjectHandler.GetClassCreditSummary)
+		subjects.GET("/:id/prerequisites", subjectHandler.GetPrerequisites)
+		subjects.GET("/:id/teacher-history", subjectHandler.GetTeacherHistory)
 
 		// Admin only routes
 		subjects.POST("", middleware.RequireAdmin(), subjectHandler.Create)
 		subjects.PUT("/:id", middleware.RequireAdmin(), subjectHandler.Update)
 		subjects.DELETE("/:id", middleware.RequireAdmin(), subjectHandler.Delete)
 		subjects.POST("/:id/assign-teacher", middleware.RequireAdmin(), subjectHandler.AssignTeacher)
+		subjects.DELETE("/:id/teacher", middleware.RequireAdmin(), subjectHandler.UnassignTeacher)
+		subjects.POST("/:id/prerequisites", middleware.RequireAdmin(), subjectHandler.AddPrerequisite)
+		subjects.DELETE("/:id/prerequisites/:prerequisiteId", middleware.RequireAdmin(), subjectHandler.RemovePrerequisite)
 	}
 
 	// Departments routes
@@ -100,25 +162,48 @@ func setupAcademicRoutes(rg *gin.RouterGroup, db *gorm.DB) {
 		departments.GET("", departmentHandler.GetAll)
 		departments.GET("/:id", departmentHandler.GetByID)
 		departments.GET("/:id/staff", departmentHandler.GetStaff)
+		departments.GET("/:id/head-history", departmentHandler.GetHeadHistory)
 
 		// Admin only routes
 		departments.POST("", middleware.RequireAdmin(), departmentHandler.Create)
 		departments.PUT("/:id", middleware.RequireAdmin(), departmentHandler.Update)
 		departments.DELETE("/:id", middleware.RequireAdmin(), departmentHandler.Delete)
+		departments.GET("/:id/staff/export", middleware.RequireAdmin(), departmentHandler.ExportStaff)
 	}
 
 	// Timetable routes
 	timetable := rg.Group("/timetable")
 	{
 		timetable.GET("", timetableHandler.GetAll)
+		timetable.GET("/me", middleware.RequireRole(models.RoleStudent, models.RoleTeacher), timetableHandler.GetMine)
 		timetable.GET("/:id", timetableHandler.GetByID)
 		timetable.GET("/class/:classId", timetableHandler.GetByClassID)
+		timetable.GET("/class/:classId/export", timetableHandler.ExportByClassID)
 		timetable.GET("/section/:sectionId", timetableHandler.GetBySectionID)
 		timetable.GET("/teacher/:teacherId", timetableHandler.GetByTeacherID)
+		timetable.GET("/teacher/:teacherId/free-slots", timetableHandler.GetTeacherFreeSlots)
+		timetable.GET("/teacher/:teacherId/export.ics", timetableHandler.ExportTeacherICS)
+		timetable.GET("/room/:roomNumber", timetableHandler.GetRoomSchedule)
+		timetable.POST("/room/check", timetableHandler.CheckRoomAvailability)
+		timetable.GET("/substitutions", substitutionHandler.ListByDate)
 
 		// Admin only routes
 		timetable.POST("", middleware.RequireAdmin(), timetableHandler.Create)
+		timetable.POST("/bulk", middleware.RequireAdmin(), timetableHandler.BulkCreate)
+		timetable.POST("/copy", middleware.RequireAdmin(), timetableHandler.Copy)
 		timetable.PUT("/:id", middleware.RequireAdmin(), timetableHandler.Update)
 		timetable.DELETE("/:id", middleware.RequireAdmin(), timetableHandler.Delete)
+		timetable.DELETE("/section/:sectionId", middleware.RequireAdmin(), timetableHandler.ClearSection)
+		timetable.POST("/:id/substitute", middleware.RequireAdmin(), substitutionHandler.Create)
+		timetable.DELETE("/substitutions/:id", middleware.RequireAdmin(), substitutionHandler.Delete)
 	}
+
+	// Reports routes
+	reports := rg.Group("/reports")
+	reports.Use(middleware.RequireAdmin())
+	{
+		reports.GET("/teacher-workload", timetableHandler.GetTeacherWorkload)
+	}
+
+	return timetableService
 }
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupAuditRoutes configures audit log routes
+func (r *Router) setupAuditRoutes(rg *gin.RouterGroup, auditService *service.AuditService) {
+	auditHandler := handler.NewAuditHandler(auditService)
+
+	auditLogs := rg.Group("/audit-logs")
+	auditLogs.Use(middleware.RequireAdmin())
+	{
+		auditLogs.GET("", auditHandler.List)
+	}
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"campus-core/internal/database"
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+	"campus-core/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDashboardRoutes configures the admin dashboard route.
+// The summary is always for the caller's institution.
+func (r *Router) setupDashboardRoutes(rg *gin.RouterGroup) {
+	dashboardService := service.NewDashboardService(
+		repository.NewDashboardRepository(r.db),
+		repository.NewAcademicYearRepository(r.db),
+		repository.NewInstitutionRepository(r.db),
+		cache.New(database.RedisClient, "cache:dashboard"),
+	)
+	dashboardHandler := handler.NewDashboardHandler(dashboardService)
+
+	rg.GET("/dashboard", middleware.RequireStaff(), dashboardHandler.GetSummary)
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupFeeRoutes configures fee structure routes
+func (r *Router) setupFeeRoutes(rg *gin.RouterGroup) {
+	feeService := service.NewFeeStructureService(
+		repository.NewFeeStructureRepository(r.db),
+		repository.NewClassRepository(r.db),
+		repository.NewAcademicYearRepository(r.db),
+	)
+	feeHandler := handler.NewFeeStructureHandler(feeService)
+
+	feeStructures := rg.Group("/fee-structures")
+	{
+		canView := middleware.RequireAnyPermission("FEE_STRUCTURE_VIEW", "FEE_STRUCTURE_MANAGE")
+		feeStructures.GET("", canView, feeHandler.GetAll)
+		feeStructures.GET("/summary", canView, feeHandler.GetSummary)
+		feeStructures.GET("/:id", canView, feeHandler.GetByID)
+
+		canManage := middleware.RequirePermission("FEE_STRUCTURE_MANAGE")
+		feeStructures.POST("", canManage, feeHandler.Create)
+		feeStructures.PUT("/:id", canManage, feeHandler.Update)
+		feeStructures.DELETE("/:id", canManage, feeHandler.Delete)
+	}
+}
@@ -1,31 +1,49 @@
 package router
 
 import (
+	"campus-core/internal/database"
 	"campus-core/internal/handler"
 	"campus-core/internal/middleware"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
+	"campus-core/pkg/cache"
 
 	"github.com/gin-gonic/gin"
 )
 
 func (r *Router) setupInstitutionRoutes(rg *gin.RouterGroup) {
 	repo := repository.NewInstitutionRepository(r.db)
-	svc := service.NewInstitutionService(repo)
+	svc := service.NewInstitutionService(repo, r.storage)
 	handler := handler.NewInstitutionHandler(svc)
 
+	// Creation requests can be retried safely with an Idempotency-Key header
+	idempotent := middleware.Idempotency(cache.New(database.RedisClient, "idempotency"))
+
 	institutions := rg.Group("/institutions")
 	// Only Super Admin can manage institutions
 	institutions.Use(middleware.RequireSuperAdmin())
 	{
-		institutions.POST("", handler.Create)
+		institutions.POST("", idempotent, handler.Create)
+		institutions.POST("/onboard", idempotent, handler.Onboard)
 		institutions.GET("", handler.GetAll)
 		institutions.GET("/:id", handler.GetByID)
 		institutions.PUT("/:id", handler.Update)
 		institutions.DELETE("/:id", handler.Delete)
 		institutions.PATCH("/:id/status", handler.ToggleStatus)
 		institutions.GET("/:id/stats", handler.GetStats)
+		institutions.GET("/:id/stats/detailed", handler.GetDetailedStats)
 		institutions.GET("/:id/admins", handler.GetAdmins)
 		institutions.POST("/:id/admins", handler.AssignAdmin)
 	}
+
+	// Admins manage the settings of their own institution
+	settings := rg.Group("/institutions/:id/settings")
+	settings.Use(middleware.RequireAdmin())
+	{
+		settings.GET("", handler.GetSettings)
+		settings.PUT("", handler.UpdateSettings)
+	}
+
+	// Admins upload the logo of their own institution
+	rg.POST("/institutions/:id/logo", middleware.RequireAdmin(), handler.UploadLogo)
 }
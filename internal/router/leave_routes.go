@@ -0,0 +1,26 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupLeaveRoutes configures leave application routes.
+// Applicants only see their own applications; approvers see the institution's.
+func (r *Router) setupLeaveRoutes(rg *gin.RouterGroup) {
+	leaveService := service.NewLeaveService(repository.NewLeaveRepository(r.db), r.mailer)
+	leaveHandler := handler.NewLeaveHandler(leaveService)
+
+	leaves := rg.Group("/leaves")
+	{
+		leaves.POST("", middleware.RequirePermission("LEAVE_APPLY"), leaveHandler.Apply)
+		leaves.GET("/mine", leaveHandler.ListMine)
+		leaves.GET("", middleware.RequirePermission("LEAVE_APPROVE"), leaveHandler.List)
+		leaves.PATCH("/:id/approve", middleware.RequirePermission("LEAVE_APPROVE"), leaveHandler.Approve)
+		leaves.PATCH("/:id/reject", middleware.RequirePermission("LEAVE_APPROVE"), leaveHandler.Reject)
+	}
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupMetaRoutes configures the reference data route, open to every authenticated user
+func (r *Router) setupMetaRoutes(rg *gin.RouterGroup) {
+	metaHandler := handler.NewMetaHandler()
+
+	rg.GET("/meta", metaHandler.Get)
+}
@@ -0,0 +1,24 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupNoticeRoutes configures notice routes.
+// Every authenticated user can list the notices addressed to their role.
+func (r *Router) setupNoticeRoutes(rg *gin.RouterGroup) {
+	noticeService := service.NewNoticeService(repository.NewNoticeRepository(r.db), service.NewNotificationService(r.hub))
+	noticeHandler := handler.NewNoticeHandler(noticeService)
+
+	notices := rg.Group("/notices")
+	{
+		notices.GET("", noticeHandler.List)
+		notices.POST("", middleware.RequirePermission("NOTICE_PUBLISH"), noticeHandler.Create)
+		notices.DELETE("/:id", middleware.RequirePermission("NOTICE_PUBLISH"), noticeHandler.Delete)
+	}
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPermissionRoutes configures custom role and permission routes
+func (r *Router) setupPermissionRoutes(rg *gin.RouterGroup) {
+	roleRepo := repository.NewRoleRepository(r.db)
+	userRepo := repository.NewUserRepository(r.db)
+
+	roleService := service.NewRoleService(roleRepo, userRepo)
+	roleHandler := handler.NewRoleHandler(roleService)
+
+	adminOnly := rg.Group("")
+	adminOnly.Use(middleware.RequireAdmin())
+	{
+		roles := adminOnly.Group("/roles")
+		{
+			roles.GET("", roleHandler.GetAll)
+			roles.POST("", roleHandler.Create)
+			roles.GET("/:role/permissions", roleHandler.GetRolePermissions)
+			roles.PUT("/:role/permissions", roleHandler.UpdatePermissions)
+		}
+
+		adminOnly.GET("/permissions", roleHandler.GetPermissions)
+		adminOnly.PUT("/users/:id/role", roleHandler.AssignUserRole)
+	}
+}
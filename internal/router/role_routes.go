@@ -1,26 +1,31 @@
 package router
 
 import (
+	"campus-core/internal/database"
 	"campus-core/internal/handler"
 	"campus-core/internal/middleware"
+	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
+	"campus-core/pkg/cache"
 
 	"github.com/gin-gonic/gin"
 )
 
-func (r *Router) setupRoleRoutes(rg *gin.RouterGroup) {
+func (r *Router) setupRoleRoutes(rg *gin.RouterGroup, timetableService *service.TimetableService) {
 	// Repositories
 	userRepo := repository.NewUserRepository(r.db)
 	teacherRepo := repository.NewTeacherRepository(r.db)
 	studentRepo := repository.NewStudentRepository(r.db)
 	parentRepo := repository.NewParentRepository(r.db)
 	accountantRepo := repository.NewAccountantRepository(r.db)
+	sectionRepo := repository.NewSectionRepository(r.db)
+	departmentRepo := repository.NewDepartmentRepository(r.db)
 
 	// Services
-	teacherService := service.NewTeacherService(teacherRepo, userRepo, r.db, r.jwtManager)
-	studentService := service.NewStudentService(studentRepo, userRepo, r.db, r.jwtManager)
-	parentService := service.NewParentService(parentRepo, userRepo, r.db, r.jwtManager)
+	teacherService := service.NewTeacherService(teacherRepo, userRepo, departmentRepo, r.db, r.jwtManager)
+	studentService := service.NewStudentService(studentRepo, userRepo, sectionRepo, r.db, r.jwtManager)
+	parentService := service.NewParentService(parentRepo, userRepo, timetableService, r.db, r.jwtManager)
 	accountantService := service.NewAccountantService(accountantRepo, userRepo, r.db, r.jwtManager)
 
 	// Handlers
@@ -29,6 +34,9 @@ func (r *Router) setupRoleRoutes(rg *gin.RouterGroup) {
 	parentHandler := handler.NewParentHandler(parentService)
 	accountantHandler := handler.NewAccountantHandler(accountantService)
 
+	// Creation requests can be retried safely with an Idempotency-Key header
+	idempotent := middleware.Idempotency(cache.New(database.RedisClient, "idempotency"))
+
 	// Admin access required for creating roles (can be refined to RequirePermission)
 	adminOnly := rg.Group("")
 	adminOnly.Use(middleware.RequireAdmin())
@@ -36,36 +44,55 @@ func (r *Router) setupRoleRoutes(rg *gin.RouterGroup) {
 	// Teachers
 	teachers := adminOnly.Group("/teachers")
 	{
-		teachers.POST("", teacherHandler.Create)
+		teachers.POST("", idempotent, teacherHandler.Create)
+		teachers.POST("/import", teacherHandler.Import)
 		teachers.GET("", teacherHandler.GetAll)
 		teachers.GET("/:id", teacherHandler.GetByID)
 		teachers.PUT("/:id", teacherHandler.Update)
 		teachers.GET("/:id/classes", teacherHandler.GetClasses)
 		teachers.GET("/:id/subjects", teacherHandler.GetSubjects)
+		teachers.POST("/:id/subjects", teacherHandler.AssignSubject)
+		teachers.DELETE("/:id/subjects/:subjectId", teacherHandler.UnassignSubject)
 	}
 
 	// Students
 	students := adminOnly.Group("/students")
 	{
-		students.POST("", studentHandler.Create)
+		students.POST("", idempotent, studentHandler.Create)
+		students.POST("/import", studentHandler.Import)
+		students.POST("/promote", studentHandler.Promote)
+		students.POST("/promotions/:batchId/revert", studentHandler.RevertPromotion)
+		students.POST("/bulk-status", studentHandler.BulkStatus)
 		students.GET("", studentHandler.GetAll)
 		students.GET("/:id", studentHandler.GetByID)
+		students.GET("/:id/full", studentHandler.GetFullProfile)
 		students.PUT("/:id", studentHandler.Update)
 		students.GET("/:id/parents", studentHandler.GetParents)
 		students.POST("/:id/parents", studentHandler.LinkParent)
 		students.DELETE("/:id/parents/:parentId", studentHandler.UnlinkParent)
+		students.PATCH("/:id/parents/:parentId/primary", studentHandler.SetPrimaryParent)
+		students.POST("/:id/transfer", middleware.RequireSuperAdmin(), studentHandler.Transfer) // Cross-tenant
 	}
 
+	// Sections
+	adminOnly.POST("/sections/move", studentHandler.MoveSection)
+
 	// Parents
 	parents := adminOnly.Group("/parents")
 	{
-		parents.POST("", parentHandler.Create)
+		parents.POST("", idempotent, parentHandler.Create)
 		parents.GET("", parentHandler.GetAll)
 		parents.GET("/:id", parentHandler.GetByID)
 		parents.PUT("/:id", parentHandler.Update)
 		parents.GET("/:id/children", parentHandler.GetChildren)
 	}
 
+	// Parent portal; parents may only view their own children
+	rg.GET("/parents/:id/children-timetables",
+		middleware.RequireRole(models.RoleSuperAdmin, models.RoleAdmin, models.RoleParent),
+		parentHandler.GetChildrenTimetables,
+	)
+
 	// Accountants
 	accountants := adminOnly.Group("/accountants")
 	{
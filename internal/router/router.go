@@ -1,14 +1,28 @@
 package router
 
 import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
 	"campus-core/internal/config"
+	"campus-core/internal/database"
 	"campus-core/internal/handler"
 	"campus-core/internal/middleware"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
 	"campus-core/internal/utils"
+	"campus-core/pkg/cache"
+	"campus-core/pkg/export"
+	"campus-core/pkg/logger"
+	"campus-core/pkg/mailer"
+	"campus-core/pkg/storage"
+	"campus-core/pkg/version"
+	"campus-core/pkg/ws"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +32,12 @@ type Router struct {
 	config     *config.Config
 	db         *gorm.DB
 	jwtManager *utils.JWTManager
+	mailer     mailer.Mailer
+	storage    storage.Storage
+	hub        *ws.Hub
+
+	// defaultInstitutionID is the institution of requests that name none, or empty
+	defaultInstitutionID string
 }
 
 // NewRouter creates a new router instance
@@ -34,64 +54,177 @@ func NewRouter(cfg *config.Config, db *gorm.DB) *Router {
 		cfg.JWT.AccessExpiry,
 		cfg.JWT.RefreshExpiry,
 	)
+	if len(cfg.JWT.Keys) > 0 {
+		// Rotating keys; the legacy secret still verifies tokens issued without a kid
+		keys := make(map[string]string, len(cfg.JWT.Keys)+1)
+		for kid, secret := range cfg.JWT.Keys {
+			keys[kid] = secret
+		}
+		if cfg.JWT.Secret != "" {
+			keys[""] = cfg.JWT.Secret
+		}
+		jwtManager = utils.NewJWTManagerWithKeys(keys, cfg.JWT.CurrentKID, cfg.JWT.AccessExpiry, cfg.JWT.RefreshExpiry)
+	}
+
+	// Create mailer
+	mail := mailer.New(mailer.Config{
+		Driver:   cfg.Mail.Driver,
+		Host:     cfg.Mail.Host,
+		Port:     cfg.Mail.Port,
+		Username: cfg.Mail.Username,
+		Password: cfg.Mail.Password,
+		From:     cfg.Mail.From,
+	})
+
+	// Create file storage for uploads
+	store := storage.New(storage.Config{
+		Driver:    cfg.Storage.Driver,
+		LocalPath: cfg.Storage.LocalPath,
+		BaseURL:   cfg.Storage.BaseURL,
+	})
+
+	// Replace the PDF export fonts if configured
+	if cfg.Export.PDFFont != "" {
+		if err := export.LoadPDFFonts(cfg.Export.PDFFont, cfg.Export.PDFBoldFont); err != nil {
+			logger.Fatal("Failed to load PDF fonts", zap.Error(err))
+		}
+	}
 
 	return &Router{
 		engine:     engine,
 		config:     cfg,
 		db:         db,
 		jwtManager: jwtManager,
+		mailer:     mail,
+		storage:    store,
+		hub:        ws.NewHub(),
+	}
+}
+
+// corsConfig builds the CORS policy from the configuration, keeping the
+// middleware defaults for anything not configured
+func (r *Router) corsConfig() middleware.CORSConfig {
+	cors := middleware.DefaultCORSConfig()
+	if len(r.config.CORS.AllowedOrigins) > 0 {
+		cors.AllowOrigins = r.config.CORS.AllowedOrigins
+	}
+	if len(r.config.CORS.AllowedMethods) > 0 {
+		cors.AllowMethods = r.config.CORS.AllowedMethods
+	}
+	if len(r.config.CORS.AllowedHeaders) > 0 {
+		cors.AllowHeaders = r.config.CORS.AllowedHeaders
+	}
+	cors.AllowCredentials = r.config.CORS.AllowCredentials
+	return cors
+}
+
+// resolveDefaultInstitution looks up the institution used for requests that name none.
+// An unknown code stops the server rather than leaving those requests without one.
+func resolveDefaultInstitution(repo *repository.InstitutionRepository, code string) string {
+	if code == "" {
+		return ""
+	}
+	institution, err := repo.FindByCode(code)
+	if err != nil {
+		logger.Fatal("Default institution not resolved", zap.String("code", code), zap.Error(err))
 	}
+	logger.Info("Using default institution", zap.String("code", code), zap.String("id", institution.ID.String()))
+	return institution.ID.String()
 }
 
 // Setup configures all routes and middleware
 func (r *Router) Setup() *gin.Engine {
+	// Localize error messages using the institution's locale when the client sends no preference
+	institutionRepo := repository.NewInstitutionRepository(r.db)
+	utils.SetInstitutionLocaleResolver(middleware.InstitutionLocale(institutionRepo))
+	utils.SetMaxPerPage(r.config.Pagination.MaxPerPage)
+	utils.SetDefaultPhoneRegion(r.config.Phone.DefaultRegion)
+	r.defaultInstitutionID = resolveDefaultInstitution(institutionRepo, r.config.Tenant.DefaultInstitutionCode)
+
 	// Apply global middleware
+	r.engine.Use(middleware.RequestID())
 	r.engine.Use(middleware.Recovery())
 	r.engine.Use(middleware.RequestLogger())
-	r.engine.Use(middleware.CORS())
+	r.engine.Use(middleware.CORSWithConfig(r.corsConfig()))
 
-	// Apply rate limiting if Redis is available
+	// Apply rate limiting by IP if Redis is available. Authenticated requests are
+	// limited per institution instead, so a school behind one NAT isn't throttled as a single client.
 	r.engine.Use(middleware.RateLimit(middleware.RateLimitConfig{
 		Requests: r.config.RateLimit.Requests,
 		Duration: r.config.RateLimit.Duration,
 		KeyFunc:  func(c *gin.Context) string { return "ratelimit:" + c.ClientIP() },
+		Skip:     middleware.SkipAuthenticated(r.jwtManager),
 	}))
+	institutionRateLimit := middleware.RateLimit(middleware.RateLimitConfig{
+		Requests:  r.config.RateLimit.Requests,
+		Duration:  r.config.RateLimit.Duration,
+		KeyFunc:   middleware.InstitutionKeyFunc,
+		LimitFunc: middleware.InstitutionRequestLimit(institutionRepo),
+	})
 
 	// Health check endpoint (no auth required)
 	r.engine.GET("/api/v1/health", r.healthCheck)
 
+	// Serve locally stored uploads (no auth required)
+	if local, ok := r.storage.(*storage.LocalStorage); ok && strings.HasPrefix(local.BaseURL(), "/") {
+		r.engine.Static(local.BaseURL(), local.Root())
+	}
+
 	// API v1 routes
 	v1 := r.engine.Group("/api/v1")
 	{
 		// Setup auth routes
-		r.setupAuthRoutes(v1)
+		r.setupAuthRoutes(v1, institutionRateLimit)
+
+		// Realtime notifications
+		r.setupWebSocketRoutes(v1)
 
 		// Protected routes (require authentication)
 		protected := v1.Group("")
 		protected.Use(middleware.AuthMiddleware(r.jwtManager))
 		{
+			// Accounts with a password set by someone else must change it first
+			protected.Use(middleware.RequirePasswordChanged(repository.NewUserRepository(r.db), "/api/v1/profile/password"))
+
 			// Tenant middleware to resolve institution context
-			protected.Use(middleware.TenantMiddleware())
+			protected.Use(middleware.TenantMiddleware(institutionRepo, r.defaultInstitutionID))
+			protected.Use(institutionRateLimit)
 
-			r.setupInstitutionRoutes(protected)
-			r.setupUserRoutes(protected)
-			r.setupRoleRoutes(protected)
+			// Record write operations once the actor and tenant are known
+			auditService := service.NewAuditService(repository.NewAuditLogRepository(r.db))
+			protected.Use(middleware.AuditMiddleware(auditService))
 
 			// Academic management routes
-			setupAcademicRoutes(protected, r.db)
+			timetableService := setupAcademicRoutes(protected, r.db, r.storage)
+
+			r.setupInstitutionRoutes(protected)
+			r.setupUserRoutes(protected)
+			r.setupRoleRoutes(protected, timetableService)
+			r.setupAuditRoutes(protected, auditService)
+			r.setupPermissionRoutes(protected)
+			r.setupSearchRoutes(protected)
+			r.setupNoticeRoutes(protected)
+			r.setupLeaveRoutes(protected)
+			r.setupFeeRoutes(protected)
+			r.setupDashboardRoutes(protected)
+			r.setupMetaRoutes(protected)
 		}
 	}
 
 	return r.engine
 }
 
-// setupAuthRoutes configures authentication routes
-func (r *Router) setupAuthRoutes(rg *gin.RouterGroup) {
+// setupAuthRoutes configures authentication routes.
+// rateLimit applies to the authenticated ones, which the global IP limiter skips.
+func (r *Router) setupAuthRoutes(rg *gin.RouterGroup, rateLimit gin.HandlerFunc) {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(r.db)
+	instRepo := repository.NewInstitutionRepository(r.db)
+	tokenRepo := repository.NewRefreshTokenRepository(r.db)
+	historyRepo := repository.NewPasswordHistoryRepository(r.db)
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, r.jwtManager)
+	authService := service.NewAuthService(userRepo, instRepo, tokenRepo, historyRepo, r.jwtManager, r.mailer, r.config.Mail.AppURL, r.config.Password.HistorySize, cache.New(database.RedisClient, "auth:forgot-password"))
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService)
@@ -100,41 +233,116 @@ func (r *Router) setupAuthRoutes(rg *gin.RouterGroup) {
 	auth := rg.Group("/auth")
 	{
 		// Public routes (with stricter rate limiting)
-		auth.POST("/login", middleware.AuthRateLimit(), authHandler.Login)
+		auth.POST("/login", middleware.AuthRateLimit(), middleware.LoginLockout(middleware.LoginLockoutConfig{
+			Threshold: r.config.RateLimit.LoginFailures,
+			Window:    r.config.RateLimit.LoginFailureWindow,
+			Delay:     r.config.RateLimit.LoginDelay,
+			MaxDelay:  r.config.RateLimit.LoginMaxDelay,
+		}), middleware.TenantMiddleware(instRepo, r.defaultInstitutionID), authHandler.Login)
 		auth.POST("/refresh-token", authHandler.RefreshToken)
 		auth.POST("/forgot-password", middleware.AuthRateLimit(), authHandler.ForgotPassword)
 		auth.POST("/reset-password", middleware.AuthRateLimit(), authHandler.ResetPassword)
+		auth.GET("/reset-password/validate", middleware.AuthRateLimit(), authHandler.ValidateResetToken)
+		auth.POST("/send-verification", middleware.AuthRateLimit(), authHandler.SendVerification)
+		auth.GET("/verify-email", middleware.AuthRateLimit(), authHandler.VerifyEmail)
 
-		// Protected routes
+		// Protected routes. Users who must change their password can only do that,
+		// look at their account and log out.
 		authProtected := auth.Group("")
-		authProtected.Use(middleware.AuthMiddleware(r.jwtManager))
+		authProtected.Use(middleware.AuthMiddleware(r.jwtManager), rateLimit)
+		authProtected.Use(middleware.RequirePasswordChanged(userRepo,
+			"/api/v1/auth/change-password", "/api/v1/auth/me", "/api/v1/auth/logout", "/api/v1/auth/logout-all"))
 		{
 			authProtected.POST("/register", middleware.RequireAdmin(), authHandler.Register)
 			authProtected.POST("/logout", authHandler.Logout)
+			authProtected.POST("/logout-all", authHandler.LogoutAll)
 			authProtected.POST("/change-password", authHandler.ChangePassword)
 			authProtected.GET("/me", authHandler.GetMe)
+			authProtected.GET("/sessions", authHandler.GetSessions)
+			authProtected.DELETE("/sessions/:id", authHandler.RevokeSession)
 		}
 	}
 }
 
-// healthCheck handles health check requests
+// Health statuses
+const (
+	healthStatusHealthy  = "healthy"
+	healthStatusDegraded = "degraded"
+	healthStatusDown     = "unhealthy"
+
+	dependencyConnected    = "connected"
+	dependencyDisconnected = "disconnected"
+	dependencyDisabled     = "disabled"
+)
+
+// healthCheckTimeout bounds each dependency ping
+const healthCheckTimeout = 2 * time.Second
+
+// HealthResponse is the payload of the health check endpoint
+type HealthResponse struct {
+	Status       string            `json:"status"`
+	Version      version.Info      `json:"version"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// healthCheck handles health check requests. The database is required: when it
+// is unreachable the service is unhealthy and 503 is returned so load balancers
+// stop routing to it. Redis is optional: when it is down the service is degraded.
 func (r *Router) healthCheck(c *gin.Context) {
-	// Check database connection
-	sqlDB, err := r.db.DB()
-	if err != nil {
-		utils.InternalServerError(c, "Database connection error")
-		return
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthCheckTimeout)
+	defer cancel()
+
+	health := HealthResponse{
+		Status:  healthStatusHealthy,
+		Version: version.Get(),
+		Dependencies: map[string]string{
+			"database": r.databaseStatus(ctx),
+			"redis":    redisStatus(ctx),
+		},
 	}
 
-	if err := sqlDB.Ping(); err != nil {
-		utils.InternalServerError(c, "Database ping failed")
+	if health.Dependencies["database"] != dependencyConnected {
+		health.Status = healthStatusDown
+		c.JSON(http.StatusServiceUnavailable, utils.APIResponse{
+			Success: false,
+			Message: "Server is unhealthy",
+			Data:    health,
+		})
 		return
 	}
 
-	utils.OK(c, "Server is healthy", gin.H{
-		"status":   "healthy",
-		"database": "connected",
-	})
+	message := "Server is healthy"
+	if health.Dependencies["redis"] == dependencyDisconnected {
+		health.Status = healthStatusDegraded
+		message = "Server is degraded"
+	}
+	utils.OK(c, message, health)
+}
+
+// databaseStatus pings the database
+func (r *Router) databaseStatus(ctx context.Context) string {
+	if r.db == nil {
+		return dependencyDisconnected
+	}
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return dependencyDisconnected
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return dependencyDisconnected
+	}
+	return dependencyConnected
+}
+
+// redisStatus pings Redis; it is "disabled" when the server started without it
+func redisStatus(ctx context.Context) string {
+	if database.RedisClient == nil {
+		return dependencyDisabled
+	}
+	if err := database.RedisClient.Ping(ctx).Err(); err != nil {
+		return dependencyDisconnected
+	}
+	return dependencyConnected
 }
 
 // GetEngine returns the Gin engine
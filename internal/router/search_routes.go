@@ -0,0 +1,22 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+	"campus-core/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSearchRoutes configures the global search route
+func (r *Router) setupSearchRoutes(rg *gin.RouterGroup) {
+	searchService := service.NewSearchService(repository.NewSearchRepository(r.db))
+	searchHandler := handler.NewSearchHandler(searchService)
+
+	search := rg.Group("/search")
+	search.Use(middleware.RequireAdmin())
+	{
+		search.GET("", searchHandler.Search)
+	}
+}
@@ -1,10 +1,12 @@
 package router
 
 import (
+	"campus-core/internal/database"
 	"campus-core/internal/handler"
 	"campus-core/internal/middleware"
 	"campus-core/internal/repository"
 	"campus-core/internal/service"
+	"campus-core/pkg/cache"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,6 +15,8 @@ func (r *Router) setupUserRoutes(rg *gin.RouterGroup) {
 	// Repos
 	userRepo := repository.NewUserRepository(r.db)
 	instRepo := repository.NewInstitutionRepository(r.db)
+	tokenRepo := repository.NewRefreshTokenRepository(r.db)
+	historyRepo := repository.NewPasswordHistoryRepository(r.db)
 
 	// Services
 	// Note: We need existing AuthService instance, or create new one?
@@ -20,8 +24,8 @@ func (r *Router) setupUserRoutes(rg *gin.RouterGroup) {
 	// Ideally we accept AuthService in router setup or create it.
 	// In `router.go`, we created `authService` inside `setupAuthRoutes` locally.
 	// We should probably promote `authService` to struct level or recreate (stateless except for repo).
-	authService := service.NewAuthService(userRepo, r.jwtManager)
-	userService := service.NewUserService(userRepo, instRepo, authService)
+	authService := service.NewAuthService(userRepo, instRepo, tokenRepo, historyRepo, r.jwtManager, r.mailer, r.config.Mail.AppURL, r.config.Password.HistorySize, cache.New(database.RedisClient, "auth:forgot-password"))
+	userService := service.NewUserService(userRepo, instRepo, authService, r.storage, r.mailer)
 	userHandler := handler.NewUserHandler(userService)
 
 	users := rg.Group("/users")
@@ -29,10 +33,13 @@ func (r *Router) setupUserRoutes(rg *gin.RouterGroup) {
 	{
 		users.POST("", userHandler.CreateUser)
 		users.GET("", userHandler.GetAllUsers)
+		users.GET("/export", userHandler.ExportUsers)
 		users.GET("/:id", userHandler.GetUser)
 		users.PUT("/:id", userHandler.UpdateUser)
 		users.DELETE("/:id", userHandler.DeleteUser)
+		users.POST("/:id/restore", userHandler.RestoreUser)
 		users.PATCH("/:id/status", userHandler.ToggleStatus)
+		users.POST("/:id/reset-credentials", userHandler.ResetCredentials)
 	}
 
 	profile := rg.Group("/profile")
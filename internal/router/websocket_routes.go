@@ -0,0 +1,22 @@
+package router
+
+import (
+	"campus-core/internal/handler"
+	"campus-core/internal/middleware"
+	"campus-core/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupWebSocketRoutes configures the realtime notification endpoint.
+// It authenticates on its own so the token can also be given as a query parameter.
+func (r *Router) setupWebSocketRoutes(rg *gin.RouterGroup) {
+	wsHandler := handler.NewWebSocketHandler(r.hub)
+
+	rg.GET("/ws",
+		middleware.WebSocketToken(),
+		middleware.AuthMiddleware(r.jwtManager),
+		middleware.TenantMiddleware(repository.NewInstitutionRepository(r.db), r.defaultInstitutionID),
+		wsHandler.Connect,
+	)
+}
@@ -10,16 +10,19 @@ import (
 	"campus-core/internal/utils"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // AcademicYearService handles academic year business logic
 type AcademicYearService struct {
-	repo *repository.AcademicYearRepository
+	repo          *repository.AcademicYearRepository
+	timetableRepo *repository.TimetableRepository
+	db            *gorm.DB
 }
 
 // NewAcademicYearService creates a new academic year service
-func NewAcademicYearService(repo *repository.AcademicYearRepository) *AcademicYearService {
-	return &AcademicYearService{repo: repo}
+func NewAcademicYearService(repo *repository.AcademicYearRepository, timetableRepo *repository.TimetableRepository, db *gorm.DB) *AcademicYearService {
+	return &AcademicYearService{repo: repo, timetableRepo: timetableRepo, db: db}
 }
 
 // Create creates a new academic year
@@ -144,15 +147,48 @@ func (s *AcademicYearService) Update(id uuid.UUID, req *request.UpdateAcademicYe
 	return s.toResponse(ay), nil
 }
 
-// Delete deletes an academic year
-func (s *AcademicYearService) Delete(id, institutionID uuid.UUID) error {
+// Delete deletes an academic year.
+// The current year can't be deleted until another year is set current, and a
+// year with timetable entries is in use unless force is set, in which case the
+// entries are soft-deleted in the same transaction.
+func (s *AcademicYearService) Delete(id, institutionID uuid.UUID, force bool) (*response.AcademicYearDeleteResponse, error) {
 	// Verify it exists and belongs to the institution
-	_, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	ay, err := s.repo.FindByIDWithInstitution(id, institutionID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if ay.IsCurrent {
+		return nil, errors.New("cannot delete the current academic year, set another year as current first")
+	}
+
+	resp := &response.AcademicYearDeleteResponse{ID: id}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		timetableRepo := s.timetableRepo.WithTx(tx)
+
+		entries, err := timetableRepo.CountByAcademicYear(id)
+		if err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		if entries > 0 {
+			if !force {
+				return utils.ErrResourceInUse
+			}
+			if err := timetableRepo.DeleteByAcademicYear(id); err != nil {
+				return utils.ErrInternalServer.Wrap(err)
+			}
+			resp.DeletedTimetableEntries = entries
+		}
+
+		if err := s.repo.WithTx(tx).Delete(id); err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repo.Delete(id)
+	return resp, nil
 }
 
 // Activate sets an academic year as current
@@ -166,6 +202,23 @@ func (s *AcademicYearService) Activate(id, institutionID uuid.UUID) error {
 	return s.repo.SetCurrent(id, institutionID)
 }
 
+// Deactivate unsets an academic year as current, leaving the institution
+// without a current year until another one is activated
+func (s *AcademicYearService) Deactivate(id, institutionID uuid.UUID) error {
+	ay, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return err
+	}
+	if !ay.IsCurrent {
+		return errors.New("academic year is not the current year")
+	}
+
+	if err := s.repo.ClearCurrent(id, institutionID); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
 // toResponse converts a model to response
 func (s *AcademicYearService) toResponse(ay *models.AcademicYear) *response.AcademicYearResponse {
 	return &response.AcademicYearResponse{
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+)
+
+func TestAcademicYearDeleteInUse(t *testing.T) {
+	db := testutil.DB(t)
+	timetableRepo := repository.NewTimetableRepository(db)
+	s := NewAcademicYearService(repository.NewAcademicYearRepository(db), timetableRepo, db)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	section := testutil.Section(t, db, class.ID)
+	year := testutil.AcademicYear(t, db, institution.ID,
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	testutil.Timetable(t, db, institution.ID, year.ID, class.ID, section.ID)
+	testutil.Timetable(t, db, institution.ID, year.ID, class.ID, section.ID)
+
+	if _, err := s.Delete(year.ID, institution.ID, false); !errors.Is(err, utils.ErrResourceInUse) {
+		t.Fatalf("Delete() without force error = %v, want ErrResourceInUse", err)
+	}
+	if count, _ := timetableRepo.CountByAcademicYear(year.ID); count != 2 {
+		t.Fatalf("rejected Delete() left %d timetable entries, want 2", count)
+	}
+
+	resp, err := s.Delete(year.ID, institution.ID, true)
+	if err != nil {
+		t.Fatalf("Delete() with force unexpected error: %v", err)
+	}
+	if resp.DeletedTimetableEntries != 2 {
+		t.Errorf("Delete() deleted %d timetable entries, want 2", resp.DeletedTimetableEntries)
+	}
+	if count, _ := timetableRepo.CountByAcademicYear(year.ID); count != 0 {
+		t.Errorf("forced Delete() left %d timetable entries, want 0", count)
+	}
+	if _, err := s.repo.FindByIDWithInstitution(year.ID, institution.ID); err == nil {
+		t.Error("forced Delete() left the academic year in place")
+	}
+}
+
+func TestAcademicYearDeleteUnused(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewAcademicYearService(repository.NewAcademicYearRepository(db), repository.NewTimetableRepository(db), db)
+
+	institution := testutil.Institution(t, db)
+	year := testutil.AcademicYear(t, db, institution.ID,
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	resp, err := s.Delete(year.ID, institution.ID, false)
+	if err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if resp.DeletedTimetableEntries != 0 {
+		t.Errorf("Delete() deleted %d timetable entries, want 0", resp.DeletedTimetableEntries)
+	}
+}
+
+func TestDeleteSectionWithTimetable(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewClassService(repository.NewClassRepository(db), repository.NewSectionRepository(db),
+		repository.NewTeacherRepository(db), repository.NewStudentRepository(db), db)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	year := testutil.AcademicYear(t, db, institution.ID,
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	scheduled := testutil.Section(t, db, class.ID)
+	testutil.Timetable(t, db, institution.ID, year.ID, class.ID, scheduled.ID)
+	empty := testutil.Section(t, db, class.ID)
+
+	if err := s.DeleteSection(scheduled.ID); !errors.Is(err, utils.ErrResourceInUse) {
+		t.Fatalf("DeleteSection() of a scheduled section error = %v, want ErrResourceInUse", err)
+	}
+	if err := s.DeleteSection(empty.ID); err != nil {
+		t.Fatalf("DeleteSection() of an unused section unexpected error: %v", err)
+	}
+
+	var remaining int64
+	db.Model(&models.Section{}).Where("id IN ?", []interface{}{scheduled.ID, empty.ID}).Count(&remaining)
+	if remaining != 1 {
+		t.Errorf("%d sections remain, want 1", remaining)
+	}
+}
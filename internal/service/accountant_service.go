@@ -2,7 +2,6 @@ package service
 
 import (
 	"errors"
-	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
@@ -40,6 +39,23 @@ func (s *AccountantService) CreateAccountant(req *request.CreateAccountantReques
 		return nil, errors.New("institution_id is required")
 	}
 
+	if req.Phone != "" {
+		phone, err := canonicalPhone(s.userRepo, req.Phone, "")
+		if err != nil {
+			return nil, err
+		}
+		req.Phone = phone
+	}
+
+	dateOfBirth, err := parseDateOfBirth(req.DateOfBirth)
+	if err != nil {
+		return nil, err
+	}
+	joiningDate, err := parsePastDate(req.JoiningDate, "joining date")
+	if err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
@@ -51,12 +67,13 @@ func (s *AccountantService) CreateAccountant(req *request.CreateAccountantReques
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// 1. Create User
 		user := &models.User{
-			BaseModel:    models.BaseModel{ID: uuid.New()},
-			Email:        req.Email,
-			Phone:        req.Phone,
-			PasswordHash: hashedPassword,
-			Role:         models.RoleAccountant,
-			IsActive:     true,
+			BaseModel:          models.BaseModel{ID: uuid.New()},
+			Email:              req.Email,
+			Phone:              req.Phone,
+			PasswordHash:       hashedPassword,
+			MustChangePassword: true,
+			Role:               models.RoleAccountant,
+			IsActive:           true,
 		}
 		if err := tx.Create(user).Error; err != nil {
 			return err
@@ -69,6 +86,9 @@ func (s *AccountantService) CreateAccountant(req *request.CreateAccountantReques
 			FirstName:     req.FirstName,
 			LastName:      req.LastName,
 			InstitutionID: &institutionID,
+			DateOfBirth:   dateOfBirth,
+			Gender:        req.Gender,
+			Address:       req.Address,
 			Occupation:    "Accountant",
 		}
 		if err := tx.Create(profile).Error; err != nil {
@@ -78,7 +98,6 @@ func (s *AccountantService) CreateAccountant(req *request.CreateAccountantReques
 		accountantUser = user
 
 		// 3. Create Accountant
-		joiningDate, _ := time.Parse("2006-01-02", req.JoiningDate)
 		accountant := &models.Accountant{
 			TenantBaseModel: models.TenantBaseModel{
 				BaseModel:     models.BaseModel{ID: uuid.New()},
@@ -96,6 +115,9 @@ func (s *AccountantService) CreateAccountant(req *request.CreateAccountantReques
 	})
 
 	if err != nil {
+		if utils.IsUniqueViolation(err, models.UserEmailIndex) {
+			return nil, utils.ErrEmailAlreadyExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -194,7 +216,11 @@ func (s *AccountantService) UpdateAccountant(id uuid.UUID, req *request.UpdateAc
 	}
 
 	if req.Phone != "" {
-		accountant.User.Phone = req.Phone
+		phone, err := canonicalPhone(s.userRepo, req.Phone, accountant.User.Phone)
+		if err != nil {
+			return nil, err
+		}
+		accountant.User.Phone = phone
 	}
 
 	if req.IsActive != nil {
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// AttendanceService handles student attendance business logic
+type AttendanceService struct {
+	repo        *repository.AttendanceRepository
+	sectionRepo *repository.SectionRepository
+	studentRepo *repository.StudentRepository
+	parentRepo  *repository.ParentRepository
+	holidays    *HolidayService
+}
+
+// NewAttendanceService creates a new attendance service
+func NewAttendanceService(
+	repo *repository.AttendanceRepository,
+	sectionRepo *repository.SectionRepository,
+	studentRepo *repository.StudentRepository,
+	parentRepo *repository.ParentRepository,
+	holidays *HolidayService,
+) *AttendanceService {
+	return &AttendanceService{
+		repo:        repo,
+		sectionRepo: sectionRepo,
+		studentRepo: studentRepo,
+		parentRepo:  parentRepo,
+		holidays:    holidays,
+	}
+}
+
+// MarkBulk marks the attendance of a section's students for a date.
+// Marking a student again on the same date overwrites the earlier record.
+// Holidays cannot be marked.
+func (s *AttendanceService) MarkBulk(sectionID uuid.UUID, req *request.MarkAttendanceRequest, institutionID string, markedBy uuid.UUID) (*response.MarkAttendanceResponse, error) {
+	section, err := s.findSection(sectionID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	holiday, err := s.holidays.IsHoliday(date, section.Class.InstitutionID)
+	if err != nil {
+		return nil, err
+	}
+	if holiday {
+		return nil, utils.ErrAttendanceOnHoliday
+	}
+
+	students, err := s.sectionRepo.GetSectionStudents(sectionID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	inSection := make(map[uuid.UUID]*models.Student, len(students))
+	for i := range students {
+		inSection[students[i].ID] = &students[i]
+	}
+
+	newRecord := func(studentID uuid.UUID, status, remarks string) models.Attendance {
+		return models.Attendance{
+			InstitutionID: section.Class.InstitutionID,
+			StudentID:     studentID,
+			SectionID:     &section.ID,
+			Date:          date,
+			Status:        models.AttendanceStatus(status),
+			MarkedBy:      &markedBy,
+			Remarks:       remarks,
+		}
+	}
+
+	// Explicit records first; a student listed twice keeps the last entry
+	marked := make(map[uuid.UUID]models.Attendance, len(students))
+	for _, rec := range req.Records {
+		studentID, err := uuid.Parse(rec.StudentID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		if _, ok := inSection[studentID]; !ok {
+			return nil, utils.ErrStudentNotInSection
+		}
+		marked[studentID] = newRecord(studentID, rec.Status, rec.Remarks)
+	}
+
+	// Everyone else in the section gets the default status, skipping deactivated accounts
+	if req.DefaultStatus != "" {
+		for id, student := range inSection {
+			if _, ok := marked[id]; ok || (student.User != nil && !student.User.IsActive) {
+				continue
+			}
+			marked[id] = newRecord(id, req.DefaultStatus, "")
+		}
+	}
+
+	records := make([]models.Attendance, 0, len(marked))
+	for _, record := range marked {
+		records = append(records, record)
+	}
+	if err := s.repo.Upsert(records); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return &response.MarkAttendanceResponse{
+		SectionID: section.ID,
+		Date:      req.Date,
+		Marked:    len(records),
+		Unmarked:  len(students) - len(records),
+	}, nil
+}
+
+// GetByStudent returns a student's attendance between two optional dates.
+// Students may only view their own attendance and parents that of their children.
+func (s *AttendanceService) GetByStudent(studentID uuid.UUID, from, to *time.Time, callerID uuid.UUID, role, institutionID string) (*response.StudentAttendanceResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify tenant access
+	if institutionID != "" && student.InstitutionID.String() != institutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+
+	switch role {
+	case models.RoleStudent:
+		if student.UserID != callerID {
+			return nil, utils.ErrResourceAccessDenied
+		}
+	case models.RoleParent:
+		linked, err := s.parentRepo.IsParentOf(callerID, student.ID)
+		if err != nil {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+		if !linked {
+			return nil, utils.ErrResourceAccessDenied
+		}
+	}
+
+	records, err := s.repo.FindByStudent(student.ID, from, to)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.StudentAttendanceResponse{
+		StudentID: student.ID,
+		Records:   make([]response.AttendanceResponse, 0, len(records)),
+	}
+	for i := range records {
+		countStatus(&resp.Counts, records[i].Status, 1)
+		resp.Records = append(resp.Records, *s.toResponse(&records[i]))
+	}
+	return resp, nil
+}
+
+// GetSectionSummary counts a section's attendance on a date by status
+func (s *AttendanceService) GetSectionSummary(sectionID uuid.UUID, date time.Time, institutionID string) (*response.SectionAttendanceSummary, error) {
+	section, err := s.findSection(sectionID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.sectionRepo.GetSectionStudentCount(section.ID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	byStatus, err := s.repo.CountBySectionAndDate(section.ID, date)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	summary := &response.SectionAttendanceSummary{
+		SectionID:     section.ID,
+		Date:          date.Format("2006-01-02"),
+		TotalStudents: total,
+	}
+	var marked int64
+	for status, count := range byStatus {
+		countStatus(&summary.Counts, status, count)
+		marked += count
+	}
+	if total > marked {
+		summary.Unmarked = total - marked
+	}
+	return summary, nil
+}
+
+// findSection finds a section and verifies its class belongs to the institution
+func (s *AttendanceService) findSection(sectionID uuid.UUID, institutionID string) (*models.Section, error) {
+	section, err := s.sectionRepo.FindByID(sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section.Class == nil {
+		return nil, utils.ErrNotFound
+	}
+	if institutionID != "" && section.Class.InstitutionID.String() != institutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+	return section, nil
+}
+
+// countStatus adds n to the counter of a status
+func countStatus(counts *response.AttendanceCounts, status models.AttendanceStatus, n int64) {
+	switch status {
+	case models.AttendanceStatusPresent:
+		counts.Present += n
+	case models.AttendanceStatusAbsent:
+		counts.Absent += n
+	case models.AttendanceStatusLate:
+		counts.Late += n
+	}
+}
+
+// toResponse converts a model to response
+func (s *AttendanceService) toResponse(record *models.Attendance) *response.AttendanceResponse {
+	return &response.AttendanceResponse{
+		ID:        record.ID,
+		StudentID: record.StudentID,
+		SectionID: record.SectionID,
+		Date:      record.Date.Format("2006-01-02"),
+		Status:    string(record.Status),
+		MarkedBy:  record.MarkedBy,
+		Remarks:   record.Remarks,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
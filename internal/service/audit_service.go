@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/json"
+
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+	"campus-core/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// AuditService handles audit log business logic
+type AuditService struct {
+	repo *repository.AuditLogRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(repo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record stores an audit log entry.
+// Failures are logged rather than returned so auditing never breaks the request.
+func (s *AuditService) Record(entry *models.AuditLog) {
+	if err := s.repo.Create(entry); err != nil {
+		logger.Error("Failed to record audit log",
+			zap.String("method", entry.Method),
+			zap.String("path", entry.Path),
+			zap.Error(err))
+	}
+}
+
+// List lists audit log entries with filters
+func (s *AuditService) List(filter repository.AuditLogFilter, params utils.PaginationParams) ([]response.AuditLogResponse, utils.Pagination, error) {
+	logs, total, err := s.repo.FindAll(filter, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	var responses []response.AuditLogResponse
+	for _, entry := range logs {
+		responses = append(responses, *s.toResponse(&entry))
+	}
+
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return responses, pagination, nil
+}
+
+// toResponse converts an audit log model to response
+func (s *AuditService) toResponse(entry *models.AuditLog) *response.AuditLogResponse {
+	resp := &response.AuditLogResponse{
+		ID:            entry.ID,
+		InstitutionID: entry.InstitutionID,
+		ActorUserID:   entry.ActorUserID,
+		Action:        entry.Action,
+		Method:        entry.Method,
+		Path:          entry.Path,
+		StatusCode:    entry.StatusCode,
+		ResourceType:  entry.ResourceType,
+		ResourceID:    entry.ResourceID,
+		IPAddress:     entry.IPAddress,
+		CreatedAt:     entry.CreatedAt,
+	}
+	if entry.Changes != nil {
+		resp.Changes = json.RawMessage(*entry.Changes)
+	}
+	return resp
+}
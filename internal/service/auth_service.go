@@ -1,13 +1,21 @@
 package service
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
 	"campus-core/internal/middleware"
 	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/utils"
+	"campus-core/pkg/cache"
 	"campus-core/pkg/logger"
+	"campus-core/pkg/mailer"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -15,34 +23,56 @@ import (
 
 // AuthService handles authentication business logic
 type AuthService struct {
-	userRepo   *repository.UserRepository
-	jwtManager *utils.JWTManager
+	userRepo    *repository.UserRepository
+	instRepo    *repository.InstitutionRepository
+	tokenRepo   *repository.RefreshTokenRepository
+	historyRepo *repository.PasswordHistoryRepository
+	jwtManager  *utils.JWTManager
+	mailer      mailer.Mailer
+	appURL      string
+	historySize int // Recent passwords, including the current one, that can't be reused
+
+	resetThrottle *cache.Cache // Last reset email per address; disabled when Redis is not connected
 }
 
+// resetEmailInterval is the minimum time between two reset emails to the same address
+const resetEmailInterval = time.Minute
+
 // NewAuthService creates a new auth service
-func NewAuthService(userRepo *repository.UserRepository, jwtManager *utils.JWTManager) *AuthService {
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	instRepo *repository.InstitutionRepository,
+	tokenRepo *repository.RefreshTokenRepository,
+	historyRepo *repository.PasswordHistoryRepository,
+	jwtManager *utils.JWTManager,
+	mailer mailer.Mailer,
+	appURL string,
+	historySize int,
+	resetThrottle *cache.Cache,
+) *AuthService {
 	return &AuthService{
-		userRepo:   userRepo,
-		jwtManager: jwtManager,
+		userRepo:    userRepo,
+		instRepo:    instRepo,
+		tokenRepo:   tokenRepo,
+		historyRepo: historyRepo,
+		jwtManager:  jwtManager,
+		mailer:      mailer,
+		appURL:      strings.TrimRight(appURL, "/"),
+		historySize: historySize,
+
+		resetThrottle: resetThrottle,
 	}
 }
 
 // Login authenticates a user and returns tokens
 func (s *AuthService) Login(req *request.LoginRequest) (*response.LoginResponse, error) {
-	var user *models.User
-	var err error
-
-	// Find user by email or phone
-	if req.Email != "" {
-		user, err = s.userRepo.FindByEmail(req.Email)
-	} else if req.Phone != "" {
-		user, err = s.userRepo.FindByPhone(req.Phone)
-	} else {
-		return nil, utils.ErrInvalidCredentials
-	}
-
+	user, err := s.findLoginUser(req)
 	if err != nil {
-		logger.Debug("User not found during login", zap.String("email", req.Email))
+		logger.Debug("User not found during login",
+			zap.String("email", req.Email),
+			zap.String("phone", req.Phone),
+			zap.String("identifier", req.Identifier),
+		)
 		return nil, utils.ErrInvalidCredentials
 	}
 
@@ -60,32 +90,52 @@ func (s *AuthService) Login(req *request.LoginRequest) (*response.LoginResponse,
 	institutionID := ""
 	if user.Profile != nil && user.Profile.InstitutionID != nil {
 		institutionID = user.Profile.InstitutionID.String()
+
+		// Reject unverified accounts if the institution requires verification
+		if !user.EmailVerified {
+			required, err := s.instRepo.RequiresEmailVerification(*user.Profile.InstitutionID)
+			if err != nil {
+				return nil, utils.ErrInternalServer.Wrap(err)
+			}
+			if required {
+				return nil, utils.ErrEmailNotVerified
+			}
+		}
 	}
 
 	// Get permissions for the user's role
-	permissions := middleware.GetPermissionsForRole(user.Role)
+	permissions := middleware.GetPermissionsForUser(user.Role, user.CustomRoleID)
 
 	// Generate access token
 	accessToken, expiresAt, err := s.jwtManager.GenerateAccessToken(
 		user.ID,
 		user.Email,
 		user.Role,
+		user.CustomRoleID,
 		institutionID,
 		permissions,
+		user.MustChangePassword,
 	)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	// Generate refresh token
-	refreshToken, _, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	refreshToken, jti, refreshExpiry, err := s.jwtManager.GenerateRefreshToken(user.ID)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
-	// Save refresh token to database
-	if err := s.userRepo.SaveRefreshToken(user.ID, refreshToken); err != nil {
-		logger.Error("Failed to save refresh token", zap.Error(err))
+	// Store refresh token as the start of a new session family
+	if err := s.tokenRepo.Create(&models.RefreshToken{
+		UserID:    user.ID,
+		JTI:       jti,
+		FamilyID:  uuid.New(),
+		ExpiresAt: refreshExpiry,
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+	}); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	// Update last login time
@@ -99,6 +149,8 @@ func (s *AuthService) Login(req *request.LoginRequest) (*response.LoginResponse,
 		TokenType:    "Bearer",
 		ExpiresAt:    expiresAt,
 		User:         s.toUserResponse(user),
+
+		MustChangePassword: user.MustChangePassword,
 	}, nil
 }
 
@@ -113,14 +165,10 @@ func (s *AuthService) Register(req *request.RegisterRequest) (*response.UserResp
 		return nil, utils.ErrEmailAlreadyExists
 	}
 
-	// Check if phone already exists (if provided)
+	// Normalize the phone and check it isn't registered yet (if provided)
 	if req.Phone != "" {
-		exists, err = s.userRepo.PhoneExists(req.Phone)
-		if err != nil {
-			return nil, utils.ErrInternalServer.Wrap(err)
-		}
-		if exists {
-			return nil, utils.ErrPhoneAlreadyExists
+		if req.Phone, err = canonicalPhone(s.userRepo, req.Phone, ""); err != nil {
+			return nil, err
 		}
 	}
 
@@ -135,11 +183,12 @@ func (s *AuthService) Register(req *request.RegisterRequest) (*response.UserResp
 		BaseModel: models.BaseModel{
 			ID: uuid.New(),
 		},
-		Email:        req.Email,
-		Phone:        req.Phone,
-		PasswordHash: hashedPassword,
-		Role:         req.Role,
-		IsActive:     true,
+		Email:              req.Email,
+		Phone:              req.Phone,
+		PasswordHash:       hashedPassword,
+		MustChangePassword: true,
+		Role:               req.Role,
+		IsActive:           true,
 	}
 
 	// Create profile
@@ -162,33 +211,114 @@ func (s *AuthService) Register(req *request.RegisterRequest) (*response.UserResp
 
 	// Create user with profile
 	if err := s.userRepo.CreateWithProfile(user, profile); err != nil {
+		if utils.IsUniqueViolation(err, models.UserEmailIndex) {
+			return nil, utils.ErrEmailAlreadyExists
+		}
 		logger.Error("Failed to create user", zap.Error(err))
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	user.Profile = profile
+
+	// Issue the initial verification token
+	if err := s.issueVerificationToken(user); err != nil {
+		logger.Error("Failed to issue verification token", zap.Error(err))
+	}
+
+	name := profile.FullName()
+	s.sendMailAsync("welcome email", user.ID, func() error {
+		return s.mailer.SendWelcome(user.Email, name)
+	})
+
 	resp := s.toUserResponse(user)
 	return &resp, nil
 }
 
+// findLoginUser resolves the user a login request refers to.
+// A generic identifier is treated as an email if it contains "@"; otherwise it is
+// matched against admission numbers of the X-Institution-ID institution first and
+// phone numbers second. Any failure is reported as ErrInvalidCredentials.
+func (s *AuthService) findLoginUser(req *request.LoginRequest) (*models.User, error) {
+	switch {
+	case req.Email != "":
+		return s.userRepo.FindByEmail(req.Email)
+	case req.Phone != "":
+		return s.userRepo.FindByPhone(loginPhone(req.Phone))
+	}
+
+	identifier := strings.TrimSpace(req.Identifier)
+	if identifier == "" {
+		return nil, utils.ErrInvalidCredentials
+	}
+
+	if strings.Contains(identifier, "@") {
+		return s.userRepo.FindByEmail(identifier)
+	}
+
+	if req.InstitutionID != "" {
+		institutionID, err := uuid.Parse(req.InstitutionID)
+		if err != nil {
+			return nil, utils.ErrInvalidCredentials
+		}
+		user, err := s.userRepo.FindByAdmissionNumber(institutionID, identifier)
+		if err == nil {
+			return user, nil
+		}
+		if err != utils.ErrUserNotFound {
+			return nil, err
+		}
+	}
+
+	return s.userRepo.FindByPhone(loginPhone(identifier))
+}
+
+// loginPhone normalizes a phone number given at login so it matches the stored
+// E.164 form. Numbers that can't be normalized are looked up as given.
+func loginPhone(phone string) string {
+	if normalized, err := utils.NormalizePhone(phone); err == nil {
+		return normalized
+	}
+	return phone
+}
+
 // RefreshToken generates new tokens using a refresh token
 func (s *AuthService) RefreshToken(req *request.RefreshTokenRequest) (*response.TokenResponse, error) {
 	// Validate refresh token
-	userID, err := s.jwtManager.ValidateRefreshToken(req.RefreshToken)
+	userID, jti, err := s.jwtManager.ValidateRefreshToken(req.RefreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	// Find user and verify refresh token matches
-	user, err := s.userRepo.FindByID(userID)
+	// Look up the stored token
+	stored, err := s.tokenRepo.FindByJTI(jti)
 	if err != nil {
-		return nil, utils.ErrInvalidCredentials
+		return nil, err
+	}
+	if stored.UserID != userID {
+		return nil, utils.ErrRefreshTokenInvalid
 	}
 
-	if user.RefreshToken != req.RefreshToken {
+	// A token that was already rotated is being replayed: revoke the whole session
+	if stored.UsedAt != nil {
+		if err := s.tokenRepo.RevokeFamily(stored.FamilyID); err != nil {
+			logger.Error("Failed to revoke token family", zap.Error(err))
+		}
+		logger.Warn("Refresh token reuse detected",
+			zap.String("user_id", userID.String()),
+			zap.String("family_id", stored.FamilyID.String()),
+		)
+		return nil, utils.ErrRefreshTokenReused
+	}
+	if stored.RevokedAt != nil {
 		return nil, utils.ErrRefreshTokenInvalid
 	}
 
+	// Find user
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.ErrInvalidCredentials
+	}
+
 	if !user.IsActive {
 		return nil, utils.ErrAccountDisabled
 	}
@@ -200,29 +330,46 @@ func (s *AuthService) RefreshToken(req *request.RefreshTokenRequest) (*response.
 	}
 
 	// Get permissions
-	permissions := middleware.GetPermissionsForRole(user.Role)
+	permissions := middleware.GetPermissionsForUser(user.Role, user.CustomRoleID)
 
 	// Generate new access token
 	accessToken, expiresAt, err := s.jwtManager.GenerateAccessToken(
 		user.ID,
 		user.Email,
 		user.Role,
+		user.CustomRoleID,
 		institutionID,
 		permissions,
+		user.MustChangePassword,
 	)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	// Generate new refresh token
-	refreshToken, _, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	refreshToken, newJTI, refreshExpiry, err := s.jwtManager.GenerateRefreshToken(user.ID)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
-	// Save new refresh token
-	if err := s.userRepo.SaveRefreshToken(user.ID, refreshToken); err != nil {
-		logger.Error("Failed to save refresh token", zap.Error(err))
+	// Rotate: mark the old token used and store the new one in the same family
+	next := &models.RefreshToken{
+		UserID:    user.ID,
+		JTI:       newJTI,
+		FamilyID:  stored.FamilyID,
+		ExpiresAt: refreshExpiry,
+		UserAgent: req.UserAgent,
+		IPAddress: req.IPAddress,
+	}
+	if err := s.tokenRepo.Rotate(stored, next); err != nil {
+		if err == utils.ErrRefreshTokenReused {
+			// Lost a race with another exchange of the same token
+			if err := s.tokenRepo.RevokeFamily(stored.FamilyID); err != nil {
+				logger.Error("Failed to revoke token family", zap.Error(err))
+			}
+			return nil, utils.ErrRefreshTokenReused
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	return &response.TokenResponse{
@@ -233,13 +380,90 @@ func (s *AuthService) RefreshToken(req *request.RefreshTokenRequest) (*response.
 	}, nil
 }
 
-// Logout invalidates the user's refresh token
-func (s *AuthService) Logout(userID uuid.UUID) error {
-	return s.userRepo.InvalidateRefreshToken(userID)
+// Logout revokes the session the refresh token belongs to,
+// or every session of the user if no refresh token is given
+func (s *AuthService) Logout(userID uuid.UUID, req *request.LogoutRequest) error {
+	if req == nil || req.RefreshToken == "" {
+		return s.tokenRepo.RevokeAllForUser(userID)
+	}
+
+	tokenUserID, jti, err := s.jwtManager.ValidateRefreshToken(req.RefreshToken)
+	if err != nil || tokenUserID != userID {
+		return utils.ErrRefreshTokenInvalid
+	}
+
+	stored, err := s.tokenRepo.FindByJTI(jti)
+	if err != nil {
+		return err
+	}
+
+	return s.tokenRepo.RevokeFamily(stored.FamilyID)
+}
+
+// LogoutAll revokes every session of the user and rejects their existing access tokens.
+// It reports whether the access tokens were revoked immediately; without Redis
+// they stay valid until they expire.
+func (s *AuthService) LogoutAll(userID uuid.UUID) (bool, error) {
+	if err := s.tokenRepo.RevokeAllForUser(userID); err != nil {
+		return false, utils.ErrInternalServer.Wrap(err)
+	}
+	return revokeAccessTokens(s.jwtManager, userID), nil
+}
+
+// revokeAccessTokens rejects the user's unexpired access tokens issued by
+// jwtManager and reports whether that worked; it needs Redis
+func revokeAccessTokens(jwtManager *utils.JWTManager, userID uuid.UUID) bool {
+	revoked := middleware.RevokeUserTokens(userID, jwtManager.AccessExpiry())
+	if !revoked {
+		logger.Warn("Access tokens not revoked, Redis unavailable", zap.String("user_id", userID.String()))
+	}
+	return revoked
+}
+
+// GetSessions returns the user's active sessions
+func (s *AuthService) GetSessions(userID uuid.UUID) ([]response.SessionResponse, error) {
+	tokens, err := s.tokenRepo.FindActiveByUser(userID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	sessions := make([]response.SessionResponse, 0, len(tokens))
+	for _, t := range tokens {
+		sessions = append(sessions, response.SessionResponse{
+			ID:        t.ID,
+			UserAgent: t.UserAgent,
+			IPAddress: t.IPAddress,
+			IssuedAt:  t.CreatedAt,
+			ExpiresAt: t.ExpiresAt,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes one of the user's sessions
+func (s *AuthService) RevokeSession(userID, sessionID uuid.UUID) error {
+	token, err := s.tokenRepo.FindByIDForUser(sessionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeFamily(token.FamilyID); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+
+	return nil
 }
 
 // ForgotPassword initiates the password reset process
+// Reset emails to the same address are throttled to one per resetEmailInterval;
+// throttled requests are silently dropped so the response stays the same.
 func (s *AuthService) ForgotPassword(req *request.ForgotPasswordRequest) error {
+	if !s.claimResetEmail(req.Email) {
+		logger.Debug("Forgot password throttled", zap.String("email", req.Email))
+		return nil
+	}
+
 	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
 		// Don't reveal if email exists
@@ -258,59 +482,175 @@ func (s *AuthService) ForgotPassword(req *request.ForgotPasswordRequest) error {
 		return utils.ErrInternalServer.Wrap(err)
 	}
 
-	// TODO: Send email with reset token
-	// For now, just log it (in development)
-	logger.Info("Password reset token generated",
-		zap.String("email", user.Email),
-		zap.String("token", resetToken),
-		zap.Time("expiry", expiry),
-	)
+	// Send the reset link in the background so the response doesn't reveal anything
+	link := s.buildLink("/reset-password", resetToken)
+	s.sendMailAsync("password reset email", user.ID, func() error {
+		return s.mailer.SendResetPassword(user.Email, link)
+	})
 
 	return nil
 }
 
+// claimResetEmail reports whether a reset email may be sent to the address now
+// and, if so, records the send. The address is stored hashed. When Redis is
+// unavailable every request is allowed and only the IP rate limit applies.
+func (s *AuthService) claimResetEmail(email string) bool {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	stored, ok := s.resetThrottle.SetIfAbsent(hex.EncodeToString(sum[:]), time.Now().Unix(), resetEmailInterval)
+	return stored || !ok
+}
+
 // ResetPassword resets the user's password using a reset token
 func (s *AuthService) ResetPassword(req *request.ResetPasswordRequest) error {
-	// Validate reset token
-	userID, err := s.jwtManager.ValidateResetToken(req.Token)
+	user, err := s.resetTokenUser(req.Token)
 	if err != nil {
 		return err
 	}
 
-	// Find user and verify token matches
-	user, err := s.userRepo.FindByResetToken(req.Token)
-	if err != nil {
+	if err := s.setPassword(user, req.NewPassword); err != nil {
 		return err
 	}
 
+	// Clear reset token
+	if err := s.userRepo.ClearResetToken(user.ID); err != nil {
+		logger.Error("Failed to clear reset token", zap.Error(err))
+	}
+
+	// Invalidate all refresh tokens
+	if err := s.tokenRepo.RevokeAllForUser(user.ID); err != nil {
+		logger.Error("Failed to revoke refresh tokens", zap.Error(err))
+	}
+
+	return nil
+}
+
+// ValidateResetToken checks that a password reset token can still be used,
+// without consuming it, and reports how long it remains valid
+func (s *AuthService) ValidateResetToken(token string) (*response.ResetTokenValidationResponse, error) {
+	user, err := s.resetTokenUser(token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.ResetTokenValidationResponse{Valid: true}
+	if user.ResetTokenExpiry != nil {
+		resp.ExpiresAt = *user.ResetTokenExpiry
+		resp.ExpiresIn = int64(time.Until(*user.ResetTokenExpiry).Seconds())
+	}
+	return resp, nil
+}
+
+// resetTokenUser finds the user a password reset token was issued to.
+// The token must be valid and still be the user's latest reset token.
+func (s *AuthService) resetTokenUser(token string) (*models.User, error) {
+	userID, err := s.jwtManager.ValidateResetToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByResetToken(token)
+	if err != nil {
+		return nil, err
+	}
+
 	if user.ID != userID {
-		return utils.ErrResetTokenInvalid
+		return nil, utils.ErrResetTokenInvalid
 	}
+	return user, nil
+}
 
-	// Hash new password
-	hashedPassword, err := utils.HashPassword(req.NewPassword)
+// SendVerification issues a new email verification token for the given email.
+// Any previously issued token is invalidated. Unknown and already verified
+// emails are silently ignored so the caller can't tell which accounts exist.
+func (s *AuthService) SendVerification(req *request.SendVerificationRequest) error {
+	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
-		return utils.ErrInternalServer.Wrap(err)
+		// Don't reveal if email exists
+		logger.Debug("Verification requested for non-existent email", zap.String("email", req.Email))
+		return nil
 	}
 
-	// Update password
-	if err := s.userRepo.UpdatePassword(user.ID, hashedPassword); err != nil {
+	if user.EmailVerified {
+		return nil
+	}
+
+	if err := s.issueVerificationToken(user); err != nil {
 		return utils.ErrInternalServer.Wrap(err)
 	}
 
-	// Clear reset token
-	if err := s.userRepo.ClearResetToken(user.ID); err != nil {
-		logger.Error("Failed to clear reset token", zap.Error(err))
+	return nil
+}
+
+// VerifyEmail verifies a user's email using a verification token.
+// Returns true if the account had already been verified.
+func (s *AuthService) VerifyEmail(token string) (bool, error) {
+	userID, err := s.jwtManager.ValidateVerificationToken(token)
+	if err != nil {
+		return false, err
 	}
 
-	// Invalidate all refresh tokens
-	if err := s.userRepo.InvalidateRefreshToken(user.ID); err != nil {
-		logger.Error("Failed to invalidate refresh token", zap.Error(err))
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return false, utils.ErrVerificationInvalid
+	}
+
+	if user.EmailVerified {
+		return true, nil
 	}
 
+	// Only the most recently issued token is accepted
+	if user.VerificationToken == "" || user.VerificationToken != token {
+		return false, utils.ErrVerificationInvalid
+	}
+
+	if err := s.userRepo.MarkEmailVerified(user.ID); err != nil {
+		return false, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return false, nil
+}
+
+// issueVerificationToken generates and stores a fresh verification token
+func (s *AuthService) issueVerificationToken(user *models.User) error {
+	token, _, err := s.jwtManager.GenerateVerificationToken(user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SaveVerificationToken(user.ID, token); err != nil {
+		return err
+	}
+
+	link := s.buildLink("/verify-email", token)
+	s.sendMailAsync("verification email", user.ID, func() error {
+		return s.mailer.SendVerification(user.Email, link)
+	})
+
 	return nil
 }
 
+// sendMailAsync sends an email in the background so a slow mail server doesn't
+// hold up the request and its response time doesn't reveal whether the address
+// belongs to an account. Failures are logged.
+func (s *AuthService) sendMailAsync(description string, userID uuid.UUID, send func() error) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Panic while sending "+description, zap.String("user_id", userID.String()), zap.Any("panic", r))
+			}
+		}()
+
+		if err := send(); err != nil {
+			logger.Error("Failed to send "+description, zap.String("user_id", userID.String()), zap.Error(err))
+		}
+	}()
+}
+
+// buildLink builds a frontend link carrying a token query parameter
+func (s *AuthService) buildLink(path, token string) string {
+	return s.appURL + path + "?token=" + url.QueryEscape(token)
+}
+
 // ChangePassword changes the user's password
 func (s *AuthService) ChangePassword(userID uuid.UUID, req *request.ChangePasswordRequest) error {
 	user, err := s.userRepo.FindByID(userID)
@@ -323,16 +663,59 @@ func (s *AuthService) ChangePassword(userID uuid.UUID, req *request.ChangePasswo
 		return utils.ErrInvalidCredentials
 	}
 
-	// Hash new password
-	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	return s.setPassword(user, req.NewPassword)
+}
+
+// setPassword replaces a user's password, rejecting recently used passwords,
+// and records the replaced hash in the password history
+func (s *AuthService) setPassword(user *models.User, newPassword string) error {
+	if err := s.checkPasswordReuse(user, newPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
 		return utils.ErrInternalServer.Wrap(err)
 	}
 
-	// Update password
-	if err := s.userRepo.UpdatePassword(userID, hashedPassword); err != nil {
+	if err := s.userRepo.UpdatePassword(user.ID, hashedPassword); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+
+	// The current password is checked from the user row, so only older ones are kept
+	if s.historySize > 1 {
+		if err := s.historyRepo.Record(user.ID, user.PasswordHash, s.historySize-1); err != nil {
+			logger.Error("Failed to record password history", zap.String("user_id", user.ID.String()), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// checkPasswordReuse returns ErrPasswordReused if the password matches the
+// current password or one of the recently replaced ones
+func (s *AuthService) checkPasswordReuse(user *models.User, password string) error {
+	if s.historySize <= 0 {
+		return nil
+	}
+
+	if user.PasswordHash != "" && utils.CheckPassword(password, user.PasswordHash) {
+		return utils.ErrPasswordReused
+	}
+
+	if s.historySize == 1 {
+		return nil
+	}
+
+	hashes, err := s.historyRepo.FindRecent(user.ID, s.historySize-1)
+	if err != nil {
 		return utils.ErrInternalServer.Wrap(err)
 	}
+	for _, hash := range hashes {
+		if utils.CheckPassword(password, hash) {
+			return utils.ErrPasswordReused
+		}
+	}
 
 	return nil
 }
@@ -351,12 +734,14 @@ func (s *AuthService) GetCurrentUser(userID uuid.UUID) (*response.UserResponse,
 // toUserResponse converts a user model to response DTO
 func (s *AuthService) toUserResponse(user *models.User) response.UserResponse {
 	resp := response.UserResponse{
-		ID:          user.ID,
-		Email:       user.Email,
-		Phone:       user.Phone,
-		Role:        user.Role,
-		IsActive:    user.IsActive,
-		LastLoginAt: user.LastLoginAt,
+		ID:            user.ID,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Role:          user.Role,
+		CustomRoleID:  user.CustomRoleID,
+		IsActive:      user.IsActive,
+		EmailVerified: user.EmailVerified,
+		LastLoginAt:   user.LastLoginAt,
 	}
 
 	if user.Profile != nil {
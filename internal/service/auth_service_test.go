@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+	"campus-core/pkg/mailer"
+
+	"github.com/google/uuid"
+)
+
+func TestFindLoginUserResolvesAmbiguousIdentifiers(t *testing.T) {
+	db := testutil.DB(t)
+	s := &AuthService{userRepo: repository.NewUserRepository(db)}
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+
+	// The same digits are an admission number in school A and, read as a
+	// national number, another user's phone
+	student := testutil.User(t, db, models.RoleStudent, &schoolA.ID)
+	if err := db.Model(&models.UserProfile{}).Where("user_id = ?", student.ID).
+		Update("admission_number", "01712345678").Error; err != nil {
+		t.Fatalf("failed to set admission number: %v", err)
+	}
+	parent := testutil.User(t, db, models.RoleParent, &schoolB.ID)
+	if err := db.Model(&models.User{}).Where("id = ?", parent.ID).
+		Update("phone", "+8801712345678").Error; err != nil {
+		t.Fatalf("failed to set phone: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		req      request.LoginRequest
+		wantUser uuid.UUID
+		wantErr  error
+	}{
+		{
+			name:     "admission number in the header's institution wins over phone",
+			req:      request.LoginRequest{Identifier: "01712345678", InstitutionID: schoolA.ID.String()},
+			wantUser: student.ID,
+		},
+		{
+			name:     "without an institution the identifier is a phone",
+			req:      request.LoginRequest{Identifier: "01712345678"},
+			wantUser: parent.ID,
+		},
+		{
+			name:     "admission number of another institution falls back to phone",
+			req:      request.LoginRequest{Identifier: "01712345678", InstitutionID: schoolB.ID.String()},
+			wantUser: parent.ID,
+		},
+		{
+			name:     "phone in another format",
+			req:      request.LoginRequest{Identifier: "+880 1712-345678", InstitutionID: schoolA.ID.String()},
+			wantUser: parent.ID,
+		},
+		{
+			name:     "identifier with @ is an email",
+			req:      request.LoginRequest{Identifier: student.Email, InstitutionID: schoolB.ID.String()},
+			wantUser: student.ID,
+		},
+		{
+			name:     "explicit phone skips admission numbers",
+			req:      request.LoginRequest{Phone: "01712345678", InstitutionID: schoolA.ID.String()},
+			wantUser: parent.ID,
+		},
+		{
+			name:    "malformed institution header",
+			req:     request.LoginRequest{Identifier: "01712345678", InstitutionID: "not-a-uuid"},
+			wantErr: utils.ErrInvalidCredentials,
+		},
+		{
+			name:    "blank identifier",
+			req:     request.LoginRequest{Identifier: "   "},
+			wantErr: utils.ErrInvalidCredentials,
+		},
+		{
+			name:    "unknown admission number",
+			req:     request.LoginRequest{Identifier: "ADM-404", InstitutionID: schoolA.ID.String()},
+			wantErr: utils.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := s.findLoginUser(&tt.req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("findLoginUser() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findLoginUser() unexpected error: %v", err)
+			}
+			if user.ID != tt.wantUser {
+				t.Errorf("findLoginUser() = user %s, want %s", user.ID, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestSendVerificationDoesNotRevealAccounts(t *testing.T) {
+	db := testutil.DB(t)
+	s := &AuthService{
+		userRepo:   repository.NewUserRepository(db),
+		jwtManager: utils.NewJWTManager("test-secret", 15*time.Minute, time.Hour),
+		mailer:     mailer.NewNoopMailer(),
+	}
+
+	school := testutil.Institution(t, db)
+	unverified := testutil.User(t, db, models.RoleTeacher, &school.ID)
+	verified := testutil.User(t, db, models.RoleTeacher, &school.ID)
+	if err := db.Model(&models.User{}).Where("id = ?", verified.ID).Update("email_verified", true).Error; err != nil {
+		t.Fatalf("failed to verify email: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		email     string
+		user      *models.User
+		wantToken bool
+	}{
+		{name: "unverified account", email: unverified.Email, user: unverified, wantToken: true},
+		{name: "verified account", email: verified.Email, user: verified},
+		{name: "unknown email", email: "nobody-" + uuid.NewString() + "@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.SendVerification(&request.SendVerificationRequest{Email: tt.email}); err != nil {
+				t.Fatalf("SendVerification() error = %v, want nil", err)
+			}
+			if tt.user == nil {
+				return
+			}
+
+			var stored models.User
+			if err := db.First(&stored, "id = ?", tt.user.ID).Error; err != nil {
+				t.Fatalf("failed to reload user: %v", err)
+			}
+			if got := stored.VerificationToken != ""; got != tt.wantToken {
+				t.Errorf("SendVerification() issued token = %v, want %v", got, tt.wantToken)
+			}
+		})
+	}
+}
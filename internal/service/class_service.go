@@ -2,6 +2,8 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
@@ -10,6 +12,14 @@ import (
 	"campus-core/internal/utils"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Duplicate names are conflicts, whether found by the checks below or by the
+// unique indexes when two requests race past them
+var (
+	errClassNameExists   = utils.ErrResourceExists.Wrap(errors.New("class with this name already exists"))
+	errSectionNameExists = utils.ErrResourceExists.Wrap(errors.New("section with this name already exists in class"))
 )
 
 // ClassService handles class business logic
@@ -17,33 +27,38 @@ type ClassService struct {
 	classRepo   *repository.ClassRepository
 	sectionRepo *repository.SectionRepository
 	teacherRepo *repository.TeacherRepository
+	studentRepo *repository.StudentRepository
+	db          *gorm.DB
 }
 
 // NewClassService creates a new class service
-func NewClassService(classRepo *repository.ClassRepository, sectionRepo *repository.SectionRepository, teacherRepo *repository.TeacherRepository) *ClassService {
+func NewClassService(classRepo *repository.ClassRepository, sectionRepo *repository.SectionRepository, teacherRepo *repository.TeacherRepository, studentRepo *repository.StudentRepository, db *gorm.DB) *ClassService {
 	return &ClassService{
 		classRepo:   classRepo,
 		sectionRepo: sectionRepo,
 		teacherRepo: teacherRepo,
+		studentRepo: studentRepo,
+		db:          db,
 	}
 }
 
 // CreateClass creates a new class
-func (s *ClassService) CreateClass(req *request.CreateClassRequest, institutionID uuid.UUID) (*response.ClassResponse, error) {
+func (s *ClassService) CreateClass(req *request.CreateClassRequest, institutionID, actorID uuid.UUID) (*response.ClassResponse, error) {
 	// Check if name already exists
 	exists, err := s.classRepo.NameExists(req.Name, institutionID, nil)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 	if exists {
-		return nil, errors.New("class with this name already exists")
+		return nil, errClassNameExists
 	}
 
 	class := &models.Class{
-		InstitutionID: institutionID,
-		Name:          req.Name,
-		Capacity:      req.Capacity,
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		Name:            req.Name,
+		Capacity:        req.Capacity,
 	}
+	class.SetCreatedBy(actorID)
 
 	// Set class teacher if provided
 	if req.ClassTeacherID != "" {
@@ -51,14 +66,16 @@ func (s *ClassService) CreateClass(req *request.CreateClassRequest, institutionI
 		if err != nil {
 			return nil, utils.ErrInvalidUUID
 		}
-		// Verify teacher exists
-		if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
-			return nil, errors.New("class teacher not found")
+		if err := s.checkClassTeacher(teacherID, institutionID, nil, 1); err != nil {
+			return nil, err
 		}
 		class.ClassTeacherID = &teacherID
 	}
 
 	if err := s.classRepo.Create(class); err != nil {
+		if utils.IsUniqueViolation(err) {
+			return nil, errClassNameExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -78,6 +95,9 @@ func (s *ClassService) GetClassByID(id, institutionID uuid.UUID) (*response.Clas
 func (s *ClassService) GetAllClasses(filter repository.ClassFilter, params utils.PaginationParams) ([]response.ClassResponse, utils.Pagination, error) {
 	classes, total, err := s.classRepo.FindAll(filter, params)
 	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok {
+			return nil, utils.Pagination{}, appErr // e.g. unsupported sort column
+		}
 		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -91,11 +111,14 @@ func (s *ClassService) GetAllClasses(filter repository.ClassFilter, params utils
 }
 
 // UpdateClass updates a class
-func (s *ClassService) UpdateClass(id uuid.UUID, req *request.UpdateClassRequest, institutionID uuid.UUID) (*response.ClassResponse, error) {
+func (s *ClassService) UpdateClass(id uuid.UUID, req *request.UpdateClassRequest, institutionID, actorID uuid.UUID) (*response.ClassResponse, error) {
 	class, err := s.classRepo.FindByIDWithInstitution(id, institutionID)
 	if err != nil {
 		return nil, err
 	}
+	if *req.Version != class.Version {
+		return nil, utils.ErrVersionConflict
+	}
 
 	// Update fields if provided
 	if req.Name != "" && req.Name != class.Name {
@@ -104,7 +127,7 @@ func (s *ClassService) UpdateClass(id uuid.UUID, req *request.UpdateClassRequest
 			return nil, utils.ErrInternalServer.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("class with this name already exists")
+			return nil, errClassNameExists
 		}
 		class.Name = req.Name
 	}
@@ -118,20 +141,132 @@ func (s *ClassService) UpdateClass(id uuid.UUID, req *request.UpdateClassRequest
 		if err != nil {
 			return nil, utils.ErrInvalidUUID
 		}
-		// Verify teacher exists
-		if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
-			return nil, errors.New("class teacher not found")
+		if err := s.checkClassTeacher(teacherID, institutionID, []uuid.UUID{class.ID}, 1); err != nil {
+			return nil, err
 		}
 		class.ClassTeacherID = &teacherID
 	}
 
+	class.SetUpdatedBy(actorID)
 	if err := s.classRepo.Update(class); err != nil {
+		if errors.Is(err, utils.ErrVersionConflict) {
+			return nil, err
+		}
+		if utils.IsUniqueViolation(err) {
+			return nil, errClassNameExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	return s.toClassResponse(class), nil
 }
 
+// AssignClassTeachers sets the class teachers of several classes at once, e.g.
+// at the start of a year. An empty teacher ID removes the class teacher. Every
+// assignment is validated first; if any fails nothing is changed and the
+// results say why. Otherwise all classes are updated in one transaction.
+func (s *ClassService) AssignClassTeachers(req *request.AssignClassTeachersRequest, institutionID, actorID uuid.UUID) (*response.ClassTeacherAssignmentResponse, error) {
+	type assignment struct {
+		class     *models.Class
+		teacherID *uuid.UUID
+		result    *response.ClassTeacherAssignmentResult
+	}
+
+	resp := &response.ClassTeacherAssignmentResponse{
+		Results: make([]response.ClassTeacherAssignmentResult, 0, len(req.Assignments)),
+	}
+	assignments := make([]assignment, 0, len(req.Assignments))
+	classIDs := make([]uuid.UUID, 0, len(req.Assignments))
+	classesPerTeacher := make(map[uuid.UUID]int)
+
+	for rawClassID, rawTeacherID := range req.Assignments {
+		classID, err := uuid.Parse(rawClassID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		var teacherID *uuid.UUID
+		if rawTeacherID != "" {
+			id, err := uuid.Parse(rawTeacherID)
+			if err != nil {
+				return nil, utils.ErrInvalidUUID
+			}
+			teacherID = &id
+			classesPerTeacher[id]++
+		}
+
+		resp.Results = append(resp.Results, response.ClassTeacherAssignmentResult{ClassID: classID, TeacherID: teacherID})
+		assignments = append(assignments, assignment{teacherID: teacherID})
+		classIDs = append(classIDs, classID)
+	}
+	for i := range assignments {
+		assignments[i].result = &resp.Results[i]
+	}
+
+	// Each teacher is checked once against all the classes they will lead
+	teacherErrs := make(map[uuid.UUID]error, len(classesPerTeacher))
+	for teacherID, count := range classesPerTeacher {
+		teacherErrs[teacherID] = s.checkClassTeacher(teacherID, institutionID, classIDs, count)
+	}
+
+	for i := range assignments {
+		a := &assignments[i]
+		class, err := s.classRepo.FindByIDWithInstitution(a.result.ClassID, institutionID)
+		if err != nil {
+			if !errors.Is(err, utils.ErrNotFound) {
+				return nil, utils.ErrInternalServer.Wrap(err)
+			}
+			a.result.Error = "class not found"
+			resp.Failed++
+			continue
+		}
+		a.class = class
+		a.result.ClassName = class.Name
+		a.result.PreviousTeacherID = class.ClassTeacherID
+
+		if a.teacherID != nil {
+			if err := teacherErrs[*a.teacherID]; err != nil {
+				var appErr *utils.AppError
+				if errors.As(err, &appErr) && appErr.Code == utils.ErrInternalServer.Code {
+					return nil, err
+				}
+				a.result.Error = err.Error()
+				resp.Failed++
+			}
+		}
+	}
+
+	sort.Slice(resp.Results, func(i, j int) bool {
+		return resp.Results[i].ClassName < resp.Results[j].ClassName
+	})
+	if resp.Failed > 0 {
+		return resp, utils.ErrUnprocessableEntity
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		classRepo := s.classRepo.WithTx(tx)
+		for _, a := range assignments {
+			a.class.ClassTeacherID = a.teacherID
+			a.class.SetUpdatedBy(actorID)
+			if err := classRepo.Update(a.class); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	for i := range resp.Results {
+		resp.Results[i].Success = true
+	}
+	resp.Assigned = len(resp.Results)
+	return resp, nil
+}
+
 // DeleteClass deletes a class
 func (s *ClassService) DeleteClass(id, institutionID uuid.UUID) error {
 	// Verify it exists and belongs to the institution
@@ -152,17 +287,52 @@ func (s *ClassService) DeleteClass(id, institutionID uuid.UUID) error {
 	return s.classRepo.Delete(id)
 }
 
-// GetClassStudents gets all students in a class
-func (s *ClassService) GetClassStudents(classID, institutionID uuid.UUID) ([]response.UserResponse, error) {
+// GetClassStudents gets a page of the students in a class ordered by roll number
+func (s *ClassService) GetClassStudents(classID, institutionID uuid.UUID, params utils.PaginationParams) ([]response.UserResponse, utils.Pagination, error) {
 	// Verify class exists and belongs to the institution
 	_, err := s.classRepo.FindByIDWithInstitution(classID, institutionID)
 	if err != nil {
+		return nil, utils.Pagination{}, err
+	}
+
+	students, total, err := s.studentRepo.FindByClassID(classID, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return toStudentUserResponses(students), utils.NewPagination(params.Page, params.PerPage, total), nil
+}
+
+// ReassignRollNumbers renumbers the students of a class from 1 by name,
+// separately for each section, or only those of the given section
+func (s *ClassService) ReassignRollNumbers(classID, institutionID uuid.UUID, req *request.ReassignRollNumbersRequest) (*response.RollReassignmentResponse, error) {
+	// Verify class exists and belongs to the institution
+	if _, err := s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
 		return nil, err
 	}
 
-	// This would need a student service/repository integration
-	// For now, return empty slice - will be implemented with student service
-	return []response.UserResponse{}, nil
+	var sectionID *uuid.UUID
+	if req.SectionID != "" {
+		id, err := uuid.Parse(req.SectionID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		section, err := s.sectionRepo.FindByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if section.ClassID != classID {
+			return nil, utils.ErrNotFound
+		}
+		sectionID = &id
+	}
+
+	renumbered, err := s.studentRepo.RenumberRolls(classID, sectionID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return &response.RollReassignmentResponse{Renumbered: renumbered}, nil
 }
 
 // GetClassTeachers gets all teachers assigned to a class
@@ -189,8 +359,7 @@ func (s *ClassService) GetClassTeachers(classID, institutionID uuid.UUID) ([]res
 // CreateSection creates a new section for a class
 func (s *ClassService) CreateSection(classID uuid.UUID, req *request.CreateSectionRequest, institutionID uuid.UUID) (*response.SectionResponse, error) {
 	// Verify class exists and belongs to the institution
-	class, err := s.classRepo.FindByIDWithInstitution(classID, institutionID)
-	if err != nil {
+	if _, err := s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
 		return nil, err
 	}
 
@@ -200,7 +369,7 @@ func (s *ClassService) CreateSection(classID uuid.UUID, req *request.CreateSecti
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 	if exists {
-		return nil, errors.New("section with this name already exists in class")
+		return nil, errSectionNameExists
 	}
 
 	section := &models.Section{
@@ -210,14 +379,20 @@ func (s *ClassService) CreateSection(classID uuid.UUID, req *request.CreateSecti
 		Capacity:   req.Capacity,
 	}
 
-	if err := s.sectionRepo.Create(section); err != nil {
+	// Keep the class's section count in step with its sections
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.sectionRepo.WithTx(tx).Create(section); err != nil {
+			return err
+		}
+		return s.classRepo.WithTx(tx).RecountSections(classID)
+	})
+	if err != nil {
+		if utils.IsUniqueViolation(err) {
+			return nil, errSectionNameExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
-	// Update section count in class
-	class.SectionCount++
-	_ = s.classRepo.Update(class)
-
 	return s.toSectionResponse(section), nil
 }
 
@@ -242,6 +417,38 @@ func (s *ClassService) GetSectionsByClass(classID, institutionID uuid.UUID) ([]r
 	return responses, nil
 }
 
+// GetSectionsWithCounts gets all sections for a class with the number of
+// students in each and the places left, never less than zero
+func (s *ClassService) GetSectionsWithCounts(classID, institutionID uuid.UUID) ([]response.SectionWithCountResponse, error) {
+	if _, err := s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
+		return nil, err
+	}
+
+	sections, err := s.sectionRepo.FindByClassID(classID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	counts, err := s.sectionRepo.StudentCountsByClass(classID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.SectionWithCountResponse, 0, len(sections))
+	for i := range sections {
+		resp := response.SectionWithCountResponse{
+			SectionResponse: *s.toSectionResponse(&sections[i]),
+			StudentCount:    counts[sections[i].ID],
+		}
+		if capacity := sections[i].Capacity; capacity > 0 {
+			remaining := max(capacity-int(resp.StudentCount), 0)
+			resp.RemainingCapacity = &remaining
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
 // UpdateSection updates a section
 func (s *ClassService) UpdateSection(sectionID uuid.UUID, req *request.UpdateSectionRequest) (*response.SectionResponse, error) {
 	section, err := s.sectionRepo.FindByID(sectionID)
@@ -256,7 +463,7 @@ func (s *ClassService) UpdateSection(sectionID uuid.UUID, req *request.UpdateSec
 			return nil, utils.ErrInternalServer.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("section with this name already exists in class")
+			return nil, errSectionNameExists
 		}
 		section.Name = req.Name
 	}
@@ -269,6 +476,9 @@ func (s *ClassService) UpdateSection(sectionID uuid.UUID, req *request.UpdateSec
 	}
 
 	if err := s.sectionRepo.Update(section); err != nil {
+		if utils.IsUniqueViolation(err) {
+			return nil, errSectionNameExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -291,27 +501,96 @@ func (s *ClassService) DeleteSection(sectionID uuid.UUID) error {
 		return errors.New("cannot delete section with students")
 	}
 
-	// Update section count in class
-	class, err := s.classRepo.FindByID(section.ClassID)
-	if err == nil && class.SectionCount > 0 {
-		class.SectionCount--
-		_ = s.classRepo.Update(class)
+	// Timetable entries filter by section and would be orphaned
+	entries, err := s.sectionRepo.GetSectionTimetableCount(sectionID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if entries > 0 {
+		return utils.ErrResourceInUse
 	}
 
-	return s.sectionRepo.Delete(sectionID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.sectionRepo.WithTx(tx).Delete(sectionID); err != nil {
+			return err
+		}
+		return s.classRepo.WithTx(tx).RecountSections(section.ClassID)
+	})
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
 }
 
-// GetSectionStudents gets all students in a section
-func (s *ClassService) GetSectionStudents(sectionID uuid.UUID) ([]response.UserResponse, error) {
-	// Verify section exists
-	_, err := s.sectionRepo.FindByID(sectionID)
+// RecountSections repairs the stored section counts of an institution's classes
+func (s *ClassService) RecountSections(institutionID uuid.UUID) (*response.SectionRecountResponse, error) {
+	corrected, err := s.classRepo.RecountAllSections(institutionID)
 	if err != nil {
-		return nil, err
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
+	return &response.SectionRecountResponse{Corrected: corrected}, nil
+}
 
-	// This would need a student service/repository integration
-	// For now, return empty slice - will be implemented with student service
-	return []response.UserResponse{}, nil
+// GetSectionStudents gets a page of the students in a section ordered by roll number
+func (s *ClassService) GetSectionStudents(sectionID, institutionID uuid.UUID, params utils.PaginationParams) ([]response.UserResponse, utils.Pagination, error) {
+	// Verify section exists and its class belongs to the institution
+	section, err := s.sectionRepo.FindByID(sectionID)
+	if err != nil {
+		return nil, utils.Pagination{}, err
+	}
+	if section.Class == nil || section.Class.InstitutionID != institutionID {
+		return nil, utils.Pagination{}, utils.ErrNotFound
+	}
+
+	students, total, err := s.studentRepo.FindBySectionID(sectionID, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return toStudentUserResponses(students), utils.NewPagination(params.Page, params.PerPage, total), nil
+}
+
+// checkClassTeacher verifies the class teacher belongs to the institution and,
+// when the institution caps the classes a teacher leads, that the teacher can
+// take on adding more classes besides those in excludeClassIDs
+func (s *ClassService) checkClassTeacher(teacherID, institutionID uuid.UUID, excludeClassIDs []uuid.UUID, adding int) error {
+	if _, err := s.teacherRepo.FindByIDWithInstitution(teacherID, institutionID); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return errors.New("class teacher not found")
+		}
+		return utils.ErrInternalServer.Wrap(err)
+	}
+
+	limit, err := classTeacherLimit(s.db, institutionID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if limit == nil {
+		return nil
+	}
+
+	count, err := s.classRepo.CountByClassTeacher(teacherID, excludeClassIDs)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if int(count)+adding > *limit {
+		return fmt.Errorf("teacher would lead %d classes, the maximum is %d", int(count)+adding, *limit)
+	}
+	return nil
+}
+
+// classTeacherLimit returns the most classes a teacher may lead as class
+// teacher in an institution, or nil when there is no limit
+func classTeacherLimit(db *gorm.DB, institutionID uuid.UUID) (*int, error) {
+	var settings models.InstitutionSettings
+	err := db.Select("max_classes_per_class_teacher").First(&settings, "institution_id = ?", institutionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings.MaxClassesPerClassTeacher, nil
 }
 
 // Helper methods for converting models to responses
@@ -322,9 +601,11 @@ func (s *ClassService) toClassResponse(class *models.Class) *response.ClassRespo
 		Name:          class.Name,
 		SectionCount:  class.SectionCount,
 		Capacity:      class.Capacity,
+		Version:       class.Version,
 		CreatedAt:     class.CreatedAt,
 		UpdatedAt:     class.UpdatedAt,
 	}
+	resp.CreatedBy, resp.UpdatedBy = authorBriefs(class.Authorship)
 
 	if class.ClassTeacherID != nil {
 		resp.ClassTeacherID = class.ClassTeacherID
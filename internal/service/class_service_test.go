@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestUpdateClassStaleVersion(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewClassService(repository.NewClassRepository(db), repository.NewSectionRepository(db),
+		repository.NewTeacherRepository(db), repository.NewStudentRepository(db), db)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	read := class.Version
+
+	// Both clients read the class at the same version before either saves
+	first := &request.UpdateClassRequest{Name: "First " + uuid.NewString()[:8], Version: &read}
+	second := &request.UpdateClassRequest{Name: "Second " + uuid.NewString()[:8], Version: &read}
+
+	resp, err := s.UpdateClass(class.ID, first, institution.ID, uuid.Nil)
+	if err != nil {
+		t.Fatalf("UpdateClass() first update unexpected error: %v", err)
+	}
+	if resp.Version != read+1 {
+		t.Errorf("UpdateClass() version = %d, want %d", resp.Version, read+1)
+	}
+
+	if _, err := s.UpdateClass(class.ID, second, institution.ID, uuid.Nil); !errors.Is(err, utils.ErrVersionConflict) {
+		t.Fatalf("UpdateClass() second update error = %v, want ErrVersionConflict", err)
+	}
+
+	// Retrying with the version the first update returned succeeds
+	second.Version = &resp.Version
+	if _, err := s.UpdateClass(class.ID, second, institution.ID, uuid.Nil); err != nil {
+		t.Fatalf("UpdateClass() retry unexpected error: %v", err)
+	}
+}
+
+// sectionCount reads the stored section count of a class
+func sectionCount(t *testing.T, db *gorm.DB, classID uuid.UUID) int {
+	t.Helper()
+
+	var class models.Class
+	if err := db.Select("section_count").First(&class, "id = ?", classID).Error; err != nil {
+		t.Fatalf("failed to load class: %v", err)
+	}
+	return class.SectionCount
+}
+
+func TestRecountSectionsRepairsDrift(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewClassService(repository.NewClassRepository(db), repository.NewSectionRepository(db),
+		repository.NewTeacherRepository(db), repository.NewStudentRepository(db), db)
+
+	institution := testutil.Institution(t, db)
+	corrupted := testutil.Class(t, db, institution.ID)
+	testutil.Section(t, db, corrupted.ID)
+	testutil.Section(t, db, corrupted.ID)
+	healthy := testutil.Class(t, db, institution.ID)
+	testutil.Section(t, db, healthy.ID)
+
+	// Another institution's drift is left alone
+	other := testutil.Class(t, db, testutil.Institution(t, db).ID)
+
+	for id, count := range map[uuid.UUID]int{corrupted.ID: 7, healthy.ID: 1, other.ID: 5} {
+		if err := db.Model(&models.Class{}).Where("id = ?", id).UpdateColumn("section_count", count).Error; err != nil {
+			t.Fatalf("failed to set section count: %v", err)
+		}
+	}
+
+	resp, err := s.RecountSections(institution.ID)
+	if err != nil {
+		t.Fatalf("RecountSections() unexpected error: %v", err)
+	}
+	if resp.Corrected != 1 {
+		t.Errorf("RecountSections() corrected %d classes, want 1", resp.Corrected)
+	}
+	if got := sectionCount(t, db, corrupted.ID); got != 2 {
+		t.Errorf("corrupted class section count = %d, want 2", got)
+	}
+	if got := sectionCount(t, db, healthy.ID); got != 1 {
+		t.Errorf("healthy class section count = %d, want 1", got)
+	}
+	if got := sectionCount(t, db, other.ID); got != 5 {
+		t.Errorf("other institution's section count = %d, want it left at 5", got)
+	}
+
+	// A second run finds nothing to repair
+	resp, err = s.RecountSections(institution.ID)
+	if err != nil {
+		t.Fatalf("RecountSections() unexpected error: %v", err)
+	}
+	if resp.Corrected != 0 {
+		t.Errorf("second RecountSections() corrected %d classes, want 0", resp.Corrected)
+	}
+}
+
+func TestSectionCountFollowsCreateAndDelete(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewClassService(repository.NewClassRepository(db), repository.NewSectionRepository(db),
+		repository.NewTeacherRepository(db), repository.NewStudentRepository(db), db)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	if err := db.Model(&models.Class{}).Where("id = ?", class.ID).UpdateColumn("section_count", 9).Error; err != nil {
+		t.Fatalf("failed to set section count: %v", err)
+	}
+
+	section, err := s.CreateSection(class.ID, &request.CreateSectionRequest{Name: "A"}, institution.ID)
+	if err != nil {
+		t.Fatalf("CreateSection() unexpected error: %v", err)
+	}
+	if got := sectionCount(t, db, class.ID); got != 1 {
+		t.Errorf("section count after CreateSection() = %d, want 1", got)
+	}
+
+	if err := s.DeleteSection(section.ID); err != nil {
+		t.Fatalf("DeleteSection() unexpected error: %v", err)
+	}
+	if got := sectionCount(t, db, class.ID); got != 0 {
+		t.Errorf("section count after DeleteSection() = %d, want 0", got)
+	}
+}
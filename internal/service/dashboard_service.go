@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/dto/response"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+	"campus-core/pkg/cache"
+
+	"github.com/google/uuid"
+)
+
+// dashboardCacheTTL is how long an institution's dashboard summary is cached.
+// It is short, since the summary is not invalidated when records change.
+const dashboardCacheTTL = time.Minute
+
+// DashboardService handles the admin dashboard summary
+type DashboardService struct {
+	repo     *repository.DashboardRepository
+	ayRepo   *repository.AcademicYearRepository
+	instRepo *repository.InstitutionRepository
+	cache    *cache.Cache // Summaries by institution ID; disabled when Redis is not connected
+}
+
+// NewDashboardService creates a new dashboard service
+func NewDashboardService(repo *repository.DashboardRepository, ayRepo *repository.AcademicYearRepository, instRepo *repository.InstitutionRepository, summaries *cache.Cache) *DashboardService {
+	return &DashboardService{
+		repo:     repo,
+		ayRepo:   ayRepo,
+		instRepo: instRepo,
+		cache:    summaries,
+	}
+}
+
+// GetSummary returns the headline numbers of an institution. Timetable entries
+// are counted for the current academic year when the institution has one.
+func (s *DashboardService) GetSummary(institutionID uuid.UUID) (*response.DashboardSummaryResponse, error) {
+	var summary response.DashboardSummaryResponse
+	if s.cache.Get(institutionID.String(), &summary) {
+		return &summary, nil
+	}
+
+	institution, err := s.instRepo.FindByID(institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var academicYearID *uuid.UUID
+	current, err := s.ayRepo.FindCurrent(institutionID)
+	switch {
+	case err == nil:
+		academicYearID = &current.ID
+		summary.CurrentAcademicYear = &response.AcademicYearBrief{
+			ID:        current.ID,
+			Name:      current.Name,
+			StartDate: current.StartDate,
+			EndDate:   current.EndDate,
+		}
+	case !errors.Is(err, utils.ErrNotFound):
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	counts, err := s.repo.Counts(institutionID, academicYearID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	summary.InstitutionID = institution.ID
+	summary.InstitutionName = institution.Name
+	summary.Students = counts.Students
+	summary.Teachers = counts.Teachers
+	summary.Parents = counts.Parents
+	summary.Classes = counts.Classes
+	summary.Subjects = counts.Subjects
+	summary.TimetableEntries = counts.TimetableEntries
+	summary.ActiveUsers = counts.ActiveUsers
+	summary.InactiveUsers = counts.InactiveUsers
+	summary.GeneratedAt = time.Now()
+
+	s.cache.Set(institutionID.String(), &summary, dashboardCacheTTL)
+	return &summary, nil
+}
@@ -2,27 +2,33 @@ package service
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
 	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/utils"
+	"campus-core/pkg/export"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // DepartmentService handles department business logic
 type DepartmentService struct {
 	deptRepo    *repository.DepartmentRepository
 	teacherRepo *repository.TeacherRepository
+	db          *gorm.DB
 }
 
 // NewDepartmentService creates a new department service
-func NewDepartmentService(deptRepo *repository.DepartmentRepository, teacherRepo *repository.TeacherRepository) *DepartmentService {
+func NewDepartmentService(deptRepo *repository.DepartmentRepository, teacherRepo *repository.TeacherRepository, db *gorm.DB) *DepartmentService {
 	return &DepartmentService{
 		deptRepo:    deptRepo,
 		teacherRepo: teacherRepo,
+		db:          db,
 	}
 }
 
@@ -38,9 +44,9 @@ func (s *DepartmentService) Create(req *request.CreateDepartmentRequest, institu
 	}
 
 	dept := &models.Department{
-		InstitutionID: institutionID,
-		Name:          req.Name,
-		Description:   req.Description,
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		Name:            req.Name,
+		Description:     req.Description,
 	}
 
 	// Set head of department if provided
@@ -56,7 +62,17 @@ func (s *DepartmentService) Create(req *request.CreateDepartmentRequest, institu
 		dept.HeadOfDepartmentID = &hodID
 	}
 
-	if err := s.deptRepo.Create(dept); err != nil {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.deptRepo.WithTx(tx)
+		if err := repo.Create(dept); err != nil {
+			return err
+		}
+		if dept.HeadOfDepartmentID == nil {
+			return nil
+		}
+		return repo.ChangeHead(dept.ID, dept.HeadOfDepartmentID, time.Now())
+	})
+	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -126,19 +142,38 @@ func (s *DepartmentService) Update(id uuid.UUID, req *request.UpdateDepartmentRe
 		dept.Description = req.Description
 	}
 
-	// Update head of department if provided
-	if req.HeadOfDepartmentID != "" {
-		hodID, err := uuid.Parse(req.HeadOfDepartmentID)
-		if err != nil {
-			return nil, utils.ErrInvalidUUID
+	// Update head of department if provided; an empty ID clears it
+	headChanged := false
+	if req.HeadOfDepartmentID != nil {
+		var hodID *uuid.UUID
+		if *req.HeadOfDepartmentID != "" {
+			id, err := uuid.Parse(*req.HeadOfDepartmentID)
+			if err != nil {
+				return nil, utils.ErrInvalidUUID
+			}
+			if _, err := s.teacherRepo.FindByID(id); err != nil {
+				return nil, errors.New("head of department not found")
+			}
+			hodID = &id
 		}
-		if _, err := s.teacherRepo.FindByID(hodID); err != nil {
-			return nil, errors.New("head of department not found")
+		headChanged = !sameUUID(dept.HeadOfDepartmentID, hodID)
+		if headChanged {
+			dept.HeadOfDepartmentID = hodID
+			dept.HeadOfDepartment = nil
 		}
-		dept.HeadOfDepartmentID = &hodID
 	}
 
-	if err := s.deptRepo.Update(dept); err != nil {
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.deptRepo.WithTx(tx)
+		if err := repo.Update(dept); err != nil {
+			return err
+		}
+		if !headChanged {
+			return nil
+		}
+		return repo.ChangeHead(dept.ID, dept.HeadOfDepartmentID, time.Now())
+	})
+	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -193,6 +228,104 @@ func (s *DepartmentService) GetDepartmentStaff(deptID, institutionID uuid.UUID)
 	return responses, nil
 }
 
+// departmentStaffExportHeaders are the columns of the staff directory export
+var departmentStaffExportHeaders = []string{
+	"name", "email", "phone", "qualifications", "joining_date",
+}
+
+// ExportStaff prepares a csv or xlsx directory of a department's teachers.
+// A department without staff produces a file with only the header row.
+func (s *DepartmentService) ExportStaff(deptID, institutionID uuid.UUID, format string) (*export.Stream, error) {
+	dept, err := s.deptRepo.FindByIDWithInstitution(deptID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	teachers, err := s.deptRepo.GetDepartmentStaff(deptID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	table := &export.Table{
+		Headers: departmentStaffExportHeaders,
+		Rows: func(yield func(cells []string) error) error {
+			for i := range teachers {
+				if err := yield(departmentStaffExportRow(&teachers[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	stream, err := export.StreamTable(table, format, exportFileName("department-staff", dept.Name))
+	if err != nil {
+		if errors.Is(err, export.ErrUnsupportedFormat) {
+			return nil, utils.ErrInvalidEnumValue
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return stream, nil
+}
+
+// departmentStaffExportRow returns the export cells of a teacher, in departmentStaffExportHeaders order
+func departmentStaffExportRow(teacher *models.Teacher) []string {
+	var name, email, phone string
+	if teacher.User != nil {
+		email, phone = teacher.User.Email, teacher.User.Phone
+		if teacher.User.Profile != nil {
+			name = teacher.User.Profile.FullName()
+		}
+	}
+
+	joiningDate := ""
+	if teacher.JoiningDate != nil {
+		joiningDate = teacher.JoiningDate.Format("2006-01-02")
+	}
+
+	return []string{
+		name,
+		email,
+		phone,
+		strings.Join(teacher.Qualifications, "; "),
+		joiningDate,
+	}
+}
+
+// GetHeadHistory lists the heads a department has had, most recent first
+func (s *DepartmentService) GetHeadHistory(deptID, institutionID uuid.UUID) ([]response.DepartmentHeadHistoryResponse, error) {
+	if _, err := s.deptRepo.FindByIDWithInstitution(deptID, institutionID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.deptRepo.FindHeadHistory(deptID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.DepartmentHeadHistoryResponse, 0, len(history))
+	for _, h := range history {
+		resp := response.DepartmentHeadHistoryResponse{
+			ID:        h.ID,
+			TeacherID: h.TeacherID,
+			FromDate:  h.FromDate,
+			ToDate:    h.ToDate,
+			IsCurrent: h.ToDate == nil,
+		}
+		if h.Teacher != nil {
+			resp.Teacher = &response.TeacherBrief{ID: h.Teacher.ID}
+			if h.Teacher.User != nil && h.Teacher.User.Profile != nil {
+				resp.Teacher.FirstName = h.Teacher.User.Profile.FirstName
+				resp.Teacher.LastName = h.Teacher.User.Profile.LastName
+			}
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
 // toResponse converts a model to response
 func (s *DepartmentService) toResponse(dept *models.Department) *response.DepartmentResponse {
 	resp := &response.DepartmentResponse{
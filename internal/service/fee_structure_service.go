@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// FeeStructureService handles fee structure business logic
+type FeeStructureService struct {
+	repo      *repository.FeeStructureRepository
+	classRepo *repository.ClassRepository
+	ayRepo    *repository.AcademicYearRepository
+}
+
+// NewFeeStructureService creates a new fee structure service
+func NewFeeStructureService(repo *repository.FeeStructureRepository, classRepo *repository.ClassRepository, ayRepo *repository.AcademicYearRepository) *FeeStructureService {
+	return &FeeStructureService{repo: repo, classRepo: classRepo, ayRepo: ayRepo}
+}
+
+// Create creates a fee structure for a class of an academic year.
+// Both must belong to the institution and the due date must fall within the year.
+func (s *FeeStructureService) Create(req *request.CreateFeeStructureRequest, institutionID uuid.UUID) (*response.FeeStructureResponse, error) {
+	if req.Amount <= 0 {
+		return nil, utils.ErrFieldOutOfRange
+	}
+
+	academicYearID, err := uuid.Parse(req.AcademicYearID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	year, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	classID, err := uuid.Parse(req.ClassID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	class, err := s.classRepo.FindByIDWithInstitution(classID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureUniqueName(req.Name, class.ID, year.ID, nil); err != nil {
+		return nil, err
+	}
+
+	fee := &models.FeeStructure{
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		AcademicYearID:  year.ID,
+		ClassID:         class.ID,
+		Name:            req.Name,
+		Amount:          req.Amount,
+		Frequency:       models.FeeFrequencyAnnual,
+		IsActive:        true,
+	}
+	if req.Frequency != "" {
+		fee.Frequency = models.FeeFrequency(req.Frequency)
+	}
+	if req.DueDate != "" {
+		if fee.DueDate, err = parseDueDate(req.DueDate, year); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Create(fee); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	fee.Class = class
+	return s.toResponse(fee), nil
+}
+
+// GetByID gets a fee structure by ID
+func (s *FeeStructureService) GetByID(id, institutionID uuid.UUID) (*response.FeeStructureResponse, error) {
+	fee, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(fee), nil
+}
+
+// GetAll lists fee structures with filters
+func (s *FeeStructureService) GetAll(filter repository.FeeStructureFilter, params utils.PaginationParams) ([]response.FeeStructureResponse, utils.Pagination, error) {
+	fees, total, err := s.repo.FindAll(filter, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.FeeStructureResponse, 0, len(fees))
+	for i := range fees {
+		responses = append(responses, *s.toResponse(&fees[i]))
+	}
+
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return responses, pagination, nil
+}
+
+// Update updates a fee structure
+func (s *FeeStructureService) Update(id uuid.UUID, req *request.UpdateFeeStructureRequest, institutionID uuid.UUID) (*response.FeeStructureResponse, error) {
+	fee, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+	class := fee.Class
+
+	if req.ClassID != "" {
+		classID, err := uuid.Parse(req.ClassID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		if classID != fee.ClassID {
+			if class, err = s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
+				return nil, err
+			}
+			fee.ClassID = class.ID
+		}
+	}
+	if req.Name != "" {
+		fee.Name = req.Name
+	}
+	if req.ClassID != "" || req.Name != "" {
+		if err := s.ensureUniqueName(fee.Name, fee.ClassID, fee.AcademicYearID, &fee.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Amount != nil {
+		if *req.Amount <= 0 {
+			return nil, utils.ErrFieldOutOfRange
+		}
+		fee.Amount = *req.Amount
+	}
+	if req.Frequency != "" {
+		fee.Frequency = models.FeeFrequency(req.Frequency)
+	}
+	if req.DueDate != "" {
+		year, err := s.ayRepo.FindByIDWithInstitution(fee.AcademicYearID, institutionID)
+		if err != nil {
+			return nil, err
+		}
+		if fee.DueDate, err = parseDueDate(req.DueDate, year); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		fee.IsActive = *req.IsActive
+	}
+
+	// Clear the preloaded class so Save doesn't overwrite class_id from it
+	fee.Class = nil
+	if err := s.repo.Update(fee); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	fee.Class = class
+	return s.toResponse(fee), nil
+}
+
+// Delete deletes a fee structure
+func (s *FeeStructureService) Delete(id, institutionID uuid.UUID) error {
+	if _, err := s.repo.FindByIDWithInstitution(id, institutionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// GetClassSummary totals the annual fees of each class in an academic year,
+// defaulting to the current one. Inactive fee structures are not counted.
+func (s *FeeStructureService) GetClassSummary(academicYearID *uuid.UUID, institutionID uuid.UUID) (*response.FeeSummaryResponse, error) {
+	var year *models.AcademicYear
+	var err error
+	if academicYearID != nil {
+		year, err = s.ayRepo.FindByIDWithInstitution(*academicYearID, institutionID)
+	} else {
+		year, err = s.ayRepo.FindCurrent(institutionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := s.repo.FindActiveByAcademicYear(year.ID, institutionID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	byClass := make(map[uuid.UUID]*response.ClassFeeSummary)
+	for i := range fees {
+		fee := &fees[i]
+		summary, ok := byClass[fee.ClassID]
+		if !ok {
+			summary = &response.ClassFeeSummary{ClassID: fee.ClassID}
+			if fee.Class != nil {
+				summary.ClassName = fee.Class.Name
+			}
+			byClass[fee.ClassID] = summary
+		}
+		summary.FeeCount++
+		summary.AnnualTotal += fee.AnnualAmount()
+	}
+
+	classes := make([]response.ClassFeeSummary, 0, len(byClass))
+	for _, summary := range byClass {
+		summary.AnnualTotal = roundAmount(summary.AnnualTotal)
+		classes = append(classes, *summary)
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].ClassName < classes[j].ClassName })
+
+	return &response.FeeSummaryResponse{AcademicYearID: year.ID, Classes: classes}, nil
+}
+
+// ensureUniqueName rejects a name already used by another fee structure of the class in the year
+func (s *FeeStructureService) ensureUniqueName(name string, classID, academicYearID uuid.UUID, excludeID *uuid.UUID) error {
+	exists, err := s.repo.NameExists(name, classID, academicYearID, excludeID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return utils.ErrResourceExists
+	}
+	return nil
+}
+
+// parseDueDate parses a due date and ensures it falls within the academic year
+func parseDueDate(value string, year *models.AcademicYear) (*time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	if date.Before(truncateToDate(year.StartDate)) || date.After(truncateToDate(year.EndDate)) {
+		return nil, errors.New("due date is outside the academic year")
+	}
+	return &date, nil
+}
+
+// roundAmount rounds a currency amount to two decimal places
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// toResponse converts a model to response
+func (s *FeeStructureService) toResponse(fee *models.FeeStructure) *response.FeeStructureResponse {
+	resp := &response.FeeStructureResponse{
+		ID:             fee.ID,
+		InstitutionID:  fee.InstitutionID,
+		AcademicYearID: fee.AcademicYearID,
+		ClassID:        fee.ClassID,
+		Name:           fee.Name,
+		Amount:         fee.Amount,
+		Frequency:      string(fee.Frequency),
+		AnnualAmount:   roundAmount(fee.AnnualAmount()),
+		IsActive:       fee.IsActive,
+		CreatedAt:      fee.CreatedAt,
+		UpdatedAt:      fee.UpdatedAt,
+	}
+	if fee.Class != nil {
+		resp.ClassName = fee.Class.Name
+	}
+	if fee.DueDate != nil {
+		resp.DueDate = fee.DueDate.Format("2006-01-02")
+	}
+	return resp
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// HolidayService handles holiday business logic
+type HolidayService struct {
+	repo   *repository.HolidayRepository
+	ayRepo *repository.AcademicYearRepository
+}
+
+// NewHolidayService creates a new holiday service
+func NewHolidayService(repo *repository.HolidayRepository, ayRepo *repository.AcademicYearRepository) *HolidayService {
+	return &HolidayService{repo: repo, ayRepo: ayRepo}
+}
+
+// Create adds a holiday to an academic year. The date must fall within the
+// year and must not already be a holiday.
+func (s *HolidayService) Create(academicYearID uuid.UUID, req *request.CreateHolidayRequest, institutionID uuid.UUID) (*response.HolidayResponse, error) {
+	year, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	if err := s.validateDate(year, date, institutionID, nil); err != nil {
+		return nil, err
+	}
+
+	holidayType := models.HolidayTypeOther
+	if req.Type != "" {
+		holidayType = models.HolidayType(req.Type)
+	}
+
+	holiday := &models.Holiday{
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		AcademicYearID:  year.ID,
+		Date:            date,
+		Name:            req.Name,
+		Type:            holidayType,
+	}
+	if err := s.repo.Create(holiday); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.toResponse(holiday), nil
+}
+
+// GetAll lists the holidays of an academic year ordered by date
+func (s *HolidayService) GetAll(academicYearID, institutionID uuid.UUID) ([]response.HolidayResponse, error) {
+	if _, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID); err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.repo.FindByAcademicYear(academicYearID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.HolidayResponse, 0, len(holidays))
+	for i := range holidays {
+		responses = append(responses, *s.toResponse(&holidays[i]))
+	}
+	return responses, nil
+}
+
+// Update updates a holiday of an academic year
+func (s *HolidayService) Update(academicYearID, id uuid.UUID, req *request.UpdateHolidayRequest, institutionID uuid.UUID) (*response.HolidayResponse, error) {
+	holiday, err := s.findInYear(academicYearID, id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Date != "" {
+		date, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, utils.ErrInvalidDateFormat
+		}
+		if !date.Equal(truncateToDate(holiday.Date)) {
+			year, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.validateDate(year, date, institutionID, &holiday.ID); err != nil {
+				return nil, err
+			}
+			holiday.Date = date
+		}
+	}
+	if req.Name != "" {
+		holiday.Name = req.Name
+	}
+	if req.Type != "" {
+		holiday.Type = models.HolidayType(req.Type)
+	}
+
+	if err := s.repo.Update(holiday); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.toResponse(holiday), nil
+}
+
+// Delete removes a holiday from an academic year
+func (s *HolidayService) Delete(academicYearID, id, institutionID uuid.UUID) error {
+	if _, err := s.findInYear(academicYearID, id, institutionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// IsHoliday reports whether the calendar date of date is a holiday for the institution
+func (s *HolidayService) IsHoliday(date time.Time, institutionID uuid.UUID) (bool, error) {
+	exists, err := s.repo.DateExists(institutionID, date, nil)
+	if err != nil {
+		return false, utils.ErrInternalServer.Wrap(err)
+	}
+	return exists, nil
+}
+
+// HolidaysInRange returns the holiday dates of an institution between two dates (inclusive)
+func (s *HolidayService) HolidaysInRange(institutionID uuid.UUID, from, to time.Time) ([]time.Time, error) {
+	holidays, err := s.repo.FindInRange(institutionID, from, to)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	dates := make([]time.Time, 0, len(holidays))
+	for _, holiday := range holidays {
+		dates = append(dates, truncateToDate(holiday.Date))
+	}
+	return dates, nil
+}
+
+// findInYear finds a holiday and ensures it belongs to the academic year
+func (s *HolidayService) findInYear(academicYearID, id, institutionID uuid.UUID) (*models.Holiday, error) {
+	holiday, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+	if holiday.AcademicYearID != academicYearID {
+		return nil, utils.ErrNotFound
+	}
+	return holiday, nil
+}
+
+// validateDate ensures a holiday date is within the academic year and not already taken
+func (s *HolidayService) validateDate(year *models.AcademicYear, date time.Time, institutionID uuid.UUID, excludeID *uuid.UUID) error {
+	if date.Before(truncateToDate(year.StartDate)) || date.After(truncateToDate(year.EndDate)) {
+		return errors.New("date is outside the academic year")
+	}
+
+	exists, err := s.repo.DateExists(institutionID, date, excludeID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return utils.ErrResourceExists
+	}
+	return nil
+}
+
+// toResponse converts a model to response
+func (s *HolidayService) toResponse(holiday *models.Holiday) *response.HolidayResponse {
+	return &response.HolidayResponse{
+		ID:             holiday.ID,
+		InstitutionID:  holiday.InstitutionID,
+		AcademicYearID: holiday.AcademicYearID,
+		Date:           holiday.Date.Format("2006-01-02"),
+		Name:           holiday.Name,
+		Type:           string(holiday.Type),
+		CreatedAt:      holiday.CreatedAt,
+		UpdatedAt:      holiday.UpdatedAt,
+	}
+}
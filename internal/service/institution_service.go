@@ -1,26 +1,80 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
 	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/utils"
+	"campus-core/pkg/logger"
+	"campus-core/pkg/storage"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // InstitutionService handles business logic for institutions
 type InstitutionService struct {
-	repo *repository.InstitutionRepository
+	repo  *repository.InstitutionRepository
+	store storage.Storage
 }
 
 // NewInstitutionService creates a new institution service
-func NewInstitutionService(repo *repository.InstitutionRepository) *InstitutionService {
-	return &InstitutionService{repo: repo}
+func NewInstitutionService(repo *repository.InstitutionRepository, store storage.Storage) *InstitutionService {
+	return &InstitutionService{repo: repo, store: store}
 }
 
 // CreateInstitution creates a new institution
 func (s *InstitutionService) Create(institution *models.Institution) error {
+	if err := s.prepareInstitution(institution); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(institution); err != nil {
+		if utils.IsUniqueViolation(err, models.InstitutionCodeConstraint) {
+			return utils.ErrInstitutionCodeExists
+		}
+		return utils.ErrInternalServer.Wrap(err)
+	}
+
+	return nil
+}
+
+// Onboard creates an institution, its default settings and its first admin in
+// one transaction, so an institution is never left without an admin
+func (s *InstitutionService) Onboard(institution *models.Institution, admin *request.OnboardAdminRequest) (*response.InstitutionOnboardResponse, error) {
+	if err := s.prepareInstitution(institution); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.CreateWithAdmin(institution, admin.Email, admin.FirstName, admin.LastName, admin.Password, admin.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.InstitutionOnboardResponse{
+		Institution: response.InstitutionBrief{
+			ID:        institution.ID,
+			Name:      institution.Name,
+			Code:      institution.Code,
+			Timezone:  institution.Settings.Timezone,
+			IsActive:  institution.IsActive,
+			CreatedAt: institution.CreatedAt,
+		},
+		Admin: toAdminResponse(user),
+	}, nil
+}
+
+// prepareInstitution checks the code of a new institution and attaches its default settings
+func (s *InstitutionService) prepareInstitution(institution *models.Institution) error {
 	// Check if code exists
 	exists, err := s.repo.CodeExists(institution.Code)
 	if err != nil {
@@ -35,9 +89,15 @@ func (s *InstitutionService) Create(institution *models.Institution) error {
 		institution.ID = uuid.New()
 	}
 
-	if err := s.repo.Create(institution); err != nil {
-		return utils.ErrInternalServer.Wrap(err)
+	// Every institution starts with default settings; a time zone may be given on creation
+	settings := models.DefaultInstitutionSettings(institution.ID)
+	if institution.Settings != nil && institution.Settings.Timezone != "" {
+		if _, err := time.LoadLocation(institution.Settings.Timezone); err != nil {
+			return utils.ErrInvalidFieldFormat
+		}
+		settings.Timezone = institution.Settings.Timezone
 	}
+	institution.Settings = settings
 
 	return nil
 }
@@ -82,17 +142,154 @@ func (s *InstitutionService) Update(id uuid.UUID, updates map[string]interface{}
 	if princ, ok := updates["principal_name"].(string); ok {
 		institution.PrincipalName = princ
 	}
+	// Kept for compatibility; the time zone is stored in the institution settings
+	var timezone *string
+	if tz, ok := updates["timezone"].(string); ok {
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" {
+			return nil, utils.ErrInvalidFieldFormat
+		}
+		timezone = &tz
+	}
 	if isActive, ok := updates["is_active"].(bool); ok {
 		institution.IsActive = isActive
 	}
 
+	if err := s.repo.Update(institution); err != nil {
+		if utils.IsUniqueViolation(err, models.InstitutionCodeConstraint) {
+			return nil, utils.ErrInstitutionCodeExists
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	if timezone != nil {
+		settings, err := s.UpdateSettings(id, &request.UpdateInstitutionSettingsRequest{Timezone: timezone})
+		if err != nil {
+			return nil, err
+		}
+		institution.Settings = settings
+	}
+
+	return institution, nil
+}
+
+// UpdateLogo stores an uploaded logo image and sets it as the institution's logo
+func (s *InstitutionService) UpdateLogo(id uuid.UUID, data []byte) (*models.Institution, error) {
+	institution, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := storeImage(s.store, "logos", data)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := institution.LogoURL
+	institution.LogoURL = url
 	if err := s.repo.Update(institution); err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
+	removeStoredFile(s.store, previous)
 	return institution, nil
 }
 
+// GetSettings returns the settings of an institution.
+// Institutions without stored settings get the defaults, which are saved.
+func (s *InstitutionService) GetSettings(institutionID uuid.UUID) (*models.InstitutionSettings, error) {
+	settings, err := s.repo.FindSettings(institutionID)
+	if err == nil {
+		return settings, nil
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	if _, err := s.repo.FindByID(institutionID); err != nil {
+		return nil, err
+	}
+	settings = models.DefaultInstitutionSettings(institutionID)
+	if err := s.repo.SaveSettings(settings); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	return settings, nil
+}
+
+// UpdateSettings updates the settings of an institution
+func (s *InstitutionService) UpdateSettings(institutionID uuid.UUID, req *request.UpdateInstitutionSettingsRequest) (*models.InstitutionSettings, error) {
+	settings, err := s.GetSettings(institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			return nil, utils.ErrInvalidFieldFormat.Wrap(fmt.Errorf("unknown timezone %q", *req.Timezone))
+		}
+		settings.Timezone = *req.Timezone
+	}
+	if req.Locale != nil {
+		settings.Locale = *req.Locale
+	}
+	if req.WeekStartDay != nil {
+		settings.WeekStartDay = *req.WeekStartDay
+	}
+	if req.GradingScale != nil {
+		scale, err := toGradingScale(req.GradingScale)
+		if err != nil {
+			return nil, err
+		}
+		settings.GradingScale = scale
+	}
+	if req.AutoRollNumbers != nil {
+		settings.AutoRollNumbers = *req.AutoRollNumbers
+	}
+	if req.RateLimitRequests != nil {
+		if *req.RateLimitRequests == 0 {
+			settings.RateLimitRequests = nil
+		} else {
+			settings.RateLimitRequests = req.RateLimitRequests
+		}
+	}
+	if req.MaxClassesPerClassTeacher != nil {
+		if *req.MaxClassesPerClassTeacher == 0 {
+			settings.MaxClassesPerClassTeacher = nil
+		} else {
+			settings.MaxClassesPerClassTeacher = req.MaxClassesPerClassTeacher
+		}
+	}
+
+	if err := s.repo.SaveSettings(settings); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	return settings, nil
+}
+
+// toGradingScale validates grade bands and orders them from the highest score down.
+// Bands must not overlap.
+func toGradingScale(bands []request.GradeBandRequest) (models.GradingScale, error) {
+	scale := make(models.GradingScale, 0, len(bands))
+	for _, band := range bands {
+		if band.MinScore > band.MaxScore {
+			return nil, utils.ErrFieldOutOfRange.Wrap(fmt.Errorf("grade %q: min_score is greater than max_score", band.Grade))
+		}
+		scale = append(scale, models.GradeBand{
+			Grade:      strings.TrimSpace(band.Grade),
+			MinScore:   band.MinScore,
+			MaxScore:   band.MaxScore,
+			GradePoint: band.GradePoint,
+		})
+	}
+
+	sort.Slice(scale, func(i, j int) bool { return scale[i].MinScore > scale[j].MinScore })
+	for i := 1; i < len(scale); i++ {
+		if scale[i].MaxScore >= scale[i-1].MinScore {
+			return nil, utils.ErrFieldOutOfRange.Wrap(fmt.Errorf("grades %q and %q overlap", scale[i-1].Grade, scale[i].Grade))
+		}
+	}
+	return scale, nil
+}
+
 // DeleteInstitution deletes an institution
 func (s *InstitutionService) Delete(id uuid.UUID) error {
 	// Check if exists
@@ -136,6 +333,21 @@ func (s *InstitutionService) GetStats(id uuid.UUID) (*models.InstitutionStats, e
 	return stats, nil
 }
 
+// GetDetailedStats returns the per class, section, gender and department breakdown for an institution
+func (s *InstitutionService) GetDetailedStats(id uuid.UUID) (*models.InstitutionDetailedStats, error) {
+	// Verify existence
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.GetDetailedStats(id)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return stats, nil
+}
+
 // ToggleStatus enables or disables an institution
 func (s *InstitutionService) ToggleStatus(id uuid.UUID, isActive bool) error {
 	institution, err := s.repo.FindByID(id)
@@ -151,19 +363,19 @@ func (s *InstitutionService) ToggleStatus(id uuid.UUID, isActive bool) error {
 	return nil
 }
 
-// GetAdmins returns all admins for an institution
-func (s *InstitutionService) GetAdmins(id uuid.UUID) ([]response.UserResponse, error) {
+// GetAdmins returns a page of the admins of an institution
+func (s *InstitutionService) GetAdmins(id uuid.UUID, filter repository.AdminFilter, params utils.PaginationParams) ([]response.UserResponse, utils.Pagination, error) {
 	// Verify existence
 	if _, err := s.repo.FindByID(id); err != nil {
-		return nil, err
+		return nil, utils.Pagination{}, err
 	}
 
-	admins, err := s.repo.GetAdmins(id)
+	admins, total, err := s.repo.GetAdmins(id, filter, params)
 	if err != nil {
-		return nil, utils.ErrInternalServer.Wrap(err)
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
 	}
 
-	var responses []response.UserResponse
+	responses := make([]response.UserResponse, 0, len(admins))
 	for _, user := range admins {
 		resp := response.UserResponse{
 			ID:       user.ID,
@@ -184,7 +396,8 @@ func (s *InstitutionService) GetAdmins(id uuid.UUID) ([]response.UserResponse, e
 		responses = append(responses, resp)
 	}
 
-	return responses, nil
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return responses, pagination, nil
 }
 
 // AssignAdmin creates a new admin for an institution
@@ -199,6 +412,11 @@ func (s *InstitutionService) AssignAdmin(institutionID uuid.UUID, email, firstNa
 		return nil, err
 	}
 
+	return toAdminResponse(admin), nil
+}
+
+// toAdminResponse converts an admin user to a response
+func toAdminResponse(admin *models.User) *response.UserResponse {
 	resp := &response.UserResponse{
 		ID:       admin.ID,
 		Email:    admin.Email,
@@ -215,6 +433,33 @@ func (s *InstitutionService) AssignAdmin(institutionID uuid.UUID, email, firstNa
 			InstitutionID: admin.Profile.InstitutionID,
 		}
 	}
+	return resp
+}
+
+// storeImage validates an uploaded image and stores it under dir, returning its URL
+func storeImage(store storage.Storage, dir string, data []byte) (string, error) {
+	contentType, ext, err := storage.DetectImage(data)
+	if err != nil {
+		if errors.Is(err, storage.ErrFileTooLarge) {
+			return "", utils.ErrFileTooLarge
+		}
+		return "", utils.ErrUnsupportedFileType
+	}
 
-	return resp, nil
+	url, err := store.Put(context.Background(), storage.NewKey(dir, ext), bytes.NewReader(data), contentType)
+	if err != nil {
+		return "", utils.ErrInternalServer.Wrap(err)
+	}
+	return url, nil
+}
+
+// removeStoredFile deletes a previously uploaded file; URLs not managed by the storage are ignored
+func removeStoredFile(store storage.Storage, url string) {
+	key, ok := store.KeyFromURL(url)
+	if !ok {
+		return
+	}
+	if err := store.Delete(context.Background(), key); err != nil {
+		logger.Warn("Failed to delete replaced upload", zap.String("key", key), zap.Error(err))
+	}
 }
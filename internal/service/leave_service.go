@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+	"campus-core/pkg/logger"
+	"campus-core/pkg/mailer"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LeaveService handles leave applications of students and teachers
+type LeaveService struct {
+	repo   *repository.LeaveRepository
+	mailer mailer.Mailer
+}
+
+// NewLeaveService creates a new leave service
+func NewLeaveService(repo *repository.LeaveRepository, mailer mailer.Mailer) *LeaveService {
+	return &LeaveService{repo: repo, mailer: mailer}
+}
+
+// Apply files a leave application for the applicant. A user may not have two
+// pending applications sharing a day.
+func (s *LeaveService) Apply(req *request.ApplyLeaveRequest, applicantID, institutionID uuid.UUID) (*response.LeaveResponse, error) {
+	from, err := time.Parse("2006-01-02", req.FromDate)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	to, err := time.Parse("2006-01-02", req.ToDate)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	if to.Before(from) {
+		return nil, utils.ErrInvalidLeaveRange
+	}
+
+	overlapping, err := s.repo.HasOverlappingPending(applicantID, from, to)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if overlapping {
+		return nil, utils.ErrOverlappingLeave
+	}
+
+	leave := &models.LeaveApplication{
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		ApplicantUserID: applicantID,
+		FromDate:        from,
+		ToDate:          to,
+		TotalDays:       int(to.Sub(from).Hours()/24) + 1,
+		Reason:          req.Reason,
+		Status:          models.LeaveStatusPending,
+	}
+	if err := s.repo.Create(leave); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.toResponse(leave), nil
+}
+
+// ListMine lists the applicant's own leave applications in the institution
+func (s *LeaveService) ListMine(applicantID uuid.UUID, institutionID, status string, params utils.PaginationParams) ([]response.LeaveResponse, utils.Pagination, error) {
+	return s.list(repository.LeaveFilter{
+		InstitutionID:   institutionID,
+		ApplicantUserID: applicantID.String(),
+		Status:          status,
+	}, params)
+}
+
+// List lists the leave applications of an institution for approvers
+func (s *LeaveService) List(institutionID uuid.UUID, status string, params utils.PaginationParams) ([]response.LeaveResponse, utils.Pagination, error) {
+	return s.list(repository.LeaveFilter{
+		InstitutionID: institutionID.String(),
+		Status:        status,
+	}, params)
+}
+
+// Approve approves a pending leave application
+func (s *LeaveService) Approve(id uuid.UUID, req *request.ReviewLeaveRequest, reviewerID, institutionID uuid.UUID) (*response.LeaveResponse, error) {
+	return s.review(id, models.LeaveStatusApproved, req.Note, reviewerID, institutionID)
+}
+
+// Reject rejects a pending leave application
+func (s *LeaveService) Reject(id uuid.UUID, req *request.ReviewLeaveRequest, reviewerID, institutionID uuid.UUID) (*response.LeaveResponse, error) {
+	return s.review(id, models.LeaveStatusRejected, req.Note, reviewerID, institutionID)
+}
+
+// review records the decision on a pending application and emails the applicant.
+// Approvers may not review their own applications.
+func (s *LeaveService) review(id uuid.UUID, status models.LeaveStatus, note string, reviewerID, institutionID uuid.UUID) (*response.LeaveResponse, error) {
+	leave, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+	if leave.Status != models.LeaveStatusPending {
+		return nil, utils.ErrInvalidResourceState
+	}
+	if leave.ApplicantUserID == reviewerID {
+		return nil, utils.ErrActionNotPermitted
+	}
+
+	now := time.Now()
+	leave.Status = status
+	leave.ReviewedBy = &reviewerID
+	leave.ReviewedAt = &now
+	leave.ReviewNote = note
+	if err := s.repo.Update(leave); err != nil {
+		if errors.Is(err, utils.ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	s.notify(leave)
+	return s.toResponse(leave), nil
+}
+
+// list gets a page of leave applications
+func (s *LeaveService) list(filter repository.LeaveFilter, params utils.PaginationParams) ([]response.LeaveResponse, utils.Pagination, error) {
+	leaves, total, err := s.repo.FindAll(filter, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.LeaveResponse, 0, len(leaves))
+	for i := range leaves {
+		responses = append(responses, *s.toResponse(&leaves[i]))
+	}
+
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return responses, pagination, nil
+}
+
+// notify emails the applicant the outcome of the review.
+// Failures are logged rather than returned since the review is already stored.
+func (s *LeaveService) notify(leave *models.LeaveApplication) {
+	applicant := leave.Applicant
+	if applicant == nil || applicant.Email == "" {
+		return
+	}
+
+	name := applicant.Email
+	if applicant.Profile != nil {
+		name = applicant.Profile.FullName()
+	}
+	err := s.mailer.SendLeaveStatus(applicant.Email, name, string(leave.Status),
+		leave.FromDate.Format("2006-01-02"), leave.ToDate.Format("2006-01-02"))
+	if err != nil {
+		logger.Error("Failed to send leave status email",
+			zap.String("leave_id", leave.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// toResponse converts a model to response
+func (s *LeaveService) toResponse(leave *models.LeaveApplication) *response.LeaveResponse {
+	resp := &response.LeaveResponse{
+		ID:              leave.ID,
+		InstitutionID:   leave.InstitutionID,
+		ApplicantUserID: leave.ApplicantUserID,
+		FromDate:        leave.FromDate.Format("2006-01-02"),
+		ToDate:          leave.ToDate.Format("2006-01-02"),
+		TotalDays:       leave.TotalDays,
+		Reason:          leave.Reason,
+		Status:          string(leave.Status),
+		ReviewedBy:      leave.ReviewedBy,
+		ReviewedAt:      leave.ReviewedAt,
+		ReviewNote:      leave.ReviewNote,
+		Version:         leave.Version,
+		CreatedAt:       leave.CreatedAt,
+		UpdatedAt:       leave.UpdatedAt,
+	}
+	if leave.Applicant != nil && leave.Applicant.Profile != nil {
+		resp.ApplicantName = leave.Applicant.Profile.FullName()
+	}
+	return resp
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+	"campus-core/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NoticeService handles notice business logic
+type NoticeService struct {
+	repo     *repository.NoticeRepository
+	notifier *NotificationService
+}
+
+// NewNoticeService creates a new notice service
+func NewNoticeService(repo *repository.NoticeRepository, notifier *NotificationService) *NoticeService {
+	return &NoticeService{repo: repo, notifier: notifier}
+}
+
+// Create publishes a notice. Notices published immediately are pushed to the
+// connected clients of the audience; scheduled ones only appear in listings once due.
+func (s *NoticeService) Create(req *request.CreateNoticeRequest, institutionID, authorID uuid.UUID) (*response.NoticeResponse, error) {
+	now := time.Now()
+	publishedAt := now
+	if req.PublishedAt != nil && req.PublishedAt.After(now) {
+		publishedAt = *req.PublishedAt
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(publishedAt) {
+		return nil, utils.ErrInvalidNoticeExpiry
+	}
+
+	notice := &models.Notice{
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		Title:           req.Title,
+		Body:            req.Body,
+		AudienceRoles:   req.AudienceRoles,
+		PublishedAt:     publishedAt,
+		ExpiresAt:       req.ExpiresAt,
+		AuthorID:        &authorID,
+	}
+	if err := s.repo.Create(notice); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := s.toResponse(notice, now)
+	if notice.IsVisibleAt(now) {
+		s.notify(notice, resp)
+	}
+	return resp, nil
+}
+
+// List lists the notices visible to a role. Admins see every notice of the
+// institution, including scheduled and expired ones; other roles only see
+// published, unexpired notices addressed to them.
+func (s *NoticeService) List(role, institutionID string, params utils.PaginationParams) ([]response.NoticeResponse, utils.Pagination, error) {
+	now := time.Now()
+	filter := repository.NoticeFilter{InstitutionID: institutionID}
+	if role != models.RoleSuperAdmin && role != models.RoleAdmin {
+		filter.Role = role
+		filter.VisibleAt = &now
+	}
+
+	notices, total, err := s.repo.FindAll(filter, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.NoticeResponse, 0, len(notices))
+	for i := range notices {
+		responses = append(responses, *s.toResponse(&notices[i], now))
+	}
+
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return responses, pagination, nil
+}
+
+// Delete removes a notice
+func (s *NoticeService) Delete(id, institutionID uuid.UUID) error {
+	if _, err := s.repo.FindByIDWithInstitution(id, institutionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// notify pushes a published notice to its audience.
+// Failures are logged rather than returned since the notice is already stored.
+func (s *NoticeService) notify(notice *models.Notice, resp *response.NoticeResponse) {
+	_, err := s.notifier.BroadcastToRoles(notice.InstitutionID, notice.AudienceRoles, &Notification{
+		Type: NotificationNoticePublished,
+		Data: resp,
+	})
+	if err != nil {
+		logger.Warn("Failed to push notice notification",
+			zap.String("notice_id", notice.ID.String()),
+			zap.Error(err))
+	}
+}
+
+// toResponse converts a model to response, deriving its status at now
+func (s *NoticeService) toResponse(notice *models.Notice, now time.Time) *response.NoticeResponse {
+	status := response.NoticeStatusActive
+	if notice.PublishedAt.After(now) {
+		status = response.NoticeStatusScheduled
+	} else if !notice.IsVisibleAt(now) {
+		status = response.NoticeStatusExpired
+	}
+
+	return &response.NoticeResponse{
+		ID:            notice.ID,
+		InstitutionID: notice.InstitutionID,
+		Title:         notice.Title,
+		Body:          notice.Body,
+		AudienceRoles: notice.AudienceRoles,
+		Status:        status,
+		PublishedAt:   notice.PublishedAt,
+		ExpiresAt:     notice.ExpiresAt,
+		AuthorID:      notice.AuthorID,
+		CreatedAt:     notice.CreatedAt,
+		UpdatedAt:     notice.UpdatedAt,
+	}
+}
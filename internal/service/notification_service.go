@@ -0,0 +1,62 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"campus-core/internal/utils"
+	"campus-core/pkg/ws"
+
+	"github.com/google/uuid"
+)
+
+// Notification types pushed to WebSocket clients
+const (
+	NotificationNoticePublished = "notice.published"
+)
+
+// Notification is the message envelope sent to WebSocket clients
+type Notification struct {
+	Type   string      `json:"type"`
+	Data   interface{} `json:"data,omitempty"`
+	SentAt time.Time   `json:"sent_at"`
+}
+
+// NotificationService pushes realtime notifications to connected clients
+type NotificationService struct {
+	hub *ws.Hub
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(hub *ws.Hub) *NotificationService {
+	return &NotificationService{hub: hub}
+}
+
+// Broadcast pushes a notification to every connected client of an institution.
+// It returns the number of connections it was delivered to.
+func (s *NotificationService) Broadcast(institutionID uuid.UUID, payload *Notification) (int, error) {
+	return s.BroadcastToRoles(institutionID, nil, payload)
+}
+
+// BroadcastToRoles pushes a notification to the connected clients of an institution
+// whose role is in roles, or to all of them when roles is empty
+func (s *NotificationService) BroadcastToRoles(institutionID uuid.UUID, roles []string, payload *Notification) (int, error) {
+	if payload.SentAt.IsZero() {
+		payload.SentAt = time.Now()
+	}
+	msg, err := json.Marshal(payload)
+	if err != nil {
+		return 0, utils.ErrWebSocketError.Wrap(err)
+	}
+
+	var filter func(*ws.Client) bool
+	if len(roles) > 0 {
+		allowed := make(map[string]bool, len(roles))
+		for _, role := range roles {
+			allowed[role] = true
+		}
+		filter = func(c *ws.Client) bool { return allowed[c.Role] }
+	}
+
+	return s.hub.BroadcastFunc(institutionID.String(), msg, filter), nil
+}
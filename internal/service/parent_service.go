@@ -15,18 +15,20 @@ import (
 
 // ParentService handles parent management logic
 type ParentService struct {
-	repo       *repository.ParentRepository
-	userRepo   *repository.UserRepository
-	db         *gorm.DB
-	jwtManager *utils.JWTManager
+	repo             *repository.ParentRepository
+	userRepo         *repository.UserRepository
+	timetableService *TimetableService
+	db               *gorm.DB
+	jwtManager       *utils.JWTManager
 }
 
-func NewParentService(repo *repository.ParentRepository, userRepo *repository.UserRepository, db *gorm.DB, jwtManager *utils.JWTManager) *ParentService {
+func NewParentService(repo *repository.ParentRepository, userRepo *repository.UserRepository, timetableService *TimetableService, db *gorm.DB, jwtManager *utils.JWTManager) *ParentService {
 	return &ParentService{
-		repo:       repo,
-		userRepo:   userRepo,
-		db:         db,
-		jwtManager: jwtManager,
+		repo:             repo,
+		userRepo:         userRepo,
+		timetableService: timetableService,
+		db:               db,
+		jwtManager:       jwtManager,
 	}
 }
 
@@ -39,6 +41,19 @@ func (s *ParentService) CreateParent(req *request.CreateParentRequest, creatorIn
 		return nil, errors.New("institution_id is required")
 	}
 
+	if req.Phone != "" {
+		phone, err := canonicalPhone(s.userRepo, req.Phone, "")
+		if err != nil {
+			return nil, err
+		}
+		req.Phone = phone
+	}
+
+	dateOfBirth, err := parseDateOfBirth(req.DateOfBirth)
+	if err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
@@ -50,12 +65,13 @@ func (s *ParentService) CreateParent(req *request.CreateParentRequest, creatorIn
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// 1. Create User
 		user := &models.User{
-			BaseModel:    models.BaseModel{ID: uuid.New()},
-			Email:        req.Email,
-			Phone:        req.Phone,
-			PasswordHash: hashedPassword,
-			Role:         models.RoleParent,
-			IsActive:     true,
+			BaseModel:          models.BaseModel{ID: uuid.New()},
+			Email:              req.Email,
+			Phone:              req.Phone,
+			PasswordHash:       hashedPassword,
+			MustChangePassword: true,
+			Role:               models.RoleParent,
+			IsActive:           true,
 		}
 		if err := tx.Create(user).Error; err != nil {
 			return err
@@ -68,6 +84,9 @@ func (s *ParentService) CreateParent(req *request.CreateParentRequest, creatorIn
 			FirstName:     req.FirstName,
 			LastName:      req.LastName,
 			InstitutionID: &institutionID,
+			DateOfBirth:   dateOfBirth,
+			Gender:        req.Gender,
+			Address:       req.Address,
 			Occupation:    req.Occupation,
 		}
 		if err := tx.Create(profile).Error; err != nil {
@@ -95,6 +114,9 @@ func (s *ParentService) CreateParent(req *request.CreateParentRequest, creatorIn
 	})
 
 	if err != nil {
+		if utils.IsUniqueViolation(err, models.UserEmailIndex) {
+			return nil, utils.ErrEmailAlreadyExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -108,6 +130,8 @@ func (s *ParentService) CreateParent(req *request.CreateParentRequest, creatorIn
 			ID:            parentUser.Profile.ID,
 			FirstName:     parentUser.Profile.FirstName,
 			LastName:      parentUser.Profile.LastName,
+			DateOfBirth:   parentUser.Profile.DateOfBirth,
+			Gender:        parentUser.Profile.Gender,
 			InstitutionID: parentUser.Profile.InstitutionID,
 		},
 	}
@@ -193,7 +217,11 @@ func (s *ParentService) UpdateParent(id uuid.UUID, req *request.UpdateParentRequ
 	}
 
 	if req.Phone != "" {
-		parent.User.Phone = req.Phone
+		phone, err := canonicalPhone(s.userRepo, req.Phone, parent.User.Phone)
+		if err != nil {
+			return nil, err
+		}
+		parent.User.Phone = phone
 	}
 
 	if req.IsActive != nil {
@@ -297,3 +325,54 @@ func (s *ParentService) GetParentChildren(id uuid.UUID) ([]response.ChildRelatio
 
 	return responses, nil
 }
+
+// GetChildrenTimetables gets the section timetable of each of a parent's children.
+// Parents may only view their own children; admins only parents of their institution.
+func (s *ParentService) GetChildrenTimetables(id uuid.UUID, academicYearID *uuid.UUID, callerID uuid.UUID, role, institutionID string) ([]response.ChildTimetableResponse, error) {
+	parent, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == models.RoleParent && parent.UserID != callerID {
+		return nil, utils.ErrResourceAccessDenied
+	}
+	if institutionID != "" && parent.InstitutionID.String() != institutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+
+	var relations []models.ParentStudentRelation
+	if err := s.db.Preload("Student.User.Profile").Where("parent_id = ?", parent.ID).Find(&relations).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.ChildTimetableResponse, 0, len(relations))
+	for _, rel := range relations {
+		student := rel.Student
+		if student == nil {
+			continue
+		}
+
+		child := response.ChildTimetableResponse{
+			StudentID: student.ID,
+			ClassID:   student.ClassID,
+			SectionID: student.SectionID,
+		}
+		if student.User != nil && student.User.Profile != nil {
+			child.FirstName = student.User.Profile.FirstName
+			child.LastName = student.User.Profile.LastName
+		}
+
+		if student.SectionID != nil {
+			timetable, err := s.timetableService.GetBySectionID(*student.SectionID, academicYearID, "")
+			if err != nil && !errors.Is(err, utils.ErrNotFound) {
+				return nil, err
+			}
+			child.Timetable = timetable
+		}
+
+		responses = append(responses, child)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// PeriodService handles the bell schedule of an institution.
+// When an institution has periods, timetable entries must start and end on them.
+type PeriodService struct {
+	repo *repository.PeriodRepository
+}
+
+// NewPeriodService creates a new period service
+func NewPeriodService(repo *repository.PeriodRepository) *PeriodService {
+	return &PeriodService{repo: repo}
+}
+
+// Create adds a period to the school day. Periods may not overlap.
+func (s *PeriodService) Create(req *request.CreatePeriodRequest, institutionID uuid.UUID) (*response.PeriodResponse, error) {
+	startTime, endTime, err := utils.NormalizeTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	period := &models.Period{
+		InstitutionID: institutionID,
+		Name:          req.Name,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Order:         req.Order,
+		IsBreak:       req.IsBreak,
+	}
+	if err := s.checkOverlap(period); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(period); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.toResponse(period), nil
+}
+
+// GetAll lists the periods of an institution in day order
+func (s *PeriodService) GetAll(institutionID uuid.UUID) ([]response.PeriodResponse, error) {
+	periods, err := s.repo.FindByInstitution(institutionID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.PeriodResponse, 0, len(periods))
+	for i := range periods {
+		responses = append(responses, *s.toResponse(&periods[i]))
+	}
+	return responses, nil
+}
+
+// GetByID gets a period
+func (s *PeriodService) GetByID(id, institutionID uuid.UUID) (*response.PeriodResponse, error) {
+	period, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(period), nil
+}
+
+// Update updates a period. Existing timetable entries are not revalidated.
+func (s *PeriodService) Update(id uuid.UUID, req *request.UpdatePeriodRequest, institutionID uuid.UUID) (*response.PeriodResponse, error) {
+	period, err := s.repo.FindByIDWithInstitution(id, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		period.Name = req.Name
+	}
+	if req.StartTime != "" {
+		period.StartTime = req.StartTime
+	}
+	if req.EndTime != "" {
+		period.EndTime = req.EndTime
+	}
+	if req.Order != nil {
+		period.Order = *req.Order
+	}
+	if req.IsBreak != nil {
+		period.IsBreak = *req.IsBreak
+	}
+
+	period.StartTime, period.EndTime, err = utils.NormalizeTimeRange(period.StartTime, period.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOverlap(period); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(period); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.toResponse(period), nil
+}
+
+// Delete removes a period
+func (s *PeriodService) Delete(id, institutionID uuid.UUID) error {
+	if _, err := s.repo.FindByIDWithInstitution(id, institutionID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// CheckSlot validates a normalized timetable slot against the institution's periods.
+// The slot must start at the start of a teaching period and end at the end of a
+// period, and may span several periods but not a break. Without periods any slot is valid.
+func (s *PeriodService) CheckSlot(institutionID uuid.UUID, startTime, endTime string) error {
+	periods, err := s.repo.FindByInstitution(institutionID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if len(periods) == 0 {
+		return nil
+	}
+
+	start, _ := utils.ParseClockTime(startTime)
+	end, _ := utils.ParseClockTime(endTime)
+
+	startsOnPeriod, endsOnPeriod := false, false
+	for _, p := range periods {
+		pStart, err := utils.ParseClockTime(p.StartTime)
+		if err != nil {
+			continue
+		}
+		pEnd, err := utils.ParseClockTime(p.EndTime)
+		if err != nil {
+			continue
+		}
+
+		if p.IsBreak {
+			if pStart < end && pEnd > start {
+				return utils.ErrOffPeriodTime
+			}
+			continue
+		}
+		if pStart == start {
+			startsOnPeriod = true
+		}
+		if pEnd == end {
+			endsOnPeriod = true
+		}
+	}
+
+	if !startsOnPeriod || !endsOnPeriod {
+		return utils.ErrOffPeriodTime
+	}
+	return nil
+}
+
+// checkOverlap rejects a period that overlaps another period of the institution
+func (s *PeriodService) checkOverlap(period *models.Period) error {
+	periods, err := s.repo.FindByInstitution(period.InstitutionID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+
+	start, _ := utils.ParseClockTime(period.StartTime)
+	end, _ := utils.ParseClockTime(period.EndTime)
+	for _, other := range periods {
+		if other.ID == period.ID {
+			continue
+		}
+		otherStart, err := utils.ParseClockTime(other.StartTime)
+		if err != nil {
+			continue
+		}
+		otherEnd, err := utils.ParseClockTime(other.EndTime)
+		if err != nil {
+			continue
+		}
+		if otherStart < end && otherEnd > start {
+			return fmt.Errorf("period overlaps %q", other.Name)
+		}
+		if other.Order == period.Order {
+			return errors.New("another period already has this order")
+		}
+	}
+	return nil
+}
+
+// toResponse converts a model to response
+func (s *PeriodService) toResponse(period *models.Period) *response.PeriodResponse {
+	resp := &response.PeriodResponse{
+		ID:            period.ID,
+		InstitutionID: period.InstitutionID,
+		Name:          period.Name,
+		StartTime:     period.StartTime,
+		EndTime:       period.EndTime,
+		Order:         period.Order,
+		IsBreak:       period.IsBreak,
+		CreatedAt:     period.CreatedAt,
+		UpdatedAt:     period.UpdatedAt,
+	}
+	// TIME columns read back as HH:MM:SS
+	if t, err := utils.NormalizeClockTime(period.StartTime); err == nil {
+		resp.StartTime = t
+	}
+	if t, err := utils.NormalizeClockTime(period.EndTime); err == nil {
+		resp.EndTime = t
+	}
+	return resp
+}
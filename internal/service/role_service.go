@@ -0,0 +1,257 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/middleware"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// RoleService handles custom role and permission business logic
+type RoleService struct {
+	roleRepo *repository.RoleRepository
+	userRepo *repository.UserRepository
+}
+
+// NewRoleService creates a new role service
+func NewRoleService(roleRepo *repository.RoleRepository, userRepo *repository.UserRepository) *RoleService {
+	return &RoleService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ListRoles lists the built-in roles and the custom roles of an institution
+func (s *RoleService) ListRoles(institutionID string) ([]response.RoleResponse, error) {
+	var instID *uuid.UUID
+	if id, err := uuid.Parse(institutionID); err == nil {
+		instID = &id
+	}
+
+	roles, err := s.roleRepo.FindAll(instID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		responses = append(responses, *s.toResponse(&role))
+	}
+	return responses, nil
+}
+
+// ListPermissions lists every permission that can be granted
+func (s *RoleService) ListPermissions() ([]response.PermissionResponse, error) {
+	permissions, err := s.roleRepo.FindAllPermissions()
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.PermissionResponse, 0, len(permissions))
+	for _, p := range permissions {
+		if p.Code == models.PermissionAll {
+			continue // Not grantable
+		}
+		responses = append(responses, response.PermissionResponse{Code: p.Code, Description: p.Description})
+	}
+	return responses, nil
+}
+
+// CreateRole creates a custom role for an institution
+func (s *RoleService) CreateRole(req *request.CreateRoleRequest, institutionID uuid.UUID) (*response.RoleResponse, error) {
+	code := strings.ToUpper(strings.Join(strings.Fields(req.Code), "_"))
+	if models.IsValidRole(code) {
+		return nil, utils.ErrResourceExists // Built-in role codes are reserved
+	}
+
+	exists, err := s.roleRepo.CodeExists(institutionID, code)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return nil, utils.ErrResourceExists
+	}
+
+	permissions, err := s.resolvePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{
+		InstitutionID: &institutionID,
+		Name:          req.Name,
+		Code:          code,
+		Description:   req.Description,
+		Permissions:   permissions,
+	}
+	if err := s.roleRepo.Create(role); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.toResponse(role), nil
+}
+
+// GetRole gets a role by ID or built-in role code
+func (s *RoleService) GetRole(roleRef string, callerRole string, callerInstitutionID string) (*response.RoleResponse, error) {
+	role, err := s.findRole(roleRef, callerRole, callerInstitutionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(role), nil
+}
+
+// UpdatePermissions replaces the permissions of a role, given by ID or built-in role code.
+// Admins can change their institution's custom roles; built-in roles are shared
+// by all institutions, so only a Super Admin can change them.
+func (s *RoleService) UpdatePermissions(roleRef string, req *request.UpdateRolePermissionsRequest, callerRole string, callerInstitutionID string) (*response.RoleResponse, error) {
+	role, err := s.findRole(roleRef, callerRole, callerInstitutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.IsBuiltIn && (callerRole != models.RoleSuperAdmin || role.Code == models.RoleSuperAdmin) {
+		return nil, utils.ErrActionNotPermitted
+	}
+
+	permissions, err := s.resolvePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.ReplacePermissions(role, permissions); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	role.Permissions = permissions
+	middleware.InvalidatePermissionCache()
+
+	return s.toResponse(role), nil
+}
+
+// AssignUserRole attaches a custom role to a user, or detaches it.
+// The new permissions apply from the user's next login or token refresh.
+func (s *RoleService) AssignUserRole(userID uuid.UUID, req *request.AssignUserRoleRequest, callerRole string, callerInstitutionID string) error {
+	var user *models.User
+	var err error
+	if callerRole == models.RoleSuperAdmin {
+		user, err = s.userRepo.FindByID(userID)
+	} else {
+		instID, parseErr := uuid.Parse(callerInstitutionID)
+		if parseErr != nil {
+			return utils.ErrUserNotFound
+		}
+		user, err = s.userRepo.FindByIDScoped(userID, instID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Admin cannot change Super Admins
+	if callerRole != models.RoleSuperAdmin && user.Role == models.RoleSuperAdmin {
+		return utils.ErrActionNotPermitted
+	}
+
+	if req.RoleID == "" {
+		if err := s.userRepo.UpdateCustomRole(user.ID, nil); err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		return nil
+	}
+
+	roleID, err := uuid.Parse(req.RoleID)
+	if err != nil {
+		return utils.ErrInvalidUUID
+	}
+	role, err := s.roleRepo.FindByID(roleID)
+	if err != nil {
+		return err
+	}
+
+	// Only a custom role of the user's own institution can be attached
+	if role.IsBuiltIn || role.InstitutionID == nil || user.Profile == nil || user.Profile.InstitutionID == nil ||
+		*role.InstitutionID != *user.Profile.InstitutionID {
+		return utils.ErrInvalidRoleAssignment
+	}
+
+	if err := s.userRepo.UpdateCustomRole(user.ID, &role.ID); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// findRole loads a role by ID or built-in role code, hiding other institutions' custom roles
+func (s *RoleService) findRole(roleRef string, callerRole string, callerInstitutionID string) (*models.Role, error) {
+	var role *models.Role
+	var err error
+	if id, parseErr := uuid.Parse(roleRef); parseErr == nil {
+		role, err = s.roleRepo.FindByID(id)
+	} else {
+		role, err = s.roleRepo.FindBuiltInByCode(strings.ToUpper(roleRef))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !role.IsBuiltIn && callerRole != models.RoleSuperAdmin {
+		if role.InstitutionID == nil || role.InstitutionID.String() != callerInstitutionID {
+			return nil, utils.ErrNotFound
+		}
+	}
+	return role, nil
+}
+
+// resolvePermissions loads the permissions for the given codes.
+// Codes must be in the canonical built-in permission list.
+func (s *RoleService) resolvePermissions(codes []string) ([]models.Permission, error) {
+	unique := make([]string, 0, len(codes))
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == models.PermissionAll {
+			return nil, utils.ErrActionNotPermitted // Only Super Admin holds every permission
+		}
+		if code != "" && !models.IsBuiltInPermission(code) {
+			return nil, utils.ErrInvalidEnumValue.Wrap(fmt.Errorf("unknown permission %q", code))
+		}
+		if code != "" && !seen[code] {
+			seen[code] = true
+			unique = append(unique, code)
+		}
+	}
+
+	permissions, err := s.roleRepo.FindPermissionsByCodes(unique)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if len(permissions) != len(unique) {
+		found := make(map[string]bool, len(permissions))
+		for _, p := range permissions {
+			found[p.Code] = true
+		}
+		for _, code := range unique {
+			if !found[code] {
+				return nil, utils.ErrInvalidEnumValue.Wrap(fmt.Errorf("permission %q has not been seeded", code))
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// toResponse converts a role model to response
+func (s *RoleService) toResponse(role *models.Role) *response.RoleResponse {
+	return &response.RoleResponse{
+		ID:            role.ID,
+		InstitutionID: role.InstitutionID,
+		Name:          role.Name,
+		Code:          role.Code,
+		Description:   role.Description,
+		IsBuiltIn:     role.IsBuiltIn,
+		Permissions:   role.PermissionCodes(),
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"campus-core/internal/dto/response"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+)
+
+// MaxSearchQueryLength caps the search term to keep ILIKE scans cheap
+const MaxSearchQueryLength = 100
+
+// SearchService handles the global search across students, teachers and parents
+type SearchService struct {
+	repo *repository.SearchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(repo *repository.SearchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search finds students, teachers and parents of an institution by name, email,
+// phone, admission number or roll number. role optionally limits the results.
+func (s *SearchService) Search(query, role, institutionID string, params utils.PaginationParams) ([]response.SearchResultResponse, utils.Pagination, error) {
+	term := strings.TrimSpace(query)
+	if term == "" {
+		return nil, utils.Pagination{}, utils.ErrRequiredFieldMissing
+	}
+	if utf8.RuneCountInString(term) > MaxSearchQueryLength {
+		return nil, utils.Pagination{}, utils.ErrFieldTooLong
+	}
+
+	filter := repository.SearchFilter{
+		InstitutionID: institutionID,
+		Term:          term,
+		Role:          role,
+	}
+	results, total, err := s.repo.Search(filter, params)
+	if err != nil {
+		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.SearchResultResponse, 0, len(results))
+	for _, result := range results {
+		responses = append(responses, response.SearchResultResponse{
+			Role:            result.Role,
+			ID:              result.RecordID,
+			UserID:          result.UserID,
+			FirstName:       result.FirstName,
+			LastName:        result.LastName,
+			Email:           result.Email,
+			Phone:           result.Phone,
+			AdmissionNumber: result.AdmissionNumber,
+			RollNumber:      result.RollNumber,
+			ClassID:         result.ClassID,
+			SectionID:       result.SectionID,
+		})
+	}
+
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return responses, pagination, nil
+}
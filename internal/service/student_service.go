@@ -1,7 +1,11 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
 	"time"
 
 	"campus-core/internal/dto/request"
@@ -9,30 +13,37 @@ import (
 	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/utils"
+	"campus-core/pkg/logger"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // StudentService handles student management logic
 type StudentService struct {
-	repo       *repository.StudentRepository
-	userRepo   *repository.UserRepository
-	db         *gorm.DB
-	jwtManager *utils.JWTManager
+	repo        *repository.StudentRepository
+	userRepo    *repository.UserRepository
+	sectionRepo *repository.SectionRepository
+	db          *gorm.DB
+	jwtManager  *utils.JWTManager
 }
 
-func NewStudentService(repo *repository.StudentRepository, userRepo *repository.UserRepository, db *gorm.DB, jwtManager *utils.JWTManager) *StudentService {
+func NewStudentService(repo *repository.StudentRepository, userRepo *repository.UserRepository, sectionRepo *repository.SectionRepository, db *gorm.DB, jwtManager *utils.JWTManager) *StudentService {
 	return &StudentService{
-		repo:       repo,
-		userRepo:   userRepo,
-		db:         db,
-		jwtManager: jwtManager,
+		repo:        repo,
+		userRepo:    userRepo,
+		sectionRepo: sectionRepo,
+		db:          db,
+		jwtManager:  jwtManager,
 	}
 }
 
 // CreateStudent creates a new student
-func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creatorInstitutionID string) (*response.UserResponse, error) {
+// Unless force is set, the student is rejected if their class or section is full.
+// Queries and failures are logged with the request-scoped logger carried by ctx.
+func (s *StudentService) CreateStudent(ctx context.Context, req *request.CreateStudentRequest, creatorInstitutionID string, actorID uuid.UUID, force bool) (*response.UserResponse, error) {
 	if req.InstitutionID == "" {
 		req.InstitutionID = creatorInstitutionID
 	}
@@ -40,6 +51,23 @@ func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creato
 		return nil, errors.New("institution_id is required")
 	}
 
+	if req.Phone != "" {
+		phone, err := canonicalPhone(s.userRepo, req.Phone, "")
+		if err != nil {
+			return nil, err
+		}
+		req.Phone = phone
+	}
+
+	dateOfBirth, err := parseDateOfBirth(req.DateOfBirth)
+	if err != nil {
+		return nil, err
+	}
+	admissionDate, err := parsePastDate(req.AdmissionDate, "admission date")
+	if err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
@@ -47,16 +75,31 @@ func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creato
 
 	institutionID, _ := uuid.Parse(req.InstitutionID)
 
+	var classID, sectionID *uuid.UUID
+	if req.ClassID != "" {
+		id, _ := uuid.Parse(req.ClassID)
+		classID = &id
+	}
+	if req.SectionID != "" {
+		id, _ := uuid.Parse(req.SectionID)
+		sectionID = &id
+	}
+	if err := s.checkSectionInClass(sectionID, classID); err != nil {
+		return nil, err
+	}
+
 	var studentUser *models.User
-	err = s.db.Transaction(func(tx *gorm.DB) error {
+	var createdStudent *models.Student
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. Create User
 		user := &models.User{
-			BaseModel:    models.BaseModel{ID: uuid.New()},
-			Email:        req.Email,
-			Phone:        req.Phone,
-			PasswordHash: hashedPassword,
-			Role:         models.RoleStudent,
-			IsActive:     true,
+			BaseModel:          models.BaseModel{ID: uuid.New()},
+			Email:              req.Email,
+			Phone:              req.Phone,
+			PasswordHash:       hashedPassword,
+			MustChangePassword: true,
+			Role:               models.RoleStudent,
+			IsActive:           true,
 		}
 		if err := tx.Create(user).Error; err != nil {
 			return err
@@ -70,7 +113,8 @@ func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creato
 			LastName:        req.LastName,
 			InstitutionID:   &institutionID,
 			AdmissionNumber: req.AdmissionNumber,
-			// DateOfBirth setting if available in request? Assuming DTO might need update or handled separately
+			DateOfBirth:     dateOfBirth,
+			Gender:          req.Gender,
 		}
 		if err := tx.Create(profile).Error; err != nil {
 			return err
@@ -79,15 +123,10 @@ func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creato
 		studentUser = user
 
 		// 3. Create Student
-		admissionDate, _ := time.Parse("2006-01-02", req.AdmissionDate)
-		var classID, sectionID *uuid.UUID
-		if req.ClassID != "" {
-			id, _ := uuid.Parse(req.ClassID)
-			classID = &id
-		}
-		if req.SectionID != "" {
-			id, _ := uuid.Parse(req.SectionID)
-			sectionID = &id
+		if !force {
+			if err := checkCapacity(tx, classID, sectionID, nil); err != nil {
+				return err
+			}
 		}
 
 		student := &models.Student{
@@ -103,14 +142,28 @@ func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creato
 			BloodGroup:    req.BloodGroup,
 			MedicalInfo:   req.MedicalInfo,
 		}
+		student.SetCreatedBy(actorID)
+		if err := s.assignRollNumber(tx, student); err != nil {
+			return err
+		}
 		if err := tx.Create(student).Error; err != nil {
 			return err
 		}
+		createdStudent = student
 
 		return nil
 	})
 
 	if err != nil {
+		if errors.Is(err, utils.ErrCapacityExceeded) || errors.Is(err, utils.ErrRollNumberTaken) {
+			return nil, err
+		}
+		if utils.IsUniqueViolation(err, models.UserEmailIndex) {
+			return nil, utils.ErrEmailAlreadyExists
+		}
+		logger.FromContext(ctx).Error("Failed to create student",
+			zap.String("institution_id", req.InstitutionID),
+			zap.Error(err))
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -124,23 +177,164 @@ func (s *StudentService) CreateStudent(req *request.CreateStudentRequest, creato
 			ID:            studentUser.Profile.ID,
 			FirstName:     studentUser.Profile.FirstName,
 			LastName:      studentUser.Profile.LastName,
+			DateOfBirth:   studentUser.Profile.DateOfBirth,
+			Gender:        studentUser.Profile.Gender,
 			InstitutionID: studentUser.Profile.InstitutionID,
 		},
 	}
+	resp.CreatedBy, resp.UpdatedBy = authorBriefs(createdStudent.Authorship)
 
 	return &resp, nil
 }
 
+// checkCapacity verifies that one more student fits in the class and section.
+// The class and section rows are locked so concurrent enrollments are
+// serialized; it must be called inside the transaction that adds the student.
+// A capacity of zero means unlimited.
+func checkCapacity(tx *gorm.DB, classID, sectionID *uuid.UUID, excludeStudentID *uuid.UUID) error {
+	if classID != nil {
+		var class models.Class
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&class, "id = ?", *classID).Error; err != nil {
+			return err
+		}
+		if err := checkCapacityCount(tx, "class_id", class.ID, class.Capacity, excludeStudentID); err != nil {
+			return err
+		}
+	}
+
+	if sectionID != nil {
+		var section models.Section
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&section, "id = ?", *sectionID).Error; err != nil {
+			return err
+		}
+		if err := checkCapacityCount(tx, "section_id", section.ID, section.Capacity, excludeStudentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assignRollNumber rejects a roll number already taken in the student's
+// section (or class, without a section). A student without a roll number gets
+// the next one when the institution has automatic roll numbers enabled. The
+// class row is locked so concurrent assignments in the class are serialized;
+// it must be called inside the transaction that saves the student.
+func (s *StudentService) assignRollNumber(tx *gorm.DB, student *models.Student) error {
+	if student.ClassID == nil {
+		return nil
+	}
+
+	var class models.Class
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&class, "id = ?", *student.ClassID).Error; err != nil {
+		return err
+	}
+
+	repo := s.repo.WithTx(tx)
+	if student.RollNumber == 0 {
+		auto, err := autoRollNumbers(tx, student.InstitutionID)
+		if err != nil || !auto {
+			return err
+		}
+		next, err := repo.NextRollNumber(*student.ClassID, student.SectionID)
+		if err != nil {
+			return err
+		}
+		student.RollNumber = next
+		return nil
+	}
+
+	taken, err := repo.RollNumberExists(*student.ClassID, student.SectionID, student.RollNumber, &student.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return utils.ErrRollNumberTaken
+	}
+	return nil
+}
+
+// autoRollNumbers reports whether an institution assigns roll numbers
+// automatically; institutions without settings use the default
+func autoRollNumbers(tx *gorm.DB, institutionID uuid.UUID) (bool, error) {
+	var settings models.InstitutionSettings
+	err := tx.Select("auto_roll_numbers").First(&settings, "institution_id = ?", institutionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultInstitutionSettings(institutionID).AutoRollNumbers, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return settings.AutoRollNumbers, nil
+}
+
+// checkCapacityCount counts the students in a class or section and rejects a
+// new one once the count has reached the capacity
+func checkCapacityCount(tx *gorm.DB, column string, id uuid.UUID, capacity int, excludeStudentID *uuid.UUID) error {
+	if capacity <= 0 {
+		return nil
+	}
+
+	var count int64
+	query := tx.Model(&models.Student{}).Where(column+" = ?", id)
+	if excludeStudentID != nil {
+		query = query.Where("id != ?", *excludeStudentID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= int64(capacity) {
+		return utils.ErrCapacityExceeded
+	}
+	return nil
+}
+
 // GetAllStudents returns all students
-func (s *StudentService) GetAllStudents(institutionID string, params utils.PaginationParams) ([]response.UserResponse, utils.Pagination, error) {
-	students, total, err := s.repo.FindAll(institutionID, "", "", params)
+func (s *StudentService) GetAllStudents(filter repository.StudentFilter, params utils.PaginationParams) ([]response.UserResponse, utils.Pagination, error) {
+	students, total, err := s.repo.FindAll(filter, params)
 	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok {
+			return nil, utils.Pagination{}, appErr // e.g. unsupported sort column
+		}
 		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
 	}
 
-	var responses []response.UserResponse
+	pagination := utils.NewPagination(params.Page, params.PerPage, total)
+	return toStudentUserResponses(students), pagination, nil
+}
+
+// GetStudentsCursor returns a page of students using cursor pagination
+func (s *StudentService) GetStudentsCursor(institutionID string, params utils.CursorParams) ([]response.UserResponse, utils.CursorPagination, error) {
+	after, err := params.Decode()
+	if err != nil {
+		return nil, utils.CursorPagination{}, err
+	}
+
+	// Fetch one extra row to know whether another page exists
+	students, err := s.repo.FindAllCursor(institutionID, after, params.Limit+1)
+	if err != nil {
+		return nil, utils.CursorPagination{}, utils.ErrInternalServer.Wrap(err)
+	}
+
+	hasMore := len(students) > params.Limit
+	if hasMore {
+		students = students[:params.Limit]
+	}
+
+	var last *utils.Cursor
+	if len(students) > 0 {
+		st := students[len(students)-1]
+		last = &utils.Cursor{CreatedAt: st.CreatedAt, ID: st.ID}
+	}
+
+	return toStudentUserResponses(students), utils.NewCursorPagination(params.Limit, hasMore, last), nil
+}
+
+// toStudentUserResponses maps students to the user responses returned by list endpoints
+func toStudentUserResponses(students []models.Student) []response.UserResponse {
+	responses := make([]response.UserResponse, 0, len(students))
 	for _, st := range students {
-		if st.User.ID != uuid.Nil {
+		if st.User != nil && st.User.ID != uuid.Nil {
 			responses = append(responses, response.UserResponse{
 				ID:       st.User.ID,
 				Email:    st.User.Email,
@@ -154,11 +348,11 @@ func (s *StudentService) GetAllStudents(institutionID string, params utils.Pagin
 					InstitutionID: st.User.Profile.InstitutionID,
 				},
 			})
+			last := &responses[len(responses)-1]
+			last.CreatedBy, last.UpdatedBy = authorBriefs(st.Authorship)
 		}
 	}
-
-	pagination := utils.NewPagination(params.Page, params.PerPage, total)
-	return responses, pagination, nil
+	return responses
 }
 
 // GetStudent gets a student by ID
@@ -168,6 +362,11 @@ func (s *StudentService) GetStudent(id uuid.UUID) (*response.UserResponse, error
 		return nil, err
 	}
 
+	return toStudentUserResponse(student), nil
+}
+
+// toStudentUserResponse maps a student loaded with its user profile to a user response
+func toStudentUserResponse(student *models.Student) *response.UserResponse {
 	resp := response.UserResponse{
 		ID:       student.User.ID,
 		Email:    student.User.Email,
@@ -181,11 +380,71 @@ func (s *StudentService) GetStudent(id uuid.UUID) (*response.UserResponse, error
 			InstitutionID: student.User.Profile.InstitutionID,
 		},
 	}
-	return &resp, nil
+	resp.CreatedBy, resp.UpdatedBy = authorBriefs(student.Authorship)
+	return &resp
+}
+
+// GetFullProfile gets a student together with their class and section, linked
+// parents and the institution's current academic year. Class, section and
+// academic year are null when not assigned.
+func (s *StudentService) GetFullProfile(id uuid.UUID, institutionID string) (*response.StudentFullProfileResponse, error) {
+	student, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if institutionID != "" && student.InstitutionID.String() != institutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+
+	resp := &response.StudentFullProfileResponse{
+		StudentID:     student.ID,
+		User:          *toStudentUserResponse(student),
+		RollNumber:    student.RollNumber,
+		AdmissionDate: student.AdmissionDate,
+		BloodGroup:    student.BloodGroup,
+		MedicalInfo:   student.MedicalInfo,
+	}
+	resp.AdmissionNumber = student.User.Profile.AdmissionNumber
+
+	if student.ClassID != nil {
+		var class models.Class
+		if err := s.db.Select("id", "name").First(&class, "id = ?", *student.ClassID).Error; err == nil {
+			resp.Class = &response.ClassBrief{ID: class.ID, Name: class.Name}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+	}
+	if student.SectionID != nil {
+		var section models.Section
+		if err := s.db.Select("id", "name").First(&section, "id = ?", *student.SectionID).Error; err == nil {
+			resp.Section = &response.SectionBrief{ID: section.ID, Name: section.Name}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+	}
+
+	var year models.AcademicYear
+	err = s.db.Where("institution_id = ? AND is_current = ?", student.InstitutionID, true).First(&year).Error
+	if err == nil {
+		resp.AcademicYear = &response.AcademicYearBrief{ID: year.ID, Name: year.Name, StartDate: year.StartDate, EndDate: year.EndDate}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp.Parents, err = s.studentParents(student.ID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Parents == nil {
+		resp.Parents = []response.ParentRelationResponse{}
+	}
+
+	return resp, nil
 }
 
 // UpdateStudent updates a student
-func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentRequest, institutionID string) (*response.UserResponse, error) {
+// Unless force is set, moving the student into a full class or section is rejected
+func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentRequest, institutionID string, actorID uuid.UUID, force bool) (*response.UserResponse, error) {
 	student, err := s.repo.FindByID(id)
 	if err != nil {
 		return nil, err
@@ -209,7 +468,11 @@ func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentR
 	}
 
 	if req.Phone != "" {
-		student.User.Phone = req.Phone
+		phone, err := canonicalPhone(s.userRepo, req.Phone, student.User.Phone)
+		if err != nil {
+			return nil, err
+		}
+		student.User.Phone = phone
 	}
 
 	if req.IsActive != nil {
@@ -227,6 +490,7 @@ func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentR
 	}
 
 	// Update student-specific fields
+	previousClassID, previousSectionID := student.ClassID, student.SectionID
 	if req.ClassID != "" {
 		classID, _ := uuid.Parse(req.ClassID)
 		student.ClassID = &classID
@@ -237,8 +501,18 @@ func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentR
 		student.SectionID = &sectionID
 	}
 
+	// A student moving without a roll number gets a new one, since the old one
+	// belongs to the previous class or section
+	moved := !sameUUID(student.ClassID, previousClassID) || !sameUUID(student.SectionID, previousSectionID)
+	if moved {
+		if err := s.checkSectionInClass(student.SectionID, student.ClassID); err != nil {
+			return nil, err
+		}
+	}
 	if req.RollNumber != nil {
 		student.RollNumber = *req.RollNumber
+	} else if moved {
+		student.RollNumber = 0
 	}
 
 	if req.BloodGroup != "" {
@@ -249,8 +523,29 @@ func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentR
 		student.MedicalInfo = req.MedicalInfo
 	}
 
+	// Only check capacity when the student moves into a different class or section
+	var newClassID, newSectionID *uuid.UUID
+	if !sameUUID(student.ClassID, previousClassID) {
+		newClassID = student.ClassID
+	}
+	if !sameUUID(student.SectionID, previousSectionID) {
+		newSectionID = student.SectionID
+	}
+
+	student.SetUpdatedBy(actorID)
+
 	// Save changes in transaction
 	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if !force {
+			if err := checkCapacity(tx, newClassID, newSectionID, &student.ID); err != nil {
+				return err
+			}
+		}
+		if req.RollNumber != nil || moved {
+			if err := s.assignRollNumber(tx, student); err != nil {
+				return err
+			}
+		}
 		if err := tx.Save(student.User).Error; err != nil {
 			return err
 		}
@@ -266,6 +561,9 @@ func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentR
 	})
 
 	if err != nil {
+		if errors.Is(err, utils.ErrCapacityExceeded) || errors.Is(err, utils.ErrRollNumberTaken) {
+			return nil, err
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -282,6 +580,7 @@ func (s *StudentService) UpdateStudent(id uuid.UUID, req *request.UpdateStudentR
 			InstitutionID: student.User.Profile.InstitutionID,
 		},
 	}
+	resp.CreatedBy, resp.UpdatedBy = authorBriefs(student.Authorship)
 	return &resp, nil
 }
 
@@ -292,9 +591,13 @@ func (s *StudentService) GetStudentParents(id uuid.UUID) ([]response.ParentRelat
 		return nil, err
 	}
 
-	// Load relations
+	return s.studentParents(student.ID)
+}
+
+// studentParents loads the parents linked to a student
+func (s *StudentService) studentParents(studentID uuid.UUID) ([]response.ParentRelationResponse, error) {
 	var relations []models.ParentStudentRelation
-	if err := s.db.Preload("Parent.User.Profile").Where("student_id = ?", student.ID).Find(&relations).Error; err != nil {
+	if err := s.db.Preload("Parent.User.Profile").Where("student_id = ?", studentID).Find(&relations).Error; err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -324,7 +627,9 @@ func (s *StudentService) GetStudentParents(id uuid.UUID) ([]response.ParentRelat
 	return responses, nil
 }
 
-// LinkParent links a parent to a student
+// LinkParent links a parent to a student. Linking a primary parent makes the
+// student's other parents non-primary, and a student's first parent always
+// becomes primary.
 func (s *StudentService) LinkParent(studentID uuid.UUID, req *request.LinkParentRequest) error {
 	// Verify student exists
 	student, err := s.repo.FindByID(studentID)
@@ -337,57 +642,1140 @@ func (s *StudentService) LinkParent(studentID uuid.UUID, req *request.LinkParent
 		return utils.ErrInvalidUUID
 	}
 
+	relationship := strings.ToLower(strings.TrimSpace(req.Relationship))
+	if !models.IsValidRelationship(relationship) {
+		return utils.ErrInvalidEnumValue
+	}
+
 	// Verify parent exists and belongs to same institution
 	var parent models.Parent
 	if err := s.db.Where("id = ? AND institution_id = ?", parentID, student.InstitutionID).First(&parent).Error; err != nil {
 		return utils.ErrInvalidParentStudentLink
 	}
 
-	// Check if relation already exists
-	var count int64
-	if err := s.db.Model(&models.ParentStudentRelation{}).
-		Where("parent_id = ? AND student_id = ?", parentID, studentID).
-		Count(&count).Error; err != nil {
-		return utils.ErrInternalServer.Wrap(err)
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// Serialize changes to the student's parents
+		if err := lockStudent(tx, studentID); err != nil {
+			return err
+		}
+
+		// Check if relation already exists
+		var count int64
+		if err := tx.Model(&models.ParentStudentRelation{}).
+			Where("parent_id = ? AND student_id = ?", parentID, studentID).
+			Count(&count).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		if count > 0 {
+			return utils.ErrResourceExists
+		}
+
+		isPrimary := req.IsPrimary
+		if isPrimary {
+			if err := clearPrimaryParent(tx, studentID); err != nil {
+				return utils.ErrInternalServer.Wrap(err)
+			}
+		} else {
+			var primaries int64
+			if err := tx.Model(&models.ParentStudentRelation{}).
+				Where("student_id = ? AND is_primary = ?", studentID, true).
+				Count(&primaries).Error; err != nil {
+				return utils.ErrInternalServer.Wrap(err)
+			}
+			isPrimary = primaries == 0
+		}
+
+		// Create relation
+		relation := &models.ParentStudentRelation{
+			BaseModel:    models.BaseModel{ID: uuid.New()},
+			ParentID:     parentID,
+			StudentID:    studentID,
+			Relationship: relationship,
+			IsPrimary:    isPrimary,
+		}
+
+		if err := tx.Create(relation).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		return nil
+	})
+}
+
+// SetPrimaryParent makes a linked parent the student's primary parent.
+// Students of another institution are reported as not found.
+func (s *StudentService) SetPrimaryParent(studentID, parentID uuid.UUID, institutionID string) error {
+	// Verify student exists in the caller's institution
+	student, err := s.repo.FindByID(studentID)
+	if err != nil {
+		return err
 	}
-	if count > 0 {
-		return utils.ErrResourceExists
+	if institutionID != "" && student.InstitutionID.String() != institutionID {
+		return utils.ErrResourceNotFound
 	}
 
-	// Create relation
-	relation := &models.ParentStudentRelation{
-		BaseModel:    models.BaseModel{ID: uuid.New()},
-		ParentID:     parentID,
-		StudentID:    studentID,
-		Relationship: req.Relationship,
-		IsPrimary:    req.IsPrimary,
-	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockStudent(tx, studentID); err != nil {
+			return err
+		}
 
-	if err := s.db.Create(relation).Error; err != nil {
-		return utils.ErrInternalServer.Wrap(err)
-	}
+		var relation models.ParentStudentRelation
+		if err := tx.Where("parent_id = ? AND student_id = ?", parentID, studentID).First(&relation).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrResourceNotFound
+			}
+			return utils.ErrInternalServer.Wrap(err)
+		}
 
-	return nil
+		if relation.IsPrimary {
+			return nil
+		}
+
+		if err := clearPrimaryParent(tx, studentID); err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		if err := tx.Model(&relation).Update("is_primary", true).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		return nil
+	})
 }
 
-// UnlinkParent removes a parent-student relationship
+// UnlinkParent removes a parent-student relationship.
+// If the primary parent is removed, the earliest linked remaining parent becomes primary.
 func (s *StudentService) UnlinkParent(studentID, parentID uuid.UUID) error {
 	// Verify student exists
 	if _, err := s.repo.FindByID(studentID); err != nil {
 		return err
 	}
 
-	// Delete the relation
-	result := s.db.Where("parent_id = ? AND student_id = ?", parentID, studentID).
-		Delete(&models.ParentStudentRelation{})
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockStudent(tx, studentID); err != nil {
+			return err
+		}
+
+		var relation models.ParentStudentRelation
+		if err := tx.Where("parent_id = ? AND student_id = ?", parentID, studentID).First(&relation).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrResourceNotFound
+			}
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		// Delete the relation
+		if err := tx.Delete(&relation).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		if !relation.IsPrimary {
+			return nil
+		}
+
+		var next models.ParentStudentRelation
+		err := tx.Where("student_id = ?", studentID).Order("created_at ASC").First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		if err := tx.Model(&next).Update("is_primary", true).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		return nil
+	})
+}
 
-	if result.Error != nil {
-		return utils.ErrInternalServer.Wrap(result.Error)
+// TransferStudent moves a student to another institution. The class, section
+// and roll number are cleared since they belong to the old institution, as is
+// the user's institution role, and the user's sessions are revoked so new
+// tokens carry the new institution.
+func (s *StudentService) TransferStudent(studentID uuid.UUID, req *request.TransferStudentRequest, transferredBy uuid.UUID) (*response.StudentTransferResponse, error) {
+	targetID, err := uuid.Parse(req.TargetInstitutionID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
 	}
 
-	if result.RowsAffected == 0 {
-		return utils.ErrResourceNotFound
+	var target models.Institution
+	if err := s.db.First(&target, "id = ?", targetID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrInstitutionNotFound
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if !target.IsActive {
+		return nil, utils.ErrInstitutionDisabled
+	}
+
+	var transfer *models.StudentTransfer
+	var userID uuid.UUID
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var student models.Student
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&student, "id = ?", studentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return utils.ErrResourceNotFound
+			}
+			return utils.ErrInternalServer.Wrap(err)
+		}
+		if student.InstitutionID == targetID {
+			return utils.ErrInvalidResourceState
+		}
+		userID = student.UserID
+
+		transfer = &models.StudentTransfer{
+			StudentID:         student.ID,
+			FromInstitutionID: student.InstitutionID,
+			ToInstitutionID:   targetID,
+			FromClassID:       student.ClassID,
+			FromSectionID:     student.SectionID,
+			FromRollNumber:    student.RollNumber,
+			Reason:            strings.TrimSpace(req.Reason),
+			TransferredBy:     &transferredBy,
+		}
+		if err := tx.Create(transfer).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		if err := tx.Model(&student).Updates(map[string]interface{}{
+			"institution_id": targetID,
+			"class_id":       nil,
+			"section_id":     nil,
+			"roll_number":    0,
+		}).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		if err := tx.Model(&models.UserProfile{}).Where("user_id = ?", student.UserID).
+			Update("institution_id", targetID).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", student.UserID).
+			Update("custom_role_id", nil).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		// Sessions carry the old institution in their tokens; access tokens are
+		// revoked once the transfer is committed
+		if err := tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", student.UserID).
+			Update("revoked_at", time.Now()).Error; err != nil {
+			return utils.ErrInternalServer.Wrap(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	revokeAccessTokens(s.jwtManager, userID)
 
+	return &response.StudentTransferResponse{
+		ID:                transfer.ID,
+		StudentID:         transfer.StudentID,
+		FromInstitutionID: transfer.FromInstitutionID,
+		ToInstitutionID:   transfer.ToInstitutionID,
+		FromClassID:       transfer.FromClassID,
+		FromSectionID:     transfer.FromSectionID,
+		FromRollNumber:    transfer.FromRollNumber,
+		Reason:            transfer.Reason,
+		TransferredBy:     transfer.TransferredBy,
+		CreatedAt:         transfer.CreatedAt,
+	}, nil
+}
+
+// lockStudent locks a student row for the rest of the transaction
+func lockStudent(tx *gorm.DB, studentID uuid.UUID) error {
+	var student models.Student
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&student, "id = ?", studentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.ErrResourceNotFound
+		}
+		return utils.ErrInternalServer.Wrap(err)
+	}
 	return nil
 }
+
+// clearPrimaryParent marks all of a student's parent relations as non-primary
+func clearPrimaryParent(tx *gorm.DB, studentID uuid.UUID) error {
+	return tx.Model(&models.ParentStudentRelation{}).
+		Where("student_id = ? AND is_primary = ?", studentID, true).
+		Update("is_primary", false).Error
+}
+
+// studentImportBatchSize is the number of rows written per transaction during an import
+const studentImportBatchSize = 50
+
+// studentImportEntry is a validated import row ready to be written
+type studentImportEntry struct {
+	index       int
+	row         request.StudentImportRow
+	classID     uuid.UUID
+	sectionID   *uuid.UUID
+	dateOfBirth *time.Time
+	password    string
+}
+
+// BulkImport creates students from parsed import rows.
+// Classes and sections are resolved by name within the institution and every
+// student gets a generated default password. Roll numbers must be free in their
+// class and section; missing ones are assigned as for a single student. With
+// skipInvalid, bad rows are reported and the rest are created; otherwise any
+// invalid row aborts the import before anything is written.
+func (s *StudentService) BulkImport(rows []request.StudentImportRow, institutionID string, actorID uuid.UUID, skipInvalid bool) (*response.StudentImportResponse, error) {
+	instID, err := uuid.Parse(institutionID)
+	if err != nil {
+		return nil, errors.New("institution_id is required")
+	}
+
+	// Lookup tables for class and section names
+	var classes []models.Class
+	if err := s.db.Preload("Sections").Where("institution_id = ?", instID).Find(&classes).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	classByName := make(map[string]*models.Class, len(classes))
+	for i := range classes {
+		classByName[normalizeImportName(classes[i].Name)] = &classes[i]
+	}
+
+	// Existing emails, phones and admission numbers, used for duplicate detection
+	emails := make([]string, 0, len(rows))
+	phones := make([]string, 0, len(rows))
+	admissionNumbers := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if email := strings.TrimSpace(row.Email); email != "" {
+			emails = append(emails, strings.ToLower(email))
+		}
+		if phone, err := utils.NormalizePhone(row.Phone); err == nil {
+			phones = append(phones, phone)
+		}
+		if number := strings.TrimSpace(row.AdmissionNumber); number != "" {
+			admissionNumbers = append(admissionNumbers, number)
+		}
+	}
+	existingEmails, err := findExistingEmails(s.db, emails)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	existingPhones, err := findExistingPhones(s.db, phones)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	existingAdmissions, err := s.existingAdmissionNumbers(instID, admissionNumbers)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.StudentImportResponse{Results: make([]response.StudentImportResult, len(rows))}
+	seenEmails := make(map[string]int)
+	seenPhones := make(map[string]int)
+	seenAdmissions := make(map[string]int)
+	var entries []studentImportEntry
+
+	for i, row := range rows {
+		row.Email = strings.TrimSpace(row.Email)
+		row.Phone = strings.TrimSpace(row.Phone)
+		row.FirstName = strings.TrimSpace(row.FirstName)
+		row.LastName = strings.TrimSpace(row.LastName)
+		row.AdmissionNumber = strings.TrimSpace(row.AdmissionNumber)
+		resp.Results[i] = response.StudentImportResult{Row: row.Row, Email: row.Email}
+
+		entry, rowErr := validateImportRow(row, classByName)
+		if rowErr == nil {
+			email := strings.ToLower(row.Email)
+			phone := entry.row.Phone
+			switch {
+			case existingEmails[email]:
+				rowErr = errors.New("email already registered")
+			case seenEmails[email] != 0:
+				rowErr = fmt.Errorf("duplicate email, first used on row %d", seenEmails[email])
+			case phone != "" && existingPhones[phone]:
+				rowErr = errors.New("phone already registered")
+			case phone != "" && seenPhones[phone] != 0:
+				rowErr = fmt.Errorf("duplicate phone, first used on row %d", seenPhones[phone])
+			case row.AdmissionNumber != "" && existingAdmissions[row.AdmissionNumber]:
+				rowErr = errors.New("admission number already exists")
+			case row.AdmissionNumber != "" && seenAdmissions[row.AdmissionNumber] != 0:
+				rowErr = fmt.Errorf("duplicate admission number, first used on row %d", seenAdmissions[row.AdmissionNumber])
+			}
+			seenEmails[email] = row.Row
+			if phone != "" {
+				seenPhones[phone] = row.Row
+			}
+			if row.AdmissionNumber != "" {
+				seenAdmissions[row.AdmissionNumber] = row.Row
+			}
+		}
+
+		if rowErr != nil {
+			resp.Results[i].Error = rowErr.Error()
+			resp.Failed++
+			continue
+		}
+
+		entry.index = i
+		entries = append(entries, *entry)
+	}
+
+	entries, err = s.rejectTakenRollNumbers(entries, resp)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	if resp.Failed > 0 && !skipInvalid {
+		return resp, utils.ErrUnprocessableEntity
+	}
+
+	for start := 0; start < len(entries); start += studentImportBatchSize {
+		end := start + studentImportBatchSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		batch := entries[start:end]
+
+		// Hash outside the transaction, bcrypt is slow
+		hashes := make([]string, len(batch))
+		for i := range batch {
+			password, err := utils.GenerateRandomPassword(12)
+			if err != nil {
+				return resp, utils.ErrInternalServer.Wrap(err)
+			}
+			hash, err := utils.HashPassword(password)
+			if err != nil {
+				return resp, utils.ErrInternalServer.Wrap(err)
+			}
+			batch[i].password = password
+			hashes[i] = hash
+		}
+
+		created := make([]*models.Student, len(batch))
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			for i, entry := range batch {
+				if !skipInvalid {
+					student, err := s.createImportedStudent(tx, instID, actorID, entry, hashes[i])
+					if err != nil {
+						return fmt.Errorf("row %d: %w", entry.row.Row, err)
+					}
+					created[i] = student
+					continue
+				}
+
+				// Savepoint per row so a failure only discards that row
+				err := tx.Transaction(func(rowTx *gorm.DB) error {
+					student, err := s.createImportedStudent(rowTx, instID, actorID, entry, hashes[i])
+					created[i] = student
+					return err
+				})
+				if err != nil {
+					created[i] = nil
+					resp.Results[entry.index].Error = err.Error()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// The whole batch was rolled back
+			for _, entry := range entries[start:] {
+				resp.Results[entry.index].Error = "not imported: " + err.Error()
+			}
+			countImportResults(resp)
+			if errors.Is(err, utils.ErrRollNumberTaken) {
+				return resp, utils.ErrUnprocessableEntity
+			}
+			return resp, utils.ErrInternalServer.Wrap(err)
+		}
+
+		for i, student := range created {
+			if student == nil {
+				continue
+			}
+			result := &resp.Results[batch[i].index]
+			result.Success = true
+			result.StudentID = &student.ID
+			result.UserID = &student.UserID
+			result.DefaultPassword = batch[i].password
+		}
+	}
+
+	countImportResults(resp)
+	return resp, nil
+}
+
+// rejectTakenRollNumbers reports the import entries whose roll number is already
+// taken in their class and section, by an existing student or an earlier row,
+// and returns the remaining entries
+func (s *StudentService) rejectTakenRollNumbers(entries []studentImportEntry, resp *response.StudentImportResponse) ([]studentImportEntry, error) {
+	var classIDs []uuid.UUID
+	var rolls []int
+	for _, entry := range entries {
+		if entry.row.RollNumber > 0 {
+			classIDs = append(classIDs, entry.classID)
+			rolls = append(rolls, entry.row.RollNumber)
+		}
+	}
+	if len(rolls) == 0 {
+		return entries, nil
+	}
+
+	var existing []models.Student
+	if err := s.db.Select("class_id", "section_id", "roll_number").
+		Where("class_id IN ? AND roll_number IN ?", classIDs, rolls).
+		Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	// Row that first used each roll number, 0 for existing students
+	taken := make(map[string]int, len(existing)+len(rolls))
+	for _, student := range existing {
+		taken[rollNumberKey(*student.ClassID, student.SectionID, student.RollNumber)] = 0
+	}
+
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.row.RollNumber > 0 {
+			key := rollNumberKey(entry.classID, entry.sectionID, entry.row.RollNumber)
+			if row, ok := taken[key]; ok {
+				if row == 0 {
+					resp.Results[entry.index].Error = "roll number already taken in this class or section"
+				} else {
+					resp.Results[entry.index].Error = fmt.Sprintf("duplicate roll number, first used on row %d", row)
+				}
+				resp.Failed++
+				continue
+			}
+			taken[key] = entry.row.Row
+		}
+		kept = append(kept, entry)
+	}
+	return kept, nil
+}
+
+// rollNumberKey identifies a roll number within a class and section
+func rollNumberKey(classID uuid.UUID, sectionID *uuid.UUID, roll int) string {
+	section := ""
+	if sectionID != nil {
+		section = sectionID.String()
+	}
+	return fmt.Sprintf("%s/%s/%d", classID, section, roll)
+}
+
+// countImportResults recomputes the created and failed totals of an import report
+func countImportResults(resp *response.StudentImportResponse) {
+	resp.Created, resp.Failed = 0, 0
+	for _, result := range resp.Results {
+		if result.Success {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+}
+
+// validateImportRow checks the fields of an import row, normalizes its phone to
+// E.164 and resolves its class and section
+func validateImportRow(row request.StudentImportRow, classByName map[string]*models.Class) (*studentImportEntry, error) {
+	if row.Email == "" {
+		return nil, errors.New("email is required")
+	}
+	if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+		return nil, errors.New("invalid email format")
+	}
+	if row.FirstName == "" || row.LastName == "" {
+		return nil, errors.New("first and last name are required")
+	}
+	if row.Phone != "" {
+		phone, err := utils.NormalizePhone(row.Phone)
+		if err != nil {
+			return nil, errors.New("invalid phone format")
+		}
+		row.Phone = phone
+	}
+	if row.RollNumber < 0 {
+		return nil, errors.New("invalid roll number")
+	}
+	if len(row.BloodGroup) > 5 {
+		return nil, errors.New("invalid blood group")
+	}
+
+	class, ok := classByName[normalizeImportName(row.ClassName)]
+	if !ok {
+		return nil, fmt.Errorf("class %q not found", row.ClassName)
+	}
+	entry := &studentImportEntry{row: row, classID: class.ID}
+
+	if name := normalizeImportName(row.SectionName); name != "" {
+		for i := range class.Sections {
+			if normalizeImportName(class.Sections[i].Name) == name {
+				entry.sectionID = &class.Sections[i].ID
+				break
+			}
+		}
+		if entry.sectionID == nil {
+			return nil, fmt.Errorf("section %q not found in class %q", row.SectionName, class.Name)
+		}
+	}
+
+	if dob := strings.TrimSpace(row.DateOfBirth); dob != "" {
+		date, err := time.Parse("2006-01-02", dob)
+		if err != nil {
+			return nil, errors.New("invalid date of birth, expected YYYY-MM-DD")
+		}
+		entry.dateOfBirth = &date
+	}
+
+	return entry, nil
+}
+
+// createImportedStudent creates the user, profile and student rows for an import entry.
+// The roll number is checked and, when missing, assigned like for a single student.
+func (s *StudentService) createImportedStudent(tx *gorm.DB, institutionID, actorID uuid.UUID, entry studentImportEntry, passwordHash string) (*models.Student, error) {
+	row := entry.row
+
+	user := &models.User{
+		BaseModel:          models.BaseModel{ID: uuid.New()},
+		Email:              row.Email,
+		Phone:              row.Phone,
+		PasswordHash:       passwordHash,
+		MustChangePassword: true,
+		Role:               models.RoleStudent,
+		IsActive:           true,
+		EmailVerified:      true,
+	}
+	if err := tx.Create(user).Error; err != nil {
+		return nil, err
+	}
+
+	profile := &models.UserProfile{
+		BaseModel:       models.BaseModel{ID: uuid.New()},
+		UserID:          user.ID,
+		InstitutionID:   &institutionID,
+		FirstName:       row.FirstName,
+		LastName:        row.LastName,
+		DateOfBirth:     entry.dateOfBirth,
+		AdmissionNumber: row.AdmissionNumber,
+	}
+	if err := tx.Create(profile).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	classID := entry.classID
+	student := &models.Student{
+		TenantBaseModel: models.TenantBaseModel{
+			BaseModel:     models.BaseModel{ID: uuid.New()},
+			InstitutionID: institutionID,
+		},
+		UserID:        user.ID,
+		AdmissionDate: &now,
+		RollNumber:    row.RollNumber,
+		ClassID:       &classID,
+		SectionID:     entry.sectionID,
+		BloodGroup:    strings.TrimSpace(row.BloodGroup),
+	}
+	student.SetCreatedBy(actorID)
+	if err := s.assignRollNumber(tx, student); err != nil {
+		return nil, err
+	}
+	if err := tx.Create(student).Error; err != nil {
+		return nil, err
+	}
+
+	return student, nil
+}
+
+// findExistingEmails returns which of the given (lower-cased) emails are already registered
+func findExistingEmails(db *gorm.DB, emails []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(emails) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	if err := db.Unscoped().Model(&models.User{}).
+		Where("LOWER(email) IN ?", emails).
+		Pluck("LOWER(email)", &found).Error; err != nil {
+		return nil, err
+	}
+	for _, email := range found {
+		existing[email] = true
+	}
+	return existing, nil
+}
+
+// findExistingPhones returns which of the given E.164 phone numbers already belong to a user
+func findExistingPhones(db *gorm.DB, phones []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(phones) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	if err := db.Model(&models.User{}).
+		Where("phone IN ?", phones).
+		Pluck("phone", &found).Error; err != nil {
+		return nil, err
+	}
+	for _, phone := range found {
+		existing[phone] = true
+	}
+	return existing, nil
+}
+
+// existingAdmissionNumbers returns which of the given admission numbers are already used in the institution
+func (s *StudentService) existingAdmissionNumbers(institutionID uuid.UUID, numbers []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(numbers) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	if err := s.db.Model(&models.UserProfile{}).
+		Where("institution_id = ? AND admission_number IN ?", institutionID, numbers).
+		Pluck("admission_number", &found).Error; err != nil {
+		return nil, err
+	}
+	for _, number := range found {
+		existing[number] = true
+	}
+	return existing, nil
+}
+
+// normalizeImportName normalizes a class or section name for case-insensitive matching
+func normalizeImportName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Promotion result statuses
+const (
+	PromotionStatusPromoted = "promoted"
+	PromotionStatusSkipped  = "skipped"
+	PromotionStatusReverted = "reverted"
+)
+
+// PromoteStudents moves students from one class/section to another.
+// Each move is recorded under a common batch ID so it can be reverted with
+// RevertPromotion. Students already in the target class are skipped.
+func (s *StudentService) PromoteStudents(req *request.PromoteStudentsRequest, institutionID string, promotedBy uuid.UUID) (*response.PromotionResponse, error) {
+	instID, err := uuid.Parse(institutionID)
+	if err != nil {
+		return nil, errors.New("institution_id is required")
+	}
+	if !req.All && len(req.StudentIDs) == 0 {
+		return nil, errors.New("student_ids or all is required")
+	}
+
+	fromClassID, _ := uuid.Parse(req.FromClassID)
+	toClassID, _ := uuid.Parse(req.ToClassID)
+	fromSectionID, err := s.resolvePromotionSection(req.FromSectionID, fromClassID)
+	if err != nil {
+		return nil, err
+	}
+	toSectionID, err := s.resolvePromotionSection(req.ToSectionID, toClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both classes must belong to the institution
+	for _, classID := range []uuid.UUID{fromClassID, toClassID} {
+		var count int64
+		if err := s.db.Model(&models.Class{}).
+			Where("id = ? AND institution_id = ?", classID, instID).
+			Count(&count).Error; err != nil {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+		if count == 0 {
+			return nil, errors.New("class not found")
+		}
+	}
+
+	if fromClassID == toClassID && sameUUID(fromSectionID, toSectionID) {
+		return nil, errors.New("source and target class/section must differ")
+	}
+
+	// Load the candidate students
+	query := s.db.Where("institution_id = ?", instID)
+	if req.All {
+		query = query.Where("class_id = ?", fromClassID)
+		if fromSectionID != nil {
+			query = query.Where("section_id = ?", *fromSectionID)
+		}
+	} else {
+		query = query.Where("id IN ?", req.StudentIDs)
+	}
+	var students []models.Student
+	if err := query.Order("roll_number ASC").Find(&students).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.PromotionResponse{BatchID: uuid.New()}
+	found := make(map[uuid.UUID]bool, len(students))
+	var toPromote []models.Student
+	for _, student := range students {
+		found[student.ID] = true
+		switch {
+		case student.ClassID != nil && *student.ClassID == toClassID &&
+			(toSectionID == nil || sameUUID(student.SectionID, toSectionID)):
+			resp.Results = append(resp.Results, response.PromotionResult{
+				StudentID: student.ID,
+				Status:    PromotionStatusSkipped,
+				Reason:    "already in target class",
+			})
+		case student.ClassID == nil || *student.ClassID != fromClassID ||
+			(fromSectionID != nil && !sameUUID(student.SectionID, fromSectionID)):
+			resp.Results = append(resp.Results, response.PromotionResult{
+				StudentID: student.ID,
+				Status:    PromotionStatusSkipped,
+				Reason:    "not in source class",
+			})
+		default:
+			toPromote = append(toPromote, student)
+		}
+	}
+	if !req.All {
+		for _, id := range req.StudentIDs {
+			studentID, _ := uuid.Parse(id)
+			if !found[studentID] {
+				found[studentID] = true
+				resp.Results = append(resp.Results, response.PromotionResult{
+					StudentID: studentID,
+					Status:    PromotionStatusSkipped,
+					Reason:    "student not found",
+				})
+			}
+		}
+	}
+
+	if len(toPromote) > 0 {
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			nextRoll := 0
+			if req.ResetRollNumbers {
+				// Continue numbering after students already in the target
+				maxQuery := tx.Model(&models.Student{}).Where("class_id = ?", toClassID)
+				if toSectionID != nil {
+					maxQuery = maxQuery.Where("section_id = ?", *toSectionID)
+				}
+				if err := maxQuery.Select("COALESCE(MAX(roll_number), 0)").Scan(&nextRoll).Error; err != nil {
+					return err
+				}
+			}
+
+			for _, student := range toPromote {
+				rollNumber := student.RollNumber
+				if req.ResetRollNumbers {
+					nextRoll++
+					rollNumber = nextRoll
+				}
+
+				promotion := &models.StudentPromotion{
+					TenantBaseModel: models.TenantBaseModel{
+						BaseModel:     models.BaseModel{ID: uuid.New()},
+						InstitutionID: instID,
+					},
+					BatchID:        resp.BatchID,
+					StudentID:      student.ID,
+					FromClassID:    student.ClassID,
+					FromSectionID:  student.SectionID,
+					FromRollNumber: student.RollNumber,
+					ToClassID:      toClassID,
+					ToSectionID:    toSectionID,
+					ToRollNumber:   rollNumber,
+					PromotedBy:     &promotedBy,
+				}
+				if err := tx.Create(promotion).Error; err != nil {
+					return err
+				}
+
+				if err := tx.Model(&models.Student{}).Where("id = ?", student.ID).Updates(map[string]interface{}{
+					"class_id":    toClassID,
+					"section_id":  toSectionID,
+					"roll_number": rollNumber,
+				}).Error; err != nil {
+					return err
+				}
+
+				resp.Results = append(resp.Results, response.PromotionResult{
+					StudentID:  student.ID,
+					Status:     PromotionStatusPromoted,
+					RollNumber: rollNumber,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+	}
+
+	for _, result := range resp.Results {
+		if result.Status == PromotionStatusPromoted {
+			resp.Promoted++
+		} else {
+			resp.Skipped++
+		}
+	}
+
+	return resp, nil
+}
+
+// RevertPromotion moves the students of a promotion batch back to their previous class.
+// Students who have been moved again since the promotion are skipped.
+func (s *StudentService) RevertPromotion(batchID uuid.UUID, institutionID string) (*response.PromotionResponse, error) {
+	var promotions []models.StudentPromotion
+	query := s.db.Preload("Student").Where("batch_id = ? AND reverted_at IS NULL", batchID)
+	if institutionID != "" {
+		query = query.Where("institution_id = ?", institutionID)
+	}
+	if err := query.Find(&promotions).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if len(promotions) == 0 {
+		return nil, utils.ErrResourceNotFound
+	}
+
+	resp := &response.PromotionResponse{BatchID: batchID}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, promotion := range promotions {
+			student := promotion.Student
+			if student == nil || student.ClassID == nil || *student.ClassID != promotion.ToClassID ||
+				!sameUUID(student.SectionID, promotion.ToSectionID) {
+				resp.Results = append(resp.Results, response.PromotionResult{
+					StudentID: promotion.StudentID,
+					Status:    PromotionStatusSkipped,
+					Reason:    "student has moved since the promotion",
+				})
+				continue
+			}
+
+			if err := tx.Model(&models.Student{}).Where("id = ?", student.ID).Updates(map[string]interface{}{
+				"class_id":    promotion.FromClassID,
+				"section_id":  promotion.FromSectionID,
+				"roll_number": promotion.FromRollNumber,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&promotion).Update("reverted_at", now).Error; err != nil {
+				return err
+			}
+
+			resp.Results = append(resp.Results, response.PromotionResult{
+				StudentID:  promotion.StudentID,
+				Status:     PromotionStatusReverted,
+				RollNumber: promotion.FromRollNumber,
+			})
+			resp.Reverted++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp.Skipped = len(resp.Results) - resp.Reverted
+	return resp, nil
+}
+
+// BulkSetStatus activates or deactivates the user accounts of many students at once,
+// e.g. a graduating class. Students are selected by ID or by class/section and are
+// always scoped to the caller's institution; accounts already in the target state are skipped.
+func (s *StudentService) BulkSetStatus(req *request.BulkStudentStatusRequest, institutionID string) (*response.BulkStatusResponse, error) {
+	query := s.db.Model(&models.Student{})
+	if institutionID != "" {
+		query = query.Where("institution_id = ?", institutionID)
+	}
+	if len(req.StudentIDs) > 0 {
+		query = query.Where("id IN ?", req.StudentIDs)
+	} else {
+		classID, err := uuid.Parse(req.ClassID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		query = query.Where("class_id = ?", classID)
+
+		sectionID, err := s.resolvePromotionSection(req.SectionID, classID)
+		if err != nil {
+			return nil, err
+		}
+		if sectionID != nil {
+			query = query.Where("section_id = ?", *sectionID)
+		}
+	}
+
+	var userIDs []uuid.UUID
+	if err := query.Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.BulkStatusResponse{Matched: int64(len(userIDs))}
+	if len(userIDs) == 0 {
+		return resp, nil
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id IN ? AND is_active <> ?", userIDs, *req.IsActive).
+			Update("is_active", *req.IsActive)
+		if result.Error != nil {
+			return result.Error
+		}
+		resp.Updated = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp.Skipped = resp.Matched - resp.Updated
+	return resp, nil
+}
+
+// MoveSectionStudents moves students from one section to another in a single
+// transaction. Both sections must belong to the institution and, unless
+// AllowCrossClass is set, to the same class. Students are moved in roll number
+// order until the target section is full; the rest are skipped, as are
+// students not in the source section. Moved students get a new roll number.
+func (s *StudentService) MoveSectionStudents(req *request.MoveSectionStudentsRequest, institutionID string) (*response.SectionMoveResponse, error) {
+	fromSectionID, _ := uuid.Parse(req.FromSectionID)
+	toSectionID, _ := uuid.Parse(req.ToSectionID)
+	if fromSectionID == toSectionID {
+		return nil, errors.New("source and target section must differ")
+	}
+
+	from, err := s.sectionRepo.FindByID(fromSectionID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.sectionRepo.FindByID(toSectionID)
+	if err != nil {
+		return nil, err
+	}
+	if institutionID != "" &&
+		(from.Class.InstitutionID.String() != institutionID || to.Class.InstitutionID.String() != institutionID) {
+		return nil, utils.ErrCrossTenantAccess
+	}
+	if from.Class.InstitutionID != to.Class.InstitutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+	crossClass := from.ClassID != to.ClassID
+	if crossClass && !req.AllowCrossClass {
+		return nil, errors.New("target section is in a different class, set allow_cross_class to move across classes")
+	}
+
+	var students []models.Student
+	if err := s.db.Where("id IN ? AND institution_id = ? AND section_id = ?", req.StudentIDs, from.Class.InstitutionID, fromSectionID).
+		Order("roll_number ASC").Find(&students).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.SectionMoveResponse{}
+	found := make(map[uuid.UUID]bool, len(students))
+	for _, student := range students {
+		found[student.ID] = true
+	}
+	for _, id := range req.StudentIDs {
+		studentID, _ := uuid.Parse(id)
+		if !found[studentID] {
+			found[studentID] = true
+			resp.Results = append(resp.Results, response.PromotionResult{
+				StudentID: studentID,
+				Status:    PromotionStatusSkipped,
+				Reason:    "not in source section",
+			})
+		}
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for i := range students {
+			student := &students[i]
+
+			// Capacity is re-checked per student since each move fills a seat
+			var capacityErr error
+			if crossClass {
+				capacityErr = checkCapacity(tx, &to.ClassID, &toSectionID, nil)
+			} else {
+				capacityErr = checkCapacity(tx, nil, &toSectionID, nil)
+			}
+			if errors.Is(capacityErr, utils.ErrCapacityExceeded) {
+				resp.Results = append(resp.Results, response.PromotionResult{
+					StudentID: student.ID,
+					Status:    PromotionStatusSkipped,
+					Reason:    "target section is full",
+				})
+				continue
+			}
+			if capacityErr != nil {
+				return capacityErr
+			}
+
+			student.ClassID = &to.ClassID
+			student.SectionID = &toSectionID
+			student.RollNumber = 0
+			if err := s.assignRollNumber(tx, student); err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Student{}).Where("id = ?", student.ID).Updates(map[string]interface{}{
+				"class_id":    to.ClassID,
+				"section_id":  toSectionID,
+				"roll_number": student.RollNumber,
+			}).Error; err != nil {
+				return err
+			}
+			resp.Moved++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp.Skipped = len(resp.Results)
+	return resp, nil
+}
+
+// resolvePromotionSection parses an optional section ID and checks it belongs to the class
+func (s *StudentService) resolvePromotionSection(sectionID string, classID uuid.UUID) (*uuid.UUID, error) {
+	if sectionID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(sectionID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	ok, err := s.sectionRepo.BelongsToClass(id, classID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if !ok {
+		return nil, errors.New("section not found in class")
+	}
+	return &id, nil
+}
+
+// checkSectionInClass rejects a section that belongs to a different class than
+// the student's, or a section without a class
+func (s *StudentService) checkSectionInClass(sectionID, classID *uuid.UUID) error {
+	if sectionID == nil {
+		return nil
+	}
+	if classID == nil {
+		return utils.ErrSectionNotInClass
+	}
+	ok, err := s.sectionRepo.BelongsToClass(*sectionID, *classID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if !ok {
+		return utils.ErrSectionNotInClass
+	}
+	return nil
+}
+
+// sameUUID reports whether two optional IDs are equal
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/middleware"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// setCapacity updates the capacity of a class or section
+func setCapacity(t *testing.T, db *gorm.DB, model interface{}, id uuid.UUID, capacity int) {
+	t.Helper()
+
+	if err := db.Model(model).Where("id = ?", id).Update("capacity", capacity).Error; err != nil {
+		t.Fatalf("failed to set capacity: %v", err)
+	}
+}
+
+func TestCheckCapacity(t *testing.T) {
+	tests := []struct {
+		name            string
+		classCapacity   int
+		sectionCapacity int
+		enrolled        int
+		excludeEnrolled bool
+		wantErr         error
+	}{
+		{name: "below capacity", classCapacity: 3, enrolled: 2},
+		{name: "count equals capacity", classCapacity: 3, enrolled: 3, wantErr: utils.ErrCapacityExceeded},
+		{name: "over capacity", classCapacity: 2, enrolled: 3, wantErr: utils.ErrCapacityExceeded},
+		{name: "zero capacity is unlimited", enrolled: 5},
+		{name: "full section in a roomy class", classCapacity: 10, sectionCapacity: 2, enrolled: 2, wantErr: utils.ErrCapacityExceeded},
+		{name: "student already counted", classCapacity: 2, sectionCapacity: 2, enrolled: 2, excludeEnrolled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.DB(t)
+			institution := testutil.Institution(t, db)
+			class := testutil.Class(t, db, institution.ID)
+			section := testutil.Section(t, db, class.ID)
+			setCapacity(t, db, &models.Class{}, class.ID, tt.classCapacity)
+			setCapacity(t, db, &models.Section{}, section.ID, tt.sectionCapacity)
+
+			var last *models.Student
+			for i := 0; i < tt.enrolled; i++ {
+				last = testutil.Student(t, db, institution.ID, &class.ID, &section.ID)
+			}
+			var exclude *uuid.UUID
+			if tt.excludeEnrolled {
+				exclude = &last.ID
+			}
+
+			err := checkCapacity(db, &class.ID, &section.ID, exclude)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkCapacity() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateStudentCapacity(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, nil)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	section := testutil.Section(t, db, class.ID)
+	setCapacity(t, db, &models.Section{}, section.ID, 2)
+	testutil.Student(t, db, institution.ID, &class.ID, &section.ID)
+
+	newRequest := func(admissionNumber string) *request.CreateStudentRequest {
+		req := &request.CreateStudentRequest{
+			AdmissionNumber: admissionNumber,
+			AdmissionDate:   "2026-01-10",
+			ClassID:         class.ID.String(),
+			SectionID:       section.ID.String(),
+		}
+		req.Email = "student-" + admissionNumber + "@example.com"
+		req.Password = "Password@123"
+		req.FirstName = "New"
+		req.LastName = "Student"
+		return req
+	}
+
+	// The second seat fills the section to exactly its capacity
+	if _, err := s.CreateStudent(context.Background(), newRequest("A-2"), institution.ID.String(), uuid.Nil, false); err != nil {
+		t.Fatalf("CreateStudent() into the last seat unexpected error: %v", err)
+	}
+
+	if _, err := s.CreateStudent(context.Background(), newRequest("A-3"), institution.ID.String(), uuid.Nil, false); !errors.Is(err, utils.ErrCapacityExceeded) {
+		t.Fatalf("CreateStudent() into a full section error = %v, want ErrCapacityExceeded", err)
+	}
+
+	if _, err := s.CreateStudent(context.Background(), newRequest("A-4"), institution.ID.String(), uuid.Nil, true); err != nil {
+		t.Fatalf("CreateStudent() with force unexpected error: %v", err)
+	}
+
+	var count int64
+	db.Model(&models.Student{}).Where("section_id = ?", section.ID).Count(&count)
+	if count != 3 {
+		t.Errorf("section has %d students, want 3", count)
+	}
+}
+
+func TestStudentSectionMustBelongToClass(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, nil)
+
+	institution := testutil.Institution(t, db)
+	classA := testutil.Class(t, db, institution.ID)
+	classB := testutil.Class(t, db, institution.ID)
+	sectionA := testutil.Section(t, db, classA.ID)
+	sectionB := testutil.Section(t, db, classB.ID)
+
+	t.Run("create with a section of another class", func(t *testing.T) {
+		req := &request.CreateStudentRequest{
+			AdmissionNumber: "MISMATCH-1",
+			AdmissionDate:   "2026-01-10",
+			ClassID:         classB.ID.String(),
+			SectionID:       sectionA.ID.String(),
+		}
+		req.Email = "mismatch-" + uuid.NewString()[:8] + "@example.com"
+		req.Password = "Password@123"
+		req.FirstName = "New"
+		req.LastName = "Student"
+
+		_, err := s.CreateStudent(context.Background(), req, institution.ID.String(), uuid.Nil, false)
+		if !errors.Is(err, utils.ErrSectionNotInClass) {
+			t.Fatalf("CreateStudent() error = %v, want ErrSectionNotInClass", err)
+		}
+	})
+
+	t.Run("update moving class but keeping the old section", func(t *testing.T) {
+		student := testutil.Student(t, db, institution.ID, &classA.ID, &sectionA.ID)
+		req := &request.UpdateStudentRequest{ClassID: classB.ID.String()}
+
+		_, err := s.UpdateStudent(student.ID, req, institution.ID.String(), uuid.Nil, false)
+		if !errors.Is(err, utils.ErrSectionNotInClass) {
+			t.Fatalf("UpdateStudent() error = %v, want ErrSectionNotInClass", err)
+		}
+	})
+
+	t.Run("update moving class and section together", func(t *testing.T) {
+		student := testutil.Student(t, db, institution.ID, &classA.ID, &sectionA.ID)
+		req := &request.UpdateStudentRequest{ClassID: classB.ID.String(), SectionID: sectionB.ID.String()}
+
+		if _, err := s.UpdateStudent(student.ID, req, institution.ID.String(), uuid.Nil, false); err != nil {
+			t.Fatalf("UpdateStudent() unexpected error: %v", err)
+		}
+	})
+}
+
+func TestCreateStudentDateOfBirth(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, nil)
+
+	institution := testutil.Institution(t, db)
+	newRequest := func(dateOfBirth string) *request.CreateStudentRequest {
+		suffix := uuid.NewString()[:8]
+		req := &request.CreateStudentRequest{
+			AdmissionNumber: "DOB-" + suffix,
+			AdmissionDate:   "2026-01-10",
+			DateOfBirth:     dateOfBirth,
+			Gender:          "female",
+		}
+		req.Email = "dob-" + suffix + "@example.com"
+		req.Password = "Password@123"
+		req.FirstName = "New"
+		req.LastName = "Student"
+		return req
+	}
+
+	tests := []struct {
+		name        string
+		dateOfBirth string
+		wantErr     error
+	}{
+		{name: "invalid date", dateOfBirth: "2014-13-01", wantErr: utils.ErrInvalidDateFormat},
+		{name: "future date", dateOfBirth: time.Now().AddDate(1, 0, 0).Format("2006-01-02"), wantErr: utils.ErrFieldOutOfRange},
+		{name: "valid date", dateOfBirth: "2014-05-20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.CreateStudent(context.Background(), newRequest(tt.dateOfBirth), institution.ID.String(), uuid.Nil, false)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateStudent() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateStudent() unexpected error: %v", err)
+			}
+			if resp.Profile == nil || resp.Profile.DateOfBirth == nil || resp.Profile.DateOfBirth.Format("2006-01-02") != tt.dateOfBirth {
+				t.Fatalf("CreateStudent() response profile = %+v, want date of birth %s", resp.Profile, tt.dateOfBirth)
+			}
+
+			var profile models.UserProfile
+			if err := db.First(&profile, "user_id = ?", resp.ID).Error; err != nil {
+				t.Fatalf("failed to load profile: %v", err)
+			}
+			if profile.DateOfBirth == nil || profile.DateOfBirth.Format("2006-01-02") != tt.dateOfBirth {
+				t.Errorf("stored date of birth = %v, want %s", profile.DateOfBirth, tt.dateOfBirth)
+			}
+		})
+	}
+}
+
+func TestSetPrimaryParentTenant(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, nil)
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+	student := testutil.Student(t, db, schoolA.ID, nil, nil)
+
+	var parents []*models.ParentStudentRelation
+	for _, relationship := range []string{models.RelationshipFather, models.RelationshipMother} {
+		parent := &models.Parent{UserID: testutil.User(t, db, models.RoleParent, &schoolA.ID).ID}
+		parent.InstitutionID = schoolA.ID
+		testutil.Create(t, db, parent)
+		relation := &models.ParentStudentRelation{
+			ParentID:     parent.ID,
+			StudentID:    student.ID,
+			Relationship: relationship,
+			IsPrimary:    relationship == models.RelationshipFather,
+		}
+		testutil.Create(t, db, relation)
+		parents = append(parents, relation)
+	}
+	mother := parents[1]
+
+	if err := s.SetPrimaryParent(student.ID, mother.ParentID, schoolB.ID.String()); !errors.Is(err, utils.ErrResourceNotFound) {
+		t.Fatalf("SetPrimaryParent() from another institution error = %v, want ErrResourceNotFound", err)
+	}
+	var stored models.ParentStudentRelation
+	if err := db.First(&stored, "id = ?", mother.ID).Error; err != nil {
+		t.Fatalf("failed to reload relation: %v", err)
+	}
+	if stored.IsPrimary {
+		t.Error("SetPrimaryParent() from another institution changed the primary parent")
+	}
+
+	if err := s.SetPrimaryParent(student.ID, mother.ParentID, schoolA.ID.String()); err != nil {
+		t.Fatalf("SetPrimaryParent() unexpected error: %v", err)
+	}
+	if err := db.First(&stored, "id = ?", mother.ID).Error; err != nil {
+		t.Fatalf("failed to reload relation: %v", err)
+	}
+	if !stored.IsPrimary {
+		t.Error("SetPrimaryParent() did not make the parent primary")
+	}
+}
+
+func TestTransferStudentSignsOut(t *testing.T) {
+	db := testutil.DB(t)
+	testutil.Redis(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, utils.NewJWTManager("test-secret", 15*time.Minute, time.Hour))
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+	student := testutil.Student(t, db, schoolA.ID, nil, nil)
+	admin := testutil.User(t, db, models.RoleSuperAdmin, nil)
+	token := &models.RefreshToken{
+		UserID:    student.UserID,
+		JTI:       "jti-" + student.UserID.String(),
+		FamilyID:  student.UserID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	testutil.Create(t, db, token)
+	claims := &utils.Claims{
+		UserID:           student.UserID,
+		InstitutionID:    schoolA.ID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+	}
+
+	if _, err := s.TransferStudent(student.ID, &request.TransferStudentRequest{TargetInstitutionID: schoolB.ID.String()}, admin.ID); err != nil {
+		t.Fatalf("TransferStudent() unexpected error: %v", err)
+	}
+
+	var stored models.RefreshToken
+	if err := db.First(&stored, "id = ?", token.ID).Error; err != nil {
+		t.Fatalf("failed to reload refresh token: %v", err)
+	}
+	if stored.RevokedAt == nil {
+		t.Error("TransferStudent() left the refresh token active")
+	}
+	if !middleware.IsTokenRevoked(claims) {
+		t.Error("TransferStudent() left the access token for the old institution valid")
+	}
+}
+
+func TestBulkImportRollNumbers(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, nil)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	section := testutil.Section(t, db, class.ID)
+	existing := testutil.Student(t, db, institution.ID, &class.ID, &section.ID)
+	if err := db.Model(existing).Update("roll_number", 1).Error; err != nil {
+		t.Fatalf("failed to set roll number: %v", err)
+	}
+
+	newRows := func() []request.StudentImportRow {
+		row := func(line, roll int) request.StudentImportRow {
+			return request.StudentImportRow{
+				Row:         line,
+				Email:       fmt.Sprintf("import-%d-%s@example.com", line, uuid.NewString()),
+				FirstName:   "Imported",
+				LastName:    "Student",
+				RollNumber:  roll,
+				ClassName:   class.Name,
+				SectionName: section.Name,
+			}
+		}
+		return []request.StudentImportRow{row(2, 1), row(3, 2), row(4, 2), row(5, 0)}
+	}
+
+	// Without skipInvalid a taken roll number aborts the whole import
+	resp, err := s.BulkImport(newRows(), institution.ID.String(), uuid.Nil, false)
+	if !errors.Is(err, utils.ErrUnprocessableEntity) {
+		t.Fatalf("BulkImport() error = %v, want ErrUnprocessableEntity", err)
+	}
+	if resp.Created != 0 || resp.Failed != 2 {
+		t.Errorf("BulkImport() created %d, failed %d, want 0 and 2", resp.Created, resp.Failed)
+	}
+
+	resp, err = s.BulkImport(newRows(), institution.ID.String(), uuid.Nil, true)
+	if err != nil {
+		t.Fatalf("BulkImport() with skipInvalid unexpected error: %v", err)
+	}
+	wantErrors := []string{
+		"roll number already taken in this class or section",
+		"",
+		"duplicate roll number, first used on row 3",
+		"",
+	}
+	for i, want := range wantErrors {
+		if got := resp.Results[i].Error; got != want {
+			t.Errorf("row %d error = %q, want %q", resp.Results[i].Row, got, want)
+		}
+	}
+
+	var rolls []int
+	if err := db.Model(&models.Student{}).Where("section_id = ?", section.ID).
+		Order("roll_number").Pluck("roll_number", &rolls).Error; err != nil {
+		t.Fatalf("failed to load roll numbers: %v", err)
+	}
+	if want := []int{1, 2, 3}; fmt.Sprint(rolls) != fmt.Sprint(want) {
+		t.Errorf("roll numbers = %v, want %v", rolls, want)
+	}
+}
+
+func TestBulkImportPhones(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewStudentService(repository.NewStudentRepository(db), repository.NewUserRepository(db),
+		repository.NewSectionRepository(db), db, nil)
+
+	institution := testutil.Institution(t, db)
+	class := testutil.Class(t, db, institution.ID)
+	owner := testutil.User(t, db, models.RoleParent, &institution.ID)
+	if err := db.Model(&models.User{}).Where("id = ?", owner.ID).Update("phone", "+8801712345678").Error; err != nil {
+		t.Fatalf("failed to set phone: %v", err)
+	}
+
+	row := func(line int, phone string) request.StudentImportRow {
+		return request.StudentImportRow{
+			Row:       line,
+			Email:     fmt.Sprintf("import-%d-%s@example.com", line, uuid.NewString()),
+			Phone:     phone,
+			FirstName: "Imported",
+			LastName:  "Student",
+			ClassName: class.Name,
+		}
+	}
+	rows := []request.StudentImportRow{
+		row(2, "01712345678"),
+		row(3, " 01812345678 "),
+		row(4, "+880 1812-345678"),
+		row(5, ""),
+	}
+
+	resp, err := s.BulkImport(rows, institution.ID.String(), uuid.Nil, true)
+	if err != nil {
+		t.Fatalf("BulkImport() unexpected error: %v", err)
+	}
+	wantErrors := []string{
+		"phone already registered",
+		"",
+		"duplicate phone, first used on row 3",
+		"",
+	}
+	for i, want := range wantErrors {
+		if got := resp.Results[i].Error; got != want {
+			t.Errorf("row %d error = %q, want %q", resp.Results[i].Row, got, want)
+		}
+	}
+
+	if resp.Results[1].UserID == nil {
+		t.Fatal("BulkImport() did not create row 3")
+	}
+	var stored models.User
+	if err := db.First(&stored, "id = ?", *resp.Results[1].UserID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	if stored.Phone != "+8801812345678" {
+		t.Errorf("stored phone = %q, want %q", stored.Phone, "+8801812345678")
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
@@ -10,33 +12,55 @@ import (
 	"campus-core/internal/utils"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// subjectCodeIndex is the unique index on the codes of an institution's subjects
+const subjectCodeIndex = "idx_subjects_institution_code"
+
+// Duplicate names and codes are conflicts, whether found by the checks below or
+// by the unique indexes when two requests race past them
+var (
+	errSubjectNameExists = utils.ErrResourceExists.Wrap(errors.New("subject with this name already exists in class"))
+	errSubjectCodeExists = utils.ErrResourceExists.Wrap(errors.New("subject with this code already exists"))
 )
 
 // SubjectService handles subject business logic
 type SubjectService struct {
-	subjectRepo *repository.SubjectRepository
-	classRepo   *repository.ClassRepository
-	teacherRepo *repository.TeacherRepository
+	subjectRepo   *repository.SubjectRepository
+	classRepo     *repository.ClassRepository
+	teacherRepo   *repository.TeacherRepository
+	timetableRepo *repository.TimetableRepository
+	db            *gorm.DB
 }
 
 // NewSubjectService creates a new subject service
-func NewSubjectService(subjectRepo *repository.SubjectRepository, classRepo *repository.ClassRepository, teacherRepo *repository.TeacherRepository) *SubjectService {
+func NewSubjectService(
+	subjectRepo *repository.SubjectRepository,
+	classRepo *repository.ClassRepository,
+	teacherRepo *repository.TeacherRepository,
+	timetableRepo *repository.TimetableRepository,
+	db *gorm.DB,
+) *SubjectService {
 	return &SubjectService{
-		subjectRepo: subjectRepo,
-		classRepo:   classRepo,
-		teacherRepo: teacherRepo,
+		subjectRepo:   subjectRepo,
+		classRepo:     classRepo,
+		teacherRepo:   teacherRepo,
+		timetableRepo: timetableRepo,
+		db:            db,
 	}
 }
 
 // Create creates a new subject
-func (s *SubjectService) Create(req *request.CreateSubjectRequest, institutionID uuid.UUID) (*response.SubjectResponse, error) {
+func (s *SubjectService) Create(req *request.CreateSubjectRequest, institutionID, actorID uuid.UUID) (*response.SubjectResponse, error) {
 	subject := &models.Subject{
-		InstitutionID: institutionID,
-		Name:          req.Name,
-		Code:          req.Code,
-		IsElective:    req.IsElective,
-		CreditHours:   req.CreditHours,
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		Name:            req.Name,
+		Code:            req.Code,
+		IsElective:      req.IsElective,
+		CreditHours:     req.CreditHours,
 	}
+	subject.SetCreatedBy(actorID)
 
 	// Set class if provided
 	if req.ClassID != "" {
@@ -56,7 +80,7 @@ func (s *SubjectService) Create(req *request.CreateSubjectRequest, institutionID
 			return nil, utils.ErrInternalServer.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("subject with this name already exists in class")
+			return nil, errSubjectNameExists
 		}
 	}
 
@@ -80,11 +104,24 @@ func (s *SubjectService) Create(req *request.CreateSubjectRequest, institutionID
 			return nil, utils.ErrInternalServer.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("subject with this code already exists")
+			return nil, errSubjectCodeExists
 		}
 	}
 
-	if err := s.subjectRepo.Create(subject); err != nil {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.subjectRepo.WithTx(tx)
+		if err := repo.Create(subject); err != nil {
+			return err
+		}
+		if subject.TeacherID == nil {
+			return nil
+		}
+		return repo.ChangeTeacher(subject.ID, subject.TeacherID, time.Now())
+	})
+	if err != nil {
+		if utils.IsUniqueViolation(err) {
+			return nil, duplicateSubjectError(err)
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -104,6 +141,9 @@ func (s *SubjectService) GetByID(id, institutionID uuid.UUID) (*response.Subject
 func (s *SubjectService) GetAll(filter repository.SubjectFilter, params utils.PaginationParams) ([]response.SubjectResponse, utils.Pagination, error) {
 	subjects, total, err := s.subjectRepo.FindAll(filter, params)
 	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok {
+			return nil, utils.Pagination{}, appErr // e.g. unsupported sort column
+		}
 		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -137,11 +177,14 @@ func (s *SubjectService) GetByClassID(classID, institutionID uuid.UUID) ([]respo
 }
 
 // Update updates a subject
-func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest, institutionID uuid.UUID) (*response.SubjectResponse, error) {
+func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest, institutionID, actorID uuid.UUID) (*response.SubjectResponse, error) {
 	subject, err := s.subjectRepo.FindByIDWithInstitution(id, institutionID)
 	if err != nil {
 		return nil, err
 	}
+	if *req.Version != subject.Version {
+		return nil, utils.ErrVersionConflict
+	}
 
 	// Update name if provided
 	if req.Name != "" && req.Name != subject.Name {
@@ -151,7 +194,7 @@ func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest,
 				return nil, utils.ErrInternalServer.Wrap(err)
 			}
 			if exists {
-				return nil, errors.New("subject with this name already exists in class")
+				return nil, errSubjectNameExists
 			}
 		}
 		subject.Name = req.Name
@@ -164,7 +207,7 @@ func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest,
 			return nil, utils.ErrInternalServer.Wrap(err)
 		}
 		if exists {
-			return nil, errors.New("subject with this code already exists")
+			return nil, errSubjectCodeExists
 		}
 		subject.Code = req.Code
 	}
@@ -182,6 +225,7 @@ func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest,
 	}
 
 	// Update teacher if provided
+	teacherChanged := false
 	if req.TeacherID != "" {
 		teacherID, err := uuid.Parse(req.TeacherID)
 		if err != nil {
@@ -190,6 +234,7 @@ func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest,
 		if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
 			return nil, errors.New("teacher not found")
 		}
+		teacherChanged = !sameUUID(subject.TeacherID, &teacherID)
 		subject.TeacherID = &teacherID
 	}
 
@@ -200,7 +245,24 @@ func (s *SubjectService) Update(id uuid.UUID, req *request.UpdateSubjectRequest,
 		subject.CreditHours = *req.CreditHours
 	}
 
-	if err := s.subjectRepo.Update(subject); err != nil {
+	subject.SetUpdatedBy(actorID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.subjectRepo.WithTx(tx)
+		if err := repo.Update(subject); err != nil {
+			return err
+		}
+		if !teacherChanged {
+			return nil
+		}
+		return repo.ChangeTeacher(subject.ID, subject.TeacherID, time.Now())
+	})
+	if err != nil {
+		if errors.Is(err, utils.ErrVersionConflict) {
+			return nil, err
+		}
+		if utils.IsUniqueViolation(err) {
+			return nil, duplicateSubjectError(err)
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -218,10 +280,10 @@ func (s *SubjectService) Delete(id, institutionID uuid.UUID) error {
 	return s.subjectRepo.Delete(id)
 }
 
-// AssignTeacher assigns a teacher to a subject
+// AssignTeacher assigns a teacher to a subject, replacing the current one
 func (s *SubjectService) AssignTeacher(subjectID uuid.UUID, req *request.AssignTeacherRequest, institutionID uuid.UUID) error {
 	// Verify subject exists and belongs to the institution
-	_, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID)
+	subject, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID)
 	if err != nil {
 		return err
 	}
@@ -236,7 +298,213 @@ func (s *SubjectService) AssignTeacher(subjectID uuid.UUID, req *request.AssignT
 		return errors.New("teacher not found")
 	}
 
-	return s.subjectRepo.AssignTeacher(subjectID, teacherID)
+	if sameUUID(subject.TeacherID, &teacherID) {
+		return nil
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.subjectRepo.WithTx(tx)
+		if err := repo.AssignTeacher(subjectID, teacherID); err != nil {
+			return err
+		}
+		return repo.ChangeTeacher(subjectID, &teacherID, time.Now())
+	})
+}
+
+// UnassignTeacher removes the teacher from a subject. It is a no-op when no teacher is assigned.
+// Timetable entries still pairing the subject with the old teacher are left in place and reported
+// in the response so they can be reassigned.
+func (s *SubjectService) UnassignTeacher(subjectID, institutionID uuid.UUID) (*response.UnassignTeacherResponse, error) {
+	subject, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.UnassignTeacherResponse{
+		TimetableEntryIDs: make([]uuid.UUID, 0),
+	}
+	if subject.TeacherID == nil {
+		resp.Subject = s.toResponse(subject)
+		return resp, nil
+	}
+
+	entries, err := s.timetableRepo.FindBySubjectTeacher(subjectID, *subject.TeacherID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := s.subjectRepo.WithTx(tx)
+		if err := repo.UnassignTeacher(subjectID); err != nil {
+			return err
+		}
+		return repo.ChangeTeacher(subjectID, nil, time.Now())
+	})
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	subject.TeacherID = nil
+	subject.Teacher = nil
+
+	for _, entry := range entries {
+		resp.TimetableEntryIDs = append(resp.TimetableEntryIDs, entry.ID)
+	}
+	if len(entries) > 0 {
+		resp.Warning = fmt.Sprintf("%d timetable entries still reference the previous teacher for this subject", len(entries))
+	}
+	resp.Subject = s.toResponse(subject)
+
+	return resp, nil
+}
+
+// GetAssignmentHistory lists the teachers a subject has had, most recent first
+func (s *SubjectService) GetAssignmentHistory(subjectID, institutionID uuid.UUID) ([]response.SubjectTeacherHistoryResponse, error) {
+	if _, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.subjectRepo.FindTeacherHistory(subjectID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.SubjectTeacherHistoryResponse, 0, len(history))
+	for _, h := range history {
+		resp := response.SubjectTeacherHistoryResponse{
+			ID:        h.ID,
+			TeacherID: h.TeacherID,
+			FromDate:  h.FromDate,
+			ToDate:    h.ToDate,
+			IsCurrent: h.ToDate == nil,
+		}
+		if h.Teacher != nil {
+			resp.Teacher = &response.TeacherBrief{ID: h.Teacher.ID}
+			if h.Teacher.User != nil && h.Teacher.User.Profile != nil {
+				resp.Teacher.FirstName = h.Teacher.User.Profile.FirstName
+				resp.Teacher.LastName = h.Teacher.User.Profile.LastName
+			}
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
+// GetPrerequisites lists the direct prerequisites of a subject
+func (s *SubjectService) GetPrerequisites(subjectID, institutionID uuid.UUID) ([]response.SubjectBrief, error) {
+	if _, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID); err != nil {
+		return nil, err
+	}
+
+	subjects, err := s.subjectRepo.FindPrerequisites(subjectID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	briefs := make([]response.SubjectBrief, 0, len(subjects))
+	for _, subject := range subjects {
+		briefs = append(briefs, response.SubjectBrief{ID: subject.ID, Name: subject.Name, Code: subject.Code})
+	}
+	return briefs, nil
+}
+
+// AddPrerequisite makes one subject a prerequisite of another.
+// Both subjects must belong to the same institution and the link must not close a cycle.
+func (s *SubjectService) AddPrerequisite(subjectID uuid.UUID, req *request.AddPrerequisiteRequest, institutionID uuid.UUID) ([]response.SubjectBrief, error) {
+	subject, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisiteID, err := uuid.Parse(req.PrerequisiteSubjectID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	if prerequisiteID == subjectID {
+		return nil, utils.ErrSelfPrerequisite
+	}
+
+	prerequisite, err := s.subjectRepo.FindByID(prerequisiteID)
+	if err != nil {
+		return nil, errors.New("prerequisite subject not found")
+	}
+	if prerequisite.InstitutionID != subject.InstitutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+
+	exists, err := s.subjectRepo.PrerequisiteExists(subjectID, prerequisiteID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return nil, utils.ErrResourceExists
+	}
+
+	// The new link closes a cycle if the prerequisite already depends on the subject
+	cyclic, err := s.subjectRepo.RequiresTransitively(prerequisiteID, subjectID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if cyclic {
+		return nil, utils.ErrPrerequisiteCycle
+	}
+
+	link := &models.SubjectPrerequisite{
+		TenantBaseModel:       models.TenantBaseModel{InstitutionID: subject.InstitutionID},
+		SubjectID:             subjectID,
+		PrerequisiteSubjectID: prerequisiteID,
+	}
+	if err := s.subjectRepo.AddPrerequisite(link); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return s.GetPrerequisites(subjectID, institutionID)
+}
+
+// RemovePrerequisite removes a prerequisite from a subject
+func (s *SubjectService) RemovePrerequisite(subjectID, prerequisiteID, institutionID uuid.UUID) error {
+	if _, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID); err != nil {
+		return err
+	}
+
+	removed, err := s.subjectRepo.RemovePrerequisite(subjectID, prerequisiteID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if !removed {
+		return utils.ErrResourceNotFound
+	}
+	return nil
+}
+
+// GetClassCreditSummary totals the credit hours of a class's subjects, split into core and elective
+func (s *SubjectService) GetClassCreditSummary(classID, institutionID uuid.UUID) (*response.ClassCreditSummaryResponse, error) {
+	class, err := s.classRepo.FindByIDWithInstitution(classID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.subjectRepo.CreditTotalsByClass(classID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	summary := &response.ClassCreditSummaryResponse{
+		ClassID:   class.ID,
+		ClassName: class.Name,
+	}
+	for _, total := range totals {
+		breakdown := response.CreditBreakdown{Subjects: total.Subjects, CreditHours: total.CreditHours}
+		if total.IsElective {
+			summary.Elective = breakdown
+		} else {
+			summary.Core = breakdown
+		}
+		summary.TotalSubjects += total.Subjects
+		summary.TotalCreditHours += total.CreditHours
+	}
+
+	return summary, nil
 }
 
 // toResponse converts a model to response
@@ -248,9 +516,11 @@ func (s *SubjectService) toResponse(subject *models.Subject) *response.SubjectRe
 		Code:          subject.Code,
 		IsElective:    subject.IsElective,
 		CreditHours:   subject.CreditHours,
+		Version:       subject.Version,
 		CreatedAt:     subject.CreatedAt,
 		UpdatedAt:     subject.UpdatedAt,
 	}
+	resp.CreatedBy, resp.UpdatedBy = authorBriefs(subject.Authorship)
 
 	if subject.ClassID != nil {
 		resp.ClassID = subject.ClassID
@@ -277,3 +547,11 @@ func (s *SubjectService) toResponse(subject *models.Subject) *response.SubjectRe
 
 	return resp
 }
+
+// duplicateSubjectError returns the conflict a unique violation on subjects reports
+func duplicateSubjectError(err error) error {
+	if utils.IsUniqueViolation(err, subjectCodeIndex) {
+		return errSubjectCodeExists
+	}
+	return errSubjectNameExists
+}
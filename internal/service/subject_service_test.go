@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestSubjectServiceAddPrerequisite(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewSubjectService(repository.NewSubjectRepository(db), repository.NewClassRepository(db),
+		repository.NewTeacherRepository(db), repository.NewTimetableRepository(db), db)
+
+	institution := testutil.Institution(t, db)
+	other := testutil.Institution(t, db)
+	a := testutil.Subject(t, db, institution.ID, nil)
+	b := testutil.Subject(t, db, institution.ID, nil)
+	c := testutil.Subject(t, db, institution.ID, nil)
+	foreign := testutil.Subject(t, db, other.ID, nil)
+
+	// Steps run in order, so later ones see the links added before them
+	steps := []struct {
+		name         string
+		subject      uuid.UUID
+		prerequisite uuid.UUID
+		wantErr      error
+	}{
+		{name: "self prerequisite", subject: a.ID, prerequisite: a.ID, wantErr: utils.ErrSelfPrerequisite},
+		{name: "a requires b", subject: a.ID, prerequisite: b.ID},
+		{name: "b requires c", subject: b.ID, prerequisite: c.ID},
+		{name: "c requires a closes a three-subject loop", subject: c.ID, prerequisite: a.ID, wantErr: utils.ErrPrerequisiteCycle},
+		{name: "b requires a closes a two-subject loop", subject: b.ID, prerequisite: a.ID, wantErr: utils.ErrPrerequisiteCycle},
+		{name: "a requires c is a shortcut, not a loop", subject: a.ID, prerequisite: c.ID},
+		{name: "duplicate link", subject: a.ID, prerequisite: b.ID, wantErr: utils.ErrResourceExists},
+		{name: "subject of another institution", subject: a.ID, prerequisite: foreign.ID, wantErr: utils.ErrCrossTenantAccess},
+	}
+
+	for _, step := range steps {
+		req := &request.AddPrerequisiteRequest{PrerequisiteSubjectID: step.prerequisite.String()}
+		_, err := s.AddPrerequisite(step.subject, req, institution.ID)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: AddPrerequisite() error = %v, want %v", step.name, err, step.wantErr)
+		}
+	}
+
+	prerequisites, err := s.GetPrerequisites(a.ID, institution.ID)
+	if err != nil {
+		t.Fatalf("GetPrerequisites() unexpected error: %v", err)
+	}
+	if len(prerequisites) != 2 {
+		t.Errorf("GetPrerequisites() = %d subjects, want 2", len(prerequisites))
+	}
+}
+
+func TestDuplicateSubjectError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "code index", err: &pq.Error{Code: "23505", Constraint: subjectCodeIndex}, want: errSubjectCodeExists},
+		{name: "wrapped code index", err: fmt.Errorf("save subject: %w", &pq.Error{Code: "23505", Constraint: subjectCodeIndex}), want: errSubjectCodeExists},
+		{name: "name index", err: &pq.Error{Code: "23505", Constraint: "idx_subjects_class_name"}, want: errSubjectNameExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := duplicateSubjectError(tt.err)
+			if err != tt.want {
+				t.Fatalf("duplicateSubjectError() = %v, want %v", err, tt.want)
+			}
+			if status := utils.StatusForError(err); status != http.StatusConflict {
+				t.Errorf("duplicateSubjectError() status = %d, want %d", status, http.StatusConflict)
+			}
+		})
+	}
+}
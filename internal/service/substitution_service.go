@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/dto/response"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// SubstitutionService handles teacher substitution business logic
+type SubstitutionService struct {
+	subRepo     *repository.SubstitutionRepository
+	ttRepo      *repository.TimetableRepository
+	teacherRepo *repository.TeacherRepository
+	ayRepo      *repository.AcademicYearRepository
+	ttService   *TimetableService // Reuse for timetable entry responses
+}
+
+// NewSubstitutionService creates a new substitution service
+func NewSubstitutionService(
+	subRepo *repository.SubstitutionRepository,
+	ttRepo *repository.TimetableRepository,
+	teacherRepo *repository.TeacherRepository,
+	ayRepo *repository.AcademicYearRepository,
+	ttService *TimetableService,
+) *SubstitutionService {
+	return &SubstitutionService{
+		subRepo:     subRepo,
+		ttRepo:      ttRepo,
+		teacherRepo: teacherRepo,
+		ayRepo:      ayRepo,
+		ttService:   ttService,
+	}
+}
+
+// Create assigns a substitute teacher to a timetable slot on a date.
+// The date must fall on the slot's day within its academic year, and the
+// substitute must be free at that time.
+func (s *SubstitutionService) Create(timetableID uuid.UUID, req *request.CreateSubstitutionRequest, institutionID, createdBy uuid.UUID) (*response.SubstitutionResponse, error) {
+	tt, err := s.ttRepo.FindByIDWithInstitution(timetableID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	substituteID, err := uuid.Parse(req.SubstituteTeacherID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	if substituteID == tt.TeacherID {
+		return nil, errors.New("substitute must be a different teacher")
+	}
+	substitute, err := s.teacherRepo.FindByID(substituteID)
+	if err != nil || substitute.InstitutionID != institutionID {
+		return nil, errors.New("substitute teacher not found")
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	if weekday, ok := weekdayOf(tt.DayOfWeek); !ok || date.Weekday() != weekday {
+		return nil, errors.New("date does not fall on the timetable entry's day")
+	}
+
+	// The date must be within the entry's academic year
+	year, err := s.ayRepo.FindByIDWithInstitution(tt.AcademicYearID, institutionID)
+	if err != nil {
+		return nil, errors.New("academic year not found")
+	}
+	if date.Before(truncateToDate(year.StartDate)) || date.After(truncateToDate(year.EndDate)) {
+		return nil, errors.New("date is outside the academic year")
+	}
+
+	exists, err := s.subRepo.ExistsForSlot(tt.ID, date)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return nil, utils.ErrResourceExists
+	}
+
+	startTime, endTime, err := utils.NormalizeTimeRange(tt.StartTime, tt.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// The substitute must not teach a regular class at this time...
+	candidate := *tt
+	candidate.TeacherID = substituteID
+	candidate.StartTime = startTime
+	candidate.EndTime = endTime
+	hasConflict, err := s.ttRepo.CheckConflict(&candidate, &tt.ID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if hasConflict {
+		return nil, utils.ErrScheduleConflict
+	}
+
+	// ...nor already cover another slot
+	hasConflict, err = s.subRepo.HasOverlappingSubstitution(substituteID, date, startTime, endTime)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	if hasConflict {
+		return nil, utils.ErrScheduleConflict
+	}
+
+	sub := &models.Substitution{
+		TenantBaseModel: models.TenantBaseModel{
+			BaseModel:     models.BaseModel{ID: uuid.New()},
+			InstitutionID: institutionID,
+		},
+		TimetableID:         tt.ID,
+		OriginalTeacherID:   tt.TeacherID,
+		SubstituteTeacherID: substituteID,
+		Date:                date,
+		Reason:              req.Reason,
+		CreatedBy:           &createdBy,
+	}
+	if err := s.subRepo.Create(sub); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	created, err := s.subRepo.FindByIDWithInstitution(sub.ID, institutionID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	return s.ttService.toSubstitutionResponse(created, uuid.Nil), nil
+}
+
+// ListByDate lists all substitutions of an institution on a date
+func (s *SubstitutionService) ListByDate(institutionID uuid.UUID, date string) ([]response.SubstitutionResponse, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+
+	subs, err := s.subRepo.FindByDate(institutionID, day)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.SubstitutionResponse, 0, len(subs))
+	for i := range subs {
+		responses = append(responses, *s.ttService.toSubstitutionResponse(&subs[i], uuid.Nil))
+	}
+	return responses, nil
+}
+
+// Delete removes a substitution
+func (s *SubstitutionService) Delete(id, institutionID uuid.UUID) error {
+	if _, err := s.subRepo.FindByIDWithInstitution(id, institutionID); err != nil {
+		return err
+	}
+
+	if err := s.subRepo.Delete(id); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// truncateToDate drops the time of day, keeping the calendar date in UTC
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
 	"time"
 
 	"campus-core/internal/dto/request"
@@ -19,14 +22,16 @@ import (
 type TeacherService struct {
 	repo       *repository.TeacherRepository
 	userRepo   *repository.UserRepository
+	deptRepo   *repository.DepartmentRepository
 	db         *gorm.DB
 	jwtManager *utils.JWTManager
 }
 
-func NewTeacherService(repo *repository.TeacherRepository, userRepo *repository.UserRepository, db *gorm.DB, jwtManager *utils.JWTManager) *TeacherService {
+func NewTeacherService(repo *repository.TeacherRepository, userRepo *repository.UserRepository, deptRepo *repository.DepartmentRepository, db *gorm.DB, jwtManager *utils.JWTManager) *TeacherService {
 	return &TeacherService{
 		repo:       repo,
 		userRepo:   userRepo,
+		deptRepo:   deptRepo,
 		db:         db,
 		jwtManager: jwtManager,
 	}
@@ -42,6 +47,23 @@ func (s *TeacherService) CreateTeacher(req *request.CreateTeacherRequest, creato
 		return nil, errors.New("institution_id is required")
 	}
 
+	if req.Phone != "" {
+		phone, err := canonicalPhone(s.userRepo, req.Phone, "")
+		if err != nil {
+			return nil, err
+		}
+		req.Phone = phone
+	}
+
+	dateOfBirth, err := parseDateOfBirth(req.DateOfBirth)
+	if err != nil {
+		return nil, err
+	}
+	joiningDate, err := parsePastDate(req.JoiningDate, "joining date")
+	if err != nil {
+		return nil, err
+	}
+
 	// Password hashing
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -50,17 +72,23 @@ func (s *TeacherService) CreateTeacher(req *request.CreateTeacherRequest, creato
 
 	institutionID, _ := uuid.Parse(req.InstitutionID)
 
+	deptID, err := s.resolveDepartment(req.DepartmentID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create User & Teacher in transaction
 	var teacherUser *models.User
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// 1. Create User
 		user := &models.User{
-			BaseModel:    models.BaseModel{ID: uuid.New()},
-			Email:        req.Email,
-			Phone:        req.Phone,
-			PasswordHash: hashedPassword,
-			Role:         models.RoleTeacher,
-			IsActive:     true,
+			BaseModel:          models.BaseModel{ID: uuid.New()},
+			Email:              req.Email,
+			Phone:              req.Phone,
+			PasswordHash:       hashedPassword,
+			MustChangePassword: true,
+			Role:               models.RoleTeacher,
+			IsActive:           true,
 		}
 		if err := tx.Create(user).Error; err != nil {
 			return err
@@ -73,6 +101,9 @@ func (s *TeacherService) CreateTeacher(req *request.CreateTeacherRequest, creato
 			FirstName:     req.FirstName,
 			LastName:      req.LastName,
 			InstitutionID: &institutionID,
+			DateOfBirth:   dateOfBirth,
+			Gender:        req.Gender,
+			Address:       req.Address,
 		}
 		if err := tx.Create(profile).Error; err != nil {
 			return err
@@ -81,13 +112,6 @@ func (s *TeacherService) CreateTeacher(req *request.CreateTeacherRequest, creato
 		teacherUser = user
 
 		// 3. Create Teacher
-		joiningDate, _ := time.Parse("2006-01-02", req.JoiningDate)
-		var deptID *uuid.UUID
-		if req.DepartmentID != "" {
-			id, _ := uuid.Parse(req.DepartmentID)
-			deptID = &id
-		}
-
 		teacher := &models.Teacher{
 			TenantBaseModel: models.TenantBaseModel{
 				BaseModel:     models.BaseModel{ID: uuid.New()},
@@ -106,6 +130,9 @@ func (s *TeacherService) CreateTeacher(req *request.CreateTeacherRequest, creato
 	})
 
 	if err != nil {
+		if utils.IsUniqueViolation(err, models.UserEmailIndex) {
+			return nil, utils.ErrEmailAlreadyExists
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -120,6 +147,8 @@ func (s *TeacherService) CreateTeacher(req *request.CreateTeacherRequest, creato
 			ID:            teacherUser.Profile.ID,
 			FirstName:     teacherUser.Profile.FirstName,
 			LastName:      teacherUser.Profile.LastName,
+			DateOfBirth:   teacherUser.Profile.DateOfBirth,
+			Gender:        teacherUser.Profile.Gender,
 			InstitutionID: teacherUser.Profile.InstitutionID,
 		},
 	}
@@ -206,7 +235,11 @@ func (s *TeacherService) UpdateTeacher(id uuid.UUID, req *request.UpdateTeacherR
 	}
 
 	if req.Phone != "" {
-		teacher.User.Phone = req.Phone
+		phone, err := canonicalPhone(s.userRepo, req.Phone, teacher.User.Phone)
+		if err != nil {
+			return nil, err
+		}
+		teacher.User.Phone = phone
 	}
 
 	if req.IsActive != nil {
@@ -229,8 +262,19 @@ func (s *TeacherService) UpdateTeacher(id uuid.UUID, req *request.UpdateTeacherR
 	}
 
 	if req.DepartmentID != "" {
-		deptID, _ := uuid.Parse(req.DepartmentID)
-		teacher.DepartmentID = &deptID
+		deptID, err := s.resolveDepartment(req.DepartmentID, teacher.InstitutionID)
+		if err != nil {
+			return nil, err
+		}
+		teacher.DepartmentID = deptID
+	}
+
+	if req.JoiningDate != "" {
+		joiningDate, err := parsePastDate(req.JoiningDate, "joining date")
+		if err != nil {
+			return nil, err
+		}
+		teacher.JoiningDate = &joiningDate
 	}
 
 	// Save changes in transaction
@@ -269,26 +313,329 @@ func (s *TeacherService) UpdateTeacher(id uuid.UUID, req *request.UpdateTeacherR
 	return &resp, nil
 }
 
-// GetTeacherClasses gets a teacher's assigned classes
-func (s *TeacherService) GetTeacherClasses(id uuid.UUID) ([]interface{}, error) {
-	// Verify teacher exists
-	if _, err := s.repo.FindByID(id); err != nil {
+// GetTeacherClasses gets the classes a teacher is class teacher of or teaches a subject in
+func (s *TeacherService) GetTeacherClasses(id uuid.UUID, institutionID string) ([]response.ClassBrief, error) {
+	if _, err := s.findTeacher(id, institutionID); err != nil {
+		return nil, err
+	}
+
+	classes, err := s.repo.FindClasses(id)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.ClassBrief, 0, len(classes))
+	for _, class := range classes {
+		responses = append(responses, response.ClassBrief{ID: class.ID, Name: class.Name})
+	}
+	return responses, nil
+}
+
+// GetTeacherSubjects gets the subjects a teacher teaches, directly or through an assignment
+func (s *TeacherService) GetTeacherSubjects(id uuid.UUID, institutionID string) ([]response.SubjectBrief, error) {
+	if _, err := s.findTeacher(id, institutionID); err != nil {
 		return nil, err
 	}
 
-	// TODO: Implement when class_teacher_assignments table is available in Phase 3
-	// For now, return empty array
-	return []interface{}{}, nil
+	subjects, err := s.repo.FindSubjects(id)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.SubjectBrief, 0, len(subjects))
+	for _, subject := range subjects {
+		responses = append(responses, response.SubjectBrief{ID: subject.ID, Name: subject.Name, Code: subject.Code})
+	}
+	return responses, nil
+}
+
+// AssignSubject assigns a teacher to a subject of the same institution
+func (s *TeacherService) AssignSubject(id uuid.UUID, req *request.AssignTeacherSubjectRequest, institutionID string) error {
+	teacher, err := s.findTeacher(id, institutionID)
+	if err != nil {
+		return err
+	}
+
+	subjectID, err := uuid.Parse(req.SubjectID)
+	if err != nil {
+		return utils.ErrInvalidUUID
+	}
+	var count int64
+	if err := s.db.Model(&models.Subject{}).Where("id = ? AND institution_id = ?", subjectID, teacher.InstitutionID).Count(&count).Error; err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if count == 0 {
+		return errors.New("subject not found")
+	}
+
+	exists, err := s.repo.SubjectAssignmentExists(id, subjectID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return utils.ErrResourceExists
+	}
+
+	assignment := &models.TeacherSubjectAssignment{
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: teacher.InstitutionID},
+		TeacherID:       id,
+		SubjectID:       subjectID,
+	}
+	if err := s.repo.AssignSubject(assignment); err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	return nil
+}
+
+// UnassignSubject removes a teacher's subject assignment
+func (s *TeacherService) UnassignSubject(id, subjectID uuid.UUID, institutionID string) error {
+	if _, err := s.findTeacher(id, institutionID); err != nil {
+		return err
+	}
+
+	removed, err := s.repo.UnassignSubject(id, subjectID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if !removed {
+		return utils.ErrResourceNotFound
+	}
+	return nil
+}
+
+// teacherImportEntry is a validated import row ready to be written
+type teacherImportEntry struct {
+	index        int
+	row          request.TeacherImportRow
+	departmentID *uuid.UUID
+	joiningDate  time.Time
+}
+
+// BulkImport creates teachers from parsed import rows.
+// Departments are resolved by name within the institution and every teacher
+// gets a generated default password. Each row is written in its own
+// transaction. With skipInvalid, bad rows are reported and the rest are
+// created; otherwise any invalid row aborts the import before anything is written.
+func (s *TeacherService) BulkImport(rows []request.TeacherImportRow, institutionID string, skipInvalid bool) (*response.TeacherImportResponse, error) {
+	instID, err := uuid.Parse(institutionID)
+	if err != nil {
+		return nil, errors.New("institution_id is required")
+	}
+
+	// Lookup table for department names
+	var departments []models.Department
+	if err := s.db.Where("institution_id = ?", instID).Find(&departments).Error; err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	deptByName := make(map[string]*models.Department, len(departments))
+	for i := range departments {
+		deptByName[normalizeImportName(departments[i].Name)] = &departments[i]
+	}
+
+	emails := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if email := strings.TrimSpace(row.Email); email != "" {
+			emails = append(emails, strings.ToLower(email))
+		}
+	}
+	existingEmails, err := findExistingEmails(s.db, emails)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.TeacherImportResponse{Results: make([]response.TeacherImportResult, len(rows))}
+	seenEmails := make(map[string]int)
+	var entries []teacherImportEntry
+
+	for i, row := range rows {
+		row.Email = strings.TrimSpace(row.Email)
+		row.FirstName = strings.TrimSpace(row.FirstName)
+		row.LastName = strings.TrimSpace(row.LastName)
+		resp.Results[i] = response.TeacherImportResult{Row: row.Row, Email: row.Email}
+
+		entry, rowErr := validateTeacherImportRow(row, deptByName)
+		if rowErr == nil {
+			email := strings.ToLower(row.Email)
+			switch {
+			case existingEmails[email]:
+				rowErr = errors.New("email already registered")
+			case seenEmails[email] != 0:
+				rowErr = fmt.Errorf("duplicate email, first used on row %d", seenEmails[email])
+			}
+			seenEmails[email] = row.Row
+		}
+
+		if rowErr != nil {
+			resp.Results[i].Error = rowErr.Error()
+			resp.Failed++
+			continue
+		}
+
+		entry.index = i
+		entries = append(entries, *entry)
+	}
+
+	if resp.Failed > 0 && !skipInvalid {
+		return resp, utils.ErrUnprocessableEntity
+	}
+
+	for _, entry := range entries {
+		result := &resp.Results[entry.index]
+
+		password, err := utils.GenerateRandomPassword(12)
+		if err != nil {
+			return resp, utils.ErrInternalServer.Wrap(err)
+		}
+		hash, err := utils.HashPassword(password)
+		if err != nil {
+			return resp, utils.ErrInternalServer.Wrap(err)
+		}
+
+		var teacher *models.Teacher
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			teacher, err = createImportedTeacher(tx, instID, entry, hash)
+			return err
+		})
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+
+		result.Success = true
+		result.TeacherID = &teacher.ID
+		result.UserID = &teacher.UserID
+		result.DepartmentID = teacher.DepartmentID
+		result.DefaultPassword = password
+	}
+
+	resp.Created, resp.Failed = 0, 0
+	for _, result := range resp.Results {
+		if result.Success {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// validateTeacherImportRow checks the fields of an import row and resolves its department
+func validateTeacherImportRow(row request.TeacherImportRow, deptByName map[string]*models.Department) (*teacherImportEntry, error) {
+	if row.Email == "" {
+		return nil, errors.New("email is required")
+	}
+	if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+		return nil, errors.New("invalid email format")
+	}
+	if row.FirstName == "" || row.LastName == "" {
+		return nil, errors.New("first and last name are required")
+	}
+	if strings.TrimSpace(row.Phone) != "" {
+		phone, err := utils.NormalizePhone(row.Phone)
+		if err != nil {
+			return nil, errors.New("invalid phone format")
+		}
+		row.Phone = phone
+	}
+
+	// Same format the single-create path requires
+	joiningDate, err := time.Parse("2006-01-02", strings.TrimSpace(row.JoiningDate))
+	if err != nil {
+		return nil, errors.New("invalid joining date, expected YYYY-MM-DD")
+	}
+	if joiningDate.After(time.Now()) {
+		return nil, errors.New("joining date can't be in the future")
+	}
+	entry := &teacherImportEntry{row: row, joiningDate: joiningDate}
+
+	if name := normalizeImportName(row.DepartmentName); name != "" {
+		dept, ok := deptByName[name]
+		if !ok {
+			return nil, fmt.Errorf("department %q not found", row.DepartmentName)
+		}
+		entry.departmentID = &dept.ID
+	}
+
+	return entry, nil
 }
 
-// GetTeacherSubjects gets a teacher's assigned subjects
-func (s *TeacherService) GetTeacherSubjects(id uuid.UUID) ([]interface{}, error) {
-	// Verify teacher exists
-	if _, err := s.repo.FindByID(id); err != nil {
+// createImportedTeacher creates the user, profile and teacher rows for an import entry
+func createImportedTeacher(tx *gorm.DB, institutionID uuid.UUID, entry teacherImportEntry, passwordHash string) (*models.Teacher, error) {
+	row := entry.row
+
+	user := &models.User{
+		BaseModel:          models.BaseModel{ID: uuid.New()},
+		Email:              row.Email,
+		Phone:              strings.TrimSpace(row.Phone),
+		PasswordHash:       passwordHash,
+		MustChangePassword: true,
+		Role:               models.RoleTeacher,
+		IsActive:           true,
+	}
+	if err := tx.Create(user).Error; err != nil {
+		return nil, err
+	}
+
+	profile := &models.UserProfile{
+		BaseModel:     models.BaseModel{ID: uuid.New()},
+		UserID:        user.ID,
+		InstitutionID: &institutionID,
+		FirstName:     row.FirstName,
+		LastName:      row.LastName,
+	}
+	if err := tx.Create(profile).Error; err != nil {
+		return nil, err
+	}
+
+	joiningDate := entry.joiningDate
+	teacher := &models.Teacher{
+		TenantBaseModel: models.TenantBaseModel{
+			BaseModel:     models.BaseModel{ID: uuid.New()},
+			InstitutionID: institutionID,
+		},
+		UserID:         user.ID,
+		JoiningDate:    &joiningDate,
+		Qualifications: pq.StringArray(row.Qualifications),
+		DepartmentID:   entry.departmentID,
+	}
+	if err := tx.Create(teacher).Error; err != nil {
 		return nil, err
 	}
 
-	// TODO: Implement when teacher_subject_assignments table is available in Phase 3
-	// For now, return empty array
-	return []interface{}{}, nil
+	return teacher, nil
+}
+
+// resolveDepartment parses an optional department ID and verifies the
+// department exists and belongs to the teacher's institution
+func (s *TeacherService) resolveDepartment(departmentID string, institutionID uuid.UUID) (*uuid.UUID, error) {
+	if departmentID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(departmentID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	if _, err := s.deptRepo.FindByIDWithInstitution(id, institutionID); err != nil {
+		if !errors.Is(err, utils.ErrNotFound) {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+		// Tell a department of another institution apart from a missing one
+		if _, err := s.deptRepo.FindByID(id); err == nil {
+			return nil, utils.ErrCrossTenantAccess
+		}
+		return nil, utils.ErrNotFound
+	}
+	return &id, nil
+}
+
+// findTeacher finds a teacher and verifies tenant access
+func (s *TeacherService) findTeacher(id uuid.UUID, institutionID string) (*models.Teacher, error) {
+	teacher, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if institutionID != "" && teacher.InstitutionID.String() != institutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+	return teacher, nil
 }
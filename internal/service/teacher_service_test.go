@@ -0,0 +1,102 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestTeacherDepartmentTenant(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewTeacherService(repository.NewTeacherRepository(db), repository.NewUserRepository(db),
+		repository.NewDepartmentRepository(db), db, nil)
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+	ownDepartment := &models.Department{Name: "Science"}
+	ownDepartment.InstitutionID = schoolA.ID
+	testutil.Create(t, db, ownDepartment)
+	otherDepartment := &models.Department{Name: "Science"}
+	otherDepartment.InstitutionID = schoolB.ID
+	testutil.Create(t, db, otherDepartment)
+
+	tests := []struct {
+		name         string
+		departmentID string
+		wantErr      error
+	}{
+		{name: "own department", departmentID: ownDepartment.ID.String()},
+		{name: "department of another institution", departmentID: otherDepartment.ID.String(), wantErr: utils.ErrCrossTenantAccess},
+		{name: "missing department", departmentID: uuid.NewString(), wantErr: utils.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run("create with "+tt.name, func(t *testing.T) {
+			req := &request.CreateTeacherRequest{JoiningDate: "2025-08-01", DepartmentID: tt.departmentID}
+			req.Email = "teacher-" + uuid.NewString()[:8] + "@example.com"
+			req.Password = "Password@123"
+			req.FirstName = "New"
+			req.LastName = "Teacher"
+
+			_, err := s.CreateTeacher(req, schoolA.ID.String())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CreateTeacher() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateTeacher() unexpected error: %v", err)
+			}
+		})
+
+		t.Run("update with "+tt.name, func(t *testing.T) {
+			teacher := testutil.Teacher(t, db, schoolA.ID)
+			req := &request.UpdateTeacherRequest{DepartmentID: tt.departmentID}
+
+			_, err := s.UpdateTeacher(teacher.ID, req, schoolA.ID.String())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UpdateTeacher() error = %v, want %v", err, tt.wantErr)
+				}
+				var stored models.Teacher
+				if err := db.First(&stored, "id = ?", teacher.ID).Error; err != nil {
+					t.Fatalf("failed to reload teacher: %v", err)
+				}
+				if stored.DepartmentID != nil {
+					t.Errorf("UpdateTeacher() linked department %s, want none", stored.DepartmentID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateTeacher() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestClassTeacherTenant(t *testing.T) {
+	db := testutil.DB(t)
+	s := NewClassService(repository.NewClassRepository(db), repository.NewSectionRepository(db),
+		repository.NewTeacherRepository(db), repository.NewStudentRepository(db), db)
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+
+	own := &request.CreateClassRequest{Name: "Own " + uuid.NewString()[:8], ClassTeacherID: testutil.Teacher(t, db, schoolA.ID).ID.String()}
+	if _, err := s.CreateClass(own, schoolA.ID, uuid.Nil); err != nil {
+		t.Fatalf("CreateClass() with an own teacher unexpected error: %v", err)
+	}
+
+	other := &request.CreateClassRequest{Name: "Other " + uuid.NewString()[:8], ClassTeacherID: testutil.Teacher(t, db, schoolB.ID).ID.String()}
+	if _, err := s.CreateClass(other, schoolA.ID, uuid.Nil); err == nil {
+		t.Fatal("CreateClass() with a teacher of another institution succeeded, want an error")
+	}
+}
@@ -2,16 +2,30 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
 	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/utils"
+	"campus-core/pkg/export"
 
 	"github.com/google/uuid"
 )
 
+// Default school-day window used when finding free slots
+const (
+	defaultSchoolDayStart = "08:00"
+	defaultSchoolDayEnd   = "16:00"
+)
+
+// dayOrder is the order in which days of the week are presented
+var dayOrder = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
 // TimetableService handles timetable business logic
 type TimetableService struct {
 	ttRepo      *repository.TimetableRepository
@@ -19,7 +33,12 @@ type TimetableService struct {
 	sectionRepo *repository.SectionRepository
 	subjectRepo *repository.SubjectRepository
 	teacherRepo *repository.TeacherRepository
+	studentRepo *repository.StudentRepository
 	ayRepo      *repository.AcademicYearRepository
+	instService *InstitutionService // Institution settings such as the time zone
+	subRepo     *repository.SubstitutionRepository
+	holidays    *HolidayService
+	periods     *PeriodService // Bell schedule entries must snap to, when configured
 }
 
 // NewTimetableService creates a new timetable service
@@ -29,7 +48,12 @@ func NewTimetableService(
 	sectionRepo *repository.SectionRepository,
 	subjectRepo *repository.SubjectRepository,
 	teacherRepo *repository.TeacherRepository,
+	studentRepo *repository.StudentRepository,
 	ayRepo *repository.AcademicYearRepository,
+	instService *InstitutionService,
+	subRepo *repository.SubstitutionRepository,
+	holidays *HolidayService,
+	periods *PeriodService,
 ) *TimetableService {
 	return &TimetableService{
 		ttRepo:      ttRepo,
@@ -37,82 +61,223 @@ func NewTimetableService(
 		sectionRepo: sectionRepo,
 		subjectRepo: subjectRepo,
 		teacherRepo: teacherRepo,
+		studentRepo: studentRepo,
 		ayRepo:      ayRepo,
+		instService: instService,
+		subRepo:     subRepo,
+		holidays:    holidays,
+		periods:     periods,
 	}
 }
 
 // Create creates a new timetable entry
 func (s *TimetableService) Create(req *request.CreateTimetableRequest, institutionID uuid.UUID) (*response.TimetableResponse, error) {
-	// Parse and validate all UUIDs
-	academicYearID, err := uuid.Parse(req.AcademicYearID)
+	tt, err := s.buildEntry(req, institutionID)
 	if err != nil {
-		return nil, utils.ErrInvalidUUID
+		return nil, err
 	}
-	classID, err := uuid.Parse(req.ClassID)
+
+	// Check for conflicts
+	hasConflict, err := s.ttRepo.CheckConflict(tt, nil)
 	if err != nil {
-		return nil, utils.ErrInvalidUUID
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
-	sectionID, err := uuid.Parse(req.SectionID)
+	if hasConflict {
+		return nil, utils.ErrScheduleConflict
+	}
+
+	if err := s.ttRepo.Create(tt); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	// Reload with preloads
+	tt, _ = s.ttRepo.FindByID(tt.ID)
+
+	return s.toResponse(tt), nil
+}
+
+// BulkCreate validates and creates multiple timetable entries at once.
+// Entries are checked against the database and against each other; if any
+// entry fails, nothing is created and the per-entry results are returned.
+func (s *TimetableService) BulkCreate(req *request.BulkTimetableRequest, institutionID uuid.UUID) (*response.BulkTimetableResponse, error) {
+	results := make([]response.BulkTimetableResult, len(req.Entries))
+	entries := make([]*models.Timetable, len(req.Entries))
+	failed := 0
+
+	for i := range req.Entries {
+		results[i].Index = i
+
+		tt, err := s.buildEntry(&req.Entries[i], institutionID)
+		if err != nil {
+			results[i].Error = err.Error()
+			failed++
+			continue
+		}
+
+		// Check against other entries in the same batch
+		if j := findBatchConflict(tt, entries[:i]); j >= 0 {
+			results[i].Error = fmt.Sprintf("conflicts with entry at index %d", j)
+			failed++
+			continue
+		}
+
+		// Check against existing entries
+		hasConflict, err := s.ttRepo.CheckConflict(tt, nil)
+		if err != nil {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+		if hasConflict {
+			results[i].Error = utils.ErrScheduleConflict.Error()
+			failed++
+			continue
+		}
+
+		entries[i] = tt
+	}
+
+	if failed > 0 {
+		return &response.BulkTimetableResponse{
+			Created: 0,
+			Failed:  failed,
+			Results: results,
+		}, utils.ErrUnprocessableEntity
+	}
+
+	timetables := make([]models.Timetable, len(entries))
+	for i, tt := range entries {
+		timetables[i] = *tt
+	}
+
+	conflictIndex, err := s.ttRepo.BulkCreateWithConflictCheck(timetables)
 	if err != nil {
-		return nil, utils.ErrInvalidUUID
+		if errors.Is(err, utils.ErrScheduleConflict) {
+			results[conflictIndex].Error = err.Error()
+			return &response.BulkTimetableResponse{
+				Created: 0,
+				Failed:  1,
+				Results: results,
+			}, err
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
-	subjectID, err := uuid.Parse(req.SubjectID)
+
+	for i := range timetables {
+		tt := &timetables[i]
+		if reloaded, err := s.ttRepo.FindByID(tt.ID); err == nil {
+			tt = reloaded
+		}
+		results[i].Success = true
+		results[i].Entry = s.toResponse(tt)
+	}
+
+	return &response.BulkTimetableResponse{
+		Created: len(timetables),
+		Failed:  0,
+		Results: results,
+	}, nil
+}
+
+// CopyTimetable copies all active entries of the source academic year into the
+// target academic year. Entries are conflict-checked against the target year;
+// conflicting entries are skipped when skipConflicts is set, otherwise nothing
+// is copied and the conflicting entries are reported.
+func (s *TimetableService) CopyTimetable(req *request.CopyTimetableRequest, institutionID uuid.UUID) (*response.CopyTimetableResponse, error) {
+	sourceID, err := uuid.Parse(req.SourceAcademicYearID)
 	if err != nil {
 		return nil, utils.ErrInvalidUUID
 	}
-	teacherID, err := uuid.Parse(req.TeacherID)
+	targetID, err := uuid.Parse(req.TargetAcademicYearID)
 	if err != nil {
 		return nil, utils.ErrInvalidUUID
 	}
+	if sourceID == targetID {
+		return nil, errors.New("source and target academic years must differ")
+	}
 
-	// Verify all entities exist
-	if _, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID); err != nil {
-		return nil, errors.New("academic year not found")
+	// Both years must belong to the institution
+	if _, err := s.ayRepo.FindByIDWithInstitution(sourceID, institutionID); err != nil {
+		return nil, errors.New("source academic year not found")
 	}
-	if _, err := s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
-		return nil, errors.New("class not found")
+	if _, err := s.ayRepo.FindByIDWithInstitution(targetID, institutionID); err != nil {
+		return nil, errors.New("target academic year not found")
 	}
-	if _, err := s.sectionRepo.FindByID(sectionID); err != nil {
-		return nil, errors.New("section not found")
+
+	sources, err := s.ttRepo.FindActiveByAcademicYear(sourceID, institutionID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
-	if _, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID); err != nil {
-		return nil, errors.New("subject not found")
+
+	resp := &response.CopyTimetableResponse{
+		SourceAcademicYearID: sourceID,
+		TargetAcademicYearID: targetID,
+		SkippedEntries:       make([]response.CopySkippedEntry, 0),
 	}
-	if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
-		return nil, errors.New("teacher not found")
+
+	var entries []models.Timetable
+	var sourceIDs []uuid.UUID
+	for _, src := range sources {
+		startTime, endTime, err := utils.NormalizeTimeRange(src.StartTime, src.EndTime)
+		if err != nil {
+			resp.SkippedEntries = append(resp.SkippedEntries, response.CopySkippedEntry{SourceID: src.ID, Reason: err.Error()})
+			continue
+		}
+
+		entry := models.Timetable{
+			TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+			AcademicYearID:  targetID,
+			ClassID:         src.ClassID,
+			SectionID:       src.SectionID,
+			SubjectID:       src.SubjectID,
+			TeacherID:       src.TeacherID,
+			DayOfWeek:       src.DayOfWeek,
+			WeekType:        src.WeekType,
+			StartTime:       startTime,
+			EndTime:         endTime,
+			RoomNumber:      src.RoomNumber,
+			IsActive:        true,
+		}
+		entry.ID = uuid.New()
+		entries = append(entries, entry)
+		sourceIDs = append(sourceIDs, src.ID)
 	}
 
-	tt := &models.Timetable{
-		InstitutionID:  institutionID,
-		AcademicYearID: academicYearID,
-		ClassID:        classID,
-		SectionID:      sectionID,
-		SubjectID:      subjectID,
-		TeacherID:      teacherID,
-		DayOfWeek:      models.DayOfWeek(req.DayOfWeek),
-		StartTime:      req.StartTime,
-		EndTime:        req.EndTime,
-		RoomNumber:     req.RoomNumber,
-		IsActive:       true,
+	if !req.SkipConflicts {
+		if len(resp.SkippedEntries) > 0 {
+			resp.Skipped = len(resp.SkippedEntries)
+			return resp, utils.ErrUnprocessableEntity
+		}
+
+		conflictIndex, err := s.ttRepo.BulkCreateWithConflictCheck(entries)
+		if err != nil {
+			if errors.Is(err, utils.ErrScheduleConflict) {
+				resp.SkippedEntries = append(resp.SkippedEntries, response.CopySkippedEntry{
+					SourceID: sourceIDs[conflictIndex],
+					Reason:   err.Error(),
+				})
+				resp.Skipped = len(resp.SkippedEntries)
+				return resp, err
+			}
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+
+		resp.Copied = len(entries)
+		return resp, nil
 	}
 
-	// Check for conflicts
-	hasConflict, err := s.ttRepo.CheckConflict(tt, nil)
+	skipped, err := s.ttRepo.BulkCreateSkippingConflicts(entries)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
-	if hasConflict {
-		return nil, errors.New("scheduling conflict detected: teacher, section, or room is already occupied at this time")
-	}
-
-	if err := s.ttRepo.Create(tt); err != nil {
-		return nil, utils.ErrInternalServer.Wrap(err)
+	for _, i := range skipped {
+		resp.SkippedEntries = append(resp.SkippedEntries, response.CopySkippedEntry{
+			SourceID: sourceIDs[i],
+			Reason:   utils.ErrScheduleConflict.Error(),
+		})
 	}
 
-	// Reload with preloads
-	tt, _ = s.ttRepo.FindByID(tt.ID)
-
-	return s.toResponse(tt), nil
+	resp.Copied = len(entries) - len(skipped)
+	resp.Skipped = len(resp.SkippedEntries)
+	return resp, nil
 }
 
 // GetByID gets a timetable entry by ID
@@ -128,6 +293,9 @@ func (s *TimetableService) GetByID(id, institutionID uuid.UUID) (*response.Timet
 func (s *TimetableService) GetAll(filter repository.TimetableFilter, params utils.PaginationParams) ([]response.TimetableResponse, utils.Pagination, error) {
 	timetables, total, err := s.ttRepo.FindAll(filter, params)
 	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok {
+			return nil, utils.Pagination{}, appErr // e.g. unsupported sort column
+		}
 		return nil, utils.Pagination{}, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -140,14 +308,14 @@ func (s *TimetableService) GetAll(filter repository.TimetableFilter, params util
 	return responses, pagination, nil
 }
 
-// GetByClassID gets timetable for a class
-func (s *TimetableService) GetByClassID(classID, institutionID uuid.UUID, academicYearID *uuid.UUID) (*response.WeekTimetableResponse, error) {
+// GetByClassID gets timetable for a class, optionally only for odd or even weeks
+func (s *TimetableService) GetByClassID(classID, institutionID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType) (*response.WeekTimetableResponse, error) {
 	// Verify class exists
 	if _, err := s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
 		return nil, err
 	}
 
-	timetables, err := s.ttRepo.FindByClassID(classID, academicYearID)
+	timetables, err := s.ttRepo.FindByClassID(classID, academicYearID, weekType)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
@@ -155,14 +323,253 @@ func (s *TimetableService) GetByClassID(classID, institutionID uuid.UUID, academ
 	return s.groupByDay(timetables), nil
 }
 
-// GetBySectionID gets timetable for a section
-func (s *TimetableService) GetBySectionID(sectionID uuid.UUID, academicYearID *uuid.UUID) (*response.WeekTimetableResponse, error) {
+// ExportClassTimetable renders a class timetable as a printable grid with days
+// as columns and periods as rows. Supported formats are "pdf" and "csv".
+func (s *TimetableService) ExportClassTimetable(classID, institutionID uuid.UUID, academicYearID *uuid.UUID, format string) (*export.File, error) {
+	class, err := s.classRepo.FindByIDWithInstitution(classID, institutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	week, err := s.GetByClassID(classID, institutionID, academicYearID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the distinct periods and the entries of each day/period cell
+	type period struct{ start, end int }
+	cells := make(map[period]map[string][]string)
+	for _, day := range week.Days {
+		for _, entry := range day.Entries {
+			start, err := utils.ParseClockTime(entry.StartTime)
+			if err != nil {
+				continue
+			}
+			end, err := utils.ParseClockTime(entry.EndTime)
+			if err != nil {
+				continue
+			}
+			p := period{start, end}
+			if cells[p] == nil {
+				cells[p] = make(map[string][]string)
+			}
+			cells[p][day.Day] = append(cells[p][day.Day], exportCellText(&entry))
+		}
+	}
+
+	periods := make([]period, 0, len(cells))
+	for p := range cells {
+		periods = append(periods, p)
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		if periods[i].start != periods[j].start {
+			return periods[i].start < periods[j].start
+		}
+		return periods[i].end < periods[j].end
+	})
+
+	// Every day gets a column so empty days still render placeholder cells
+	grid := &export.Grid{
+		Title:   "Class Timetable - " + class.Name,
+		Corner:  "Period",
+		Columns: make([]string, len(dayOrder)),
+	}
+	for i, day := range dayOrder {
+		grid.Columns[i] = strings.ToUpper(day[:1]) + strings.ToLower(day[1:])
+	}
+	for _, p := range periods {
+		row := export.GridRow{
+			Label: formatClockMinutes(p.start) + " - " + formatClockMinutes(p.end),
+			Cells: make([]string, len(dayOrder)),
+		}
+		for i, day := range dayOrder {
+			row.Cells[i] = strings.Join(cells[p][day], "\n")
+		}
+		grid.Rows = append(grid.Rows, row)
+	}
+
+	file, err := export.Render(grid, format, exportFileName("timetable", class.Name))
+	if err != nil {
+		if errors.Is(err, export.ErrUnsupportedFormat) {
+			return nil, utils.ErrInvalidEnumValue
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return file, nil
+}
+
+// ExportICS builds an iCalendar feed of a teacher's weekly schedule. Each entry
+// becomes a weekly recurring event from its first occurrence on or after the
+// academic year's start date until the year's end date, in the institution's
+// time zone; odd-week and even-week entries repeat every other week. Occurrences
+// on holidays are excluded. Without an academic year, the current one is used.
+func (s *TimetableService) ExportICS(teacherID, institutionID uuid.UUID, academicYearID *uuid.UUID) (*export.File, error) {
+	teacher, err := s.teacherRepo.FindByID(teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if teacher.InstitutionID != institutionID {
+		return nil, utils.ErrCrossTenantAccess
+	}
+
+	var year *models.AcademicYear
+	if academicYearID != nil {
+		year, err = s.ayRepo.FindByIDWithInstitution(*academicYearID, institutionID)
+	} else {
+		year, err = s.ayRepo.FindCurrent(institutionID)
+	}
+	if err != nil {
+		return nil, errors.New("academic year not found")
+	}
+
+	settings, err := s.instService.GetSettings(institutionID)
+	if err != nil {
+		return nil, err
+	}
+	loc := settings.Location()
+
+	timetables, err := s.ttRepo.FindByTeacherID(teacherID, &year.ID, "")
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	// Dates are interpreted as calendar days in the institution's time zone
+	firstDay := time.Date(year.StartDate.Year(), year.StartDate.Month(), year.StartDate.Day(), 0, 0, 0, 0, loc)
+	until := time.Date(year.EndDate.Year(), year.EndDate.Month(), year.EndDate.Day(), 23, 59, 59, 0, loc)
+
+	holidays, err := s.holidays.HolidaysInRange(institutionID, year.StartDate, year.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &export.Calendar{Name: "Teaching schedule", Location: loc}
+	if teacher.User != nil && teacher.User.Profile != nil {
+		cal.Name += " - " + strings.TrimSpace(teacher.User.Profile.FirstName+" "+teacher.User.Profile.LastName)
+	}
+
+	for _, tt := range timetables {
+		weekday, ok := weekdayOf(tt.DayOfWeek)
+		if !ok {
+			continue
+		}
+		start, err := utils.ParseClockTime(tt.StartTime)
+		if err != nil {
+			continue
+		}
+		end, err := utils.ParseClockTime(tt.EndTime)
+		if err != nil || end <= start {
+			continue
+		}
+
+		date := firstDay.AddDate(0, 0, (int(weekday)-int(firstDay.Weekday())+7)%7)
+		interval := 1
+		if tt.WeekType == models.WeekTypeOdd || tt.WeekType == models.WeekTypeEven {
+			// Start on the first occurrence in an ISO week of the right parity;
+			// the rest follow every other week
+			interval = 2
+			if _, week := date.ISOWeek(); (week%2 == 1) != (tt.WeekType == models.WeekTypeOdd) {
+				date = date.AddDate(0, 0, 7)
+			}
+		}
+		if date.After(until) {
+			continue
+		}
+
+		event := export.Event{
+			UID:          tt.ID.String() + "@campus-core",
+			Summary:      "Class",
+			Start:        time.Date(date.Year(), date.Month(), date.Day(), start/60, start%60, 0, 0, loc),
+			End:          time.Date(date.Year(), date.Month(), date.Day(), end/60, end%60, 0, 0, loc),
+			WeeklyUntil:  until,
+			Interval:     interval,
+			Location:     tt.RoomNumber,
+			LastModified: tt.UpdatedAt,
+		}
+		for _, holiday := range holidays {
+			if holiday.Weekday() == weekday {
+				event.ExceptDates = append(event.ExceptDates,
+					time.Date(holiday.Year(), holiday.Month(), holiday.Day(), start/60, start%60, 0, 0, loc))
+			}
+		}
+		if tt.Subject != nil {
+			event.Summary = tt.Subject.Name
+		}
+		var details []string
+		if tt.Class != nil {
+			details = append(details, "Class "+tt.Class.Name)
+		}
+		if tt.Section != nil {
+			details = append(details, "Section "+tt.Section.Name)
+		}
+		if len(details) > 0 {
+			event.Summary += " (" + strings.Join(details, ", ") + ")"
+			event.Description = strings.Join(details, "\n")
+		}
+
+		cal.Events = append(cal.Events, event)
+	}
+
+	file, err := export.RenderICS(cal, exportFileName("teacher-schedule", year.Name))
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	return file, nil
+}
+
+// weekdayOf maps a timetable day to a time.Weekday
+func weekdayOf(day models.DayOfWeek) (time.Weekday, bool) {
+	for i, name := range dayOrder {
+		if string(day) == name {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// exportFileName builds a download file name from a prefix and a display name,
+// keeping only characters that are safe in a Content-Disposition header
+func exportFileName(prefix, name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		case r == ' ' || r == '-' || r == '_':
+			return '-'
+		}
+		return -1
+	}, strings.TrimSpace(name))
+	if safe == "" {
+		return prefix
+	}
+	return prefix + "-" + safe
+}
+
+// exportCellText describes a timetable entry for a printed cell
+func exportCellText(entry *response.TimetableResponse) string {
+	parts := make([]string, 0, 3)
+	if entry.Subject != nil {
+		parts = append(parts, entry.Subject.Name)
+	}
+	if entry.Section != nil {
+		parts = append(parts, "Sec "+entry.Section.Name)
+	}
+	if entry.RoomNumber != "" {
+		parts = append(parts, "Rm "+entry.RoomNumber)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// GetBySectionID gets timetable for a section, optionally only for odd or even weeks
+func (s *TimetableService) GetBySectionID(sectionID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType) (*response.WeekTimetableResponse, error) {
 	// Verify section exists
 	if _, err := s.sectionRepo.FindByID(sectionID); err != nil {
 		return nil, err
 	}
 
-	timetables, err := s.ttRepo.FindBySectionID(sectionID, academicYearID)
+	timetables, err := s.ttRepo.FindBySectionID(sectionID, academicYearID, weekType)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
@@ -170,27 +577,330 @@ func (s *TimetableService) GetBySectionID(sectionID uuid.UUID, academicYearID *u
 	return s.groupByDay(timetables), nil
 }
 
-// GetByTeacherID gets timetable for a teacher
-func (s *TimetableService) GetByTeacherID(teacherID uuid.UUID, academicYearID *uuid.UUID) (*response.WeekTimetableResponse, error) {
+// GetByTeacherID gets timetable for a teacher, including the substitutions
+// the teacher is involved in between from and to (inclusive). An optional week
+// type keeps only the entries held in odd or even weeks.
+func (s *TimetableService) GetByTeacherID(teacherID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType, from, to time.Time) (*response.WeekTimetableResponse, error) {
 	// Verify teacher exists
 	if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
 		return nil, err
 	}
 
-	timetables, err := s.ttRepo.FindByTeacherID(teacherID, academicYearID)
+	timetables, err := s.ttRepo.FindByTeacherID(teacherID, academicYearID, weekType)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	week := s.groupByDay(timetables)
+
+	subs, err := s.subRepo.FindByTeacherInRange(teacherID, from, to)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	for i := range subs {
+		if academicYearID != nil && subs[i].Timetable != nil && subs[i].Timetable.AcademicYearID != *academicYearID {
+			continue
+		}
+		week.Substitutions = append(week.Substitutions, *s.toSubstitutionResponse(&subs[i], teacherID))
+	}
+
+	return week, nil
+}
+
+// GetMine gets the timetable of the signed-in student's section or of the
+// signed-in teacher, for the given academic year or else the current one.
+// A teacher's timetable includes the substitutions between from and to.
+func (s *TimetableService) GetMine(userID uuid.UUID, role string, institutionID uuid.UUID, academicYearID *uuid.UUID, weekType models.WeekType, from, to time.Time) (*response.WeekTimetableResponse, error) {
+	var year *models.AcademicYear
+	var err error
+	if academicYearID != nil {
+		year, err = s.ayRepo.FindByIDWithInstitution(*academicYearID, institutionID)
+	} else {
+		year, err = s.ayRepo.FindCurrent(institutionID)
+	}
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, errors.New("academic year not found")
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	switch role {
+	case models.RoleStudent:
+		student, err := s.studentRepo.FindByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if student.SectionID == nil {
+			return nil, errors.New("you are not assigned to a section yet")
+		}
+		return s.GetBySectionID(*student.SectionID, &year.ID, weekType)
+	case models.RoleTeacher:
+		teacher, err := s.teacherRepo.FindByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		return s.GetByTeacherID(teacher.ID, &year.ID, weekType, from, to)
+	default:
+		return nil, utils.ErrActionNotPermitted
+	}
+}
+
+// GetTeacherFreeSlots computes, per day, the gaps between a teacher's scheduled
+// entries within the school-day window. Days without entries are entirely free;
+// overlapping or duplicated entries are merged.
+func (s *TimetableService) GetTeacherFreeSlots(teacherID, institutionID uuid.UUID, query *request.TeacherFreeSlotsQuery) (*response.TeacherFreeSlotsResponse, error) {
+	// Verify teacher exists in the institution
+	if _, err := s.teacherRepo.FindByIDWithInstitution(teacherID, institutionID); err != nil {
+		return nil, err
+	}
+
+	dayStart, dayEnd := defaultSchoolDayStart, defaultSchoolDayEnd
+	if query.DayStart != "" {
+		dayStart = query.DayStart
+	}
+	if query.DayEnd != "" {
+		dayEnd = query.DayEnd
+	}
+	dayStart, dayEnd, err := utils.NormalizeTimeRange(dayStart, dayEnd)
+	if err != nil {
+		return nil, err
+	}
+	windowStart, _ := utils.ParseClockTime(dayStart)
+	windowEnd, _ := utils.ParseClockTime(dayEnd)
+
+	days := dayOrder
+	if query.Days != "" {
+		requested := make(map[string]bool)
+		for _, day := range strings.Split(query.Days, ",") {
+			requested[strings.ToUpper(strings.TrimSpace(day))] = true
+		}
+		days = nil
+		for _, day := range dayOrder {
+			if requested[day] {
+				days = append(days, day)
+			}
+		}
+		if len(days) == 0 {
+			return nil, utils.ErrInvalidEnumValue
+		}
+	}
+
+	var academicYearID *uuid.UUID
+	if query.AcademicYearID != "" {
+		id, err := uuid.Parse(query.AcademicYearID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		if _, err := s.ayRepo.FindByIDWithInstitution(id, institutionID); err != nil {
+			return nil, err
+		}
+		academicYearID = &id
+	}
+
+	timetables, err := s.ttRepo.FindByTeacherID(teacherID, academicYearID, "")
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	// Busy intervals per day in minutes, clipped to the window
+	type interval struct{ start, end int }
+	busy := make(map[string][]interval)
+	for _, tt := range timetables {
+		start, err := utils.ParseClockTime(tt.StartTime)
+		if err != nil {
+			continue
+		}
+		end, err := utils.ParseClockTime(tt.EndTime)
+		if err != nil || end <= start {
+			continue
+		}
+		if start < windowStart {
+			start = windowStart
+		}
+		if end > windowEnd {
+			end = windowEnd
+		}
+		if start >= end {
+			continue
+		}
+		day := string(tt.DayOfWeek)
+		busy[day] = append(busy[day], interval{start, end})
+	}
+
+	slots := make([]response.FreeSlot, 0)
+	for _, day := range days {
+		intervals := busy[day]
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+
+		// Walk the sorted intervals, emitting the gap before each one
+		cursor := windowStart
+		for _, iv := range intervals {
+			if iv.start > cursor {
+				slots = append(slots, response.FreeSlot{Day: day, Start: formatClockMinutes(cursor), End: formatClockMinutes(iv.start)})
+			}
+			if iv.end > cursor {
+				cursor = iv.end
+			}
+		}
+		if cursor < windowEnd {
+			slots = append(slots, response.FreeSlot{Day: day, Start: formatClockMinutes(cursor), End: formatClockMinutes(windowEnd)})
+		}
+	}
+
+	return &response.TeacherFreeSlotsResponse{
+		TeacherID: teacherID,
+		DayStart:  dayStart,
+		DayEnd:    dayEnd,
+		Slots:     slots,
+	}, nil
+}
+
+// GetRoomSchedule gets the occupied periods of a room grouped by day
+func (s *TimetableService) GetRoomSchedule(roomNumber string, institutionID uuid.UUID, academicYearID *uuid.UUID) (*response.WeekTimetableResponse, error) {
+	if strings.TrimSpace(roomNumber) == "" {
+		return nil, utils.ErrRequiredFieldMissing
+	}
+
+	timetables, err := s.ttRepo.FindRoomSchedule(institutionID, roomNumber, academicYearID)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
+	// Sort in memory since legacy times such as "9:00" do not sort as strings
+	sort.SliceStable(timetables, func(i, j int) bool {
+		a, _ := utils.ParseClockTime(timetables[i].StartTime)
+		b, _ := utils.ParseClockTime(timetables[j].StartTime)
+		return a < b
+	})
+
 	return s.groupByDay(timetables), nil
 }
 
+// GetTeacherWorkload reports the weekly periods and minutes of every teacher,
+// busiest first. Without an academic year, the current one is used.
+func (s *TimetableService) GetTeacherWorkload(query *request.TeacherWorkloadQuery, institutionID uuid.UUID) ([]response.TeacherWorkloadResponse, error) {
+	var year *models.AcademicYear
+	var err error
+	if query.AcademicYearID != "" {
+		id, parseErr := uuid.Parse(query.AcademicYearID)
+		if parseErr != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		year, err = s.ayRepo.FindByIDWithInstitution(id, institutionID)
+	} else {
+		year, err = s.ayRepo.FindCurrent(institutionID)
+	}
+	if err != nil {
+		return nil, errors.New("academic year not found")
+	}
+
+	var departmentID *uuid.UUID
+	if query.DepartmentID != "" {
+		id, err := uuid.Parse(query.DepartmentID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		departmentID = &id
+	}
+
+	workloads, err := s.ttRepo.GetTeacherWorkload(institutionID, year.ID, departmentID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	responses := make([]response.TeacherWorkloadResponse, 0, len(workloads))
+	for _, w := range workloads {
+		resp := response.TeacherWorkloadResponse{
+			Teacher:      response.TeacherBrief{ID: w.TeacherID, FirstName: w.FirstName, LastName: w.LastName},
+			DepartmentID: w.DepartmentID,
+			Periods:      w.Periods,
+			Minutes:      w.Minutes,
+			Days:         []response.DayWorkloadResponse{},
+		}
+		for _, day := range dayOrder {
+			load, ok := w.Days[models.DayOfWeek(day)]
+			if !ok {
+				continue
+			}
+			dayResp := response.DayWorkloadResponse{Day: day, Periods: load.Periods, Minutes: load.Minutes}
+			resp.Days = append(resp.Days, dayResp)
+			if resp.BusiestDay == nil || load.Periods > resp.BusiestDay.Periods ||
+				(load.Periods == resp.BusiestDay.Periods && load.Minutes > resp.BusiestDay.Minutes) {
+				busiest := dayResp
+				resp.BusiestDay = &busiest
+			}
+		}
+		responses = append(responses, resp)
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		a, b := responses[i], responses[j]
+		if a.Minutes != b.Minutes {
+			return a.Minutes > b.Minutes
+		}
+		if a.Periods != b.Periods {
+			return a.Periods > b.Periods
+		}
+		return strings.ToLower(a.Teacher.FirstName+" "+a.Teacher.LastName) < strings.ToLower(b.Teacher.FirstName+" "+b.Teacher.LastName)
+	})
+
+	return responses, nil
+}
+
+// CheckRoomAvailability reports whether a room is free on a day and time range,
+// listing the entries that occupy it otherwise
+func (s *TimetableService) CheckRoomAvailability(req *request.RoomCheckRequest, institutionID uuid.UUID) (*response.RoomAvailabilityResponse, error) {
+	startTime, endTime, err := utils.NormalizeTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var academicYearID *uuid.UUID
+	if req.AcademicYearID != "" {
+		id, err := uuid.Parse(req.AcademicYearID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		academicYearID = &id
+	}
+
+	conflicts, err := s.ttRepo.FindRoomConflicts(
+		institutionID, req.RoomNumber, models.DayOfWeek(req.DayOfWeek), startTime, endTime, academicYearID,
+	)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := &response.RoomAvailabilityResponse{
+		RoomNumber: strings.TrimSpace(req.RoomNumber),
+		DayOfWeek:  req.DayOfWeek,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Available:  len(conflicts) == 0,
+		Conflicts:  make([]response.TimetableResponse, 0, len(conflicts)),
+	}
+	for i := range conflicts {
+		resp.Conflicts = append(resp.Conflicts, *s.toResponse(&conflicts[i]))
+	}
+
+	return resp, nil
+}
+
+// formatClockMinutes formats minutes since midnight as "HH:MM"
+func formatClockMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
 // Update updates a timetable entry
 func (s *TimetableService) Update(id uuid.UUID, req *request.UpdateTimetableRequest, institutionID uuid.UUID) (*response.TimetableResponse, error) {
 	tt, err := s.ttRepo.FindByIDWithInstitution(id, institutionID)
 	if err != nil {
 		return nil, err
 	}
+	if *req.Version != tt.Version {
+		return nil, utils.ErrVersionConflict
+	}
 
 	// Update fields if provided
 	if req.AcademicYearID != "" {
@@ -233,6 +943,11 @@ func (s *TimetableService) Update(id uuid.UUID, req *request.UpdateTimetableRequ
 		}
 		tt.SubjectID = subjectID
 	}
+	if req.ClassID != "" || req.SectionID != "" {
+		if err := s.checkSectionInClass(tt.SectionID, tt.ClassID); err != nil {
+			return nil, err
+		}
+	}
 	if req.TeacherID != "" {
 		teacherID, err := uuid.Parse(req.TeacherID)
 		if err != nil {
@@ -246,6 +961,9 @@ func (s *TimetableService) Update(id uuid.UUID, req *request.UpdateTimetableRequ
 	if req.DayOfWeek != "" {
 		tt.DayOfWeek = models.DayOfWeek(req.DayOfWeek)
 	}
+	if req.WeekType != "" {
+		tt.WeekType = models.WeekType(req.WeekType)
+	}
 	if req.StartTime != "" {
 		tt.StartTime = req.StartTime
 	}
@@ -259,16 +977,30 @@ func (s *TimetableService) Update(id uuid.UUID, req *request.UpdateTimetableRequ
 		tt.IsActive = *req.IsActive
 	}
 
+	// Validate and normalize the resulting time slot
+	tt.StartTime, tt.EndTime, err = utils.NormalizeTimeRange(tt.StartTime, tt.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if req.StartTime != "" || req.EndTime != "" {
+		if err := s.periods.CheckSlot(institutionID, tt.StartTime, tt.EndTime); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check for conflicts
 	hasConflict, err := s.ttRepo.CheckConflict(tt, &id)
 	if err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 	if hasConflict {
-		return nil, errors.New("scheduling conflict detected: teacher, section, or room is already occupied at this time")
+		return nil, utils.ErrScheduleConflict
 	}
 
 	if err := s.ttRepo.Update(tt); err != nil {
+		if errors.Is(err, utils.ErrVersionConflict) {
+			return nil, err
+		}
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
@@ -289,9 +1021,143 @@ func (s *TimetableService) Delete(id, institutionID uuid.UUID) error {
 	return s.ttRepo.Delete(id)
 }
 
+// ClearSection deletes a section's timetable entries in one academic year so the
+// schedule can be rebuilt. Entries of other years are kept.
+func (s *TimetableService) ClearSection(sectionID, academicYearID, institutionID uuid.UUID) (*response.SectionTimetableClearResponse, error) {
+	// Verify the section's class belongs to the institution
+	section, err := s.sectionRepo.FindByID(sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section.Class == nil || section.Class.InstitutionID != institutionID {
+		return nil, utils.ErrNotFound
+	}
+	if _, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID); err != nil {
+		return nil, errors.New("academic year not found")
+	}
+
+	deleted, err := s.ttRepo.DeleteBySection(sectionID, academicYearID)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return &response.SectionTimetableClearResponse{
+		SectionID:      sectionID,
+		AcademicYearID: academicYearID,
+		DeletedEntries: deleted,
+	}, nil
+}
+
+// buildEntry validates a create request and builds the timetable model
+func (s *TimetableService) buildEntry(req *request.CreateTimetableRequest, institutionID uuid.UUID) (*models.Timetable, error) {
+	// Parse and validate all UUIDs
+	academicYearID, err := uuid.Parse(req.AcademicYearID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	classID, err := uuid.Parse(req.ClassID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	sectionID, err := uuid.Parse(req.SectionID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	subjectID, err := uuid.Parse(req.SubjectID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+	teacherID, err := uuid.Parse(req.TeacherID)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	// Validate and normalize the time slot
+	startTime, endTime, err := utils.NormalizeTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.periods.CheckSlot(institutionID, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	// Verify all entities exist
+	if _, err := s.ayRepo.FindByIDWithInstitution(academicYearID, institutionID); err != nil {
+		return nil, errors.New("academic year not found")
+	}
+	if _, err := s.classRepo.FindByIDWithInstitution(classID, institutionID); err != nil {
+		return nil, errors.New("class not found")
+	}
+	if _, err := s.sectionRepo.FindByID(sectionID); err != nil {
+		return nil, errors.New("section not found")
+	}
+	if err := s.checkSectionInClass(sectionID, classID); err != nil {
+		return nil, err
+	}
+	if _, err := s.subjectRepo.FindByIDWithInstitution(subjectID, institutionID); err != nil {
+		return nil, errors.New("subject not found")
+	}
+	if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
+		return nil, errors.New("teacher not found")
+	}
+
+	weekType := models.WeekTypeAll
+	if req.WeekType != "" {
+		weekType = models.WeekType(req.WeekType)
+	}
+
+	return &models.Timetable{
+		TenantBaseModel: models.TenantBaseModel{InstitutionID: institutionID},
+		AcademicYearID:  academicYearID,
+		ClassID:         classID,
+		SectionID:       sectionID,
+		SubjectID:       subjectID,
+		TeacherID:       teacherID,
+		DayOfWeek:       models.DayOfWeek(req.DayOfWeek),
+		WeekType:        weekType,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		RoomNumber:      req.RoomNumber,
+		IsActive:        true,
+	}, nil
+}
+
+// checkSectionInClass rejects a section that belongs to a different class
+func (s *TimetableService) checkSectionInClass(sectionID, classID uuid.UUID) error {
+	ok, err := s.sectionRepo.BelongsToClass(sectionID, classID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if !ok {
+		return utils.ErrSectionNotInClass
+	}
+	return nil
+}
+
+// findBatchConflict returns the index of the first earlier batch entry that
+// shares a teacher, section, or room with tt at an overlapping time, or -1
+func findBatchConflict(tt *models.Timetable, earlier []*models.Timetable) int {
+	for i, other := range earlier {
+		if other == nil || other.DayOfWeek != tt.DayOfWeek || other.AcademicYearID != tt.AcademicYearID {
+			continue
+		}
+		if !other.WeekType.Overlaps(tt.WeekType) {
+			continue
+		}
+		// Times are normalized to "HH:MM" so string comparison is safe
+		if !(other.StartTime < tt.EndTime && other.EndTime > tt.StartTime) {
+			continue
+		}
+		if other.TeacherID == tt.TeacherID || other.SectionID == tt.SectionID ||
+			(tt.RoomNumber != "" && utils.NormalizeRoomNumber(other.RoomNumber) == utils.NormalizeRoomNumber(tt.RoomNumber)) {
+			return i
+		}
+	}
+	return -1
+}
+
 // groupByDay groups timetable entries by day of week
 func (s *TimetableService) groupByDay(timetables []models.Timetable) *response.WeekTimetableResponse {
-	dayOrder := []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
 	dayMap := make(map[string][]response.TimetableResponse)
 
 	for _, tt := range timetables {
@@ -312,6 +1178,49 @@ func (s *TimetableService) groupByDay(timetables []models.Timetable) *response.W
 	return &response.WeekTimetableResponse{Days: days}
 }
 
+// toSubstitutionResponse converts a substitution to response. If teacherID is
+// set, the role of that teacher in the substitution is included.
+func (s *TimetableService) toSubstitutionResponse(sub *models.Substitution, teacherID uuid.UUID) *response.SubstitutionResponse {
+	resp := &response.SubstitutionResponse{
+		ID:                  sub.ID,
+		TimetableID:         sub.TimetableID,
+		Date:                sub.Date.Format("2006-01-02"),
+		OriginalTeacherID:   sub.OriginalTeacherID,
+		SubstituteTeacherID: sub.SubstituteTeacherID,
+		OriginalTeacher:     toTeacherBrief(sub.OriginalTeacher),
+		SubstituteTeacher:   toTeacherBrief(sub.SubstituteTeacher),
+		Reason:              sub.Reason,
+		CreatedAt:           sub.CreatedAt,
+	}
+
+	switch teacherID {
+	case uuid.Nil:
+	case sub.SubstituteTeacherID:
+		resp.Role = response.SubstitutionRoleCovering
+	case sub.OriginalTeacherID:
+		resp.Role = response.SubstitutionRoleCovered
+	}
+
+	if sub.Timetable != nil {
+		resp.Entry = s.toResponse(sub.Timetable)
+	}
+
+	return resp
+}
+
+// toTeacherBrief converts a teacher with a loaded profile to a brief response
+func toTeacherBrief(teacher *models.Teacher) *response.TeacherBrief {
+	if teacher == nil {
+		return nil
+	}
+	brief := &response.TeacherBrief{ID: teacher.ID}
+	if teacher.User != nil && teacher.User.Profile != nil {
+		brief.FirstName = teacher.User.Profile.FirstName
+		brief.LastName = teacher.User.Profile.LastName
+	}
+	return brief
+}
+
 // toResponse converts a model to response
 func (s *TimetableService) toResponse(tt *models.Timetable) *response.TimetableResponse {
 	resp := &response.TimetableResponse{
@@ -323,10 +1232,12 @@ func (s *TimetableService) toResponse(tt *models.Timetable) *response.TimetableR
 		SubjectID:      tt.SubjectID,
 		TeacherID:      tt.TeacherID,
 		DayOfWeek:      string(tt.DayOfWeek),
+		WeekType:       string(tt.WeekType),
 		StartTime:      tt.StartTime,
 		EndTime:        tt.EndTime,
 		RoomNumber:     tt.RoomNumber,
 		IsActive:       tt.IsActive,
+		Version:        tt.Version,
 		CreatedAt:      tt.CreatedAt,
 		UpdatedAt:      tt.UpdatedAt,
 	}
@@ -351,13 +1262,7 @@ func (s *TimetableService) toResponse(tt *models.Timetable) *response.TimetableR
 		}
 	}
 	if tt.Teacher != nil {
-		resp.Teacher = &response.TeacherBrief{
-			ID: tt.Teacher.ID,
-		}
-		if tt.Teacher.User != nil && tt.Teacher.User.Profile != nil {
-			resp.Teacher.FirstName = tt.Teacher.User.Profile.FirstName
-			resp.Teacher.LastName = tt.Teacher.User.Profile.LastName
-		}
+		resp.Teacher = toTeacherBrief(tt.Teacher)
 	}
 
 	return resp
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"campus-core/internal/dto/request"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// newTestTimetableService builds a TimetableService with the repositories entry validation needs
+func newTestTimetableService(db *gorm.DB) *TimetableService {
+	return NewTimetableService(
+		repository.NewTimetableRepository(db),
+		repository.NewClassRepository(db),
+		repository.NewSectionRepository(db),
+		repository.NewSubjectRepository(db),
+		repository.NewTeacherRepository(db),
+		repository.NewStudentRepository(db),
+		repository.NewAcademicYearRepository(db),
+		nil,
+		nil,
+		nil,
+		NewPeriodService(repository.NewPeriodRepository(db)),
+	)
+}
+
+func TestTimetableBuildEntrySectionInClass(t *testing.T) {
+	db := testutil.DB(t)
+	s := newTestTimetableService(db)
+
+	institution := testutil.Institution(t, db)
+	year := testutil.AcademicYear(t, db, institution.ID,
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	classA := testutil.Class(t, db, institution.ID)
+	classB := testutil.Class(t, db, institution.ID)
+	sectionA := testutil.Section(t, db, classA.ID)
+	subject := testutil.Subject(t, db, institution.ID, nil)
+	teacher := testutil.Teacher(t, db, institution.ID)
+
+	newRequest := func(classID string) *request.CreateTimetableRequest {
+		return &request.CreateTimetableRequest{
+			AcademicYearID: year.ID.String(),
+			ClassID:        classID,
+			SectionID:      sectionA.ID.String(),
+			SubjectID:      subject.ID.String(),
+			TeacherID:      teacher.ID.String(),
+			DayOfWeek:      "MONDAY",
+			StartTime:      "09:00",
+			EndTime:        "09:45",
+		}
+	}
+
+	if _, err := s.buildEntry(newRequest(classA.ID.String()), institution.ID); err != nil {
+		t.Fatalf("buildEntry() with the section's own class unexpected error: %v", err)
+	}
+	if _, err := s.buildEntry(newRequest(classB.ID.String()), institution.ID); !errors.Is(err, utils.ErrSectionNotInClass) {
+		t.Fatalf("buildEntry() with another class error = %v, want ErrSectionNotInClass", err)
+	}
+}
@@ -2,14 +2,22 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
 	"campus-core/internal/dto/request"
 	"campus-core/internal/dto/response"
 	"campus-core/internal/models"
 	"campus-core/internal/repository"
 	"campus-core/internal/utils"
+	"campus-core/pkg/export"
+	"campus-core/pkg/logger"
+	"campus-core/pkg/mailer"
+	"campus-core/pkg/storage"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // UserService handles user management business logic
@@ -17,14 +25,18 @@ type UserService struct {
 	repo        *repository.UserRepository
 	instRepo    *repository.InstitutionRepository
 	authService *AuthService // Reuse for registration logic including hashing
+	store       storage.Storage
+	mailer      mailer.Mailer
 }
 
 // NewUserService creates a new user service
-func NewUserService(repo *repository.UserRepository, instRepo *repository.InstitutionRepository, authService *AuthService) *UserService {
+func NewUserService(repo *repository.UserRepository, instRepo *repository.InstitutionRepository, authService *AuthService, store storage.Storage, mailer mailer.Mailer) *UserService {
 	return &UserService{
 		repo:        repo,
 		instRepo:    instRepo,
 		authService: authService,
+		store:       store,
+		mailer:      mailer,
 	}
 }
 
@@ -74,9 +86,22 @@ func (s *UserService) CreateUser(req *request.RegisterRequest, creatorRole strin
 	return s.authService.Register(req)
 }
 
-// GetUser gets a user by ID
-func (s *UserService) GetUser(id uuid.UUID) (*response.UserResponse, error) {
-	user, err := s.repo.FindByID(id)
+// findUserForCaller loads a user visible to the caller
+// Super Admins see every user; everyone else only sees users of their own institution
+func (s *UserService) findUserForCaller(id uuid.UUID, callerRole string, callerInstitutionID string) (*models.User, error) {
+	if callerRole == models.RoleSuperAdmin {
+		return s.repo.FindByID(id)
+	}
+	instID, err := uuid.Parse(callerInstitutionID)
+	if err != nil {
+		return nil, utils.ErrUserNotFound
+	}
+	return s.repo.FindByIDScoped(id, instID)
+}
+
+// GetUser gets a user by ID within the caller's institution
+func (s *UserService) GetUser(id uuid.UUID, callerRole string, callerInstitutionID string) (*response.UserResponse, error) {
+	user, err := s.findUserForCaller(id, callerRole, callerInstitutionID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +109,29 @@ func (s *UserService) GetUser(id uuid.UUID) (*response.UserResponse, error) {
 	return &resp, nil
 }
 
+// authorBriefs returns brief references to the users who created and last
+// updated a record; unset authors are left nil
+func authorBriefs(a models.Authorship) (createdBy, updatedBy *response.UserBrief) {
+	return userBrief(a.CreatedBy, a.Creator), userBrief(a.UpdatedBy, a.Updater)
+}
+
+// userBrief builds a brief user reference, naming the user when loaded
+func userBrief(id *uuid.UUID, user *models.User) *response.UserBrief {
+	if id == nil {
+		return nil
+	}
+	brief := &response.UserBrief{ID: *id}
+	switch {
+	case *id == models.SystemActorID:
+		brief.Name = "System"
+	case user != nil && user.Profile != nil:
+		brief.Name = user.Profile.FullName()
+	case user != nil:
+		brief.Name = user.Email
+	}
+	return brief
+}
+
 // GetAllUsers lists users with filters
 func (s *UserService) GetAllUsers(filter repository.UserFilter, params utils.PaginationParams) ([]response.UserResponse, utils.Pagination, error) {
 	users, total, err := s.repo.FindAll(filter, params)
@@ -100,20 +148,80 @@ func (s *UserService) GetAllUsers(filter repository.UserFilter, params utils.Pag
 	return userResponses, pagination, nil
 }
 
+// userExportBatchSize is the number of users loaded at a time while exporting
+const userExportBatchSize = 500
+
+// userExportHeaders are the columns of the user export
+var userExportHeaders = []string{
+	"ID", "First Name", "Last Name", "Email", "Phone", "Role",
+	"Active", "Email Verified", "Last Login", "Created At",
+}
+
+// Export prepares a csv or xlsx download of all users matching the filter.
+// Users are read in batches while the file is written, so exports of any size
+// use constant memory.
+func (s *UserService) Export(filter repository.UserFilter, format string) (*export.Stream, error) {
+	table := &export.Table{
+		Headers: userExportHeaders,
+		Rows: func(yield func(cells []string) error) error {
+			return s.repo.FindAllInBatches(filter, userExportBatchSize, func(users []models.User) error {
+				for i := range users {
+					if err := yield(userExportRow(&users[i])); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		},
+	}
+
+	baseName := "users-" + time.Now().Format("20060102-150405")
+	stream, err := export.StreamTable(table, format, baseName)
+	if err != nil {
+		if errors.Is(err, export.ErrUnsupportedFormat) {
+			return nil, utils.ErrInvalidEnumValue
+		}
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	return stream, nil
+}
+
+// userExportRow returns the export cells of a user, in userExportHeaders order
+func userExportRow(user *models.User) []string {
+	var firstName, lastName string
+	if user.Profile != nil {
+		firstName, lastName = user.Profile.FirstName, user.Profile.LastName
+	}
+
+	lastLogin := ""
+	if user.LastLoginAt != nil {
+		lastLogin = user.LastLoginAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		user.ID.String(),
+		firstName,
+		lastName,
+		user.Email,
+		user.Phone,
+		user.Role,
+		strconv.FormatBool(user.IsActive),
+		strconv.FormatBool(user.EmailVerified),
+		lastLogin,
+		user.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
 // UpdateUser updates a user (Admin function)
 func (s *UserService) UpdateUser(id uuid.UUID, req *request.UpdateUserRequest, creatorRole string, creatorInstitutionID string) (*response.UserResponse, error) {
-	user, err := s.repo.FindByID(id)
+	// Users of other institutions are hidden, not forbidden, to avoid disclosing they exist
+	user, err := s.findUserForCaller(id, creatorRole, creatorInstitutionID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Security: Verify tenant access for non-super admins
 	if creatorRole != models.RoleSuperAdmin {
-		if user.Profile != nil && user.Profile.InstitutionID != nil {
-			if user.Profile.InstitutionID.String() != creatorInstitutionID {
-				return nil, utils.ErrCrossTenantAccess
-			}
-		}
 		// Admin cannot update Super Admins
 		if user.Role == models.RoleSuperAdmin {
 			return nil, utils.ErrActionNotPermitted
@@ -133,19 +241,23 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *request.UpdateUserRequest, c
 	}
 
 	// Update phone if provided and changed
-	if req.Phone != "" && req.Phone != user.Phone {
-		exists, err := s.repo.PhoneExists(req.Phone)
+	if req.Phone != "" {
+		phone, err := canonicalPhone(s.repo, req.Phone, user.Phone)
 		if err != nil {
-			return nil, utils.ErrInternalServer.Wrap(err)
-		}
-		if exists {
-			return nil, utils.ErrPhoneAlreadyExists
+			return nil, err
 		}
-		user.Phone = req.Phone
+		user.Phone = phone
 	}
 
 	// Update active status if provided
+	deactivated := false
 	if req.IsActive != nil {
+		if !*req.IsActive {
+			if err := s.ensureNotLastAdmin(user); err != nil {
+				return nil, err
+			}
+			deactivated = user.IsActive
+		}
 		user.IsActive = *req.IsActive
 	}
 
@@ -162,44 +274,182 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *request.UpdateUserRequest, c
 	if err := s.repo.Update(user); err != nil {
 		return nil, utils.ErrInternalServer.Wrap(err)
 	}
+	if deactivated {
+		if _, err := s.authService.LogoutAll(user.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	resp := s.authService.toUserResponse(user)
 	return &resp, nil
 }
 
-// DeleteUser soft deletes a user
-func (s *UserService) DeleteUser(id uuid.UUID, creatorRole string, creatorInstitutionID string) error {
-	user, err := s.repo.FindByID(id)
+// DeleteUser soft deletes a user. Users cannot delete themselves or the last active admin of an institution.
+func (s *UserService) DeleteUser(id, actorID uuid.UUID, creatorRole string, creatorInstitutionID string) error {
+	if id == actorID {
+		return utils.ErrCannotDeleteSelf
+	}
+
+	user, err := s.findUserForCaller(id, creatorRole, creatorInstitutionID)
 	if err != nil {
 		return err
 	}
 
-	// Security: Verify tenant access for non-super admins
 	if creatorRole != models.RoleSuperAdmin {
-		if user.Profile != nil && user.Profile.InstitutionID != nil {
-			if user.Profile.InstitutionID.String() != creatorInstitutionID {
-				return utils.ErrCrossTenantAccess
-			}
-		}
 		// Admin cannot delete Super Admins
 		if user.Role == models.RoleSuperAdmin {
 			return utils.ErrActionNotPermitted
 		}
 	}
 
-	return s.repo.Delete(id)
+	if err := s.ensureNotLastAdmin(user); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	// Sign the deleted user out everywhere, including open WebSocket connections
+	_, err = s.authService.LogoutAll(id)
+	return err
 }
 
-// ToggleStatus changes user active status
+// RestoreUser restores a soft-deleted user
+func (s *UserService) RestoreUser(id uuid.UUID, creatorRole string, creatorInstitutionID string) (*response.UserResponse, error) {
+	// Users of other institutions are hidden, same as for DeleteUser
+	scope := ""
+	if creatorRole != models.RoleSuperAdmin {
+		if _, err := uuid.Parse(creatorInstitutionID); err != nil {
+			return nil, utils.ErrUserNotFound
+		}
+		scope = creatorInstitutionID
+	}
+
+	user, err := s.repo.FindDeletedByID(id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admin cannot restore Super Admins
+	if creatorRole != models.RoleSuperAdmin && user.Role == models.RoleSuperAdmin {
+		return nil, utils.ErrActionNotPermitted
+	}
+
+	// The email or phone may have been registered by another account since the deletion
+	if user.Email != "" {
+		exists, err := s.repo.EmailExists(user.Email)
+		if err != nil {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+		if exists {
+			return nil, utils.ErrEmailAlreadyExists
+		}
+	}
+	if user.Phone != "" {
+		exists, err := s.repo.PhoneExists(user.Phone)
+		if err != nil {
+			return nil, utils.ErrInternalServer.Wrap(err)
+		}
+		if exists {
+			return nil, utils.ErrPhoneAlreadyExists
+		}
+	}
+
+	if err := s.repo.Restore(id); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	resp := s.authService.toUserResponse(user)
+	return &resp, nil
+}
+
+// ToggleStatus changes user active status. Deactivated users are signed out everywhere.
 func (s *UserService) ToggleStatus(id uuid.UUID, isActive bool) error {
-	if _, err := s.repo.FindByID(id); err != nil {
+	user, err := s.repo.FindByID(id)
+	if err != nil {
 		return err
 	}
-	return s.repo.UpdateStatus(id, isActive)
+	if !isActive {
+		if err := s.ensureNotLastAdmin(user); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.UpdateStatus(id, isActive); err != nil {
+		return err
+	}
+	if !isActive {
+		_, err = s.authService.LogoutAll(id)
+	}
+	return err
 }
 
-// UpdateProfile updates the user's profile
-func (s *UserService) UpdateProfile(userID uuid.UUID, firstName, lastName string) (*response.UserResponse, error) {
+// RegenerateCredentials replaces a user's password with a random temporary one
+// they must change on their next login, and signs them out everywhere.
+// The password is returned once and emailed to the user when they have an email address.
+func (s *UserService) RegenerateCredentials(id uuid.UUID, callerRole string, callerInstitutionID string) (*response.CredentialsResponse, error) {
+	user, err := s.findUserForCaller(id, callerRole, callerInstitutionID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == models.RoleSuperAdmin && callerRole != models.RoleSuperAdmin {
+		return nil, utils.ErrActionNotPermitted
+	}
+
+	password, err := utils.GenerateRandomPassword(12)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	// Replacing the password also revokes the refresh tokens, so whoever knew
+	// the old password is signed out
+	if err := s.repo.SetTemporaryPassword(user.ID, hash); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+	revokeAccessTokens(s.authService.jwtManager, user.ID)
+
+	resp := &response.CredentialsResponse{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Password: password,
+	}
+	if user.Email != "" {
+		name := user.Email
+		if user.Profile != nil {
+			name = user.Profile.FullName()
+		}
+		if err := s.mailer.SendCredentials(user.Email, name, password); err != nil {
+			logger.Error("Failed to send credentials email", zap.String("user_id", user.ID.String()), zap.Error(err))
+		} else {
+			resp.Emailed = true
+		}
+	}
+	return resp, nil
+}
+
+// ensureNotLastAdmin rejects deactivating or deleting the only active admin of an institution
+func (s *UserService) ensureNotLastAdmin(user *models.User) error {
+	if user.Role != models.RoleAdmin || !user.IsActive {
+		return nil
+	}
+	if user.Profile == nil || user.Profile.InstitutionID == nil {
+		return nil
+	}
+
+	count, err := s.repo.CountOtherActiveAdmins(*user.Profile.InstitutionID, user.ID)
+	if err != nil {
+		return utils.ErrInternalServer.Wrap(err)
+	}
+	if count == 0 {
+		return utils.ErrCannotDeactivateLastAdmin
+	}
+	return nil
+}
+
+// UpdateProfile updates the user's own profile; fields left empty are unchanged
+func (s *UserService) UpdateProfile(userID uuid.UUID, req *request.UpdateProfileRequest) (*response.UserResponse, error) {
 	user, err := s.repo.FindByID(userID)
 	if err != nil {
 		return nil, err
@@ -208,12 +458,38 @@ func (s *UserService) UpdateProfile(userID uuid.UUID, firstName, lastName string
 	if user.Profile == nil {
 		user.Profile = &models.UserProfile{UserID: userID} // Should exist, but safety check
 	}
+	profile := user.Profile
+
+	if req.DateOfBirth != "" {
+		dob, err := parseDateOfBirth(req.DateOfBirth)
+		if err != nil {
+			return nil, err
+		}
+		profile.DateOfBirth = dob
+	}
 
-	user.Profile.FirstName = firstName
-	user.Profile.LastName = lastName
+	if req.Gender != "" {
+		if !models.IsValidGender(req.Gender) {
+			return nil, utils.ErrInvalidEnumValue
+		}
+		profile.Gender = req.Gender
+	}
 
-	if err := s.repo.Update(user); err != nil { // Updates relations too if configured
-		return nil, err
+	if req.FirstName != "" {
+		profile.FirstName = req.FirstName
+	}
+	if req.LastName != "" {
+		profile.LastName = req.LastName
+	}
+	if req.Address != "" {
+		profile.Address = req.Address
+	}
+	if req.ProfileImageURL != "" {
+		profile.ProfileImageURL = req.ProfileImageURL
+	}
+
+	if err := s.repo.SaveProfile(profile); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
 	}
 
 	resp := s.authService.toUserResponse(user)
@@ -241,6 +517,82 @@ func (s *UserService) UpdateAvatar(userID uuid.UUID, avatarURL string) (*respons
 	return &resp, nil
 }
 
+// UploadAvatar stores an uploaded image and sets it as the user's avatar
+func (s *UserService) UploadAvatar(userID uuid.UUID, data []byte) (*response.UserResponse, error) {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := storeImage(s.store, "avatars", data)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Profile == nil {
+		user.Profile = &models.UserProfile{UserID: userID}
+	}
+	previous := user.Profile.ProfileImageURL
+	user.Profile.ProfileImageURL = url
+
+	if err := s.repo.Update(user); err != nil {
+		return nil, utils.ErrInternalServer.Wrap(err)
+	}
+
+	removeStoredFile(s.store, previous)
+	resp := s.authService.toUserResponse(user)
+	return &resp, nil
+}
+
+// canonicalPhone normalizes a phone number to E.164 and rejects it when another
+// account already uses it. current is the number of the account being updated, if any.
+func canonicalPhone(userRepo *repository.UserRepository, phone, current string) (string, error) {
+	normalized, err := utils.NormalizePhone(phone)
+	if err != nil {
+		return "", err
+	}
+	if normalized == current {
+		return normalized, nil
+	}
+
+	exists, err := userRepo.PhoneExists(normalized)
+	if err != nil {
+		return "", utils.ErrInternalServer.Wrap(err)
+	}
+	if exists {
+		return "", utils.ErrPhoneAlreadyExists
+	}
+	return normalized, nil
+}
+
+// parseDateOfBirth parses an optional YYYY-MM-DD date of birth, which must not be in the future
+func parseDateOfBirth(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	dob, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, utils.ErrInvalidDateFormat
+	}
+	if dob.After(time.Now()) {
+		return nil, utils.ErrFieldOutOfRange
+	}
+	return &dob, nil
+}
+
+// parsePastDate parses a YYYY-MM-DD date such as a joining or admission date,
+// which must not be in the future; field names the date in the error
+func parsePastDate(value, field string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, utils.ErrInvalidDateFormat
+	}
+	if date.After(time.Now()) {
+		return time.Time{}, utils.ErrFieldOutOfRange.Wrap(fmt.Errorf("%s can't be in the future", field))
+	}
+	return date, nil
+}
+
 // UpdatePassword updates the user's password
 func (s *UserService) UpdatePassword(userID uuid.UUID, oldPassword, newPassword string) error {
 	user, err := s.repo.FindByID(userID)
@@ -253,11 +605,5 @@ func (s *UserService) UpdatePassword(userID uuid.UUID, oldPassword, newPassword
 		return utils.ErrInvalidCredentials
 	}
 
-	// Hash new password
-	hashedPassword, err := utils.HashPassword(newPassword)
-	if err != nil {
-		return utils.ErrInternalServer.Wrap(err)
-	}
-
-	return s.repo.UpdatePassword(userID, hashedPassword)
+	return s.authService.setPassword(user, newPassword)
 }
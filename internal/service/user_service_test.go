@@ -0,0 +1,324 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"campus-core/internal/middleware"
+	"campus-core/internal/models"
+	"campus-core/internal/repository"
+	"campus-core/internal/testutil"
+	"campus-core/internal/utils"
+	"campus-core/pkg/mailer"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// newTestUserService builds a UserService whose sign-outs revoke refresh tokens in db
+func newTestUserService(db *gorm.DB) *UserService {
+	return &UserService{
+		repo: repository.NewUserRepository(db),
+		authService: &AuthService{
+			tokenRepo:  repository.NewRefreshTokenRepository(db),
+			jwtManager: utils.NewJWTManager("test-secret", 15*time.Minute, time.Hour),
+		},
+		mailer: mailer.NewNoopMailer(),
+	}
+}
+
+// credentialsMailer records the passwords sent by SendCredentials
+type credentialsMailer struct {
+	mailer.NoopMailer
+	sent map[string]string
+}
+
+func (m *credentialsMailer) SendCredentials(to, name, password string) error {
+	m.sent[to] = password
+	return nil
+}
+
+func TestUserServiceGetUserTenantScope(t *testing.T) {
+	db := testutil.DB(t)
+	s := &UserService{repo: repository.NewUserRepository(db), authService: &AuthService{}}
+
+	schoolA := testutil.Institution(t, db)
+	schoolB := testutil.Institution(t, db)
+	user := testutil.User(t, db, models.RoleTeacher, &schoolA.ID)
+
+	tests := []struct {
+		name        string
+		role        string
+		institution string
+		wantErr     error
+	}{
+		{name: "same institution", role: models.RoleAdmin, institution: schoolA.ID.String()},
+		{name: "other institution is hidden", role: models.RoleAdmin, institution: schoolB.ID.String(), wantErr: utils.ErrUserNotFound},
+		{name: "missing institution is hidden", role: models.RoleAdmin, institution: "", wantErr: utils.ErrUserNotFound},
+		{name: "super admin bypasses the scope", role: models.RoleSuperAdmin, institution: ""},
+		{name: "super admin acting in another institution", role: models.RoleSuperAdmin, institution: schoolB.ID.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.GetUser(user.ID, tt.role, tt.institution)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetUser() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetUser() unexpected error: %v", err)
+			}
+			if resp.ID != user.ID {
+				t.Errorf("GetUser() = user %s, want %s", resp.ID, user.ID)
+			}
+		})
+	}
+}
+
+func TestUserServiceLastAdminGuard(t *testing.T) {
+	db := testutil.DB(t)
+	s := newTestUserService(db)
+	superAdmin := testutil.User(t, db, models.RoleSuperAdmin, nil)
+
+	t.Run("self delete", func(t *testing.T) {
+		school := testutil.Institution(t, db)
+		admin := testutil.User(t, db, models.RoleAdmin, &school.ID)
+		testutil.User(t, db, models.RoleAdmin, &school.ID)
+
+		err := s.DeleteUser(admin.ID, admin.ID, models.RoleAdmin, school.ID.String())
+		if !errors.Is(err, utils.ErrCannotDeleteSelf) {
+			t.Fatalf("DeleteUser() error = %v, want %v", err, utils.ErrCannotDeleteSelf)
+		}
+	})
+
+	t.Run("only admin cannot be deleted", func(t *testing.T) {
+		school := testutil.Institution(t, db)
+		admin := testutil.User(t, db, models.RoleAdmin, &school.ID)
+
+		err := s.DeleteUser(admin.ID, superAdmin.ID, models.RoleSuperAdmin, "")
+		if !errors.Is(err, utils.ErrCannotDeactivateLastAdmin) {
+			t.Fatalf("DeleteUser() error = %v, want %v", err, utils.ErrCannotDeactivateLastAdmin)
+		}
+	})
+
+	t.Run("only admin cannot be deactivated", func(t *testing.T) {
+		school := testutil.Institution(t, db)
+		admin := testutil.User(t, db, models.RoleAdmin, &school.ID)
+
+		if err := s.ToggleStatus(admin.ID, false); !errors.Is(err, utils.ErrCannotDeactivateLastAdmin) {
+			t.Fatalf("ToggleStatus() error = %v, want %v", err, utils.ErrCannotDeactivateLastAdmin)
+		}
+		// Activating is always allowed
+		if err := s.ToggleStatus(admin.ID, true); err != nil {
+			t.Fatalf("ToggleStatus(true) unexpected error: %v", err)
+		}
+	})
+
+	t.Run("inactive admins do not count", func(t *testing.T) {
+		school := testutil.Institution(t, db)
+		admin := testutil.User(t, db, models.RoleAdmin, &school.ID)
+		inactive := testutil.User(t, db, models.RoleAdmin, &school.ID)
+		if err := db.Model(&models.User{}).Where("id = ?", inactive.ID).Update("is_active", false).Error; err != nil {
+			t.Fatalf("failed to deactivate admin: %v", err)
+		}
+
+		if err := s.ToggleStatus(admin.ID, false); !errors.Is(err, utils.ErrCannotDeactivateLastAdmin) {
+			t.Fatalf("ToggleStatus() error = %v, want %v", err, utils.ErrCannotDeactivateLastAdmin)
+		}
+	})
+
+	t.Run("another active admin allows delete", func(t *testing.T) {
+		school := testutil.Institution(t, db)
+		admin := testutil.User(t, db, models.RoleAdmin, &school.ID)
+		other := testutil.User(t, db, models.RoleAdmin, &school.ID)
+
+		if err := s.DeleteUser(admin.ID, other.ID, models.RoleAdmin, school.ID.String()); err != nil {
+			t.Fatalf("DeleteUser() unexpected error: %v", err)
+		}
+		// other is now the only admin left
+		if err := s.ToggleStatus(other.ID, false); !errors.Is(err, utils.ErrCannotDeactivateLastAdmin) {
+			t.Fatalf("ToggleStatus() error = %v, want %v", err, utils.ErrCannotDeactivateLastAdmin)
+		}
+	})
+}
+
+func TestUserServiceDeactivationSignsOut(t *testing.T) {
+	db := testutil.DB(t)
+	testutil.Redis(t)
+	s := newTestUserService(db)
+
+	school := testutil.Institution(t, db)
+	user := testutil.User(t, db, models.RoleTeacher, &school.ID)
+	token := &models.RefreshToken{
+		UserID:    user.ID,
+		JTI:       "jti-" + user.ID.String(),
+		FamilyID:  user.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	testutil.Create(t, db, token)
+	claims := &utils.Claims{
+		UserID:           user.ID,
+		RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+	}
+
+	if err := s.ToggleStatus(user.ID, false); err != nil {
+		t.Fatalf("ToggleStatus() unexpected error: %v", err)
+	}
+
+	var stored models.RefreshToken
+	if err := db.First(&stored, "id = ?", token.ID).Error; err != nil {
+		t.Fatalf("failed to reload refresh token: %v", err)
+	}
+	if stored.RevokedAt == nil {
+		t.Error("ToggleStatus() left the refresh token active")
+	}
+	if !middleware.IsTokenRevoked(claims) {
+		t.Error("ToggleStatus() left the access token valid")
+	}
+}
+
+func TestRegenerateCredentials(t *testing.T) {
+	db := testutil.DB(t)
+	testutil.Redis(t)
+	mail := &credentialsMailer{sent: map[string]string{}}
+	s := newTestUserService(db)
+	s.mailer = mail
+
+	school := testutil.Institution(t, db)
+	user := testutil.User(t, db, models.RoleTeacher, &school.ID)
+	token := &models.RefreshToken{
+		UserID:    user.ID,
+		JTI:       "jti-" + user.ID.String(),
+		FamilyID:  user.ID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	testutil.Create(t, db, token)
+	claims := &utils.Claims{
+		UserID:           user.ID,
+		RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+	}
+
+	resp, err := s.RegenerateCredentials(user.ID, models.RoleAdmin, school.ID.String())
+	if err != nil {
+		t.Fatalf("RegenerateCredentials() unexpected error: %v", err)
+	}
+	if !resp.Emailed || mail.sent[user.Email] != resp.Password {
+		t.Errorf("RegenerateCredentials() emailed = %v, sent %q, want the returned password sent", resp.Emailed, mail.sent[user.Email])
+	}
+
+	var stored models.User
+	if err := db.First(&stored, "id = ?", user.ID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	if !utils.CheckPassword(resp.Password, stored.PasswordHash) {
+		t.Error("RegenerateCredentials() returned a password that does not match the stored hash")
+	}
+	if !stored.MustChangePassword {
+		t.Error("RegenerateCredentials() did not force a password change")
+	}
+
+	var storedToken models.RefreshToken
+	if err := db.First(&storedToken, "id = ?", token.ID).Error; err != nil {
+		t.Fatalf("failed to reload refresh token: %v", err)
+	}
+	if storedToken.RevokedAt == nil {
+		t.Error("RegenerateCredentials() left the refresh token active")
+	}
+	if !middleware.IsTokenRevoked(claims) {
+		t.Error("RegenerateCredentials() left the access token valid")
+	}
+
+	if _, err := s.RegenerateCredentials(user.ID, models.RoleAdmin, testutil.Institution(t, db).ID.String()); err == nil {
+		t.Error("RegenerateCredentials() for another institution's user succeeded, want error")
+	}
+}
+
+func TestCanonicalPhoneDedupAcrossFormats(t *testing.T) {
+	db := testutil.DB(t)
+	repo := repository.NewUserRepository(db)
+
+	school := testutil.Institution(t, db)
+	owner := testutil.User(t, db, models.RoleParent, &school.ID)
+	if err := db.Model(&models.User{}).Where("id = ?", owner.ID).Update("phone", "+8801712345678").Error; err != nil {
+		t.Fatalf("failed to set phone: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		phone   string
+		current string
+		want    string
+		wantErr error
+	}{
+		{name: "national form of a taken number", phone: "01712345678", wantErr: utils.ErrPhoneAlreadyExists},
+		{name: "formatted international form of a taken number", phone: "+880 1712-345678", wantErr: utils.ErrPhoneAlreadyExists},
+		{name: "00 form of a taken number", phone: "008801712345678", wantErr: utils.ErrPhoneAlreadyExists},
+		{name: "owner keeping their number", phone: "01712-345678", current: "+8801712345678", want: "+8801712345678"},
+		{name: "free number", phone: "01812345678", want: "+8801812345678"},
+		{name: "invalid number", phone: "not a phone", wantErr: utils.ErrInvalidPhoneFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalPhone(repo, tt.phone, tt.current)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("canonicalPhone(%q) error = %v, want %v", tt.phone, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("canonicalPhone(%q) unexpected error: %v", tt.phone, err)
+			}
+			if got != tt.want {
+				t.Errorf("canonicalPhone(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateOfBirth(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr error
+	}{
+		{name: "empty is allowed", value: ""},
+		{name: "past date", value: "2012-02-29", want: "2012-02-29"},
+		{name: "today", value: time.Now().UTC().Format("2006-01-02"), want: time.Now().UTC().Format("2006-01-02")},
+		{name: "future date", value: tomorrow, wantErr: utils.ErrFieldOutOfRange},
+		{name: "not a leap year", value: "2013-02-29", wantErr: utils.ErrInvalidDateFormat},
+		{name: "day first", value: "15-06-2012", wantErr: utils.ErrInvalidDateFormat},
+		{name: "with time", value: "2012-06-15T00:00:00Z", wantErr: utils.ErrInvalidDateFormat},
+		{name: "garbage", value: "yesterday", wantErr: utils.ErrInvalidDateFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dob, err := parseDateOfBirth(tt.value)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("parseDateOfBirth(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDateOfBirth(%q) unexpected error: %v", tt.value, err)
+			}
+			got := ""
+			if dob != nil {
+				got = dob.Format("2006-01-02")
+			}
+			if got != tt.want {
+				t.Errorf("parseDateOfBirth(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package testutil
+
+import (
+	"testing"
+	"time"
+
+	"campus-core/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Create inserts value and fails the test on error
+func Create(t testing.TB, db *gorm.DB, value interface{}) {
+	t.Helper()
+
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("failed to create %T: %v", value, err)
+	}
+}
+
+// uniqueSuffix returns a short random suffix for names that must be unique
+func uniqueSuffix() string {
+	return uuid.NewString()[:8]
+}
+
+// Institution creates an active institution with a unique code
+func Institution(t testing.TB, db *gorm.DB) *models.Institution {
+	t.Helper()
+
+	suffix := uniqueSuffix()
+	institution := &models.Institution{
+		Name:     "Test School " + suffix,
+		Code:     "TS-" + suffix,
+		IsActive: true,
+	}
+	Create(t, db, institution)
+	return institution
+}
+
+// User creates an active user with a profile. The institution is optional for
+// super admins.
+func User(t testing.TB, db *gorm.DB, role string, institutionID *uuid.UUID) *models.User {
+	t.Helper()
+
+	suffix := uniqueSuffix()
+	user := &models.User{
+		Email:    "user-" + suffix + "@example.com",
+		Role:     role,
+		IsActive: true,
+		Profile: &models.UserProfile{
+			InstitutionID: institutionID,
+			FirstName:     "Test",
+			LastName:      "User " + suffix,
+		},
+	}
+	Create(t, db, user)
+	return user
+}
+
+// Teacher creates a teacher and its user in the institution
+func Teacher(t testing.TB, db *gorm.DB, institutionID uuid.UUID) *models.Teacher {
+	t.Helper()
+
+	user := User(t, db, models.RoleTeacher, &institutionID)
+	teacher := &models.Teacher{UserID: user.ID}
+	teacher.InstitutionID = institutionID
+	Create(t, db, teacher)
+	teacher.User = user
+	return teacher
+}
+
+// AcademicYear creates an academic year running from start to end
+func AcademicYear(t testing.TB, db *gorm.DB, institutionID uuid.UUID, start, end time.Time) *models.AcademicYear {
+	t.Helper()
+
+	year := &models.AcademicYear{
+		InstitutionID: institutionID,
+		Name:          start.Format("2006") + "-" + end.Format("2006") + " " + uniqueSuffix(),
+		StartDate:     start,
+		EndDate:       end,
+	}
+	Create(t, db, year)
+	return year
+}
+
+// Class creates a class with a unique name
+func Class(t testing.TB, db *gorm.DB, institutionID uuid.UUID) *models.Class {
+	t.Helper()
+
+	class := &models.Class{Name: "Class " + uniqueSuffix()}
+	class.InstitutionID = institutionID
+	Create(t, db, class)
+	return class
+}
+
+// Section creates a section in the class with a unique name
+func Section(t testing.TB, db *gorm.DB, classID uuid.UUID) *models.Section {
+	t.Helper()
+
+	section := &models.Section{ClassID: classID, Name: "Section " + uniqueSuffix()}
+	Create(t, db, section)
+	return section
+}
+
+// Subject creates a subject in the class with a unique name
+func Subject(t testing.TB, db *gorm.DB, institutionID uuid.UUID, classID *uuid.UUID) *models.Subject {
+	t.Helper()
+
+	subject := &models.Subject{ClassID: classID, Name: "Subject " + uniqueSuffix()}
+	subject.InstitutionID = institutionID
+	Create(t, db, subject)
+	return subject
+}
+
+// Student creates a student and its user in the institution, optionally in a
+// class and section
+func Student(t testing.TB, db *gorm.DB, institutionID uuid.UUID, classID, sectionID *uuid.UUID) *models.Student {
+	t.Helper()
+
+	user := User(t, db, models.RoleStudent, &institutionID)
+	student := &models.Student{UserID: user.ID, ClassID: classID, SectionID: sectionID}
+	student.InstitutionID = institutionID
+	Create(t, db, student)
+	student.User = user
+	return student
+}
+
+// Timetable creates a Monday 09:00-09:45 entry for the section with a new
+// subject and teacher
+func Timetable(t testing.TB, db *gorm.DB, institutionID, academicYearID, classID, sectionID uuid.UUID) *models.Timetable {
+	t.Helper()
+
+	entry := &models.Timetable{
+		AcademicYearID: academicYearID,
+		ClassID:        classID,
+		SectionID:      sectionID,
+		SubjectID:      Subject(t, db, institutionID, &classID).ID,
+		TeacherID:      Teacher(t, db, institutionID).ID,
+		DayOfWeek:      models.Monday,
+		WeekType:       models.WeekTypeAll,
+		StartTime:      "09:00",
+		EndTime:        "09:45",
+		IsActive:       true,
+	}
+	entry.InstitutionID = institutionID
+	Create(t, db, entry)
+	return entry
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CursorParams holds cursor pagination request parameters
+type CursorParams struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+// Cursor is the position of the last row of a page, ordered by (created_at, id)
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// CursorPagination holds cursor pagination information
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// NewCursorParams creates cursor params with validation
+func NewCursorParams(cursor string, limit int) CursorParams {
+	if limit < 1 {
+		limit = DefaultPerPage
+	}
+	if limit > maxPerPage {
+		limit = maxPerPage
+	}
+	return CursorParams{
+		Cursor: strings.TrimSpace(cursor),
+		Limit:  limit,
+	}
+}
+
+// Decode returns the position encoded in the cursor, or nil for the first page
+func (p CursorParams) Decode() (*Cursor, error) {
+	if p.Cursor == "" {
+		return nil, nil
+	}
+	return DecodeCursor(p.Cursor)
+}
+
+// EncodeCursor encodes a row position into an opaque cursor string
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidFieldFormat.Wrap(fmt.Errorf("invalid cursor"))
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidFieldFormat.Wrap(fmt.Errorf("invalid cursor"))
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, ErrInvalidFieldFormat.Wrap(fmt.Errorf("invalid cursor"))
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, ErrInvalidFieldFormat.Wrap(fmt.Errorf("invalid cursor"))
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// NewCursorPagination creates a CursorPagination object.
+// last is the position of the final row returned; it is ignored when there are no more rows.
+func NewCursorPagination(limit int, hasMore bool, last *Cursor) CursorPagination {
+	pagination := CursorPagination{
+		Limit:   limit,
+		HasMore: hasMore,
+	}
+	if hasMore && last != nil {
+		pagination.NextCursor = EncodeCursor(last.CreatedAt, last.ID)
+	}
+	return pagination
+}
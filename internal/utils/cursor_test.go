@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 26, 53, 589793000, time.FixedZone("BDT", 6*60*60))
+	id := uuid.New()
+
+	cursor, err := DecodeCursor(EncodeCursor(createdAt, id))
+	if err != nil {
+		t.Fatalf("DecodeCursor() unexpected error: %v", err)
+	}
+	if !cursor.CreatedAt.Equal(createdAt) || cursor.ID != id {
+		t.Errorf("DecodeCursor() = %v %s, want %v %s", cursor.CreatedAt, cursor.ID, createdAt, id)
+	}
+}
+
+func TestDecodeCursorRejectsMalformedInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "%%%"},
+		{name: "missing id", cursor: "MjAyNi0wMS0wMVQwMDowMDowMFo"},
+		{name: "bad time", cursor: EncodeCursor(time.Time{}, uuid.New())[4:]},
+		{name: "bad id", cursor: "MjAyNi0wMS0wMVQwMDowMDowMFp8bm90LWEtdXVpZA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); !hasCode(err, ErrInvalidFieldFormat) {
+				t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidFieldFormat", tt.cursor, err)
+			}
+		})
+	}
+}
+
+// hasCode reports whether err is an AppError with want's code, which also
+// matches errors built from want with Wrap
+func hasCode(err error, want *AppError) bool {
+	var appErr *AppError
+	return errors.As(err, &appErr) && appErr.Code == want.Code
+}
+
+func TestNewCursorParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+	}{
+		{name: "zero uses the default", limit: 0, wantLimit: DefaultPerPage},
+		{name: "negative uses the default", limit: -5, wantLimit: DefaultPerPage},
+		{name: "within range", limit: 25, wantLimit: 25},
+		{name: "clamped to the maximum", limit: maxPerPage + 1, wantLimit: maxPerPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := NewCursorParams("  abc  ", tt.limit)
+			if params.Limit != tt.wantLimit || params.Cursor != "abc" {
+				t.Errorf("NewCursorParams() = %+v, want limit %d and trimmed cursor", params, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestNewCursorPagination(t *testing.T) {
+	last := &Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+
+	if p := NewCursorPagination(10, false, last); p.NextCursor != "" || p.HasMore {
+		t.Errorf("NewCursorPagination() on the last page = %+v, want no next cursor", p)
+	}
+	if p := NewCursorPagination(10, true, last); p.NextCursor != EncodeCursor(last.CreatedAt, last.ID) || !p.HasMore {
+		t.Errorf("NewCursorPagination() = %+v, want the next cursor of the last row", p)
+	}
+}
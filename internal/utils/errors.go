@@ -1,8 +1,14 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 // AppError represents a structured application error
@@ -11,6 +17,9 @@ type AppError struct {
 	Message    string            `json:"error"`
 	StatusCode int               `json:"-"`
 	Details    map[string]string `json:"details,omitempty"`
+
+	base   string // English message before wrapping, used to look up translations
+	detail string // Context added by Wrap, appended to the translated message
 }
 
 // Error implements the error interface
@@ -20,31 +29,124 @@ func (e *AppError) Error() string {
 
 // NewAppError creates a new application error
 func NewAppError(code, message string, statusCode int) *AppError {
+	registerDefaultMessage(code, message)
 	return &AppError{
 		Code:       code,
 		Message:    message,
 		StatusCode: statusCode,
+		base:       message,
 	}
 }
 
 // NewAppErrorWithDetails creates a new application error with details
 func NewAppErrorWithDetails(code, message string, statusCode int, details map[string]string) *AppError {
+	registerDefaultMessage(code, message)
 	return &AppError{
 		Code:       code,
 		Message:    message,
 		StatusCode: statusCode,
 		Details:    details,
+		base:       message,
 	}
 }
 
 // Wrap wraps an error with additional context
 func (e *AppError) Wrap(err error) *AppError {
+	detail := err.Error()
+	if e.detail != "" {
+		detail = e.detail + ": " + detail
+	}
 	return &AppError{
 		Code:       e.Code,
 		Message:    fmt.Sprintf("%s: %v", e.Message, err),
 		StatusCode: e.StatusCode,
 		Details:    e.Details,
+		base:       e.base,
+		detail:     detail,
+	}
+}
+
+// Localize returns the error message in the given locale. Only errors whose
+// message is the default for their code are translated, since a code shared by
+// several messages has a single translation; anything else stays in English.
+func (e *AppError) Localize(locale string) string {
+	if e.base == "" || e.base != defaultMessage(e.Code) {
+		return e.Message
+	}
+	translated, ok := lookupMessage(locale, e.Code)
+	if !ok {
+		return e.Message
+	}
+	if e.detail != "" {
+		return translated + ": " + e.detail
+	}
+	return translated
+}
+
+// StatusForError returns the HTTP status a service error should be reported with.
+// An AppError carries its own status; one without falls back to its code family
+// (RES_001 is 404, AUTHZ_* is 403, SYS_* is 500). A missing record is a 404, and
+// any other plain error is a rule violation reported by a service, so a 400.
+func StatusForError(err error) int {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		if appErr.StatusCode != 0 {
+			return appErr.StatusCode
+		}
+		return statusForCode(appErr.Code)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+// statusForCode maps an error code to the default status of its family
+func statusForCode(code string) int {
+	switch {
+	case code == ErrResourceNotFound.Code, code == ErrUserNotFound.Code, code == ErrInstitutionNotFound.Code:
+		return http.StatusNotFound
+	case strings.HasPrefix(code, "AUTHZ_"):
+		return http.StatusForbidden
+	case strings.HasPrefix(code, "AUTH_"):
+		return http.StatusUnauthorized
+	case strings.HasPrefix(code, "SYS_"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// uniqueViolationCode is the Postgres SQLSTATE of a unique constraint violation
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation reports whether err is a Postgres unique constraint violation,
+// which the services' own duplicate checks miss when two requests race. Given
+// constraint or index names, only violations of one of them are reported.
+func IsUniqueViolation(err error, constraints ...string) bool {
+	var code, constraint string
+	var pgErr *pgconn.PgError
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &pgErr):
+		code, constraint = pgErr.Code, pgErr.ConstraintName
+	case errors.As(err, &pqErr):
+		code, constraint = string(pqErr.Code), pqErr.Constraint
+	default:
+		return false
+	}
+	if code != uniqueViolationCode {
+		return false
+	}
+	if len(constraints) == 0 {
+		return true
+	}
+	for _, name := range constraints {
+		if name == constraint {
+			return true
+		}
 	}
+	return false
 }
 
 // Authentication Errors (AUTH_xxx)
@@ -62,6 +164,13 @@ var (
 	ErrResetTokenExpired    = NewAppError("AUTH_011", "Password reset token has expired", http.StatusBadRequest)
 	ErrTooManyLoginAttempts = NewAppError("AUTH_012", "Too many login attempts, please try again later", http.StatusTooManyRequests)
 	ErrPasswordTooShort     = NewAppError("AUTH_009", "Password must be at least 8 characters", http.StatusBadRequest)
+	ErrEmailNotVerified     = NewAppError("AUTH_013", "Email address has not been verified", http.StatusForbidden)
+	ErrVerificationInvalid  = NewAppError("AUTH_014", "Email verification token is invalid", http.StatusBadRequest)
+	ErrVerificationExpired  = NewAppError("AUTH_015", "Email verification token has expired", http.StatusBadRequest)
+	ErrRefreshTokenReused   = NewAppError("AUTH_016", "Refresh token has already been used, session revoked", http.StatusUnauthorized)
+	ErrPasswordReused       = NewAppError("AUTH_017", "New password must differ from your recent passwords", http.StatusBadRequest)
+	ErrTokenRevoked         = NewAppError("AUTH_018", "Token has been revoked", http.StatusUnauthorized)
+	ErrMustChangePassword   = NewAppError("AUTH_019", "You must change your password before continuing", http.StatusForbidden)
 )
 
 // Authorization Errors (AUTHZ_xxx)
@@ -86,6 +195,16 @@ var (
 	ErrInvalidUUID          = NewAppError("VAL_009", "Invalid UUID format", http.StatusBadRequest)
 	ErrInvalidEnumValue     = NewAppError("VAL_010", "Invalid enum value", http.StatusBadRequest)
 	ErrUnprocessableEntity  = NewAppError("VAL_011", "Unprocessable entity", http.StatusUnprocessableEntity)
+	ErrInvalidTimeFormat    = NewAppError("VAL_012", "Invalid time format, expected HH:MM", http.StatusBadRequest)
+	ErrInvalidTimeRange     = NewAppError("VAL_013", "End time must be after start time", http.StatusBadRequest)
+	ErrFileTooLarge         = NewAppError("VAL_014", "Uploaded file is too large", http.StatusRequestEntityTooLarge)
+	ErrUnsupportedFileType  = NewAppError("VAL_015", "Unsupported file type", http.StatusUnsupportedMediaType)
+	ErrSelfPrerequisite     = NewAppError("VAL_016", "A subject cannot be its own prerequisite", http.StatusBadRequest)
+	ErrInvalidNoticeExpiry  = NewAppError("VAL_017", "Notice expiry must be after its publish time", http.StatusBadRequest)
+	ErrStudentNotInSection  = NewAppError("VAL_018", "Student does not belong to the section", http.StatusBadRequest)
+	ErrSectionNotInClass    = NewAppError("VAL_019", "Section does not belong to the class", http.StatusBadRequest)
+	ErrOffPeriodTime        = NewAppError("VAL_020", "Time does not match the institution's period schedule", http.StatusBadRequest)
+	ErrInvalidLeaveRange    = NewAppError("VAL_021", "Leave must end on or after the day it starts", http.StatusBadRequest)
 )
 
 // Resource Errors (RES_xxx)
@@ -97,6 +216,15 @@ var (
 	ErrResourceInUse         = NewAppError("RES_004", "Resource is in use and cannot be deleted", http.StatusBadRequest)
 	ErrResourceLimitExceeded = NewAppError("RES_005", "Resource limit exceeded", http.StatusBadRequest)
 	ErrInvalidResourceState  = NewAppError("RES_006", "Invalid resource state", http.StatusBadRequest)
+	ErrScheduleConflict      = NewAppError("RES_007", "Scheduling conflict detected: teacher, section, or room is already occupied at this time", http.StatusConflict)
+	ErrCapacityExceeded      = NewAppError("RES_008", "Class or section capacity exceeded", http.StatusConflict)
+	ErrRollNumberTaken       = NewAppError("RES_009", "Roll number is already taken in this class or section", http.StatusConflict)
+	ErrPrerequisiteCycle     = NewAppError("RES_010", "Prerequisite would create a cycle", http.StatusConflict)
+	ErrAttendanceOnHoliday   = NewAppError("RES_011", "Attendance cannot be marked on a holiday", http.StatusConflict)
+	ErrVersionConflict       = NewAppError("RES_012", "The resource was modified by someone else, reload it and try again", http.StatusConflict)
+	ErrIdempotencyKeyReused  = NewAppError("RES_013", "Idempotency key was already used for a different request", http.StatusConflict)
+	ErrIdempotencyInProgress = NewAppError("RES_014", "A request with this idempotency key is still being processed", http.StatusConflict)
+	ErrOverlappingLeave      = NewAppError("RES_015", "You already have a pending leave application for these dates", http.StatusConflict)
 )
 
 // User Management Errors (USER_xxx)
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+func TestStatusForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "RES_001", err: ErrResourceNotFound, want: http.StatusNotFound},
+		{name: "RES_001 without a status", err: &AppError{Code: "RES_001"}, want: http.StatusNotFound},
+		{name: "user not found without a status", err: &AppError{Code: ErrUserNotFound.Code}, want: http.StatusNotFound},
+		{name: "AUTHZ_001", err: ErrInsufficientPermissions, want: http.StatusForbidden},
+		{name: "cross tenant access", err: ErrCrossTenantAccess, want: http.StatusForbidden},
+		{name: "AUTHZ_* without a status", err: &AppError{Code: "AUTHZ_099"}, want: http.StatusForbidden},
+		{name: "AUTH_* without a status", err: &AppError{Code: "AUTH_099"}, want: http.StatusUnauthorized},
+		{name: "SYS_001", err: ErrInternalServer, want: http.StatusInternalServerError},
+		{name: "wrapped SYS_003", err: ErrDatabaseError.Wrap(errors.New("connection reset")), want: http.StatusInternalServerError},
+		{name: "SYS_* without a status", err: &AppError{Code: "SYS_099"}, want: http.StatusInternalServerError},
+		{name: "explicit status wins over the family", err: ErrRateLimitExceeded, want: http.StatusTooManyRequests},
+		{name: "app error wrapped with fmt", err: fmt.Errorf("loading class: %w", ErrResourceNotFound), want: http.StatusNotFound},
+		{name: "unknown family", err: &AppError{Code: "VAL_099"}, want: http.StatusBadRequest},
+		{name: "record not found", err: gorm.ErrRecordNotFound, want: http.StatusNotFound},
+		{name: "plain error", err: errors.New("class is full"), want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusForError(tt.err); got != tt.want {
+				t.Errorf("StatusForError(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondServiceError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "not found", err: ErrResourceNotFound, wantStatus: http.StatusNotFound, wantCode: "RES_001"},
+		{name: "forbidden", err: ErrCrossTenantAccess, wantStatus: http.StatusForbidden, wantCode: ErrCrossTenantAccess.Code},
+		{name: "internal", err: ErrInternalServer.Wrap(errors.New("boom")), wantStatus: http.StatusInternalServerError, wantCode: "SYS_001"},
+		{name: "plain error", err: errors.New("class is full"), wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+			RespondServiceError(c, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("RespondServiceError() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if body.Success || body.Code != tt.wantCode {
+				t.Errorf("RespondServiceError() body = %+v, want code %q", body, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	pqUnique := &pq.Error{Code: "23505", Constraint: "idx_users_email"}
+	pgxUnique := &pgconn.PgError{Code: "23505", ConstraintName: "institutions_code_key"}
+
+	tests := []struct {
+		name        string
+		err         error
+		constraints []string
+		want        bool
+	}{
+		{name: "pq unique violation", err: pqUnique, want: true},
+		{name: "pgx unique violation", err: pgxUnique, want: true},
+		{name: "wrapped pq unique violation", err: fmt.Errorf("create user: %w", pqUnique), want: true},
+		{name: "matching constraint", err: pqUnique, constraints: []string{"other_key", "idx_users_email"}, want: true},
+		{name: "pgx matching constraint", err: pgxUnique, constraints: []string{"institutions_code_key"}, want: true},
+		{name: "other constraint", err: pqUnique, constraints: []string{"institutions_code_key"}, want: false},
+		{name: "foreign key violation", err: &pq.Error{Code: "23503", Constraint: "idx_users_email"}, want: false},
+		{name: "pgx not null violation", err: &pgconn.PgError{Code: "23502"}, want: false},
+		{name: "plain error", err: errors.New("duplicate key value violates unique constraint"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUniqueViolation(tt.err, tt.constraints...); got != tt.want {
+				t.Errorf("IsUniqueViolation(%v, %v) = %v, want %v", tt.err, tt.constraints, got, tt.want)
+			}
+		})
+	}
+}
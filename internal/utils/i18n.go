@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the locale of the messages defined in errors.go
+const DefaultLocale = "en"
+
+var (
+	// defaultMessages maps each error code to the first English message registered for it
+	defaultMessages = make(map[string]string)
+
+	// catalogs maps a locale to translated messages keyed by error code
+	catalogs = map[string]map[string]string{
+		"bn": bengaliMessages,
+	}
+	catalogsMu sync.RWMutex
+
+	// institutionLocale resolves the locale configured for the caller's institution
+	institutionLocale func(c *gin.Context) string
+)
+
+// RegisterLocale adds translated error messages, keyed by error code, for a
+// locale such as "fr" or "pt-BR". Registering an existing locale merges the
+// messages into it. Intended to be called at startup.
+func RegisterLocale(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return
+	}
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		catalogs[locale] = catalog
+	}
+	for code, message := range messages {
+		catalog[code] = message
+	}
+}
+
+// SetInstitutionLocaleResolver sets how the institution locale of a request is
+// found. It is only consulted when an error response is sent without a
+// supported Accept-Language header.
+func SetInstitutionLocaleResolver(resolver func(c *gin.Context) string) {
+	institutionLocale = resolver
+}
+
+// RequestLocale returns the locale for messages sent to a request: the first
+// supported language of the Accept-Language header, else the institution's
+// locale, else DefaultLocale
+func RequestLocale(c *gin.Context) string {
+	if header := c.GetHeader("Accept-Language"); header != "" {
+		if tags, _, err := language.ParseAcceptLanguage(header); err == nil {
+			for _, tag := range tags {
+				if locale, ok := supportedLocale(tag.String()); ok {
+					return locale
+				}
+			}
+		}
+	}
+
+	if institutionLocale != nil {
+		if locale, ok := supportedLocale(institutionLocale(c)); ok {
+			return locale
+		}
+	}
+
+	return DefaultLocale
+}
+
+// supportedLocale matches a requested locale against the registered catalogs,
+// trying the full tag first and then its base language ("bn-BD" -> "bn")
+func supportedLocale(requested string) (string, bool) {
+	locale := normalizeLocale(requested)
+	if locale == "" {
+		return "", false
+	}
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	if locale == DefaultLocale || strings.HasPrefix(locale, DefaultLocale+"-") {
+		return DefaultLocale, true
+	}
+	if _, ok := catalogs[locale]; ok {
+		return locale, true
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if _, ok := catalogs[base]; ok {
+			return base, true
+		}
+	}
+	return "", false
+}
+
+// lookupMessage returns the translation of an error code in a locale
+func lookupMessage(locale, code string) (string, bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	message, ok := catalogs[locale][code]
+	return message, ok
+}
+
+// registerDefaultMessage records the English message of an error code
+func registerDefaultMessage(code, message string) {
+	if _, ok := defaultMessages[code]; !ok {
+		defaultMessages[code] = message
+	}
+}
+
+// defaultMessage returns the English message of an error code
+func defaultMessage(code string) string {
+	return defaultMessages[code]
+}
+
+// normalizeLocale lowercases a locale and uses "-" as the separator ("pt_BR" -> "pt-br")
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newLocaleContext returns a test context for a request with the given Accept-Language header
+func newLocaleContext(acceptLanguage string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptLanguage != "" {
+		c.Request.Header.Set("Accept-Language", acceptLanguage)
+	}
+	return c, w
+}
+
+// useInstitutionLocale sets the institution locale resolver for the rest of the test
+func useInstitutionLocale(t *testing.T, locale string) {
+	t.Helper()
+
+	previous := institutionLocale
+	SetInstitutionLocaleResolver(func(*gin.Context) string { return locale })
+	t.Cleanup(func() {
+		institutionLocale = previous
+	})
+}
+
+func TestRequestLocale(t *testing.T) {
+	tests := []struct {
+		name              string
+		acceptLanguage    string
+		institutionLocale string
+		want              string
+	}{
+		{name: "no preference", want: DefaultLocale},
+		{name: "supported language", acceptLanguage: "bn", want: "bn"},
+		{name: "regional variant", acceptLanguage: "bn-BD", want: "bn"},
+		{name: "English variant", acceptLanguage: "en-GB", want: DefaultLocale},
+		{name: "first supported by quality", acceptLanguage: "fr-FR;q=0.9, bn;q=0.8, en;q=0.5", want: "bn"},
+		{name: "unknown locale", acceptLanguage: "xx-YY", want: DefaultLocale},
+		{name: "malformed header", acceptLanguage: "@@@;q=abc", want: DefaultLocale},
+		{name: "institution locale", institutionLocale: "bn", want: "bn"},
+		{name: "header wins over institution", acceptLanguage: "en", institutionLocale: "bn", want: DefaultLocale},
+		{name: "unknown institution locale", institutionLocale: "zz", want: DefaultLocale},
+		{name: "unknown header falls back to institution", acceptLanguage: "xx", institutionLocale: "bn_BD", want: "bn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useInstitutionLocale(t, tt.institutionLocale)
+			c, _ := newLocaleContext(tt.acceptLanguage)
+			if got := RequestLocale(c); got != tt.want {
+				t.Errorf("RequestLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorLocalizesMessage(t *testing.T) {
+	useInstitutionLocale(t, "")
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		err            error
+		wantMessage    string
+	}{
+		{name: "English", acceptLanguage: "en", err: ErrUserNotFound, wantMessage: ErrUserNotFound.Message},
+		{name: "Bengali", acceptLanguage: "bn", err: ErrUserNotFound, wantMessage: bengaliMessages[ErrUserNotFound.Code]},
+		{name: "unknown locale falls back to English", acceptLanguage: "xx-YY", err: ErrUserNotFound, wantMessage: ErrUserNotFound.Message},
+		{name: "wrapped detail is kept", acceptLanguage: "bn", err: ErrUserNotFound.Wrap(errors.New("id 42")),
+			wantMessage: bengaliMessages[ErrUserNotFound.Code] + ": id 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := newLocaleContext(tt.acceptLanguage)
+			Error(c, http.StatusBadRequest, tt.err)
+
+			var resp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Error != tt.wantMessage {
+				t.Errorf("Error() message = %q, want %q", resp.Error, tt.wantMessage)
+			}
+			if resp.Code != ErrUserNotFound.Code {
+				t.Errorf("Error() code = %q, want %q", resp.Code, ErrUserNotFound.Code)
+			}
+			if w.Code != http.StatusNotFound {
+				t.Errorf("Error() status = %d, want %d", w.Code, http.StatusNotFound)
+			}
+		})
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	useInstitutionLocale(t, "")
+	RegisterLocale("fr_FR", map[string]string{ErrUserNotFound.Code: "Utilisateur introuvable"})
+	t.Cleanup(func() {
+		catalogsMu.Lock()
+		delete(catalogs, "fr-fr")
+		catalogsMu.Unlock()
+	})
+
+	c, _ := newLocaleContext("fr-FR")
+	if locale := RequestLocale(c); locale != "fr-fr" {
+		t.Fatalf("RequestLocale() = %q, want %q", locale, "fr-fr")
+	}
+	if got := ErrUserNotFound.Localize("fr-fr"); got != "Utilisateur introuvable" {
+		t.Errorf("Localize() = %q, want the registered translation", got)
+	}
+
+	// Codes missing from the catalog stay in English
+	if got := ErrInvalidCredentials.Localize("fr-fr"); got != ErrInvalidCredentials.Message {
+		t.Errorf("Localize() of an untranslated code = %q, want %q", got, ErrInvalidCredentials.Message)
+	}
+}
@@ -8,13 +8,22 @@ import (
 	"github.com/google/uuid"
 )
 
+func init() {
+	// Token revocation compares issue times with the revocation time, which
+	// needs better than the library's default one-second precision
+	jwt.TimePrecision = time.Millisecond
+}
+
 // Claims represents the JWT claims structure
 type Claims struct {
-	UserID        uuid.UUID `json:"user_id"`
-	Email         string    `json:"email"`
-	Role          string    `json:"role"`
-	InstitutionID string    `json:"institution_id,omitempty"`
-	Permissions   []string  `json:"permissions,omitempty"`
+	UserID        uuid.UUID  `json:"user_id"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	CustomRoleID  *uuid.UUID `json:"custom_role_id,omitempty"`
+	InstitutionID string     `json:"institution_id,omitempty"`
+	Permissions   []string   `json:"permissions,omitempty"`
+	// MustChangePassword marks a token issued while the user had to change their password
+	MustChangePassword bool `json:"must_change_password,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -26,42 +35,97 @@ const (
 	RefreshToken TokenType = "refresh"
 )
 
-// JWTManager handles JWT operations
+// JWTManager handles JWT operations.
+// Tokens are signed with the current key and carry its ID in the kid header;
+// any configured key can verify, so old keys can be phased out without logging everyone out.
 type JWTManager struct {
-	secret        []byte
+	keys          map[string][]byte // Key ID -> secret; "" verifies tokens without a kid header
+	currentKID    string
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 }
 
-// NewJWTManager creates a new JWT manager
+// NewJWTManager creates a JWT manager with a single secret and no key IDs
 func NewJWTManager(secret string, accessExpiry, refreshExpiry time.Duration) *JWTManager {
+	return NewJWTManagerWithKeys(map[string]string{"": secret}, "", accessExpiry, refreshExpiry)
+}
+
+// NewJWTManagerWithKeys creates a JWT manager that signs with the key currentKID
+// and verifies with any key in keys. A key with an empty ID verifies tokens
+// issued before key IDs were introduced.
+func NewJWTManagerWithKeys(keys map[string]string, currentKID string, accessExpiry, refreshExpiry time.Duration) *JWTManager {
+	secrets := make(map[string][]byte, len(keys))
+	for kid, secret := range keys {
+		secrets[kid] = []byte(secret)
+	}
 	return &JWTManager{
-		secret:        []byte(secret),
+		keys:          secrets,
+		currentKID:    currentKID,
 		accessExpiry:  accessExpiry,
 		refreshExpiry: refreshExpiry,
 	}
 }
 
+// AccessExpiry returns how long access tokens are valid
+func (m *JWTManager) AccessExpiry() time.Duration {
+	return m.accessExpiry
+}
+
+// sign signs claims with the current key, setting the kid header when keys have IDs
+func (m *JWTManager) sign(claims jwt.Claims) (string, error) {
+	secret, ok := m.keys[m.currentKID]
+	if !ok || len(secret) == 0 {
+		return "", errors.New("no secret configured for the current signing key")
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if m.currentKID != "" {
+		token.Header["kid"] = m.currentKID
+	}
+	return token.SignedString(secret)
+}
+
+// verificationKey selects the secret to verify a token with from its kid header.
+// Tokens without a kid use the key with an empty ID, or the only key when just one is configured.
+func (m *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, errors.New("unexpected signing method")
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if secret, ok := m.keys[kid]; ok && len(secret) > 0 {
+		return secret, nil
+	}
+	if kid == "" && len(m.keys) == 1 {
+		for _, secret := range m.keys {
+			return secret, nil
+		}
+	}
+	return nil, errors.New("unknown signing key")
+}
+
 // GenerateAccessToken generates a new access token
-func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email, role, institutionID string, permissions []string) (string, time.Time, error) {
+func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email, role string, customRoleID *uuid.UUID, institutionID string, permissions []string, mustChangePassword bool) (string, time.Time, error) {
 	expiresAt := time.Now().Add(m.accessExpiry)
 
 	claims := &Claims{
-		UserID:        userID,
-		Email:         email,
-		Role:          role,
-		InstitutionID: institutionID,
-		Permissions:   permissions,
+		UserID:             userID,
+		Email:              email,
+		Role:               role,
+		CustomRoleID:       customRoleID,
+		InstitutionID:      institutionID,
+		Permissions:        permissions,
+		MustChangePassword: mustChangePassword,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   userID.String(),
 			Issuer:    "campus-core",
+			ID:        uuid.New().String(),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(m.secret)
+	tokenString, err := m.sign(claims)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -70,34 +134,30 @@ func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email, role, institut
 }
 
 // GenerateRefreshToken generates a new refresh token
-func (m *JWTManager) GenerateRefreshToken(userID uuid.UUID) (string, time.Time, error) {
+// Returns the signed token, its JWT ID and expiry
+func (m *JWTManager) GenerateRefreshToken(userID uuid.UUID) (string, string, time.Time, error) {
 	expiresAt := time.Now().Add(m.refreshExpiry)
+	jti := uuid.New().String()
 
 	claims := &jwt.RegisteredClaims{
 		ExpiresAt: jwt.NewNumericDate(expiresAt),
 		IssuedAt:  jwt.NewNumericDate(time.Now()),
 		Subject:   userID.String(),
 		Issuer:    "campus-core",
-		ID:        uuid.New().String(),
+		ID:        jti,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(m.secret)
+	tokenString, err := m.sign(claims)
 	if err != nil {
-		return "", time.Time{}, err
+		return "", "", time.Time{}, err
 	}
 
-	return tokenString, expiresAt, nil
+	return tokenString, jti, expiresAt, nil
 }
 
 // ValidateAccessToken validates and parses an access token
 func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return m.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.verificationKey)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -115,32 +175,28 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (*Claims, error) {
 }
 
 // ValidateRefreshToken validates and parses a refresh token
-func (m *JWTManager) ValidateRefreshToken(tokenString string) (uuid.UUID, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return m.secret, nil
-	})
+// Returns the user ID and the token's JWT ID
+func (m *JWTManager) ValidateRefreshToken(tokenString string) (uuid.UUID, string, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, m.verificationKey)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return uuid.Nil, ErrRefreshTokenExpired
+			return uuid.Nil, "", ErrRefreshTokenExpired
 		}
-		return uuid.Nil, ErrRefreshTokenInvalid
+		return uuid.Nil, "", ErrRefreshTokenInvalid
 	}
 
 	claims, ok := token.Claims.(*jwt.RegisteredClaims)
-	if !ok || !token.Valid {
-		return uuid.Nil, ErrRefreshTokenInvalid
+	if !ok || !token.Valid || claims.ID == "" {
+		return uuid.Nil, "", ErrRefreshTokenInvalid
 	}
 
 	userID, err := uuid.Parse(claims.Subject)
 	if err != nil {
-		return uuid.Nil, ErrRefreshTokenInvalid
+		return uuid.Nil, "", ErrRefreshTokenInvalid
 	}
 
-	return userID, nil
+	return userID, claims.ID, nil
 }
 
 // GenerateResetToken generates a password reset token
@@ -155,8 +211,7 @@ func (m *JWTManager) GenerateResetToken(userID uuid.UUID, email string) (string,
 		ID:        uuid.New().String(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(m.secret)
+	tokenString, err := m.sign(claims)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -166,12 +221,7 @@ func (m *JWTManager) GenerateResetToken(userID uuid.UUID, email string) (string,
 
 // ValidateResetToken validates a password reset token
 func (m *JWTManager) ValidateResetToken(tokenString string) (uuid.UUID, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return m.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, m.verificationKey)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -192,3 +242,48 @@ func (m *JWTManager) ValidateResetToken(tokenString string) (uuid.UUID, error) {
 
 	return userID, nil
 }
+
+// GenerateVerificationToken generates an email verification token
+func (m *JWTManager) GenerateVerificationToken(userID uuid.UUID, email string) (string, time.Time, error) {
+	expiresAt := time.Now().Add(24 * time.Hour) // Verification token valid for 24 hours
+
+	claims := &jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		Subject:   userID.String(),
+		Issuer:    "campus-core-verify",
+		Audience:  jwt.ClaimStrings{email},
+		ID:        uuid.New().String(),
+	}
+
+	tokenString, err := m.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// ValidateVerificationToken validates an email verification token
+func (m *JWTManager) ValidateVerificationToken(tokenString string) (uuid.UUID, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.RegisteredClaims{}, m.verificationKey)
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return uuid.Nil, ErrVerificationExpired
+		}
+		return uuid.Nil, ErrVerificationInvalid
+	}
+
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || !token.Valid || claims.Issuer != "campus-core-verify" {
+		return uuid.Nil, ErrVerificationInvalid
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, ErrVerificationInvalid
+	}
+
+	return userID, nil
+}
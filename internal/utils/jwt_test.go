@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestJWTManagerKeyRotation(t *testing.T) {
+	userID := uuid.New()
+	legacy := NewJWTManager("legacy-secret", time.Hour, 24*time.Hour)
+	retiring := NewJWTManagerWithKeys(map[string]string{"2025": "old-secret"}, "2025", time.Hour, 24*time.Hour)
+
+	// The 2025 key is retired for signing but still verifies, as does the legacy secret
+	current := NewJWTManagerWithKeys(map[string]string{
+		"":     "legacy-secret",
+		"2025": "old-secret",
+		"2026": "new-secret",
+	}, "2026", time.Hour, 24*time.Hour)
+
+	// Once the 2025 key is dropped its tokens no longer verify
+	rotated := NewJWTManagerWithKeys(map[string]string{"2026": "new-secret"}, "2026", time.Hour, 24*time.Hour)
+
+	accessToken := func(t *testing.T, m *JWTManager) string {
+		t.Helper()
+		token, _, err := m.GenerateAccessToken(userID, "user@example.com", "teacher", nil, "", nil, false)
+		if err != nil {
+			t.Fatalf("GenerateAccessToken() unexpected error: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		signer  *JWTManager
+		verify  *JWTManager
+		wantErr error
+	}{
+		{name: "current key", signer: current, verify: current},
+		{name: "retired but still configured key", signer: retiring, verify: current},
+		{name: "legacy token without kid", signer: legacy, verify: current},
+		{name: "single legacy secret", signer: legacy, verify: legacy},
+		{name: "removed key", signer: retiring, verify: rotated, wantErr: ErrTokenInvalid},
+		{name: "legacy token after the legacy secret is removed", signer: legacy, verify: rotated, wantErr: ErrTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.verify.ValidateAccessToken(accessToken(t, tt.signer))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ValidateAccessToken() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateAccessToken() unexpected error: %v", err)
+			}
+			if claims.UserID != userID {
+				t.Errorf("ValidateAccessToken() user = %s, want %s", claims.UserID, userID)
+			}
+		})
+	}
+
+	t.Run("refresh token signed with the retired key", func(t *testing.T) {
+		token, jti, _, err := retiring.GenerateRefreshToken(userID)
+		if err != nil {
+			t.Fatalf("GenerateRefreshToken() unexpected error: %v", err)
+		}
+		gotUser, gotJTI, err := current.ValidateRefreshToken(token)
+		if err != nil {
+			t.Fatalf("ValidateRefreshToken() unexpected error: %v", err)
+		}
+		if gotUser != userID || gotJTI != jti {
+			t.Errorf("ValidateRefreshToken() = %s, %s, want %s, %s", gotUser, gotJTI, userID, jti)
+		}
+	})
+
+	t.Run("new tokens carry the current kid", func(t *testing.T) {
+		token, _, err := jwt.NewParser().ParseUnverified(accessToken(t, current), &Claims{})
+		if err != nil {
+			t.Fatalf("ParseUnverified() unexpected error: %v", err)
+		}
+		if kid := token.Header["kid"]; kid != "2026" {
+			t.Errorf("kid header = %v, want 2026", kid)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package utils
+
+// bengaliMessages translates the error messages into Bengali
+var bengaliMessages = map[string]string{
+	// Authentication
+	"AUTH_001": "ভুল লগইন তথ্য",
+	"AUTH_002": "টোকেনের মেয়াদ শেষ হয়েছে",
+	"AUTH_003": "টোকেনটি সঠিক নয়",
+	"AUTH_004": "অনুমোদন টোকেন প্রয়োজন",
+	"AUTH_005": "রিফ্রেশ টোকেনের মেয়াদ শেষ হয়েছে",
+	"AUTH_006": "রিফ্রেশ টোকেনটি সঠিক নয়",
+	"AUTH_007": "অ্যাকাউন্টটি নিষ্ক্রিয় করা হয়েছে",
+	"AUTH_008": "অ্যাকাউন্টটি লক করা হয়েছে",
+	"AUTH_009": "পাসওয়ার্ড প্রয়োজনীয় শর্ত পূরণ করে না",
+	"AUTH_010": "পাসওয়ার্ড রিসেট টোকেনটি সঠিক নয়",
+	"AUTH_011": "পাসওয়ার্ড রিসেট টোকেনের মেয়াদ শেষ হয়েছে",
+	"AUTH_012": "অনেকবার লগইনের চেষ্টা করা হয়েছে, কিছুক্ষণ পরে আবার চেষ্টা করুন",
+	"AUTH_013": "ইমেইল ঠিকানা যাচাই করা হয়নি",
+	"AUTH_014": "ইমেইল যাচাইকরণ টোকেনটি সঠিক নয়",
+	"AUTH_015": "ইমেইল যাচাইকরণ টোকেনের মেয়াদ শেষ হয়েছে",
+	"AUTH_016": "রিফ্রেশ টোকেনটি আগেই ব্যবহৃত হয়েছে, সেশন বাতিল করা হয়েছে",
+	"AUTH_017": "নতুন পাসওয়ার্ড সাম্প্রতিক পাসওয়ার্ডগুলো থেকে ভিন্ন হতে হবে",
+	"AUTH_018": "টোকেনটি বাতিল করা হয়েছে",
+	"AUTH_019": "চালিয়ে যাওয়ার আগে আপনার পাসওয়ার্ড পরিবর্তন করুন",
+
+	// Authorization
+	"AUTHZ_001": "পর্যাপ্ত অনুমতি নেই",
+	"AUTHZ_002": "এই কাজের জন্য ভূমিকাটি অনুমোদিত নয়",
+	"AUTHZ_003": "রিসোর্সে প্রবেশাধিকার নেই",
+	"AUTHZ_004": "আপনার ভূমিকার জন্য এই কাজটি অনুমোদিত নয়",
+	"AUTHZ_005": "অন্য প্রতিষ্ঠানের তথ্যে প্রবেশাধিকার নেই",
+
+	// Validation
+	"VAL_001": "প্রয়োজনীয় তথ্য অনুপস্থিত",
+	"VAL_002": "তথ্যের ফরম্যাট সঠিক নয়",
+	"VAL_003": "মান অনুমোদিত সীমার বাইরে",
+	"VAL_004": "তারিখের ফরম্যাট সঠিক নয়",
+	"VAL_005": "ইমেইলের ফরম্যাট সঠিক নয়",
+	"VAL_006": "ফোন নম্বরের ফরম্যাট সঠিক নয়",
+	"VAL_007": "মানটি অনেক দীর্ঘ",
+	"VAL_008": "মানটি অনেক ছোট",
+	"VAL_009": "UUID ফরম্যাট সঠিক নয়",
+	"VAL_010": "অগ্রহণযোগ্য মান",
+	"VAL_011": "অনুরোধটি প্রক্রিয়া করা যায়নি",
+	"VAL_012": "সময়ের ফরম্যাট সঠিক নয়, HH:MM প্রত্যাশিত",
+	"VAL_013": "শেষের সময় শুরুর সময়ের পরে হতে হবে",
+	"VAL_014": "আপলোড করা ফাইলটি অনেক বড়",
+	"VAL_015": "ফাইলের ধরন সমর্থিত নয়",
+	"VAL_016": "কোনো বিষয় নিজের পূর্বশর্ত হতে পারে না",
+	"VAL_017": "নোটিশের মেয়াদ প্রকাশের সময়ের পরে শেষ হতে হবে",
+	"VAL_018": "শিক্ষার্থী এই সেকশনের অন্তর্ভুক্ত নয়",
+	"VAL_019": "সেকশনটি এই ক্লাসের অন্তর্ভুক্ত নয়",
+	"VAL_020": "সময়টি প্রতিষ্ঠানের পিরিয়ড সূচির সাথে মেলে না",
+	"VAL_021": "ছুটি শুরুর দিনে বা তার পরে শেষ হতে হবে",
+
+	// Resources
+	"RES_001": "রিসোর্স পাওয়া যায়নি",
+	"RES_002": "রিসোর্সটি আগে থেকেই বিদ্যমান",
+	"RES_003": "ডুপ্লিকেট এন্ট্রি",
+	"RES_004": "রিসোর্সটি ব্যবহৃত হচ্ছে, মুছে ফেলা যাবে না",
+	"RES_005": "রিসোর্সের সীমা অতিক্রম করেছে",
+	"RES_006": "রিসোর্সের অবস্থা সঠিক নয়",
+	"RES_007": "সময়সূচিতে সংঘাত: শিক্ষক, সেকশন বা কক্ষ এই সময়ে ব্যস্ত",
+	"RES_008": "ক্লাস বা সেকশনের ধারণক্ষমতা অতিক্রম করেছে",
+	"RES_009": "এই ক্লাস বা সেকশনে রোল নম্বরটি আগেই ব্যবহৃত হয়েছে",
+	"RES_010": "এই পূর্বশর্তটি একটি চক্র তৈরি করবে",
+	"RES_011": "ছুটির দিনে উপস্থিতি নেওয়া যাবে না",
+	"RES_012": "অন্য কেউ এটি পরিবর্তন করেছে, আবার লোড করে চেষ্টা করুন",
+	"RES_013": "এই আইডেমপোটেন্সি কী অন্য একটি অনুরোধে আগেই ব্যবহৃত হয়েছে",
+	"RES_014": "এই আইডেমপোটেন্সি কী সহ একটি অনুরোধ এখনও প্রক্রিয়াধীন",
+	"RES_015": "এই তারিখগুলোর জন্য আপনার একটি ছুটির আবেদন আগেই অপেক্ষমাণ আছে",
+
+	// Users
+	"USER_001": "ব্যবহারকারী পাওয়া যায়নি",
+	"USER_002": "ইমেইলটি আগেই নিবন্ধিত",
+	"USER_003": "ফোন নম্বরটি আগেই নিবন্ধিত",
+	"USER_004": "ভূমিকা নির্ধারণ সঠিক নয়",
+	"USER_005": "নিজের অ্যাকাউন্ট মুছে ফেলা যাবে না",
+	"USER_006": "শেষ অ্যাডমিনকে নিষ্ক্রিয় করা যাবে না",
+	"USER_007": "অভিভাবক-শিক্ষার্থী সংযোগ সঠিক নয়",
+
+	// Institutions
+	"INST_001": "প্রতিষ্ঠান পাওয়া যায়নি",
+	"INST_002": "প্রতিষ্ঠানের কোডটি আগেই বিদ্যমান",
+	"INST_003": "প্রতিষ্ঠানটি নিষ্ক্রিয়",
+	"INST_004": "X-Institution-ID হেডার প্রয়োজন",
+	"INST_005": "ব্যবহারকারী এই প্রতিষ্ঠানের সদস্য নন",
+
+	// System
+	"SYS_001": "সার্ভারে অভ্যন্তরীণ ত্রুটি",
+	"SYS_002": "সেবাটি সাময়িকভাবে অনুপলব্ধ",
+	"SYS_003": "ডাটাবেস ত্রুটি",
+	"SYS_004": "ক্যাশ ত্রুটি",
+	"SYS_005": "অনুরোধের সীমা অতিক্রম করেছে",
+	"SYS_006": "WebSocket সংযোগে ত্রুটি",
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"fmt"
 	"math"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -16,28 +18,50 @@ type Pagination struct {
 
 // PaginationParams holds pagination request parameters
 type PaginationParams struct {
-	Page    int
-	PerPage int
+	Page      int    `form:"page"`
+	PerPage   int    `form:"per_page"`
+	SortBy    string `form:"sort_by"`
+	SortOrder string `form:"order"`
+}
+
+// DefaultPerPage is the page size used when the client doesn't ask for one
+const DefaultPerPage = 20
+
+// maxPerPage caps the page size clients can request
+var maxPerPage = 100
+
+// SetMaxPerPage sets the largest page size clients can request; non-positive values are ignored
+func SetMaxPerPage(max int) {
+	if max > 0 {
+		maxPerPage = max
+	}
+}
+
+// MaxPerPage returns the largest page size clients can request
+func MaxPerPage() int {
+	return maxPerPage
 }
 
 // DefaultPagination returns default pagination parameters
 func DefaultPagination() PaginationParams {
 	return PaginationParams{
 		Page:    1,
-		PerPage: 20,
+		PerPage: DefaultPerPage,
 	}
 }
 
-// NewPaginationParams creates pagination params with validation
+// NewPaginationParams creates pagination params with validation.
+// Pages below 1 become 1 and the page size is clamped to MaxPerPage;
+// the clamped values are what the response reports back.
 func NewPaginationParams(page, perPage int) PaginationParams {
 	if page < 1 {
 		page = 1
 	}
 	if perPage < 1 {
-		perPage = 20
+		perPage = DefaultPerPage
 	}
-	if perPage > 100 {
-		perPage = 100 // Max limit
+	if perPage > maxPerPage {
+		perPage = maxPerPage
 	}
 	return PaginationParams{
 		Page:    page,
@@ -45,6 +69,13 @@ func NewPaginationParams(page, perPage int) PaginationParams {
 	}
 }
 
+// WithSort returns a copy of the params with the requested sort applied
+func (p PaginationParams) WithSort(sortBy, sortOrder string) PaginationParams {
+	p.SortBy = strings.TrimSpace(sortBy)
+	p.SortOrder = strings.ToLower(strings.TrimSpace(sortOrder))
+	return p
+}
+
 // GetOffset returns the offset for database queries
 func (p PaginationParams) GetOffset() int {
 	return (p.Page - 1) * p.PerPage
@@ -77,6 +108,32 @@ func Paginate(params PaginationParams) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// ApplySort orders the query by the requested sort column.
+// allowedColumns maps the public sort_by names to SQL columns, so only whitelisted
+// columns ever reach the query. When no sort is requested the query is returned
+// unchanged and the caller's default ordering applies.
+func ApplySort(query *gorm.DB, allowedColumns map[string]string, params PaginationParams) (*gorm.DB, error) {
+	if params.SortBy == "" {
+		return query, nil
+	}
+
+	column, ok := allowedColumns[params.SortBy]
+	if !ok {
+		return nil, ErrInvalidFieldFormat.Wrap(fmt.Errorf("unsupported sort_by %q", params.SortBy))
+	}
+
+	direction := "ASC"
+	switch strings.ToLower(params.SortOrder) {
+	case "", "asc":
+	case "desc":
+		direction = "DESC"
+	default:
+		return nil, ErrInvalidFieldFormat.Wrap(fmt.Errorf("order must be asc or desc"))
+	}
+
+	return query.Order(column + " " + direction), nil
+}
+
 // CountAndPaginate counts total records and applies pagination
 func CountAndPaginate(db *gorm.DB, model interface{}, params PaginationParams) (*gorm.DB, int64, error) {
 	var totalItems int64
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewPaginationParams(t *testing.T) {
+	defer SetMaxPerPage(MaxPerPage())
+	SetMaxPerPage(100)
+
+	tests := []struct {
+		name        string
+		page        int
+		perPage     int
+		wantPage    int
+		wantPerPage int
+	}{
+		{name: "within limits", page: 3, perPage: 50, wantPage: 3, wantPerPage: 50},
+		{name: "at the maximum", page: 1, perPage: 100, wantPage: 1, wantPerPage: 100},
+		{name: "above the maximum", page: 1, perPage: 101, wantPage: 1, wantPerPage: 100},
+		{name: "huge page size", page: 1, perPage: 100000, wantPage: 1, wantPerPage: 100},
+		{name: "zero page", page: 0, perPage: 10, wantPage: 1, wantPerPage: 10},
+		{name: "negative page", page: -5, perPage: 10, wantPage: 1, wantPerPage: 10},
+		{name: "zero page size", page: 2, perPage: 0, wantPage: 2, wantPerPage: DefaultPerPage},
+		{name: "negative page size", page: 2, perPage: -1, wantPage: 2, wantPerPage: DefaultPerPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPaginationParams(tt.page, tt.perPage)
+			if got.Page != tt.wantPage || got.PerPage != tt.wantPerPage {
+				t.Errorf("NewPaginationParams(%d, %d) = page %d, per page %d, want %d, %d",
+					tt.page, tt.perPage, got.Page, got.PerPage, tt.wantPage, tt.wantPerPage)
+			}
+		})
+	}
+}
+
+func TestSetMaxPerPage(t *testing.T) {
+	defer SetMaxPerPage(MaxPerPage())
+
+	SetMaxPerPage(25)
+	if got := NewPaginationParams(1, 50).PerPage; got != 25 {
+		t.Errorf("NewPaginationParams() per page = %d, want 25", got)
+	}
+
+	// Non-positive maximums are ignored
+	SetMaxPerPage(0)
+	SetMaxPerPage(-10)
+	if got := MaxPerPage(); got != 25 {
+		t.Errorf("MaxPerPage() = %d, want 25", got)
+	}
+}
+
+func TestPaginatedHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	params := NewPaginationParams(2, 100000)
+	Paginated(c, []string{}, NewPagination(params.Page, params.PerPage, 250))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Paginated() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	want := map[string]string{
+		"X-Total-Count": "250",
+		"X-Page":        "2",
+		"X-Per-Page":    "100",
+	}
+	for header, value := range want {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("Paginated() header %s = %q, want %q", header, got, value)
+		}
+	}
+}
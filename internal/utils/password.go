@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/rand"
+	"math/big"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -50,3 +53,45 @@ func ValidatePasswordStrength(password string) error {
 
 	return nil
 }
+
+// GenerateRandomPassword creates a random password of the given length that
+// satisfies ValidatePasswordStrength
+func GenerateRandomPassword(length int) (string, error) {
+	const (
+		upper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+		lower   = "abcdefghijkmnopqrstuvwxyz"
+		digits  = "23456789"
+		special = "@#$%!&*"
+	)
+	if length < 8 {
+		length = 8
+	}
+
+	// Guarantee one character from every required class, fill the rest from all of them
+	sets := []string{upper, lower, digits, special}
+	all := upper + lower + digits + special
+	password := make([]byte, length)
+	for i := range password {
+		set := all
+		if i < len(sets) {
+			set = sets[i]
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = set[n.Int64()]
+	}
+
+	// Shuffle so the required classes are not always at the front
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import "strings"
+
+// phoneCallingCodes maps the supported ISO 3166-1 regions to their country calling codes
+var phoneCallingCodes = map[string]string{
+	"AE": "971",
+	"AU": "61",
+	"BD": "880",
+	"CA": "1",
+	"GB": "44",
+	"IN": "91",
+	"LK": "94",
+	"MY": "60",
+	"NP": "977",
+	"PK": "92",
+	"SA": "966",
+	"SG": "65",
+	"US": "1",
+}
+
+// defaultPhoneRegion is the region assumed for numbers written without a country code
+var defaultPhoneRegion = "BD"
+
+// SetDefaultPhoneRegion sets the region assumed for numbers written without a
+// country code; unsupported regions are ignored
+func SetDefaultPhoneRegion(region string) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if _, ok := phoneCallingCodes[region]; ok {
+		defaultPhoneRegion = region
+	}
+}
+
+// NormalizePhone validates a phone number and returns it in E.164 form, so that
+// "01712345678", "+880 1712-345678" and "008801712345678" all become "+8801712345678".
+// Numbers without a country code are read as national numbers of the default region.
+func NormalizePhone(phone string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phone))
+
+	var digits string
+	switch {
+	case strings.HasPrefix(cleaned, "+"):
+		digits = cleaned[1:]
+	case strings.HasPrefix(cleaned, "00"):
+		digits = cleaned[2:]
+	default:
+		code := phoneCallingCodes[defaultPhoneRegion]
+		national := cleaned
+		if code == "1" {
+			// North American numbers are dialled nationally with a leading 1
+			if len(national) == 11 {
+				national = strings.TrimPrefix(national, "1")
+			}
+		} else {
+			national = strings.TrimPrefix(national, "0")
+		}
+		digits = code + national
+	}
+
+	// E.164 allows at most 15 digits, and no country code starts with 0
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalidPhoneFormat
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhoneFormat
+		}
+	}
+	return "+" + digits, nil
+}
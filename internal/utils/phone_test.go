@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		region  string
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{name: "national", region: "BD", phone: "01712345678", want: "+8801712345678"},
+		{name: "international", region: "BD", phone: "+8801712345678", want: "+8801712345678"},
+		{name: "international with separators", region: "BD", phone: "+880 1712-345678", want: "+8801712345678"},
+		{name: "00 prefix", region: "BD", phone: "008801712345678", want: "+8801712345678"},
+		{name: "parentheses and dots", region: "BD", phone: "(017) 1234.5678", want: "+8801712345678"},
+		{name: "surrounding spaces", region: "BD", phone: "  01712345678 ", want: "+8801712345678"},
+		{name: "national without trunk prefix", region: "BD", phone: "1712345678", want: "+8801712345678"},
+		{name: "other region national", region: "GB", phone: "07911 123456", want: "+447911123456"},
+		{name: "international ignores the region", region: "GB", phone: "+8801712345678", want: "+8801712345678"},
+		{name: "north american with leading 1", region: "US", phone: "1 (415) 555-2671", want: "+14155552671"},
+		{name: "north american without leading 1", region: "US", phone: "415-555-2671", want: "+14155552671"},
+		{name: "letters", region: "BD", phone: "01712ABC678", wantErr: true},
+		{name: "too short", region: "BD", phone: "+12345", wantErr: true},
+		{name: "too long", region: "BD", phone: "+1234567890123456", wantErr: true},
+		{name: "country code starting with 0", region: "BD", phone: "+0801712345678", wantErr: true},
+		{name: "empty", region: "BD", phone: "", wantErr: true},
+	}
+
+	defer SetDefaultPhoneRegion(defaultPhoneRegion)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaultPhoneRegion(tt.region)
+			got, err := NormalizePhone(tt.phone)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPhoneFormat) {
+					t.Fatalf("NormalizePhone(%q) error = %v, want ErrInvalidPhoneFormat", tt.phone, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizePhone(%q) unexpected error: %v", tt.phone, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDefaultPhoneRegionIgnoresUnsupported(t *testing.T) {
+	defer SetDefaultPhoneRegion(defaultPhoneRegion)
+
+	SetDefaultPhoneRegion(" gb ")
+	SetDefaultPhoneRegion("XX")
+	if defaultPhoneRegion != "GB" {
+		t.Errorf("defaultPhoneRegion = %q, want GB", defaultPhoneRegion)
+	}
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,12 +22,20 @@ type PaginatedResponse struct {
 	Pagination Pagination  `json:"pagination"`
 }
 
+// CursorPaginatedResponse represents a cursor paginated API response
+type CursorPaginatedResponse struct {
+	Success    bool             `json:"success"`
+	Data       interface{}      `json:"data"`
+	Pagination CursorPagination `json:"pagination"`
+}
+
 // ErrorResponse represents an error API response
 type ErrorResponse struct {
 	Success bool              `json:"success"`
 	Error   string            `json:"error"`
 	Code    string            `json:"code,omitempty"`
 	Details map[string]string `json:"details,omitempty"`
+	Rules   map[string]string `json:"rules,omitempty"` // Validation rule each field in Details broke
 }
 
 // Success sends a success response
@@ -60,8 +70,12 @@ func NoContent(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-// Paginated sends a paginated response
+// Paginated sends a paginated response.
+// The pagination is also sent in the X-Total-Count, X-Page and X-Per-Page headers.
 func Paginated(c *gin.Context, data interface{}, pagination Pagination) {
+	c.Header("X-Total-Count", strconv.FormatInt(pagination.TotalItems, 10))
+	c.Header("X-Page", strconv.Itoa(pagination.CurrentPage))
+	c.Header("X-Per-Page", strconv.Itoa(pagination.PerPage))
 	c.JSON(http.StatusOK, PaginatedResponse{
 		Success:    true,
 		Data:       data,
@@ -69,6 +83,15 @@ func Paginated(c *gin.Context, data interface{}, pagination Pagination) {
 	})
 }
 
+// CursorPaginated sends a cursor paginated response
+func CursorPaginated(c *gin.Context, data interface{}, pagination CursorPagination) {
+	c.JSON(http.StatusOK, CursorPaginatedResponse{
+		Success:    true,
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
 // Error sends an error response
 func Error(c *gin.Context, statusCode int, err error) {
 	response := ErrorResponse{
@@ -77,15 +100,23 @@ func Error(c *gin.Context, statusCode int, err error) {
 	}
 
 	// Check if it's an AppError to get more details
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		response.Error = appErr.Localize(RequestLocale(c))
 		response.Code = appErr.Code
 		response.Details = appErr.Details
-		statusCode = appErr.StatusCode
+		statusCode = StatusForError(appErr)
 	}
 
 	c.JSON(statusCode, response)
 }
 
+// RespondServiceError sends an error returned by a service with the status
+// derived from the error itself, see StatusForError.
+func RespondServiceError(c *gin.Context, err error) {
+	Error(c, StatusForError(err), err)
+}
+
 // ErrorWithCode sends an error response with a specific code
 func ErrorWithCode(c *gin.Context, statusCode int, code, message string) {
 	c.JSON(statusCode, ErrorResponse{
@@ -95,13 +126,15 @@ func ErrorWithCode(c *gin.Context, statusCode int, code, message string) {
 	})
 }
 
-// ValidationError sends a validation error response
-func ValidationError(c *gin.Context, details map[string]string) {
+// ValidationError sends a validation error response with a message and the
+// broken rule for each invalid field
+func ValidationError(c *gin.Context, details ValidationDetails) {
 	c.JSON(http.StatusBadRequest, ErrorResponse{
 		Success: false,
 		Error:   "Validation failed",
 		Code:    "VAL_001",
-		Details: details,
+		Details: details.Messages,
+		Rules:   details.Rules,
 	})
 }
 
@@ -0,0 +1,9 @@
+package utils
+
+import "strings"
+
+// NormalizeRoomNumber lower-cases a free-text room number and collapses its
+// whitespace so that "Room 101" and " room  101" compare equal
+func NormalizeRoomNumber(room string) string {
+	return strings.ToLower(strings.Join(strings.Fields(room), " "))
+}
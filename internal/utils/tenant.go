@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantScope is a GORM scope restricting a query to one institution.
+// The column is qualified with the query's own table, so it is safe to combine with joins.
+// An empty institution ID (Super Admins) leaves the query unscoped.
+func TenantScope(institutionID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if institutionID == "" {
+			return db
+		}
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "institution_id"},
+			Value:  institutionID,
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseClockTime parses a "HH:MM" (or "H:MM") time string and returns
+// the number of minutes since midnight. A trailing ":SS" component, as
+// produced by Postgres TIME values, is accepted and ignored.
+func ParseClockTime(value string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) == 3 && len(parts[2]) == 2 {
+		parts = parts[:2]
+	}
+	if len(parts) != 2 || len(parts[0]) < 1 || len(parts[0]) > 2 || len(parts[1]) != 2 {
+		return 0, ErrInvalidTimeFormat
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, ErrInvalidTimeFormat
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || minutes < 0 || minutes > 59 {
+		return 0, ErrInvalidTimeFormat
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// NormalizeClockTime converts a time string such as "9:00" to the
+// zero-padded "09:00" form used for storage and comparison
+func NormalizeClockTime(value string) (string, error) {
+	minutes, err := ParseClockTime(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60), nil
+}
+
+// NormalizeTimeRange normalizes a start/end pair and ensures the end
+// time is strictly after the start time
+func NormalizeTimeRange(start, end string) (string, string, error) {
+	startMinutes, err := ParseClockTime(start)
+	if err != nil {
+		return "", "", err
+	}
+	endMinutes, err := ParseClockTime(end)
+	if err != nil {
+		return "", "", err
+	}
+	if endMinutes <= startMinutes {
+		return "", "", ErrInvalidTimeRange
+	}
+
+	return fmt.Sprintf("%02d:%02d", startMinutes/60, startMinutes%60),
+		fmt.Sprintf("%02d:%02d", endMinutes/60, endMinutes%60), nil
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "padded", value: "09:05", want: 9*60 + 5},
+		{name: "unpadded hour", value: "9:05", want: 9*60 + 5},
+		{name: "midnight", value: "00:00", want: 0},
+		{name: "last minute", value: "23:59", want: 23*60 + 59},
+		{name: "seconds ignored", value: "14:30:00", want: 14*60 + 30},
+		{name: "surrounding spaces", value: " 08:15 ", want: 8*60 + 15},
+		{name: "hour out of range", value: "24:00", wantErr: true},
+		{name: "minute out of range", value: "12:60", wantErr: true},
+		{name: "single digit minute", value: "12:5", wantErr: true},
+		{name: "three digit hour", value: "009:00", wantErr: true},
+		{name: "missing minutes", value: "12", wantErr: true},
+		{name: "not a number", value: "ab:cd", wantErr: true},
+		{name: "negative", value: "-1:00", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClockTime(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTimeFormat) {
+					t.Fatalf("ParseClockTime(%q) error = %v, want ErrInvalidTimeFormat", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseClockTime(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseClockTime(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeClockTime(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "9:00", want: "09:00"},
+		{value: "09:00", want: "09:00"},
+		{value: "0:00", want: "00:00"},
+		{value: "23:59:00", want: "23:59"},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeClockTime(tt.value)
+		if err != nil {
+			t.Fatalf("NormalizeClockTime(%q) unexpected error: %v", tt.value, err)
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeClockTime(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTimeRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		wantStart string
+		wantEnd   string
+		wantErr   error
+	}{
+		{name: "unpadded", start: "9:00", end: "9:45", wantStart: "09:00", wantEnd: "09:45"},
+		{name: "whole day", start: "00:00", end: "23:59", wantStart: "00:00", wantEnd: "23:59"},
+		{name: "one minute", start: "10:00", end: "10:01", wantStart: "10:00", wantEnd: "10:01"},
+		{name: "equal", start: "10:00", end: "10:00", wantErr: ErrInvalidTimeRange},
+		{name: "reversed", start: "11:00", end: "10:00", wantErr: ErrInvalidTimeRange},
+		{name: "invalid start", start: "25:00", end: "10:00", wantErr: ErrInvalidTimeFormat},
+		{name: "invalid end", start: "10:00", end: "10:7", wantErr: ErrInvalidTimeFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := NormalizeTimeRange(tt.start, tt.end)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NormalizeTimeRange(%q, %q) error = %v, want %v", tt.start, tt.end, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeTimeRange(%q, %q) unexpected error: %v", tt.start, tt.end, err)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("NormalizeTimeRange(%q, %q) = %q, %q, want %q, %q", tt.start, tt.end, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
-	"regexp"
 	"strings"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 // CustomValidator holds the custom validator instance
@@ -38,6 +40,18 @@ func InitValidator() error {
 		if err := v.RegisterValidation("password", validatePassword); err != nil {
 			return err
 		}
+
+		if err := v.RegisterValidation("hhmm", validateClockTime); err != nil {
+			return err
+		}
+
+		if err := v.RegisterValidation("hhmmafter", validateClockTimeAfter); err != nil {
+			return err
+		}
+
+		if err := v.RegisterValidation("uuidslice", validateUUIDSlice); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -63,9 +77,8 @@ func validatePhone(fl validator.FieldLevel) bool {
 		return true // Optional field
 	}
 
-	// Basic phone validation: allow +, digits, spaces, dashes
-	phoneRegex := regexp.MustCompile(`^\+?[\d\s\-()]{10,20}$`)
-	return phoneRegex.MatchString(phone)
+	_, err := NormalizePhone(phone)
+	return err == nil
 }
 
 // validatePassword validates password strength
@@ -91,35 +104,136 @@ func validatePassword(fl validator.FieldLevel) bool {
 	return hasUpper && hasLower && hasDigit
 }
 
-// FormatValidationErrors formats validation errors into a map
-func FormatValidationErrors(err error) map[string]string {
-	errors := make(map[string]string)
-
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		for _, e := range validationErrors {
-			field := e.Field()
-			switch e.Tag() {
-			case "required":
-				errors[field] = field + " is required"
-			case "email":
-				errors[field] = field + " must be a valid email address"
-			case "min":
-				errors[field] = field + " must be at least " + e.Param() + " characters"
-			case "max":
-				errors[field] = field + " must be at most " + e.Param() + " characters"
-			case "role":
-				errors[field] = field + " must be a valid role (SUPER_ADMIN, ADMIN, TEACHER, STUDENT, PARENT, ACCOUNTANT)"
-			case "phone":
-				errors[field] = field + " must be a valid phone number"
-			case "password":
-				errors[field] = field + " must be at least 8 characters with uppercase, lowercase, and digits"
-			case "uuid":
-				errors[field] = field + " must be a valid UUID"
-			default:
-				errors[field] = field + " is invalid"
-			}
+// validateClockTime validates a 24-hour HH:MM time of day, from 00:00 to 23:59
+func validateClockTime(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Optional field; use required to demand it
+	}
+
+	_, err := ParseClockTime(value)
+	return err == nil
+}
+
+// validateClockTimeAfter validates that a time of day is later than the time in
+// the sibling field whose JSON name is the parameter, e.g. hhmmafter=start_time.
+// Missing or malformed times are left to the required and hhmm rules.
+func validateClockTimeAfter(fl validator.FieldLevel) bool {
+	end, err := ParseClockTime(fl.Field().String())
+	if err != nil {
+		return true
+	}
+
+	parent := reflect.Indirect(fl.Parent())
+	if parent.Kind() != reflect.Struct {
+		return true
+	}
+	for i := 0; i < parent.NumField(); i++ {
+		name := strings.SplitN(parent.Type().Field(i).Tag.Get("json"), ",", 2)[0]
+		if name != fl.Param() || parent.Field(i).Kind() != reflect.String {
+			continue
 		}
+		start, err := ParseClockTime(parent.Field(i).String())
+		if err != nil {
+			return true
+		}
+		return end > start
 	}
+	return true
+}
 
-	return errors
+// validateUUIDSlice validates that every entry of a list of IDs is a UUID
+func validateUUIDSlice(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.Slice && field.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < field.Len(); i++ {
+		if _, err := uuid.Parse(field.Index(i).String()); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidationDetails describes why a request body or query failed to bind, keyed
+// by the JSON path of each invalid field, e.g. "email" or "entries[0].start_time"
+type ValidationDetails struct {
+	Messages map[string]string // Readable message per field
+	Rules    map[string]string // Rule the field broke, e.g. "required", "min" or "uuid"
+}
+
+// FormatValidationErrors describes validation errors, and JSON values of the
+// wrong type, per field so clients can show them next to their inputs
+func FormatValidationErrors(err error) ValidationDetails {
+	details := ValidationDetails{
+		Messages: make(map[string]string),
+		Rules:    make(map[string]string),
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		details.Messages[typeErr.Field] = typeErr.Field + " must be of type " + typeErr.Type.String()
+		details.Rules[typeErr.Field] = "type"
+		return details
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return details
+	}
+	for _, e := range validationErrors {
+		field := validationField(e)
+		details.Rules[field] = e.Tag()
+		switch e.Tag() {
+		case "required":
+			details.Messages[field] = field + " is required"
+		case "email":
+			details.Messages[field] = field + " must be a valid email address"
+		case "min":
+			details.Messages[field] = field + " must be at least " + e.Param() + " characters"
+		case "max":
+			details.Messages[field] = field + " must be at most " + e.Param() + " characters"
+		case "role":
+			details.Messages[field] = field + " must be a valid role (SUPER_ADMIN, ADMIN, TEACHER, STUDENT, PARENT, ACCOUNTANT)"
+		case "phone":
+			details.Messages[field] = field + " must be a valid phone number"
+		case "password":
+			details.Messages[field] = field + " must be at least 8 characters with uppercase, lowercase, and digits"
+		case "uuid":
+			details.Messages[field] = field + " must be a valid UUID"
+		case "uuidslice":
+			details.Messages[field] = field + " must be a list of valid UUIDs"
+		case "hhmm":
+			details.Messages[field] = field + " must be a time between 00:00 and 23:59 in HH:MM format"
+		case "hhmmafter":
+			details.Messages[field] = field + " must be after " + e.Param()
+		default:
+			details.Messages[field] = field + " is invalid"
+		}
+	}
+
+	return details
+}
+
+// validationField returns the JSON path of an invalid field. The namespace
+// starts with the Go name of the request struct, and embedded structs (such as
+// RegisterRequest) add their Go name, which is the same in the struct namespace;
+// neither is part of the JSON.
+func validationField(e validator.FieldError) string {
+	names := strings.Split(e.Namespace(), ".")
+	goNames := strings.Split(e.StructNamespace(), ".")
+	if len(names) < 2 || len(names) != len(goNames) {
+		return e.Field()
+	}
+
+	path := make([]string, 0, len(names)-1)
+	for i := 1; i < len(names); i++ {
+		if i < len(names)-1 && names[i] == goNames[i] {
+			continue
+		}
+		path = append(path, names[i])
+	}
+	return strings.Join(path, ".")
 }
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validate runs the binding validator set up by InitValidator on value
+func validate(t *testing.T, value interface{}) error {
+	t.Helper()
+
+	if err := InitValidator(); err != nil {
+		t.Fatalf("InitValidator() unexpected error: %v", err)
+	}
+	return CustomValidator.Struct(value)
+}
+
+type testName struct {
+	FirstName string `json:"first_name" binding:"required"`
+}
+
+type testEntry struct {
+	Day       string `json:"day_of_week" binding:"required"`
+	StartTime string `json:"start_time" binding:"required,hhmm"`
+}
+
+type testSignup struct {
+	testName
+	Email    string      `json:"email" binding:"required,email"`
+	Password string      `json:"password" binding:"required,password"`
+	Username string      `json:"username" binding:"min=3"`
+	Role     string      `json:"role" binding:"role"`
+	Entries  []testEntry `json:"entries" binding:"dive"`
+	Internal string      `json:"-" binding:"required"`
+}
+
+func TestFormatValidationErrors(t *testing.T) {
+	err := validate(t, testSignup{
+		Email:    "not-an-email",
+		Password: "short",
+		Username: "ab",
+		Role:     "JANITOR",
+		Entries:  []testEntry{{Day: "MONDAY", StartTime: "09:00"}, {StartTime: "25:00"}},
+	})
+	if err == nil {
+		t.Fatal("validation passed, want errors")
+	}
+
+	details := FormatValidationErrors(err)
+	wantRules := map[string]string{
+		"first_name":             "required",
+		"email":                  "email",
+		"password":               "password",
+		"username":               "min",
+		"role":                   "role",
+		"entries[1].day_of_week": "required",
+		"entries[1].start_time":  "hhmm",
+		"Internal":               "required",
+	}
+	if !reflect.DeepEqual(details.Rules, wantRules) {
+		t.Errorf("FormatValidationErrors() rules = %v, want %v", details.Rules, wantRules)
+	}
+
+	wantMessages := map[string]string{
+		"first_name": "first_name is required",
+		"email":      "email must be a valid email address",
+		"username":   "username must be at least 3 characters",
+	}
+	for field, want := range wantMessages {
+		if got := details.Messages[field]; got != want {
+			t.Errorf("FormatValidationErrors() message for %s = %q, want %q", field, got, want)
+		}
+	}
+	for field := range details.Rules {
+		if details.Messages[field] == "" {
+			t.Errorf("FormatValidationErrors() has a rule but no message for %s", field)
+		}
+	}
+}
+
+func TestFormatValidationErrorsTypeMismatch(t *testing.T) {
+	var body struct {
+		Capacity int `json:"capacity"`
+	}
+	err := json.NewDecoder(strings.NewReader(`{"capacity": "forty"}`)).Decode(&body)
+
+	details := FormatValidationErrors(err)
+	if details.Rules["capacity"] != "type" {
+		t.Errorf("FormatValidationErrors() rules = %v, want capacity: type", details.Rules)
+	}
+	if details.Messages["capacity"] != "capacity must be of type int" {
+		t.Errorf("FormatValidationErrors() message = %q, want %q", details.Messages["capacity"], "capacity must be of type int")
+	}
+}
+
+func TestFormatValidationErrorsOtherError(t *testing.T) {
+	details := FormatValidationErrors(json.Unmarshal([]byte("{"), &struct{}{}))
+	if len(details.Rules) != 0 || len(details.Messages) != 0 {
+		t.Errorf("FormatValidationErrors() = %+v, want no field details", details)
+	}
+}
+
+type testPeriod struct {
+	StartTime string `json:"start_time" binding:"required,hhmm"`
+	EndTime   string `json:"end_time" binding:"required,hhmm,hhmmafter=start_time"`
+}
+
+func TestClockTimeValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		period    testPeriod
+		wantRules map[string]string
+	}{
+		{"first minute of the day", testPeriod{"00:00", "00:01"}, nil},
+		{"last minute of the day", testPeriod{"23:58", "23:59"}, nil},
+		{"whole day", testPeriod{"00:00", "23:59"}, nil},
+		{"hour 24", testPeriod{"09:00", "24:00"}, map[string]string{"end_time": "hhmm"}},
+		{"minute 60", testPeriod{"23:60", "23:59"}, map[string]string{"start_time": "hhmm"}},
+		{"unpadded hour", testPeriod{"9:00", "09:45"}, nil},
+		{"with seconds", testPeriod{"09:00", "23:59:00"}, nil},
+		{"three digit hour", testPeriod{"009:00", "09:45"}, map[string]string{"start_time": "hhmm"}},
+		{"equal times", testPeriod{"23:59", "23:59"}, map[string]string{"end_time": "hhmmafter"}},
+		{"end before start", testPeriod{"00:01", "00:00"}, map[string]string{"end_time": "hhmmafter"}},
+		{"missing end", testPeriod{"09:00", ""}, map[string]string{"end_time": "required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(t, tt.period)
+			if tt.wantRules == nil {
+				if err != nil {
+					t.Fatalf("validation error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("validation passed, want errors")
+			}
+			if got := FormatValidationErrors(err).Rules; !reflect.DeepEqual(got, tt.wantRules) {
+				t.Errorf("FormatValidationErrors() rules = %v, want %v", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestUUIDSliceValidation(t *testing.T) {
+	type idList struct {
+		IDs []string `json:"ids" binding:"omitempty,uuidslice"`
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"valid", []string{"6f1c2b8e-3d4a-4e5f-8a9b-0c1d2e3f4a5b"}, false},
+		{"one invalid", []string{"6f1c2b8e-3d4a-4e5f-8a9b-0c1d2e3f4a5b", "not-a-uuid"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(t, idList{IDs: tt.ids})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validation error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && FormatValidationErrors(err).Rules["ids"] != "uuidslice" {
+				t.Errorf("FormatValidationErrors() rules = %v, want ids: uuidslice", FormatValidationErrors(err).Rules)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"campus-core/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// operationTimeout bounds every Redis call so a slow cache never stalls a request
+const operationTimeout = 500 * time.Millisecond
+
+// Cache stores JSON-encoded values in Redis under a key prefix.
+// A cache without a client is disabled: lookups always miss and writes are
+// ignored, so callers fall back to the database. Redis errors are logged and
+// treated the same way.
+type Cache struct {
+	client *redis.Client
+	prefix string
+}
+
+// New creates a cache using client, which may be nil when Redis is not connected
+func New(client *redis.Client, prefix string) *Cache {
+	return &Cache{client: client, prefix: prefix + ":"}
+}
+
+// Enabled reports whether the cache is backed by Redis
+func (c *Cache) Enabled() bool {
+	return c != nil && c.client != nil
+}
+
+// Get decodes the value stored under key into dest and reports whether it was found
+func (c *Cache) Get(key string, dest interface{}) bool {
+	if !c.Enabled() {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logger.Warn("Cache read failed", zap.String("key", c.prefix+key), zap.Error(err))
+		}
+		return false
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		logger.Warn("Cache entry is corrupt", zap.String("key", c.prefix+key), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// Set stores value under key for ttl
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) {
+	if !c.Enabled() {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.Warn("Cache entry could not be encoded", zap.String("key", c.prefix+key), zap.Error(err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, c.prefix+key, data, ttl).Err(); err != nil {
+		logger.Warn("Cache write failed", zap.String("key", c.prefix+key), zap.Error(err))
+	}
+}
+
+// SetIfAbsent stores value under key for ttl unless the key already exists.
+// stored reports whether the value was written; ok is false when the cache is
+// disabled or Redis failed, in which case nothing is known about the key.
+func (c *Cache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (stored, ok bool) {
+	if !c.Enabled() {
+		return false, false
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.Warn("Cache entry could not be encoded", zap.String("key", c.prefix+key), zap.Error(err))
+		return false, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+
+	stored, err = c.client.SetNX(ctx, c.prefix+key, data, ttl).Result()
+	if err != nil {
+		logger.Warn("Cache write failed", zap.String("key", c.prefix+key), zap.Error(err))
+		return false, false
+	}
+	return stored, true
+}
+
+// Delete removes the given keys
+func (c *Cache) Delete(keys ...string) {
+	if !c.Enabled() || len(keys) == 0 {
+		return
+	}
+
+	full := make([]string, len(keys))
+	for i, key := range keys {
+		full[i] = c.prefix + key
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), operationTimeout)
+	defer cancel()
+
+	if err := c.client.Del(ctx, full...).Err(); err != nil {
+		logger.Warn("Cache delete failed", zap.Strings("keys", full), zap.Error(err))
+	}
+}
+
+// Clear removes every key under the cache prefix
+func (c *Cache) Clear() {
+	if !c.Enabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	iter := c.client.Scan(ctx, 0, c.prefix+"*", 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		logger.Warn("Cache scan failed", zap.String("prefix", c.prefix), zap.Error(err))
+		return
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		logger.Warn("Cache clear failed", zap.String("prefix", c.prefix), zap.Error(err))
+	}
+}
@@ -0,0 +1,33 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// WriteCSV writes the grid as CSV with a header row.
+// Multi-line cells are joined with "; " and cells that look like formulas are
+// escaped.
+func WriteCSV(w io.Writer, grid *Grid) error {
+	writer := csv.NewWriter(w)
+
+	header := append([]string{grid.Corner}, grid.Columns...)
+	if err := writer.Write(escapeFormulas(header)); err != nil {
+		return err
+	}
+
+	for _, row := range grid.Rows {
+		record := make([]string, 0, len(grid.Columns)+1)
+		record = append(record, row.Label)
+		for i := range grid.Columns {
+			record = append(record, strings.ReplaceAll(row.cell(i), "\n", "; "))
+		}
+		if err := writer.Write(escapeFormulas(record)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,76 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// Format constants
+const (
+	FormatPDF = "pdf"
+	FormatCSV = "csv"
+)
+
+// ErrUnsupportedFormat is returned for formats an export does not support
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Placeholder is rendered in cells that have no content
+const Placeholder = "-"
+
+// Grid is a titled table with a header row and labelled rows.
+// A cell may contain several lines separated by "\n".
+type Grid struct {
+	Title   string
+	Corner  string // Header of the row label column
+	Columns []string
+	Rows    []GridRow
+}
+
+// GridRow is a single row of a grid
+type GridRow struct {
+	Label string
+	Cells []string // One per column; missing or empty cells render as Placeholder
+}
+
+// File is a rendered export
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Render renders the grid in the given format.
+// The file name is built from baseName and the format's extension.
+func Render(grid *Grid, format, baseName string) (*File, error) {
+	var buf bytes.Buffer
+	file := &File{}
+
+	switch strings.ToLower(format) {
+	case FormatPDF:
+		if err := WritePDF(&buf, grid); err != nil {
+			return nil, err
+		}
+		file.Name = baseName + ".pdf"
+		file.ContentType = "application/pdf"
+	case FormatCSV:
+		if err := WriteCSV(&buf, grid); err != nil {
+			return nil, err
+		}
+		file.Name = baseName + ".csv"
+		file.ContentType = "text/csv; charset=utf-8"
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	file.Data = buf.Bytes()
+	return file, nil
+}
+
+// cell returns the content of a row cell, or Placeholder if it is empty
+func (r GridRow) cell(i int) string {
+	if i < len(r.Cells) && strings.TrimSpace(r.Cells[i]) != "" {
+		return r.Cells[i]
+	}
+	return Placeholder
+}
@@ -0,0 +1,225 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Calendar is an iCalendar (RFC 5545) document
+type Calendar struct {
+	Name     string
+	Location *time.Location // Time zone for event times; nil means UTC
+	Events   []Event
+}
+
+// Event is a calendar event, optionally repeating weekly
+type Event struct {
+	UID          string // Stable identifier so re-imports update rather than duplicate
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	WeeklyUntil  time.Time   // Repeat weekly until this time; zero means a single event
+	Interval     int         // With WeeklyUntil, repeat every this many weeks; 0 means every week
+	ExceptDates  []time.Time // Start times of occurrences removed from the recurrence
+	LastModified time.Time
+}
+
+// icsDateTime is the local date-time form used with a TZID parameter
+const icsDateTime = "20060102T150405"
+
+// WriteICS writes the calendar in iCalendar format
+func WriteICS(w io.Writer, cal *Calendar) error {
+	loc := cal.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var buf bytes.Buffer
+	line := func(format string, args ...interface{}) {
+		writeICSLine(&buf, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Campus Core//Timetable//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME:%s", icsEscape(cal.Name))
+	}
+	line("X-WR-TIMEZONE:%s", loc.String())
+	from, to := cal.span()
+	writeICSTimezone(line, loc, from, to)
+
+	stamp := time.Now().UTC().Format(icsDateTime + "Z")
+	for _, event := range cal.Events {
+		line("BEGIN:VEVENT")
+		line("UID:%s", event.UID)
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;TZID=%s:%s", loc.String(), event.Start.In(loc).Format(icsDateTime))
+		line("DTEND;TZID=%s:%s", loc.String(), event.End.In(loc).Format(icsDateTime))
+		if !event.WeeklyUntil.IsZero() {
+			until := event.WeeklyUntil.UTC().Format(icsDateTime + "Z")
+			if event.Interval > 1 {
+				line("RRULE:FREQ=WEEKLY;INTERVAL=%d;UNTIL=%s", event.Interval, until)
+			} else {
+				line("RRULE:FREQ=WEEKLY;UNTIL=%s", until)
+			}
+		}
+		if len(event.ExceptDates) > 0 {
+			dates := make([]string, len(event.ExceptDates))
+			for i, date := range event.ExceptDates {
+				dates[i] = date.In(loc).Format(icsDateTime)
+			}
+			line("EXDATE;TZID=%s:%s", loc.String(), strings.Join(dates, ","))
+		}
+		line("SUMMARY:%s", icsEscape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:%s", icsEscape(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION:%s", icsEscape(event.Location))
+		}
+		if !event.LastModified.IsZero() {
+			line("LAST-MODIFIED:%s", event.LastModified.UTC().Format(icsDateTime+"Z"))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// span returns the period covered by the calendar's events, including repeats
+func (cal *Calendar) span() (from, to time.Time) {
+	for i, event := range cal.Events {
+		end := event.End
+		if event.WeeklyUntil.After(end) {
+			end = event.WeeklyUntil
+		}
+		if i == 0 || event.Start.Before(from) {
+			from = event.Start
+		}
+		if i == 0 || end.After(to) {
+			to = end
+		}
+	}
+	if len(cal.Events) == 0 {
+		from = time.Now()
+		to = from
+	}
+	return from, to
+}
+
+// writeICSTimezone writes a VTIMEZONE component describing loc from the given
+// time to the given time. Every change of UTC offset in that period is listed
+// as its own observance, so no recurrence rules are needed.
+func writeICSTimezone(line func(string, ...interface{}), loc *time.Location, from, to time.Time) {
+	line("BEGIN:VTIMEZONE")
+	line("TZID:%s", loc.String())
+
+	observance := func(onset time.Time, offsetFrom int) {
+		name, offset := onset.In(loc).Zone()
+		component := "STANDARD"
+		if onset.In(loc).IsDST() {
+			component = "DAYLIGHT"
+		}
+		line("BEGIN:%s", component)
+		// The onset is given in the local time in effect before it
+		line("DTSTART:%s", onset.In(time.FixedZone("", offsetFrom)).Format(icsDateTime))
+		line("TZOFFSETFROM:%s", icsUTCOffset(offsetFrom))
+		line("TZOFFSETTO:%s", icsUTCOffset(offset))
+		if name != "" {
+			line("TZNAME:%s", icsEscape(name))
+		}
+		line("END:%s", component)
+	}
+
+	// The observance in effect at the start of the period
+	from = time.Date(from.In(loc).Year(), from.In(loc).Month(), from.In(loc).Day(), 0, 0, 0, 0, loc)
+	_, offset := from.Zone()
+	observance(from, offset)
+
+	// Find each offset change day by day, then narrow it down to the second
+	for day := from; day.Before(to); {
+		next := day.Add(24 * time.Hour)
+		if _, nextOffset := next.In(loc).Zone(); nextOffset != offset {
+			before, after := day, next
+			for after.Sub(before) > time.Second {
+				middle := before.Add(after.Sub(before) / 2)
+				if _, middleOffset := middle.In(loc).Zone(); middleOffset == offset {
+					before = middle
+				} else {
+					after = middle
+				}
+			}
+			observance(after, offset)
+			offset = nextOffset
+		}
+		day = next
+	}
+
+	line("END:VTIMEZONE")
+}
+
+// icsUTCOffset formats a UTC offset in seconds as used by TZOFFSETFROM and TZOFFSETTO
+func icsUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		offset += fmt.Sprintf("%02d", seconds%60)
+	}
+	return offset
+}
+
+// RenderICS renders the calendar as a downloadable file
+func RenderICS(cal *Calendar, baseName string) (*File, error) {
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, cal); err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        baseName + ".ics",
+		ContentType: "text/calendar; charset=utf-8",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// writeICSLine writes a content line with CRLF, folding it at 75 octets
+func writeICSLine(buf *bytes.Buffer, content string) {
+	limit := 75
+	for len(content) > limit {
+		// Do not split a multi-byte UTF-8 sequence
+		cut := limit
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
+
+// icsEscape escapes a TEXT property value
+func icsEscape(text string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+	).Replace(text)
+}
@@ -0,0 +1,149 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+// renderICS writes the calendar and returns its unfolded content lines
+func renderICS(t *testing.T, cal *Calendar) []string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, cal); err != nil {
+		t.Fatalf("WriteICS() unexpected error: %v", err)
+	}
+	content := strings.ReplaceAll(buf.String(), "\r\n ", "")
+	return strings.Split(strings.TrimSuffix(content, "\r\n"), "\r\n")
+}
+
+// containsSequence reports whether want appears in lines in order, not
+// necessarily adjacent
+func containsSequence(lines, want []string) bool {
+	i := 0
+	for _, line := range lines {
+		if i < len(want) && line == want[i] {
+			i++
+		}
+	}
+	return i == len(want)
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestWriteICSTimezoneWithoutTransitions(t *testing.T) {
+	loc := mustLoadLocation(t, "Asia/Dhaka")
+	start := time.Date(2026, 1, 4, 9, 0, 0, 0, loc)
+	lines := renderICS(t, &Calendar{
+		Location: loc,
+		Events: []Event{{
+			UID:         "a@test",
+			Summary:     "Math",
+			Start:       start,
+			End:         start.Add(45 * time.Minute),
+			WeeklyUntil: time.Date(2026, 12, 31, 23, 59, 59, 0, loc),
+		}},
+	})
+
+	want := []string{
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Dhaka",
+		"BEGIN:STANDARD",
+		"DTSTART:20260104T000000",
+		"TZOFFSETFROM:+0600",
+		"TZOFFSETTO:+0600",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=Asia/Dhaka:20260104T090000",
+	}
+	if !containsSequence(lines, want) {
+		t.Errorf("WriteICS() =\n%s\nwant lines in order:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+	if strings.Count(strings.Join(lines, "\n"), "BEGIN:STANDARD") != 1 {
+		t.Error("WriteICS() listed more than one observance for a zone without transitions")
+	}
+}
+
+func TestWriteICSTimezoneWithDaylightSaving(t *testing.T) {
+	loc := mustLoadLocation(t, "Europe/London")
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, loc)
+	lines := renderICS(t, &Calendar{
+		Location: loc,
+		Events: []Event{{
+			UID:         "a@test",
+			Summary:     "Math",
+			Start:       start,
+			End:         start.Add(time.Hour),
+			WeeklyUntil: time.Date(2026, 12, 18, 23, 59, 59, 0, loc),
+		}},
+	})
+
+	want := []string{
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/London",
+		"BEGIN:STANDARD",
+		"DTSTART:20260105T000000",
+		"TZOFFSETFROM:+0000",
+		"TZOFFSETTO:+0000",
+		"TZNAME:GMT",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20260329T010000",
+		"TZOFFSETFROM:+0000",
+		"TZOFFSETTO:+0100",
+		"TZNAME:BST",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20261025T020000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0000",
+		"TZNAME:GMT",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+	}
+	if !containsSequence(lines, want) {
+		t.Errorf("WriteICS() =\n%s\nwant lines in order:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWriteICSRecurrence(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 6, 30, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval int
+		want     string
+	}{
+		{name: "every week", interval: 0, want: "RRULE:FREQ=WEEKLY;UNTIL=20260630T235959Z"},
+		{name: "every other week", interval: 2, want: "RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20260630T235959Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := renderICS(t, &Calendar{Events: []Event{{
+				UID:         "a@test",
+				Summary:     "Math",
+				Start:       start,
+				End:         start.Add(time.Hour),
+				WeeklyUntil: until,
+				Interval:    tt.interval,
+			}}})
+			if !containsSequence(lines, []string{"BEGIN:VEVENT", tt.want, "END:VEVENT"}) {
+				t.Errorf("WriteICS() =\n%s\nwant %s", strings.Join(lines, "\n"), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Page layout in PDF points (A4 landscape)
+const (
+	pdfMargin       = 36.0
+	pdfTitleSize    = 14.0
+	pdfFontSize     = 8.0
+	pdfLineHeight   = 10.0
+	pdfCellPadding  = 4.0
+	pdfLabelWidth   = 80.0
+	pdfHeaderHeight = 20.0
+	pdfMinRowHeight = 24.0
+
+	pdfFontFamily = "export"
+)
+
+// DejaVu Sans covers Latin, Greek, Cyrillic and many other scripts, but not Bengali
+var (
+	//go:embed fonts/DejaVuSans.ttf
+	pdfRegularFont []byte
+
+	//go:embed fonts/DejaVuSans-Bold.ttf
+	pdfBoldFont []byte
+)
+
+// LoadPDFFonts replaces the fonts embedded in PDF exports with the TrueType
+// fonts at the given paths. An empty bold path uses the regular font for bold
+// text too. It is meant to be called once at startup.
+func LoadPDFFonts(regularPath, boldPath string) error {
+	regular, err := os.ReadFile(regularPath)
+	if err != nil {
+		return fmt.Errorf("failed to read PDF font: %w", err)
+	}
+	bold := regular
+	if boldPath != "" {
+		if bold, err = os.ReadFile(boldPath); err != nil {
+			return fmt.Errorf("failed to read PDF bold font: %w", err)
+		}
+	}
+
+	pdfRegularFont, pdfBoldFont = regular, bold
+	return nil
+}
+
+// WritePDF renders the grid as a table on one or more A4 landscape pages.
+// Text is set in the embedded UTF-8 fonts; characters the fonts have no
+// glyph for are drawn as empty boxes.
+func WritePDF(w io.Writer, grid *Grid) error {
+	pdf := fpdf.NewCustom(&fpdf.InitType{OrientationStr: "L", UnitStr: "pt", SizeStr: "A4"})
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "", pdfRegularFont)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily, "B", pdfBoldFont)
+	pdf.SetLineWidth(0.5)
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	columnWidth := pageWidth - 2*pdfMargin - pdfLabelWidth
+	if len(grid.Columns) > 0 {
+		columnWidth /= float64(len(grid.Columns))
+	}
+
+	// y is the top of the next row, measured from the top of the page
+	var y float64
+
+	newPage := func() {
+		pdf.AddPage()
+		y = pdfMargin
+
+		if grid.Title != "" {
+			pdf.SetFont(pdfFontFamily, "B", pdfTitleSize)
+			pdf.Text(pdfMargin, y+pdfTitleSize, grid.Title)
+			y += pdfTitleSize + 10
+		}
+
+		// Header row on every page
+		header := append([]string{grid.Corner}, grid.Columns...)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.Rect(pdfMargin, y, pageWidth-2*pdfMargin, pdfHeaderHeight, "F")
+		pdf.SetFont(pdfFontFamily, "B", pdfFontSize)
+		x := pdfMargin
+		for i, text := range header {
+			width := columnWidth
+			if i == 0 {
+				width = pdfLabelWidth
+			}
+			pdf.Rect(x, y, width, pdfHeaderHeight, "D")
+			lines := wrapText(text, width-2*pdfCellPadding, pdf.GetStringWidth)
+			if len(lines) > 0 {
+				pdf.Text(x+pdfCellPadding, y+pdfHeaderHeight/2+pdfFontSize/3, lines[0])
+			}
+			x += width
+		}
+		y += pdfHeaderHeight
+	}
+
+	newPage()
+	for _, row := range grid.Rows {
+		// Wrap every cell first to know the row height
+		cells := make([][]string, len(grid.Columns)+1)
+		pdf.SetFont(pdfFontFamily, "B", pdfFontSize)
+		cells[0] = wrapText(row.Label, pdfLabelWidth-2*pdfCellPadding, pdf.GetStringWidth)
+		maxLines := len(cells[0])
+		pdf.SetFont(pdfFontFamily, "", pdfFontSize)
+		for i := range grid.Columns {
+			cells[i+1] = wrapText(row.cell(i), columnWidth-2*pdfCellPadding, pdf.GetStringWidth)
+			if len(cells[i+1]) > maxLines {
+				maxLines = len(cells[i+1])
+			}
+		}
+		height := float64(maxLines)*pdfLineHeight + 2*pdfCellPadding
+		if height < pdfMinRowHeight {
+			height = pdfMinRowHeight
+		}
+
+		if y+height > pageHeight-pdfMargin {
+			newPage()
+		}
+
+		x := pdfMargin
+		for i, lines := range cells {
+			width := columnWidth
+			style := ""
+			if i == 0 {
+				width = pdfLabelWidth
+				style = "B"
+			}
+			pdf.SetFont(pdfFontFamily, style, pdfFontSize)
+			pdf.Rect(x, y, width, height, "D")
+			for j, line := range lines {
+				pdf.Text(x+pdfCellPadding, y+pdfCellPadding+float64(j+1)*pdfLineHeight-2, line)
+			}
+			x += width
+		}
+		y += height
+	}
+
+	return pdf.Output(w)
+}
+
+// wrapText splits text into lines no wider than width as measured by the
+// current font, breaking on spaces and explicit newlines. Words longer than a
+// line are cut.
+func wrapText(text string, width float64, measure func(string) float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for measure(word) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				runes := []rune(word)
+				cut := 1
+				for cut < len(runes) && measure(string(runes[:cut+1])) <= width {
+					cut++
+				}
+				lines = append(lines, string(runes[:cut]))
+				word = string(runes[cut:])
+			}
+			if word == "" {
+				continue
+			}
+			switch {
+			case line == "":
+				line = word
+			case measure(line+" "+word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,61 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWritePDFRendersUnicode(t *testing.T) {
+	grid := &Grid{
+		Title:   "Class Routine — শ্রেণি ১০",
+		Corner:  "Time",
+		Columns: []string{"Montag", "Вторник", "বুধবার"},
+		Rows: []GridRow{
+			{Label: "09:00", Cells: []string{"Mathématiques\nÉlodie", "Физика", "বাংলা"}},
+			{Label: "10:00"},
+		},
+	}
+	for i := 0; i < 40; i++ {
+		grid.Rows = append(grid.Rows, GridRow{Label: "Extra", Cells: []string{"A long cell that has to wrap over several lines of the column"}})
+	}
+
+	var buf bytes.Buffer
+	if err := WritePDF(&buf, grid); err != nil {
+		t.Fatalf("WritePDF() unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Fatalf("WritePDF() output does not start with a PDF header")
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("/FontFile2")) {
+		t.Error("WritePDF() did not embed a TrueType font")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	// Every rune is one unit wide
+	measure := func(s string) float64 { return float64(utf8.RuneCountInString(s)) }
+
+	tests := []struct {
+		name  string
+		text  string
+		width float64
+		want  []string
+	}{
+		{name: "fits", text: "short text", width: 20, want: []string{"short text"}},
+		{name: "breaks on spaces", text: "one two three", width: 8, want: []string{"one two", "three"}},
+		{name: "explicit newline", text: "one\ntwo", width: 20, want: []string{"one", "two"}},
+		{name: "long word is cut", text: "abcdefghij", width: 4, want: []string{"abcd", "efgh", "ij"}},
+		{name: "multibyte runes", text: "বাংলা ভাষা", width: 5, want: []string{"বাংলা", "ভাষা"}},
+		{name: "empty", text: "", width: 10, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.width, measure); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
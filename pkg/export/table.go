@@ -0,0 +1,103 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// FormatXLSX is the Excel workbook format, supported by tables only
+const FormatXLSX = "xlsx"
+
+// RowIterator produces the rows of a table by calling yield once per row, in
+// order. It must stop and return the error if yield fails.
+type RowIterator func(yield func(cells []string) error) error
+
+// Table is a header row followed by rows that are produced while writing, so
+// large tables are never held in memory
+type Table struct {
+	Headers []string
+	Rows    RowIterator
+}
+
+// Stream is an export whose content is written on demand
+type Stream struct {
+	Name        string
+	ContentType string
+	Write       func(w io.Writer) error
+}
+
+// StreamTable prepares a table for download as csv or xlsx.
+// The file name is built from baseName and the format's extension.
+func StreamTable(table *Table, format, baseName string) (*Stream, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return &Stream{
+			Name:        baseName + ".csv",
+			ContentType: "text/csv; charset=utf-8",
+			Write:       func(w io.Writer) error { return WriteTableCSV(w, table) },
+		}, nil
+	case FormatXLSX:
+		return &Stream{
+			Name:        baseName + ".xlsx",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Write:       func(w io.Writer) error { return WriteTableXLSX(w, table) },
+		}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// WriteTableCSV streams the table as CSV with a header row
+func WriteTableCSV(w io.Writer, table *Table) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(escapeFormulas(table.Headers)); err != nil {
+		return err
+	}
+
+	err := table.Rows(func(cells []string) error {
+		return writer.Write(escapeFormulas(cells))
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// isFormula reports whether a spreadsheet would read the cell as a formula.
+// Cells are exported as text, so such cells are marked as literal text to keep
+// user-entered values like "=HYPERLINK(...)" from being run when opened.
+// A lone sign such as the "-" placeholder is left as it is.
+func isFormula(cell string) bool {
+	if len(cell) < 2 {
+		return false
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return true
+	}
+	return false
+}
+
+// escapeFormulas prefixes cells that would be read as formulas with "'",
+// which spreadsheets treat as a marker for literal text
+func escapeFormulas(cells []string) []string {
+	var escaped []string
+	for i, cell := range cells {
+		if !isFormula(cell) {
+			continue
+		}
+		// Copy on the first change so the caller's slice is left alone
+		if escaped == nil {
+			escaped = append([]string(nil), cells...)
+		}
+		escaped[i] = "'" + cell
+	}
+	if escaped == nil {
+		return cells
+	}
+	return escaped
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rowsOf returns a row iterator over fixed rows
+func rowsOf(rows ...[]string) RowIterator {
+	return func(yield func(cells []string) error) error {
+		for _, row := range rows {
+			if err := yield(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestEscapeFormulas(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{cell: "=1+2", want: "'=1+2"},
+		{cell: "+8801712345678", want: "'+8801712345678"},
+		{cell: "-2+3", want: "'-2+3"},
+		{cell: "@SUM(A1:A2)", want: "'@SUM(A1:A2)"},
+		{cell: "\t=cmd", want: "'\t=cmd"},
+		{cell: "\r=cmd", want: "'\r=cmd"},
+		{cell: "-", want: "-"},
+		{cell: "", want: ""},
+		{cell: "Rahim Uddin", want: "Rahim Uddin"},
+		{cell: "a=b", want: "a=b"},
+	}
+
+	for _, tt := range tests {
+		cells := []string{tt.cell}
+		got := escapeFormulas(cells)
+		if got[0] != tt.want {
+			t.Errorf("escapeFormulas(%q) = %q, want %q", tt.cell, got[0], tt.want)
+		}
+		if cells[0] != tt.cell {
+			t.Errorf("escapeFormulas(%q) modified its input", tt.cell)
+		}
+	}
+}
+
+func TestWriteTableCSVEscapesFormulas(t *testing.T) {
+	table := &Table{
+		Headers: []string{"Name", "Phone"},
+		Rows:    rowsOf([]string{`=HYPERLINK("http://evil","x")`, "+8801712345678"}, []string{"Karim", "-"}),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTableCSV(&buf, table); err != nil {
+		t.Fatalf("WriteTableCSV() unexpected error: %v", err)
+	}
+
+	want := "Name,Phone\n" +
+		`"'=HYPERLINK(""http://evil"",""x"")",'+8801712345678` + "\n" +
+		"Karim,-\n"
+	if buf.String() != want {
+		t.Errorf("WriteTableCSV() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	grid := &Grid{
+		Corner:  "Time",
+		Columns: []string{"Sunday", "Monday"},
+		Rows:    []GridRow{{Label: "09:00", Cells: []string{"@cmd"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, grid); err != nil {
+		t.Fatalf("WriteCSV() unexpected error: %v", err)
+	}
+	if want := "Time,Sunday,Monday\n09:00,'@cmd,-\n"; buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteTableXLSXMarksFormulasAsText(t *testing.T) {
+	table := &Table{
+		Headers: []string{"Name"},
+		Rows:    rowsOf([]string{"=1+2"}, []string{"Karim"}),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTableXLSX(&buf, table); err != nil {
+		t.Fatalf("WriteTableXLSX() unexpected error: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("WriteTableXLSX() did not write a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(content)
+	}
+
+	var names []string
+	for name := range parts {
+		names = append(names, name)
+	}
+	if _, ok := parts["xl/styles.xml"]; !ok {
+		t.Fatalf("WriteTableXLSX() parts = %v, want xl/styles.xml", names)
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	cells := []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`,
+		`<c r="A2" s="1" t="inlineStr"><is><t xml:space="preserve">=1+2</t></is></c>`,
+		`<c r="A3" t="inlineStr"><is><t xml:space="preserve">Karim</t></is></c>`,
+	}
+	var found []string
+	for _, cell := range cells {
+		if strings.Contains(sheet, cell) {
+			found = append(found, cell)
+		}
+	}
+	if !reflect.DeepEqual(found, cells) {
+		t.Errorf("WriteTableXLSX() sheet =\n%s\nwant cells\n%s", sheet, strings.Join(cells, "\n"))
+	}
+}
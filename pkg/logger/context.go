@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// contextKey is the context key the request-scoped logger is stored under
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the given logger
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx, falling back to the global logger.
+// A *gin.Context is resolved to its request's context, so handlers can pass c directly.
+func FromContext(ctx context.Context) *zap.Logger {
+	if c, ok := ctx.(*gin.Context); ok {
+		ctx = nil
+		if c.Request != nil {
+			ctx = c.Request.Context()
+		}
+	}
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+			return l
+		}
+	}
+	if Log == nil {
+		return zap.NewNop()
+	}
+	return Log
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// SlowQueryThreshold is the duration above which queries are logged as warnings
+const SlowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger writes GORM logs through zap. Queries run with db.WithContext(ctx)
+// are logged with the request-scoped logger, so they carry the request ID.
+type GormLogger struct {
+	level gormlogger.LogLevel
+}
+
+// NewGormLogger creates a GORM logger at the given level
+func NewGormLogger(level gormlogger.LogLevel) *GormLogger {
+	return &GormLogger{level: level}
+}
+
+// LogMode returns a copy of the logger at the given level
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &GormLogger{level: level}
+}
+
+// Info logs an informational message
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		FromContext(ctx).Info(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn logs a warning message
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		FromContext(ctx).Warn(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error logs an error message
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		FromContext(ctx).Error(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace logs an executed SQL statement. Failed queries are errors, slow ones warnings
+// and everything else is logged at debug level.
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+	}
+
+	log := FromContext(ctx)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		log.Error("Query failed", append(fields, zap.Error(err))...)
+	case elapsed > SlowQueryThreshold && l.level >= gormlogger.Warn:
+		log.Warn("Slow query", fields...)
+	case l.level >= gormlogger.Info:
+		log.Debug("Query", fields...)
+	}
+}
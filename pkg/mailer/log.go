@@ -0,0 +1,77 @@
+package mailer
+
+import (
+	"campus-core/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer writes emails to the application log instead of sending them.
+// Intended for local development only, since links contain secret tokens.
+type LogMailer struct{}
+
+// NewLogMailer creates a new log mailer
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// SendResetPassword logs a password reset link
+func (m *LogMailer) SendResetPassword(to, resetLink string) error {
+	logger.Info("Mail: password reset", zap.String("to", to), zap.String("link", resetLink))
+	return nil
+}
+
+// SendVerification logs an email verification link
+func (m *LogMailer) SendVerification(to, verifyLink string) error {
+	logger.Info("Mail: email verification", zap.String("to", to), zap.String("link", verifyLink))
+	return nil
+}
+
+// SendWelcome logs a welcome email
+func (m *LogMailer) SendWelcome(to, name string) error {
+	logger.Info("Mail: welcome", zap.String("to", to), zap.String("name", name))
+	return nil
+}
+
+// SendLeaveStatus logs a leave review outcome
+func (m *LogMailer) SendLeaveStatus(to, name, status, from, until string) error {
+	logger.Info("Mail: leave status",
+		zap.String("to", to),
+		zap.String("name", name),
+		zap.String("status", status),
+		zap.String("from", from),
+		zap.String("until", until))
+	return nil
+}
+
+// SendCredentials logs a temporary password
+func (m *LogMailer) SendCredentials(to, name, password string) error {
+	logger.Info("Mail: credentials",
+		zap.String("to", to),
+		zap.String("name", name),
+		zap.String("password", password))
+	return nil
+}
+
+// NoopMailer discards all emails
+type NoopMailer struct{}
+
+// NewNoopMailer creates a new no-op mailer
+func NewNoopMailer() *NoopMailer {
+	return &NoopMailer{}
+}
+
+// SendResetPassword does nothing
+func (m *NoopMailer) SendResetPassword(to, resetLink string) error { return nil }
+
+// SendVerification does nothing
+func (m *NoopMailer) SendVerification(to, verifyLink string) error { return nil }
+
+// SendWelcome does nothing
+func (m *NoopMailer) SendWelcome(to, name string) error { return nil }
+
+// SendLeaveStatus does nothing
+func (m *NoopMailer) SendLeaveStatus(to, name, status, from, until string) error { return nil }
+
+// SendCredentials does nothing
+func (m *NoopMailer) SendCredentials(to, name, password string) error { return nil }
@@ -0,0 +1,42 @@
+package mailer
+
+import "strings"
+
+// Driver constants
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+	DriverNoop = "noop"
+)
+
+// Mailer sends transactional emails
+type Mailer interface {
+	SendResetPassword(to, resetLink string) error
+	SendVerification(to, verifyLink string) error
+	SendWelcome(to, name string) error
+	SendLeaveStatus(to, name, status, from, until string) error
+	SendCredentials(to, name, password string) error
+}
+
+// Config holds mailer configuration
+type Config struct {
+	Driver   string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// New creates a mailer for the configured driver.
+// Unknown or empty drivers fall back to the no-op mailer.
+func New(cfg Config) Mailer {
+	switch strings.ToLower(cfg.Driver) {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg)
+	case DriverLog:
+		return NewLogMailer()
+	default:
+		return NewNoopMailer()
+	}
+}
@@ -0,0 +1,86 @@
+package mailer
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a new SMTP mailer
+func NewSMTPMailer(cfg Config) *SMTPMailer {
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(cfg.Host, cfg.Port),
+		auth: auth,
+		from: cfg.From,
+	}
+}
+
+// SendResetPassword sends a password reset link
+func (m *SMTPMailer) SendResetPassword(to, resetLink string) error {
+	body := "We received a request to reset your Campus Core password.\r\n\r\n" +
+		"Use the link below to choose a new password. The link expires in 1 hour.\r\n\r\n" +
+		resetLink + "\r\n\r\n" +
+		"If you did not request a password reset, you can ignore this email."
+	return m.send(to, "Reset your password", body)
+}
+
+// SendVerification sends an email verification link
+func (m *SMTPMailer) SendVerification(to, verifyLink string) error {
+	body := "Please confirm your email address for Campus Core.\r\n\r\n" +
+		"Use the link below to verify your email. The link expires in 24 hours.\r\n\r\n" +
+		verifyLink
+	return m.send(to, "Verify your email address", body)
+}
+
+// SendWelcome sends a welcome email to a newly created user
+func (m *SMTPMailer) SendWelcome(to, name string) error {
+	body := fmt.Sprintf("Hello %s,\r\n\r\nYour Campus Core account has been created. "+
+		"You can now sign in with this email address.", name)
+	return m.send(to, "Welcome to Campus Core", body)
+}
+
+// SendLeaveStatus tells an applicant that their leave application was reviewed.
+// from and until are the first and last day of the leave.
+func (m *SMTPMailer) SendLeaveStatus(to, name, status, from, until string) error {
+	body := fmt.Sprintf("Hello %s,\r\n\r\nYour leave application for %s to %s has been %s.",
+		name, from, until, strings.ToLower(status))
+	return m.send(to, "Your leave application has been reviewed", body)
+}
+
+// SendCredentials sends a temporary password set by an administrator
+func (m *SMTPMailer) SendCredentials(to, name, password string) error {
+	body := fmt.Sprintf("Hello %s,\r\n\r\nAn administrator has reset your Campus Core password. "+
+		"Sign in with this email address and the temporary password below. "+
+		"You will be asked to choose a new password.\r\n\r\n%s", name, password)
+	return m.send(to, "Your new Campus Core password", body)
+}
+
+// send builds a plain text message and delivers it
+func (m *SMTPMailer) send(to, subject, body string) error {
+	headers := []string{
+		"From: " + m.from,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=\"UTF-8\"",
+	}
+	msg := strings.Join(headers, "\r\n") + "\r\n\r\n" + body
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", to, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Driver constants
+const (
+	DriverLocal = "local"
+)
+
+// MaxImageSize is the largest accepted image upload (2 MB)
+const MaxImageSize = 2 << 20
+
+// Errors returned when validating uploads
+var (
+	ErrFileTooLarge        = errors.New("file is too large")
+	ErrUnsupportedFileType = errors.New("unsupported file type")
+)
+
+// imageExtensions maps accepted image content types to file extensions.
+// SVG is deliberately excluded since it can carry scripts.
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Storage stores uploaded files and resolves their public URLs.
+// Implementations must be safe for concurrent use.
+type Storage interface {
+	// Put stores the content under key and returns its public URL
+	Put(ctx context.Context, key string, content io.Reader, contentType string) (string, error)
+	// Delete removes the file stored under key; missing files are not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL of key
+	URL(key string) string
+	// KeyFromURL returns the key of a URL produced by this storage
+	KeyFromURL(url string) (string, bool)
+}
+
+// Config holds storage configuration
+type Config struct {
+	Driver    string
+	LocalPath string // Directory for the local driver
+	BaseURL   string // Public URL prefix the files are served from
+}
+
+// New creates a storage for the configured driver.
+// Only local disk storage is implemented so far; unknown or empty drivers fall back to it.
+func New(cfg Config) Storage {
+	return NewLocalStorage(cfg.LocalPath, cfg.BaseURL)
+}
+
+// DetectImage checks that data is an accepted image by sniffing its content,
+// returning the content type and file extension to store it with
+func DetectImage(data []byte) (contentType string, ext string, err error) {
+	if len(data) > MaxImageSize {
+		return "", "", ErrFileTooLarge
+	}
+	contentType = http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return "", "", ErrUnsupportedFileType
+	}
+	return contentType, ext, nil
+}
+
+// NewKey generates a unique key under the given directory, e.g. "logos/<uuid>.png"
+func NewKey(dir, ext string) string {
+	return strings.Trim(dir, "/") + "/" + uuid.New().String() + ext
+}
@@ -0,0 +1,30 @@
+package version
+
+// Build information, injected at build time with
+// -ldflags "-X campus-core/pkg/version.Version=v1.2.0 -X campus-core/pkg/version.Commit=abc1234"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time,omitempty"`
+}
+
+// Get returns the build information
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	}
+}
+
+// String returns the version and commit, e.g. "v1.2.0 (abc1234)"
+func String() string {
+	return Version + " (" + Commit + ")"
+}
@@ -0,0 +1,212 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"campus-core/pkg/logger"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// sendBufferSize is the number of messages queued per connection before it is dropped as too slow
+	sendBufferSize = 32
+
+	// writeTimeout bounds a single write so a stalled client can't block its writer forever
+	writeTimeout = 10 * time.Second
+)
+
+// revocationCheckInterval is how often open connections check whether their token was revoked
+var revocationCheckInterval = 30 * time.Second
+
+// Client is one authenticated WebSocket connection.
+// A user with several tabs or devices open has one client per connection.
+type Client struct {
+	UserID        string
+	Role          string
+	InstitutionID string
+
+	// ExpiresAt is when the access token the connection was opened with expires.
+	// The connection is closed then; the zero time keeps it open.
+	ExpiresAt time.Time
+
+	// Revoked, when set, is polled while the connection is open and closes it
+	// once it reports the token as revoked
+	Revoked func() bool
+
+	conn *websocket.Conn
+	send chan []byte
+	once sync.Once
+}
+
+// NewClient wraps an upgraded connection
+func NewClient(conn *websocket.Conn, userID, role, institutionID string) *Client {
+	return &Client{
+		UserID:        userID,
+		Role:          role,
+		InstitutionID: institutionID,
+		conn:          conn,
+		send:          make(chan []byte, sendBufferSize),
+	}
+}
+
+// close stops the client's writer and closes the connection; it is safe to call more than once
+func (c *Client) close() {
+	c.once.Do(func() {
+		close(c.send)
+		_ = c.conn.Close()
+	})
+}
+
+// Hub tracks connected clients in one room per institution and fans messages out to them
+type Hub struct {
+	mu    sync.RWMutex
+	rooms map[string]map[*Client]struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{rooms: make(map[string]map[*Client]struct{})}
+}
+
+// register adds a client to its institution's room
+func (h *Hub) register(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, ok := h.rooms[c.InstitutionID]
+	if !ok {
+		room = make(map[*Client]struct{})
+		h.rooms[c.InstitutionID] = room
+	}
+	room[c] = struct{}{}
+}
+
+// unregister removes a client from its room and closes it
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	if room, ok := h.rooms[c.InstitutionID]; ok {
+		delete(room, c)
+		if len(room) == 0 {
+			delete(h.rooms, c.InstitutionID)
+		}
+	}
+	h.mu.Unlock()
+
+	c.close()
+}
+
+// Serve registers the client and blocks until the connection is closed.
+// Messages from the client are discarded; reading only detects the disconnect,
+// after which the client is unregistered and its writer goroutine exits.
+// The connection is also closed when the client's token expires or is revoked.
+func (h *Hub) Serve(c *Client) {
+	h.register(c)
+	defer h.unregister(c)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go c.writeLoop()
+	go h.watch(c, done)
+
+	var discard []byte
+	for {
+		if err := websocket.Message.Receive(c.conn, &discard); err != nil {
+			return
+		}
+	}
+}
+
+// watch unregisters the client once its token expires or is revoked.
+// It returns early when done is closed because the client disconnected.
+func (h *Hub) watch(c *Client, done <-chan struct{}) {
+	var expired <-chan time.Time
+	if !c.ExpiresAt.IsZero() {
+		timer := time.NewTimer(time.Until(c.ExpiresAt))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	var poll <-chan time.Time
+	if c.Revoked != nil {
+		ticker := time.NewTicker(revocationCheckInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-expired:
+			h.unregister(c)
+			return
+		case <-poll:
+			if c.Revoked() {
+				h.unregister(c)
+				return
+			}
+		}
+	}
+}
+
+// writeLoop delivers queued messages until the send channel is closed
+func (c *Client) writeLoop() {
+	for msg := range c.send {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := websocket.Message.Send(c.conn, string(msg)); err != nil {
+			// Closing the connection makes Serve's read fail, which unregisters the client
+			_ = c.conn.Close()
+			return
+		}
+	}
+}
+
+// Broadcast sends a message to every client of an institution
+func (h *Hub) Broadcast(institutionID string, msg []byte) int {
+	return h.BroadcastFunc(institutionID, msg, nil)
+}
+
+// BroadcastFunc sends a message to the clients of an institution accepted by filter,
+// or to all of them when filter is nil. It returns the number of clients the message
+// was queued for. Clients whose queue is full are disconnected rather than blocking the broadcast.
+func (h *Hub) BroadcastFunc(institutionID string, msg []byte, filter func(*Client) bool) int {
+	h.mu.RLock()
+	var slow []*Client
+	sent := 0
+	for c := range h.rooms[institutionID] {
+		if filter != nil && !filter(c) {
+			continue
+		}
+		select {
+		case c.send <- msg:
+			sent++
+		default:
+			slow = append(slow, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range slow {
+		logger.Warn("Dropping slow WebSocket client", zap.String("user_id", c.UserID))
+		h.unregister(c)
+	}
+	return sent
+}
+
+// SendToUser sends a message to every connection of one user
+func (h *Hub) SendToUser(institutionID, userID string, msg []byte) int {
+	return h.BroadcastFunc(institutionID, msg, func(c *Client) bool {
+		return c.UserID == userID
+	})
+}
+
+// ConnectionCount returns the number of clients connected for an institution
+func (h *Hub) ConnectionCount(institutionID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.rooms[institutionID])
+}
@@ -0,0 +1,128 @@
+package ws
+
+import (
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// serveHub starts a server joining every connection to institution "inst" after
+// configure has set up the client, and returns the URL to dial
+func serveHub(t *testing.T, hub *Hub, configure func(*Client)) string {
+	t.Helper()
+
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		client := NewClient(conn, "user", "teacher", "inst")
+		if configure != nil {
+			configure(client)
+		}
+		hub.Serve(client)
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+
+	conn, err := websocket.Dial(url, "", "http://localhost")
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// waitClosed fails the test unless the server closes the connection within timeout
+func waitClosed(t *testing.T, conn *websocket.Conn, timeout time.Duration) {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	var msg string
+	for {
+		err := websocket.Message.Receive(conn, &msg)
+		if err == nil {
+			continue
+		}
+		if strings.Contains(err.Error(), "timeout") {
+			t.Fatal("connection still open, want it closed")
+		}
+		return
+	}
+}
+
+// waitConnections waits until the hub has want clients for institution "inst"
+func waitConnections(t *testing.T, hub *Hub, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for hub.ConnectionCount("inst") != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("ConnectionCount() = %d, want %d", hub.ConnectionCount("inst"), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHubBroadcast(t *testing.T) {
+	hub := NewHub()
+	conn := dial(t, serveHub(t, hub, nil))
+	waitConnections(t, hub, 1)
+
+	if sent := hub.Broadcast("inst", []byte("hello")); sent != 1 {
+		t.Fatalf("Broadcast() = %d, want 1", sent)
+	}
+	if sent := hub.Broadcast("other", []byte("hello")); sent != 0 {
+		t.Errorf("Broadcast() to another institution = %d, want 0", sent)
+	}
+
+	var msg string
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := websocket.Message.Receive(conn, &msg); err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	if msg != "hello" {
+		t.Errorf("received %q, want %q", msg, "hello")
+	}
+
+	// Disconnecting unregisters the client
+	_ = conn.Close()
+	waitConnections(t, hub, 0)
+}
+
+func TestHubClosesExpiredConnections(t *testing.T) {
+	hub := NewHub()
+	conn := dial(t, serveHub(t, hub, func(c *Client) {
+		c.ExpiresAt = time.Now().Add(100 * time.Millisecond)
+	}))
+
+	waitClosed(t, conn, 2*time.Second)
+	waitConnections(t, hub, 0)
+}
+
+func TestHubClosesRevokedConnections(t *testing.T) {
+	defer func(interval time.Duration) { revocationCheckInterval = interval }(revocationCheckInterval)
+	revocationCheckInterval = 10 * time.Millisecond
+
+	hub := NewHub()
+	var revoked atomic.Bool
+	conn := dial(t, serveHub(t, hub, func(c *Client) {
+		c.ExpiresAt = time.Now().Add(time.Hour)
+		c.Revoked = revoked.Load
+	}))
+	waitConnections(t, hub, 1)
+
+	// Still connected while the token is valid
+	time.Sleep(50 * time.Millisecond)
+	if got := hub.ConnectionCount("inst"); got != 1 {
+		t.Fatalf("ConnectionCount() before revocation = %d, want 1", got)
+	}
+
+	revoked.Store(true)
+	waitClosed(t, conn, 2*time.Second)
+	waitConnections(t, hub, 0)
+}